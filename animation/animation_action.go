@@ -0,0 +1,628 @@
+package animation
+
+import (
+	"math"
+
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/objects"
+)
+
+// InfiniteRepetitions repeats a looping action until it is stopped
+const InfiniteRepetitions = math.MaxInt32
+
+// fade linearly changes a factor of an action between two mixer times
+type fade struct {
+	startTime, endTime float64
+	from, to           float64
+}
+
+func (f *fade) evaluate(time float64) float64 {
+
+	if time <= f.startTime {
+		return f.from
+	}
+	if time >= f.endTime {
+		return f.to
+	}
+
+	return f.from + (f.to-f.from)*(time-f.startTime)/(f.endTime-f.startTime)
+
+}
+
+// AnimationAction schedules the playback of a clip on the
+// objects below a root, it is created by AnimationMixer.ClipAction
+type AnimationAction struct {
+	mixer *AnimationMixer
+	clip  *AnimationClip
+	root  objects.Node
+
+	// tracks are the valid tracks of the clip, with string values
+	// mapped to the strings of the bindings, and mixers holds the
+	// property mixer of each track or nil if it could not be bound.
+	// interpolants sample each bound track into the buffer of its mixer.
+	tracks       []*KeyframeTrack
+	mixers       []*propertyMixer
+	interpolants []math3.Interpolator
+
+	// Loop is one of three.LoopOnce, three.LoopRepeat or three.LoopPingPong
+	Loop int
+	// Repetitions is the number of times a looping clip is played
+	Repetitions int
+
+	// Time is the local time of this action in seconds, which
+	// is scaled by TimeScale relative to the time of the mixer
+	Time      float64
+	TimeScale float64
+
+	// Weight is the influence of this action from 0 to 1
+	Weight float64
+
+	// Paused stops the time of this action but keeps it
+	// applied, disabled actions have no influence
+	Paused  bool
+	Enabled bool
+
+	// ClampWhenFinished keeps the last frame of the clip applied when
+	// the action finishes, rather than disabling the action
+	ClampWhenFinished bool
+
+	// ZeroSlopeAtStart and ZeroSlopeAtEnd ease smooth tracks in and out
+	// at the start and end of playback, rather than leaving them with
+	// zero curvature. Repeating clips wrap around between repetitions
+	// and ping pong clips always end with zero slope.
+	ZeroSlopeAtStart bool
+	ZeroSlopeAtEnd   bool
+
+	effectiveTimeScale float64
+	effectiveWeight    float64
+
+	loopCount int
+	startTime float64
+	scheduled bool
+
+	weightFade    *fade
+	timeScaleFade *fade
+}
+
+func newAnimationAction(mixer *AnimationMixer, clip *AnimationClip, root objects.Node) *AnimationAction {
+
+	return &AnimationAction{
+		mixer: mixer,
+		clip:  clip,
+		root:  root,
+
+		Loop:        three.LoopRepeat,
+		Repetitions: InfiniteRepetitions,
+
+		TimeScale: 1,
+		Weight:    1,
+		Enabled:   true,
+
+		ZeroSlopeAtStart: true,
+		ZeroSlopeAtEnd:   true,
+
+		effectiveTimeScale: 1,
+		effectiveWeight:    1,
+
+		loopCount: -1,
+	}
+
+}
+
+// GetMixer returns the mixer that controls this action
+func (a *AnimationAction) GetMixer() *AnimationMixer {
+
+	return a.mixer
+
+}
+
+// GetClip returns the clip played by this action
+func (a *AnimationAction) GetClip() *AnimationClip {
+
+	return a.clip
+
+}
+
+// GetRoot returns the node that the tracks of the clip are bound below
+func (a *AnimationAction) GetRoot() objects.Node {
+
+	return a.root
+
+}
+
+// Play activates this action in its mixer
+func (a *AnimationAction) Play() *AnimationAction {
+
+	a.mixer.activateAction(a)
+
+	return a
+
+}
+
+// Stop deactivates this action, restoring the original values of the
+// properties that no other action animates, and resets it
+func (a *AnimationAction) Stop() *AnimationAction {
+
+	a.mixer.deactivateAction(a)
+
+	return a.Reset()
+
+}
+
+// Reset moves this action back to its start, enabling
+// it and cancelling any scheduled fades and warps
+func (a *AnimationAction) Reset() *AnimationAction {
+
+	a.Paused = false
+	a.Enabled = true
+
+	a.Time = 0
+	a.loopCount = -1
+	a.scheduled = false
+
+	return a.StopFading().StopWarping()
+
+}
+
+// IsRunning reports whether this action is active and advancing
+func (a *AnimationAction) IsRunning() bool {
+
+	return a.Enabled && !a.Paused && a.TimeScale != 0 && !a.scheduled && a.mixer.isActiveAction(a)
+
+}
+
+// IsScheduled reports whether this action has been played in its mixer
+func (a *AnimationAction) IsScheduled() bool {
+
+	return a.mixer.isActiveAction(a)
+
+}
+
+// StartAt delays the start of this action until the mixer reaches time
+func (a *AnimationAction) StartAt(time float64) *AnimationAction {
+
+	a.startTime = time
+	a.scheduled = true
+
+	return a
+
+}
+
+// SetLoop sets the loop mode and the number of repetitions of this action
+func (a *AnimationAction) SetLoop(mode, repetitions int) *AnimationAction {
+
+	a.Loop = mode
+	a.Repetitions = repetitions
+
+	return a
+
+}
+
+// SetEffectiveWeight sets the weight of this action, cancelling any fade
+func (a *AnimationAction) SetEffectiveWeight(weight float64) *AnimationAction {
+
+	a.Weight = weight
+
+	// note: same logic as when updated at runtime
+	a.effectiveWeight = 0
+	if a.Enabled {
+		a.effectiveWeight = weight
+	}
+
+	return a.StopFading()
+
+}
+
+// GetEffectiveWeight returns the weight of this action
+// in the last update, including any fade
+func (a *AnimationAction) GetEffectiveWeight() float64 {
+
+	return a.effectiveWeight
+
+}
+
+// FadeIn increases the weight of this action from 0 to 1 over duration seconds
+func (a *AnimationAction) FadeIn(duration float64) *AnimationAction {
+
+	return a.scheduleFading(duration, 0, 1)
+
+}
+
+// FadeOut decreases the weight of this action from 1 to 0 over duration
+// seconds, the action is disabled once it has faded out
+func (a *AnimationAction) FadeOut(duration float64) *AnimationAction {
+
+	return a.scheduleFading(duration, 1, 0)
+
+}
+
+// CrossFadeFrom fades this action in while fading fadeOutAction out. When
+// warp is set the time scales are also blended, so that clips of different
+// durations stay in step.
+func (a *AnimationAction) CrossFadeFrom(fadeOutAction *AnimationAction, duration float64, warp bool) *AnimationAction {
+
+	fadeOutAction.FadeOut(duration)
+	a.FadeIn(duration)
+
+	if warp {
+
+		fadeInDuration := a.clip.Duration
+		fadeOutDuration := fadeOutAction.clip.Duration
+
+		startEndRatio := fadeOutDuration / fadeInDuration
+		endStartRatio := fadeInDuration / fadeOutDuration
+
+		fadeOutAction.Warp(1, startEndRatio, duration)
+		a.Warp(endStartRatio, 1, duration)
+
+	}
+
+	return a
+
+}
+
+// CrossFadeTo fades this action out while fading fadeInAction in
+func (a *AnimationAction) CrossFadeTo(fadeInAction *AnimationAction, duration float64, warp bool) *AnimationAction {
+
+	fadeInAction.CrossFadeFrom(a, duration, warp)
+
+	return a
+
+}
+
+// StopFading cancels any fade of this action
+func (a *AnimationAction) StopFading() *AnimationAction {
+
+	a.weightFade = nil
+
+	return a
+
+}
+
+// SetEffectiveTimeScale sets the time scale of this action, cancelling any warp
+func (a *AnimationAction) SetEffectiveTimeScale(timeScale float64) *AnimationAction {
+
+	a.TimeScale = timeScale
+	a.effectiveTimeScale = timeScale
+	if a.Paused {
+		a.effectiveTimeScale = 0
+	}
+
+	return a.StopWarping()
+
+}
+
+// GetEffectiveTimeScale returns the time scale of this
+// action in the last update, including any warp
+func (a *AnimationAction) GetEffectiveTimeScale() float64 {
+
+	return a.effectiveTimeScale
+
+}
+
+// SetDuration scales the time of this action so that
+// a single loop of the clip takes duration seconds
+func (a *AnimationAction) SetDuration(duration float64) *AnimationAction {
+
+	a.TimeScale = a.clip.Duration / duration
+
+	return a.StopWarping()
+
+}
+
+// SyncWith copies the time and time scale of action
+func (a *AnimationAction) SyncWith(action *AnimationAction) *AnimationAction {
+
+	a.Time = action.Time
+	a.TimeScale = action.TimeScale
+
+	return a.StopWarping()
+
+}
+
+// Halt slows this action to a stop over duration seconds
+func (a *AnimationAction) Halt(duration float64) *AnimationAction {
+
+	return a.Warp(a.effectiveTimeScale, 0, duration)
+
+}
+
+// Warp changes the time scale of this action from startTimeScale
+// to endTimeScale over duration seconds
+func (a *AnimationAction) Warp(startTimeScale, endTimeScale, duration float64) *AnimationAction {
+
+	now := a.mixer.Time
+
+	a.timeScaleFade = &fade{
+		startTime: now,
+		endTime:   now + duration,
+		from:      startTimeScale / a.TimeScale,
+		to:        endTimeScale / a.TimeScale,
+	}
+
+	return a
+
+}
+
+// StopWarping cancels any warp of this action
+func (a *AnimationAction) StopWarping() *AnimationAction {
+
+	a.timeScaleFade = nil
+
+	return a
+
+}
+
+func (a *AnimationAction) scheduleFading(duration, weightNow, weightThen float64) *AnimationAction {
+
+	now := a.mixer.Time
+
+	a.weightFade = &fade{
+		startTime: now,
+		endTime:   now + duration,
+		from:      weightNow,
+		to:        weightThen,
+	}
+
+	return a
+
+}
+
+// update advances this action to the given mixer time and
+// accumulates the values of its tracks into the property mixers
+func (a *AnimationAction) update(time, deltaTime, timeDirection float64, accuIndex int) {
+
+	if a.scheduled {
+
+		timeRunning := (time - a.startTime) * timeDirection
+		if timeRunning < 0 || timeDirection == 0 {
+			return
+		}
+
+		// the action starts part way through this update
+		a.scheduled = false
+		deltaTime = timeDirection * timeRunning
+
+	}
+
+	deltaTime *= a.updateTimeScale(time)
+	clipTime := a.updateTime(deltaTime)
+	weight := a.updateWeight(time)
+
+	if weight > 0 {
+		for i, mixer := range a.mixers {
+
+			if mixer == nil {
+				continue
+			}
+			a.interpolants[i].Evaluate(clipTime)
+			mixer.accumulate(accuIndex, weight)
+
+		}
+	}
+
+}
+
+func (a *AnimationAction) updateWeight(time float64) float64 {
+
+	weight := 0.0
+
+	if a.Enabled {
+
+		weight = a.Weight
+
+		if f := a.weightFade; f != nil {
+
+			value := f.evaluate(time)
+			weight *= value
+
+			if time > f.endTime {
+
+				a.StopFading()
+
+				// faded out, disable
+				if value == 0 {
+					a.Enabled = false
+				}
+
+			}
+
+		}
+
+	}
+
+	a.effectiveWeight = weight
+
+	return weight
+
+}
+
+func (a *AnimationAction) updateTimeScale(time float64) float64 {
+
+	timeScale := 0.0
+
+	if !a.Paused {
+
+		timeScale = a.TimeScale
+
+		if f := a.timeScaleFade; f != nil {
+
+			timeScale *= f.evaluate(time)
+
+			if time > f.endTime {
+
+				a.StopWarping()
+
+				if timeScale == 0 {
+					// motion has halted, pause
+					a.Paused = true
+				} else {
+					// warp done, apply the final time scale
+					a.TimeScale = timeScale
+				}
+
+			}
+
+		}
+
+	}
+
+	a.effectiveTimeScale = timeScale
+
+	return timeScale
+
+}
+
+// updateTime advances the local time of this action, handling the loop
+// mode, and returns the time at which the clip should be sampled
+func (a *AnimationAction) updateTime(deltaTime float64) float64 {
+
+	time := a.Time + deltaTime
+
+	if deltaTime == 0 {
+		return time
+	}
+
+	duration := a.clip.Duration
+	loopCount := a.loopCount
+
+	direction := 1
+	if deltaTime < 0 {
+		direction = -1
+	}
+
+	if a.Loop == three.LoopOnce {
+
+		if loopCount == -1 {
+			// just started
+			a.loopCount = 0
+			a.setEndings(true, true, false)
+		}
+
+		if time >= duration || time < 0 {
+
+			time = math.Max(0, math.Min(time, duration))
+			a.finish(direction)
+
+		}
+
+		a.Time = time
+		return time
+
+	}
+
+	// repeating or ping pong
+	pingPong := a.Loop == three.LoopPingPong
+
+	if loopCount == -1 {
+
+		// just started, when looping in reverse the initial transition
+		// through zero counts as a repetition so loopCount stays at -1
+		if deltaTime >= 0 {
+			loopCount = 0
+			a.setEndings(true, a.Repetitions <= 1, pingPong)
+		} else {
+			a.setEndings(a.Repetitions <= 1, true, pingPong)
+		}
+
+	}
+
+	if time >= duration || time < 0 {
+
+		// wrap around
+		loopDelta := math.Floor(time / duration)
+		time -= duration * loopDelta
+		loopCount += int(math.Abs(loopDelta))
+
+		if a.Repetitions-loopCount <= 0 {
+
+			// have to stop, clamp the time and finish
+			time = 0
+			if deltaTime > 0 {
+				time = duration
+			}
+			a.finish(direction)
+
+		} else {
+
+			// keep running, easing out of the last repetition
+			if a.Repetitions-loopCount == 1 {
+				atStart := deltaTime < 0
+				a.setEndings(atStart, !atStart, pingPong)
+			} else {
+				a.setEndings(false, false, pingPong)
+			}
+
+			a.loopCount = loopCount
+			if a.mixer.OnLoop != nil {
+				a.mixer.OnLoop(a, int(loopDelta))
+			}
+
+		}
+
+	}
+
+	a.Time = time
+
+	if pingPong && loopCount&1 == 1 {
+		// invert time for the pong round
+		return duration - time
+	}
+
+	return time
+
+}
+
+// setEndings shapes the smooth tracks at either end of the clip,
+// ending them with zero slope or curvature where playback starts or
+// stops and wrapping them around where playback loops
+func (a *AnimationAction) setEndings(atStart, atEnd, pingPong bool) {
+
+	start, end := three.WrapAroundEnding, three.WrapAroundEnding
+
+	if pingPong {
+
+		start, end = three.ZeroSlopeEnding, three.ZeroSlopeEnding
+
+	} else {
+
+		if atStart {
+			start = three.ZeroCurvatureEnding
+			if a.ZeroSlopeAtStart {
+				start = three.ZeroSlopeEnding
+			}
+		}
+
+		if atEnd {
+			end = three.ZeroCurvatureEnding
+			if a.ZeroSlopeAtEnd {
+				end = three.ZeroSlopeEnding
+			}
+		}
+
+	}
+
+	for _, interpolant := range a.interpolants {
+		if cubic, ok := interpolant.(*math3.CubicInterpolant); ok {
+			cubic.EndingStart = start
+			cubic.EndingEnd = end
+		}
+	}
+
+}
+
+// finish pauses or disables this action once it has played through
+func (a *AnimationAction) finish(direction int) {
+
+	if a.ClampWhenFinished {
+		a.Paused = true
+	} else {
+		a.Enabled = false
+	}
+
+	if a.mixer.OnFinished != nil {
+		a.mixer.OnFinished(a, direction)
+	}
+
+}
@@ -0,0 +1,100 @@
+package animation
+
+import "github.com/rydrman/three.go/math3"
+
+// AnimationClip is a reusable set of keyframe tracks that together
+// make up a single animation, such as a walk cycle of a character
+type AnimationClip struct {
+	UUID string
+	Name string
+
+	// Duration is the length of this clip in seconds, it
+	// may be longer than the last keyframe of any track
+	Duration float64
+	Tracks   []*KeyframeTrack
+}
+
+// NewAnimationClip creates a clip from the given tracks. A negative
+// duration is replaced by the time of the last keyframe of any track.
+func NewAnimationClip(name string, duration float64, tracks []*KeyframeTrack) *AnimationClip {
+
+	c := &AnimationClip{
+		UUID: math3.GenerateUUID(),
+		Name: name,
+
+		Duration: duration,
+		Tracks:   tracks,
+	}
+
+	if duration < 0 {
+		c.ResetDuration()
+	}
+
+	return c
+
+}
+
+// FindAnimationClipByName returns the clip with the given name, or nil
+func FindAnimationClipByName(clips []*AnimationClip, name string) *AnimationClip {
+
+	for _, clip := range clips {
+		if clip.Name == name {
+			return clip
+		}
+	}
+
+	return nil
+
+}
+
+// ResetDuration sets the duration of this clip to
+// the time of the last keyframe of any of its tracks
+func (c *AnimationClip) ResetDuration() *AnimationClip {
+
+	duration := 0.0
+
+	for _, track := range c.Tracks {
+		if n := len(track.Times); n > 0 && track.Times[n-1] > duration {
+			duration = track.Times[n-1]
+		}
+	}
+
+	c.Duration = duration
+
+	return c
+
+}
+
+// Trim removes the keyframes of each track that fall outside of the clip
+func (c *AnimationClip) Trim() *AnimationClip {
+
+	for _, track := range c.Tracks {
+		track.Trim(0, c.Duration)
+	}
+
+	return c
+
+}
+
+// Optimize removes the redundant keyframes of each track
+func (c *AnimationClip) Optimize() *AnimationClip {
+
+	for _, track := range c.Tracks {
+		track.Optimize()
+	}
+
+	return c
+
+}
+
+// Clone returns a deep copy of this clip with a new uuid
+func (c *AnimationClip) Clone() *AnimationClip {
+
+	tracks := make([]*KeyframeTrack, len(c.Tracks))
+	for i, track := range c.Tracks {
+		tracks[i] = track.Clone()
+	}
+
+	return NewAnimationClip(c.Name, c.Duration, tracks)
+
+}
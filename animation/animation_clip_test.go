@@ -0,0 +1,36 @@
+package animation_test
+
+import (
+	"testing"
+
+	"github.com/rydrman/three.go/animation"
+)
+
+func TestAnimationClip_Duration(t *testing.T) {
+	clip := animation.NewAnimationClip("wave", -1, []*animation.KeyframeTrack{
+		animation.NewNumberKeyframeTrack(".opacity", []float64{0, 2}, []float64{0, 1}),
+		animation.NewVectorKeyframeTrack(".position", []float64{1, 3.5}, []float64{0, 0, 0, 1, 1, 1}),
+	})
+	if clip.Duration != 3.5 || clip.UUID == "" {
+		t.Errorf("expected the duration of the longest track, got %v", clip.Duration)
+	}
+
+	clip.Duration = 1.5
+	clip.Trim()
+	if len(clip.Tracks[0].Times) != 1 || len(clip.Tracks[1].Times) != 1 {
+		t.Errorf("expected the keyframes after the end to be removed")
+	}
+
+	clone := clip.Clone()
+	clone.Tracks[0].Values[0] = 5
+	if clone.UUID == clip.UUID || clip.Tracks[0].Values[0] != 0 || clone.Duration != 1.5 {
+		t.Error("expected a deep copy with a new uuid")
+	}
+
+	if animation.FindAnimationClipByName([]*animation.AnimationClip{clone, clip}, "wave") != clone {
+		t.Error("expected the first clip with the name")
+	}
+	if animation.FindAnimationClipByName(nil, "wave") != nil {
+		t.Error("expected no clip")
+	}
+}
@@ -0,0 +1,350 @@
+/*
+Package animation plays keyframe animations on the objects of a scene.
+Clips hold the keyframe tracks of an animation, a mixer creates an action
+for each clip that is played below a root object and blends the values
+of all running actions into the properties that their tracks are bound to.
+*/
+package animation
+
+import (
+	"github.com/golang/glog"
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/objects"
+)
+
+// actionKey identifies the action playing a clip below a root
+type actionKey struct {
+	clip *AnimationClip
+	root objects.Node
+}
+
+// bindingKey identifies the property bound by a track below a root
+type bindingKey struct {
+	root objects.Node
+	path string
+}
+
+// AnimationMixer plays the actions of clips on the objects below
+// a root and blends their results, Update advances all actions
+type AnimationMixer struct {
+	root objects.Node
+
+	// Time is the global time of this mixer in seconds,
+	// TimeScale scales the time passed to Update
+	Time      float64
+	TimeScale float64
+
+	// OnLoop is called when a repeating action wraps around, loopDelta
+	// is the number of times it wrapped and is negative when playing
+	// in reverse
+	OnLoop func(action *AnimationAction, loopDelta int)
+	// OnFinished is called when an action has played all of its
+	// repetitions, direction is -1 when it was playing in reverse
+	OnFinished func(action *AnimationAction, direction int)
+
+	actions  map[actionKey]*AnimationAction
+	bindings map[bindingKey]*propertyMixer
+
+	// active holds the played actions and activeBindings the property
+	// mixers that they use, in the order that they were activated
+	active         []*AnimationAction
+	activeBindings []*propertyMixer
+
+	accuIndex int
+}
+
+// NewAnimationMixer creates a mixer for the objects below root
+func NewAnimationMixer(root objects.Node) *AnimationMixer {
+
+	return &AnimationMixer{
+		root: root,
+
+		TimeScale: 1,
+
+		actions:  make(map[actionKey]*AnimationAction),
+		bindings: make(map[bindingKey]*propertyMixer),
+	}
+
+}
+
+// GetRoot returns the default root of the actions of this mixer
+func (m *AnimationMixer) GetRoot() objects.Node {
+
+	return m.root
+
+}
+
+// ClipAction returns the action that plays clip below root, creating it
+// the first time. The root of the mixer is used if root is nil. Tracks
+// that are invalid or cannot be bound are logged and ignored.
+func (m *AnimationMixer) ClipAction(clip *AnimationClip, root objects.Node) *AnimationAction {
+
+	if nil == root {
+		root = m.root
+	}
+
+	key := actionKey{clip, root}
+	if action, ok := m.actions[key]; ok {
+		return action
+	}
+
+	action := newAnimationAction(m, clip, root)
+
+	for _, track := range clip.Tracks {
+
+		if err := track.Validate(); err != nil {
+			glog.Warningf("animation: skipping track of clip %q: %v", clip.Name, err)
+			continue
+		}
+
+		mixer := m.bindTrack(track, root)
+		if mixer != nil && track.ValueType == StringValue {
+
+			// strings are mapped into the strings of the binding
+			track = track.Clone()
+			for i, v := range track.Values {
+				track.Values[i] = mixer.binding.intern(track.Strings[int(v)])
+			}
+
+		}
+
+		var interpolant math3.Interpolator
+		if mixer != nil {
+			interpolant = track.CreateInterpolant(mixer.buffer[:mixer.valueSize])
+		}
+
+		action.tracks = append(action.tracks, track)
+		action.mixers = append(action.mixers, mixer)
+		action.interpolants = append(action.interpolants, interpolant)
+
+	}
+
+	m.actions[key] = action
+
+	return action
+
+}
+
+// ExistingAction returns the action that plays clip below root, or nil
+// if there is none. The root of the mixer is used if root is nil.
+func (m *AnimationMixer) ExistingAction(clip *AnimationClip, root objects.Node) *AnimationAction {
+
+	if nil == root {
+		root = m.root
+	}
+
+	return m.actions[actionKey{clip, root}]
+
+}
+
+// StopAllAction stops all of the active actions of this mixer
+func (m *AnimationMixer) StopAllAction() *AnimationMixer {
+
+	for _, action := range m.active {
+		action.Stop()
+	}
+
+	return m
+
+}
+
+// Update advances the time of this mixer by deltaTime seconds and
+// sets the animated properties to the blended values of the actions
+func (m *AnimationMixer) Update(deltaTime float64) *AnimationMixer {
+
+	deltaTime *= m.TimeScale
+	m.Time += deltaTime
+
+	timeDirection := 0.0
+	if deltaTime > 0 {
+		timeDirection = 1
+	} else if deltaTime < 0 {
+		timeDirection = -1
+	}
+
+	m.accuIndex ^= 1
+
+	// actions may be stopped from the callbacks, which replaces
+	// the slices rather than modifying them while iterating
+	for _, action := range m.active {
+		if action.Enabled {
+			action.update(m.Time, deltaTime, timeDirection, m.accuIndex)
+		}
+	}
+
+	for _, binding := range m.activeBindings {
+		binding.apply(m.accuIndex)
+	}
+
+	return m
+
+}
+
+// UncacheClip stops and forgets all actions of clip
+func (m *AnimationMixer) UncacheClip(clip *AnimationClip) {
+
+	for key, action := range m.actions {
+		if key.clip == clip {
+			m.removeAction(key, action)
+		}
+	}
+
+}
+
+// UncacheRoot stops and forgets all actions and bindings below root
+func (m *AnimationMixer) UncacheRoot(root objects.Node) {
+
+	for key, action := range m.actions {
+		if key.root == root {
+			m.removeAction(key, action)
+		}
+	}
+
+}
+
+// UncacheAction stops and forgets the action that plays clip below root
+func (m *AnimationMixer) UncacheAction(clip *AnimationClip, root objects.Node) {
+
+	if nil == root {
+		root = m.root
+	}
+
+	key := actionKey{clip, root}
+	if action, ok := m.actions[key]; ok {
+		m.removeAction(key, action)
+	}
+
+}
+
+// bindTrack returns the property mixer of a track below root,
+// creating the binding if no other action uses it yet
+func (m *AnimationMixer) bindTrack(track *KeyframeTrack, root objects.Node) *propertyMixer {
+
+	key := bindingKey{root, track.Name}
+
+	mixer, ok := m.bindings[key]
+	if !ok {
+
+		binding, err := NewPropertyBinding(root, track.Name)
+		if err != nil {
+			glog.Warningf("%v", err)
+			return nil
+		}
+
+		mixer = newPropertyMixer(binding, track.ValueType)
+		m.bindings[key] = mixer
+
+	}
+
+	if mixer.valueSize != track.ValueSize() {
+		glog.Warningf("animation: track %q has %d values per keyframe but its property has %d", track.Name, track.ValueSize(), mixer.valueSize)
+		if mixer.referenceCount == 0 {
+			delete(m.bindings, key)
+		}
+		return nil
+	}
+
+	mixer.referenceCount++
+
+	return mixer
+
+}
+
+func (m *AnimationMixer) removeAction(key actionKey, action *AnimationAction) {
+
+	action.Stop()
+	delete(m.actions, key)
+
+	for i, mixer := range action.mixers {
+
+		if mixer == nil {
+			continue
+		}
+
+		mixer.referenceCount--
+		if mixer.referenceCount == 0 {
+			delete(m.bindings, bindingKey{key.root, action.tracks[i].Name})
+		}
+
+	}
+
+}
+
+func (m *AnimationMixer) isActiveAction(action *AnimationAction) bool {
+
+	for _, a := range m.active {
+		if a == action {
+			return true
+		}
+	}
+
+	return false
+
+}
+
+func (m *AnimationMixer) activateAction(action *AnimationAction) {
+
+	if m.isActiveAction(action) {
+		return
+	}
+
+	for _, mixer := range action.mixers {
+
+		if mixer == nil {
+			continue
+		}
+
+		if mixer.useCount == 0 {
+			mixer.saveOriginalState()
+			m.activeBindings = append(m.activeBindings[:len(m.activeBindings):len(m.activeBindings)], mixer)
+		}
+		mixer.useCount++
+
+	}
+
+	m.active = append(m.active[:len(m.active):len(m.active)], action)
+
+}
+
+func (m *AnimationMixer) deactivateAction(action *AnimationAction) {
+
+	if !m.isActiveAction(action) {
+		return
+	}
+
+	for _, mixer := range action.mixers {
+
+		if mixer == nil {
+			continue
+		}
+
+		mixer.useCount--
+		if mixer.useCount == 0 {
+			mixer.restoreOriginalState()
+			m.activeBindings = removeMixer(m.activeBindings, mixer)
+		}
+
+	}
+
+	active := make([]*AnimationAction, 0, len(m.active))
+	for _, a := range m.active {
+		if a != action {
+			active = append(active, a)
+		}
+	}
+	m.active = active
+
+}
+
+func removeMixer(mixers []*propertyMixer, mixer *propertyMixer) []*propertyMixer {
+
+	result := make([]*propertyMixer, 0, len(mixers))
+	for _, m := range mixers {
+		if m != mixer {
+			result = append(result, m)
+		}
+	}
+
+	return result
+
+}
@@ -0,0 +1,285 @@
+package animation_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/animation"
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/objects"
+)
+
+// testSlide returns a one second clip moving cube along x from 0 to to
+func testSlide(name string, to float64) *animation.AnimationClip {
+	return animation.NewAnimationClip(name, -1, []*animation.KeyframeTrack{
+		animation.NewVectorKeyframeTrack("cube.position", []float64{0, 1}, []float64{0, 0, 0, to, 0, 0}),
+	})
+}
+
+func testCube() (*objects.Object, *objects.Object) {
+	root := objects.NewObject()
+	cube := objects.NewObject()
+	cube.Name = "cube"
+	cube.Position.Set(-1, 0, 0)
+	root.Add(cube)
+	return root, cube
+}
+
+func expectX(t *testing.T, cube *objects.Object, expected float64) {
+	t.Helper()
+	if math.Abs(cube.Position.X-expected) > 1e-9 {
+		t.Errorf("expected x %v, got %v", expected, cube.Position.X)
+	}
+}
+
+func TestAnimationMixer_Play(t *testing.T) {
+	root, cube := testCube()
+	mixer := animation.NewAnimationMixer(root)
+	clip := testSlide("slide", 2)
+
+	action := mixer.ClipAction(clip, nil)
+	if mixer.ClipAction(clip, root) != action || mixer.ExistingAction(clip, nil) != action {
+		t.Error("expected the action to be reused")
+	}
+	if action.IsScheduled() || action.GetClip() != clip || action.GetRoot() != root {
+		t.Error("expected an inactive action for the clip")
+	}
+
+	mixer.Update(0.5)
+	expectX(t, cube, -1)
+
+	action.Play()
+	if !action.IsRunning() {
+		t.Error("expected the action to be running")
+	}
+	mixer.Update(0.25)
+	expectX(t, cube, 0.5)
+
+	mixer.TimeScale = 2
+	mixer.Update(0.25)
+	expectX(t, cube, 1.5)
+	if mixer.Time != 1.25 || action.Time != 0.75 {
+		t.Errorf("expected the mixer and action times, got %v and %v", mixer.Time, action.Time)
+	}
+
+	action.Stop()
+	expectX(t, cube, -1)
+	if action.IsScheduled() || action.Time != 0 {
+		t.Error("expected the action to be stopped and reset")
+	}
+}
+
+func TestAnimationMixer_Weights(t *testing.T) {
+	root, cube := testCube()
+	mixer := animation.NewAnimationMixer(root)
+
+	a := mixer.ClipAction(testSlide("a", 2), nil).Play()
+	b := mixer.ClipAction(testSlide("b", 4), nil).Play()
+	mixer.Update(0.5)
+	expectX(t, cube, 1.5)
+
+	b.Stop()
+	a.SetEffectiveWeight(0.5)
+	mixer.Update(0)
+	expectX(t, cube, 0)
+	if a.GetEffectiveWeight() != 0.5 {
+		t.Errorf("expected the effective weight, got %v", a.GetEffectiveWeight())
+	}
+
+	a.Enabled = false
+	mixer.Update(0)
+	expectX(t, cube, -1)
+}
+
+func TestAnimationMixer_Fades(t *testing.T) {
+	root, cube := testCube()
+	mixer := animation.NewAnimationMixer(root)
+
+	a := mixer.ClipAction(testSlide("a", 2), nil)
+	a.Paused = true
+	a.Time = 1
+	a.Play().FadeIn(1)
+
+	mixer.Update(0.5)
+	expectX(t, cube, 0.5)
+	if a.GetEffectiveWeight() != 0.5 {
+		t.Errorf("expected half of the weight, got %v", a.GetEffectiveWeight())
+	}
+	mixer.Update(1)
+	expectX(t, cube, 2)
+
+	b := mixer.ClipAction(testSlide("b", 4), nil)
+	b.Paused = true
+	b.Time = 1
+	b.Play()
+	a.CrossFadeTo(b, 1, false)
+
+	mixer.Update(0.25)
+	expectX(t, cube, 2.5)
+	mixer.Update(1)
+	expectX(t, cube, 4)
+	if a.Enabled || a.GetEffectiveWeight() != 0 {
+		t.Error("expected the faded out action to be disabled")
+	}
+}
+
+func TestAnimationMixer_Warp(t *testing.T) {
+	root, cube := testCube()
+	mixer := animation.NewAnimationMixer(root)
+
+	action := mixer.ClipAction(testSlide("slide", 2), nil)
+	action.SetLoop(three.LoopOnce, 1).Play()
+	action.SetDuration(4)
+	mixer.Update(1)
+	expectX(t, cube, 0.5)
+
+	action.Halt(1)
+	mixer.Update(1)
+	mixer.Update(1)
+	if !action.Paused || action.GetEffectiveTimeScale() != 0 {
+		t.Error("expected the halted action to be paused")
+	}
+	halted := cube.Position.X
+	mixer.Update(1)
+	expectX(t, cube, halted)
+
+	other := mixer.ClipAction(testSlide("other", 2), cube)
+	other.SyncWith(action)
+	if other.Time != action.Time || other.TimeScale != action.TimeScale {
+		t.Error("expected the time to be copied")
+	}
+
+	action.Reset().SetLoop(three.LoopRepeat, animation.InfiniteRepetitions).SetEffectiveTimeScale(2)
+	action.Warp(2, 4, 1)
+	mixer.Update(1)
+	mixer.Update(0.01)
+	if action.TimeScale != 4 {
+		t.Errorf("expected the final time scale to be applied, got %v", action.TimeScale)
+	}
+}
+
+func TestAnimationMixer_Loops(t *testing.T) {
+	root, cube := testCube()
+	mixer := animation.NewAnimationMixer(root)
+
+	var loops []int
+	var finished []*animation.AnimationAction
+	mixer.OnLoop = func(action *animation.AnimationAction, loopDelta int) {
+		loops = append(loops, loopDelta)
+	}
+	mixer.OnFinished = func(action *animation.AnimationAction, direction int) {
+		finished = append(finished, action)
+	}
+
+	once := mixer.ClipAction(testSlide("once", 2), nil)
+	once.SetLoop(three.LoopOnce, 1)
+	once.ClampWhenFinished = true
+	once.Play()
+	mixer.Update(1.5)
+	expectX(t, cube, 2)
+	if len(finished) != 1 || !once.Paused || once.Time != 1 {
+		t.Fatal("expected the clamped action to finish on the last frame")
+	}
+
+	once.Stop()
+	once.ClampWhenFinished = false
+	once.Play()
+	mixer.Update(1.5)
+	expectX(t, cube, -1)
+	if len(finished) != 2 || once.Enabled {
+		t.Fatal("expected the finished action to be disabled")
+	}
+	once.Stop()
+
+	repeat := mixer.ClipAction(testSlide("repeat", 2), nil)
+	repeat.SetLoop(three.LoopRepeat, 2).Play()
+	mixer.Update(1.25)
+	expectX(t, cube, 0.5)
+	mixer.Update(1)
+	if len(loops) != 1 || loops[0] != 1 || len(finished) != 3 {
+		t.Fatalf("expected a loop and then the end, got %v loops", loops)
+	}
+	repeat.Stop()
+
+	pingPong := mixer.ClipAction(testSlide("pingpong", 2), nil)
+	pingPong.SetLoop(three.LoopPingPong, animation.InfiniteRepetitions).Play()
+	mixer.Update(1.25)
+	expectX(t, cube, 1.5)
+	mixer.Update(1)
+	expectX(t, cube, 0.5)
+}
+
+func TestAnimationMixer_StartAt(t *testing.T) {
+	root, cube := testCube()
+	mixer := animation.NewAnimationMixer(root)
+
+	action := mixer.ClipAction(testSlide("slide", 2), nil).StartAt(1).Play()
+	mixer.Update(0.5)
+	if action.IsRunning() {
+		t.Error("expected the action to wait")
+	}
+	mixer.Update(0.75)
+	expectX(t, cube, 0.5)
+	if !action.IsRunning() {
+		t.Error("expected the action to run")
+	}
+}
+
+func TestAnimationMixer_Smooth(t *testing.T) {
+	root, cube := testCube()
+	mixer := animation.NewAnimationMixer(root)
+
+	track := animation.NewNumberKeyframeTrack("cube.position[x]", []float64{0, 1, 2, 3}, []float64{0, 1, 2, 3})
+	track.Interpolation = three.InterpolateSmooth
+	action := mixer.ClipAction(animation.NewAnimationClip("smooth", -1, []*animation.KeyframeTrack{track}), nil)
+
+	// eases in from the start by default
+	action.SetLoop(three.LoopOnce, 1).Play()
+	mixer.Update(0.5)
+	expectX(t, cube, 0.375)
+
+	action.Stop()
+	action.ZeroSlopeAtStart = false
+	action.Play()
+	mixer.Update(0.5)
+	expectX(t, cube, 0.5)
+}
+
+func TestAnimationMixer_Values(t *testing.T) {
+	root, cube := testCube()
+	mixer := animation.NewAnimationMixer(root)
+
+	turn := math3.NewQuaternion().SetFromAxisAngle(math3.NewVector3().Set(0, 0, 1), math.Pi/2)
+	values := append(make([]float64, 4), turn.ToArray(make([]float64, 4), 0)...)
+	values[3] = 1
+	clip := animation.NewAnimationClip("mixed", 1, []*animation.KeyframeTrack{
+		animation.NewQuaternionKeyframeTrack("cube.quaternion", []float64{0, 1}, values),
+		animation.NewBooleanKeyframeTrack("cube.visible", []float64{0, 0.5}, []bool{true, false}),
+		animation.NewStringKeyframeTrack("cube.name", []float64{0, 0.5}, []string{"cube", "box"}),
+		animation.NewNumberKeyframeTrack("sphere.scale[x]", []float64{0}, []float64{2}),
+		animation.NewNumberKeyframeTrack("cube.position", []float64{0}, []float64{2}),
+	})
+
+	mixer.ClipAction(clip, nil).Play()
+	mixer.Update(0.75)
+
+	expected := math3.NewQuaternion().SetFromAxisAngle(math3.NewVector3().Set(0, 0, 1), math.Pi*3/8)
+	if math.Abs(cube.Quaternion.Dot(expected)-1) > 1e-9 {
+		t.Errorf("expected the interpolated rotation, got %v", cube.Quaternion)
+	}
+	if cube.Visible || cube.Name != "box" {
+		t.Error("expected the discrete values")
+	}
+	expectX(t, cube, -1)
+
+	mixer.StopAllAction()
+	if !cube.Visible || cube.Name != "cube" || cube.Quaternion.GetW() != 1 {
+		t.Error("expected the original values to be restored")
+	}
+
+	mixer.UncacheClip(clip)
+	if mixer.ExistingAction(clip, nil) != nil {
+		t.Error("expected the action to be forgotten")
+	}
+}
@@ -0,0 +1,370 @@
+package animation
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/math3"
+)
+
+// ValueType names the kind of property that a keyframe track animates,
+// it decides how keyframes are interpolated and how actions are blended
+type ValueType string
+
+const (
+	NumberValue     ValueType = "number"
+	VectorValue     ValueType = "vector"
+	QuaternionValue ValueType = "quaternion"
+	ColorValue      ValueType = "color"
+	BooleanValue    ValueType = "bool"
+	StringValue     ValueType = "string"
+)
+
+// KeyframeTrack is a timed sequence of values for a single property,
+// which is named by a path that is resolved by a PropertyBinding
+type KeyframeTrack struct {
+	Name      string
+	ValueType ValueType
+
+	// Times holds the time of each keyframe in seconds in increasing
+	// order, and Values holds the same number of values for each keyframe.
+	// Booleans are stored as 0 and 1, strings are stored as indices into
+	// Strings.
+	Times   []float64
+	Values  []float64
+	Strings []string
+
+	// Interpolation is one of three.InterpolateDiscrete,
+	// three.InterpolateLinear or three.InterpolateSmooth, quaternion tracks
+	// cannot be smooth and boolean and string tracks are always discrete
+	Interpolation int
+}
+
+func newKeyframeTrack(name string, valueType ValueType, times, values []float64, interpolation int) *KeyframeTrack {
+
+	return &KeyframeTrack{
+		Name:          name,
+		ValueType:     valueType,
+		Times:         times,
+		Values:        values,
+		Interpolation: interpolation,
+	}
+
+}
+
+// NewNumberKeyframeTrack creates a track of single numbers,
+// such as the intensity of a light or a morph target influence
+func NewNumberKeyframeTrack(name string, times, values []float64) *KeyframeTrack {
+
+	return newKeyframeTrack(name, NumberValue, times, values, three.InterpolateLinear)
+
+}
+
+// NewVectorKeyframeTrack creates a track of vectors, such as the position
+// or scale of an object. The size of the vectors is given by the number
+// of values for each keyframe.
+func NewVectorKeyframeTrack(name string, times, values []float64) *KeyframeTrack {
+
+	return newKeyframeTrack(name, VectorValue, times, values, three.InterpolateLinear)
+
+}
+
+// NewQuaternionKeyframeTrack creates a track of rotations stored as
+// x, y, z, w quaternions which are spherically interpolated
+func NewQuaternionKeyframeTrack(name string, times, values []float64) *KeyframeTrack {
+
+	return newKeyframeTrack(name, QuaternionValue, times, values, three.InterpolateLinear)
+
+}
+
+// NewColorKeyframeTrack creates a track of r, g, b colors
+func NewColorKeyframeTrack(name string, times, values []float64) *KeyframeTrack {
+
+	return newKeyframeTrack(name, ColorValue, times, values, three.InterpolateLinear)
+
+}
+
+// NewBooleanKeyframeTrack creates a track of flags, such as the
+// visibility of an object, which switch at each keyframe
+func NewBooleanKeyframeTrack(name string, times []float64, values []bool) *KeyframeTrack {
+
+	array := make([]float64, len(values))
+	for i, v := range values {
+		if v {
+			array[i] = 1
+		}
+	}
+
+	return newKeyframeTrack(name, BooleanValue, times, array, three.InterpolateDiscrete)
+
+}
+
+// NewStringKeyframeTrack creates a track of strings which switch
+// at each keyframe, each distinct string is stored once in Strings
+func NewStringKeyframeTrack(name string, times []float64, values []string) *KeyframeTrack {
+
+	t := newKeyframeTrack(name, StringValue, times, make([]float64, len(values)), three.InterpolateDiscrete)
+
+	indices := make(map[string]int)
+	for i, v := range values {
+
+		index, ok := indices[v]
+		if !ok {
+			index = len(t.Strings)
+			indices[v] = index
+			t.Strings = append(t.Strings, v)
+		}
+		t.Values[i] = float64(index)
+
+	}
+
+	return t
+
+}
+
+// ValueSize returns the number of values stored for each keyframe
+func (t *KeyframeTrack) ValueSize() int {
+
+	if len(t.Times) == 0 {
+		return 0
+	}
+
+	return len(t.Values) / len(t.Times)
+
+}
+
+// CreateInterpolant returns an interpolant that samples this track
+// into result, which is created if nil. Quaternions are always
+// spherically interpolated unless the track is discrete.
+func (t *KeyframeTrack) CreateInterpolant(result []float64) math3.Interpolator {
+
+	stride := t.ValueSize()
+
+	switch {
+
+	case t.Interpolation == three.InterpolateDiscrete:
+		return math3.NewDiscreteInterpolant(t.Times, t.Values, stride, result)
+
+	case t.ValueType == QuaternionValue:
+		return math3.NewQuaternionLinearInterpolant(t.Times, t.Values, stride, result)
+
+	case t.Interpolation == three.InterpolateSmooth:
+		return math3.NewCubicInterpolant(t.Times, t.Values, stride, result)
+
+	default:
+		return math3.NewLinearInterpolant(t.Times, t.Values, stride, result)
+
+	}
+
+}
+
+// Evaluate samples this track at the given time into result, which is
+// created if nil. Times before the first or after the last keyframe
+// hold the value of that keyframe.
+func (t *KeyframeTrack) Evaluate(time float64, result []float64) []float64 {
+
+	return t.CreateInterpolant(result).Evaluate(time)
+
+}
+
+// Shift moves all keyframes of this track by timeOffset seconds
+func (t *KeyframeTrack) Shift(timeOffset float64) *KeyframeTrack {
+
+	if timeOffset != 0 {
+		for i := range t.Times {
+			t.Times[i] += timeOffset
+		}
+	}
+
+	return t
+
+}
+
+// Scale multiplies the times of all keyframes of this track by timeScale
+func (t *KeyframeTrack) Scale(timeScale float64) *KeyframeTrack {
+
+	if timeScale != 1 {
+		for i := range t.Times {
+			t.Times[i] *= timeScale
+		}
+	}
+
+	return t
+
+}
+
+// Trim removes the keyframes outside of startTime and endTime,
+// keeping at least one keyframe
+func (t *KeyframeTrack) Trim(startTime, endTime float64) *KeyframeTrack {
+
+	nKeys := len(t.Times)
+	from, to := 0, nKeys-1
+
+	for from != nKeys && t.Times[from] < startTime {
+		from++
+	}
+	for to != -1 && t.Times[to] > endTime {
+		to--
+	}
+	// inclusive to exclusive bound
+	to++
+
+	if from != 0 || to != nKeys {
+
+		// empty tracks are invalid, so keep at least one keyframe
+		if from >= to {
+			if to < 1 {
+				to = 1
+			}
+			from = to - 1
+		}
+
+		stride := t.ValueSize()
+		t.Times = t.Times[from:to]
+		t.Values = t.Values[from*stride : to*stride]
+
+	}
+
+	return t
+
+}
+
+// Validate reports the first problem that would
+// keep this track from being sampled correctly
+func (t *KeyframeTrack) Validate() error {
+
+	if len(t.Times) == 0 {
+		return fmt.Errorf("animation: track %q has no keyframes", t.Name)
+	}
+
+	stride := t.ValueSize()
+	if stride == 0 || len(t.Values) != stride*len(t.Times) {
+		return fmt.Errorf("animation: track %q has %d values for %d keyframes", t.Name, len(t.Values), len(t.Times))
+	}
+
+	switch t.ValueType {
+
+	case NumberValue, VectorValue, ColorValue, QuaternionValue:
+		switch t.Interpolation {
+		case three.InterpolateDiscrete, three.InterpolateLinear:
+		case three.InterpolateSmooth:
+			if t.ValueType == QuaternionValue {
+				return fmt.Errorf("animation: track %q of quaternions cannot be smooth", t.Name)
+			}
+		default:
+			return fmt.Errorf("animation: track %q has unsupported interpolation %d", t.Name, t.Interpolation)
+		}
+
+	case BooleanValue, StringValue:
+		if t.Interpolation != three.InterpolateDiscrete {
+			return fmt.Errorf("animation: track %q of %s values must be discrete", t.Name, t.ValueType)
+		}
+
+	default:
+		return fmt.Errorf("animation: track %q has unknown value type %q", t.Name, t.ValueType)
+
+	}
+
+	for i, time := range t.Times {
+
+		if math.IsNaN(time) || math.IsInf(time, 0) {
+			return fmt.Errorf("animation: track %q has an invalid time at keyframe %d", t.Name, i)
+		}
+		if i > 0 && time < t.Times[i-1] {
+			return fmt.Errorf("animation: track %q is out of order at keyframe %d", t.Name, i)
+		}
+
+	}
+
+	for i, v := range t.Values {
+
+		if math.IsNaN(v) {
+			return fmt.Errorf("animation: track %q has an invalid value at keyframe %d", t.Name, i/stride)
+		}
+		if t.ValueType == StringValue && (v < 0 || int(v) >= len(t.Strings)) {
+			return fmt.Errorf("animation: track %q has no string %v", t.Name, v)
+		}
+
+	}
+
+	return nil
+
+}
+
+// Optimize removes keyframes that share their time with the next keyframe
+// and keyframes whose values match both of their neighbors
+func (t *KeyframeTrack) Optimize() *KeyframeTrack {
+
+	times, values := t.Times, t.Values
+	stride := t.ValueSize()
+
+	writeIndex := 1
+	lastIndex := len(times) - 1
+
+	for i := 1; i < lastIndex; i++ {
+
+		keep := false
+
+		time := times[i]
+		timeNext := times[i+1]
+
+		// remove adjacent keyframes scheduled at the same time
+		if time != timeNext && (i != 1 || time != times[0]) {
+
+			// remove unnecessary keyframes same as their neighbors
+			offset := i * stride
+			offsetP, offsetN := offset-stride, offset+stride
+
+			for j := 0; j != stride; j++ {
+
+				value := values[offset+j]
+				if value != values[offsetP+j] || value != values[offsetN+j] {
+					keep = true
+					break
+				}
+
+			}
+
+		}
+
+		// in place compaction
+		if keep {
+
+			if i != writeIndex {
+				times[writeIndex] = times[i]
+				copy(values[writeIndex*stride:], values[i*stride:(i+1)*stride])
+			}
+			writeIndex++
+
+		}
+
+	}
+
+	// flush the last keyframe, the compaction looks ahead
+	if lastIndex > 0 {
+		times[writeIndex] = times[lastIndex]
+		copy(values[writeIndex*stride:], values[lastIndex*stride:(lastIndex+1)*stride])
+		writeIndex++
+	}
+
+	if writeIndex < len(times) {
+		t.Times = times[:writeIndex]
+		t.Values = values[:writeIndex*stride]
+	}
+
+	return t
+
+}
+
+// Clone returns a deep copy of this track
+func (t *KeyframeTrack) Clone() *KeyframeTrack {
+
+	clone := *t
+	clone.Times = append([]float64(nil), t.Times...)
+	clone.Values = append([]float64(nil), t.Values...)
+	clone.Strings = append([]string(nil), t.Strings...)
+
+	return &clone
+
+}
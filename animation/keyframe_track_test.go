@@ -0,0 +1,142 @@
+package animation_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/animation"
+	"github.com/rydrman/three.go/math3"
+)
+
+func TestKeyframeTrack_Evaluate(t *testing.T) {
+	track := animation.NewVectorKeyframeTrack(".position", []float64{0, 1, 3}, []float64{
+		0, 0, 0, 1, 2, 3, 5, 2, 3,
+	})
+	if track.ValueSize() != 3 {
+		t.Fatalf("expected a value size of 3, got %d", track.ValueSize())
+	}
+
+	tests := []struct {
+		time     float64
+		expected []float64
+	}{
+		{-1, []float64{0, 0, 0}},
+		{0.5, []float64{0.5, 1, 1.5}},
+		{1, []float64{1, 2, 3}},
+		{2, []float64{3, 2, 3}},
+		{4, []float64{5, 2, 3}},
+	}
+	for _, test := range tests {
+		result := track.Evaluate(test.time, nil)
+		for i := range result {
+			if math.Abs(result[i]-test.expected[i]) > 1e-9 {
+				t.Errorf("%v: expected %v, got %v", test.time, test.expected, result)
+				break
+			}
+		}
+	}
+
+	track.Interpolation = three.InterpolateDiscrete
+	if result := track.Evaluate(2.9, nil); result[0] != 1 {
+		t.Errorf("expected the previous keyframe, got %v", result)
+	}
+}
+
+func TestKeyframeTrack_Smooth(t *testing.T) {
+	track := animation.NewNumberKeyframeTrack(".opacity", []float64{0, 1, 2}, []float64{0, 1, 0})
+	track.Interpolation = three.InterpolateSmooth
+	if err := track.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if result := track.Evaluate(0.5, nil); math.Abs(result[0]-0.625) > 1e-9 {
+		t.Errorf("expected the curve through the keyframes, got %v", result)
+	}
+
+	interpolant := track.CreateInterpolant(nil)
+	if _, ok := interpolant.(*math3.CubicInterpolant); !ok {
+		t.Errorf("expected a cubic interpolant, got %T", interpolant)
+	}
+}
+
+func TestKeyframeTrack_Quaternion(t *testing.T) {
+	a := math3.NewQuaternion()
+	b := math3.NewQuaternion().SetFromAxisAngle(math3.NewVector3().Set(0, 1, 0), math.Pi/2)
+	track := animation.NewQuaternionKeyframeTrack(".quaternion", []float64{0, 2}, append(a.ToArray(make([]float64, 4), 0), b.ToArray(make([]float64, 4), 0)...))
+
+	expected := math3.NewQuaternion().SetFromAxisAngle(math3.NewVector3().Set(0, 1, 0), math.Pi/4)
+	result := math3.NewQuaternion().FromArray(track.Evaluate(1, nil), 0)
+	if math.Abs(result.Dot(expected)-1) > 1e-9 {
+		t.Errorf("expected the halfway rotation, got %v", result)
+	}
+}
+
+func TestKeyframeTrack_Discrete(t *testing.T) {
+	visible := animation.NewBooleanKeyframeTrack(".visible", []float64{0, 1}, []bool{true, false})
+	if visible.Interpolation != three.InterpolateDiscrete || visible.Evaluate(0.9, nil)[0] != 1 || visible.Evaluate(1, nil)[0] != 0 {
+		t.Error("expected the flag to switch at the keyframe")
+	}
+
+	name := animation.NewStringKeyframeTrack(".name", []float64{0, 1, 2}, []string{"a", "b", "a"})
+	if len(name.Strings) != 2 || name.Strings[int(name.Evaluate(1.5, nil)[0])] != "b" {
+		t.Errorf("expected each string to be stored once, got %v", name.Strings)
+	}
+}
+
+func TestKeyframeTrack_Optimize(t *testing.T) {
+	track := animation.NewNumberKeyframeTrack(".opacity", []float64{0, 1, 2, 3, 3, 4}, []float64{0, 1, 1, 1, 2, 2})
+	track.Optimize()
+
+	expectedTimes := []float64{0, 1, 3, 4}
+	expectedValues := []float64{0, 1, 2, 2}
+	if len(track.Times) != len(expectedTimes) {
+		t.Fatalf("expected times %v, got %v", expectedTimes, track.Times)
+	}
+	for i := range expectedTimes {
+		if track.Times[i] != expectedTimes[i] || track.Values[i] != expectedValues[i] {
+			t.Fatalf("expected %v %v, got %v %v", expectedTimes, expectedValues, track.Times, track.Values)
+		}
+	}
+}
+
+func TestKeyframeTrack_Trim(t *testing.T) {
+	track := animation.NewVectorKeyframeTrack(".scale", []float64{-1, 0, 1, 2}, []float64{0, 0, 1, 1, 2, 2, 3, 3})
+	track.Trim(0, 1)
+	if len(track.Times) != 2 || track.Times[0] != 0 || track.Values[3] != 2 {
+		t.Errorf("expected the keyframes inside the range, got %v %v", track.Times, track.Values)
+	}
+
+	track.Trim(5, 6)
+	if len(track.Times) != 1 {
+		t.Errorf("expected a single keyframe to be kept, got %v", track.Times)
+	}
+
+	track = animation.NewNumberKeyframeTrack(".fov", []float64{1, 2}, []float64{10, 20})
+	track.Shift(1).Scale(2)
+	if track.Times[0] != 4 || track.Times[1] != 6 {
+		t.Errorf("expected shifted and scaled times, got %v", track.Times)
+	}
+}
+
+func TestKeyframeTrack_Validate(t *testing.T) {
+	tests := []*animation.KeyframeTrack{
+		animation.NewNumberKeyframeTrack(".a", nil, nil),
+		animation.NewNumberKeyframeTrack(".a", []float64{0, 1}, []float64{1, 2, 3}),
+		animation.NewNumberKeyframeTrack(".a", []float64{1, 0}, []float64{1, 2}),
+		animation.NewNumberKeyframeTrack(".a", []float64{0, math.NaN()}, []float64{1, 2}),
+		animation.NewNumberKeyframeTrack(".a", []float64{0, 1}, []float64{1, math.NaN()}),
+		{Name: ".a", ValueType: animation.BooleanValue, Times: []float64{0}, Values: []float64{1}, Interpolation: three.InterpolateLinear},
+		{Name: ".a", ValueType: animation.StringValue, Times: []float64{0}, Values: []float64{1}, Interpolation: three.InterpolateDiscrete},
+		{Name: ".a", ValueType: animation.QuaternionValue, Times: []float64{0}, Values: []float64{0, 0, 0, 1}, Interpolation: three.InterpolateSmooth},
+	}
+
+	for i, track := range tests {
+		if err := track.Validate(); err == nil {
+			t.Errorf("%d: expected an error", i)
+		}
+	}
+
+	if err := animation.NewColorKeyframeTrack(".color", []float64{0, 0}, []float64{1, 0, 0, 0, 1, 0}).Validate(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,482 @@
+package animation
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/objects"
+)
+
+// TrackPath is the parsed form of a track name such as "arm.quaternion",
+// "body.morphTargetInfluences[smile]" or "skin.bones[hip].position",
+// the general form is node.object[objectIndex].property[propertyIndex]
+type TrackPath struct {
+	// Directory is any leading part of the path ending in a / or :
+	Directory string
+	// NodeName is the name or uuid of the animated node, an empty
+	// name refers to the root that the binding is created for
+	NodeName string
+
+	ObjectName  string
+	ObjectIndex string
+
+	PropertyName  string
+	PropertyIndex string
+}
+
+var trackNamePattern = regexp.MustCompile(`^((?:[\w-]+[/:])*)([\w-]+)?(?:\.([\w-]+)(?:\[(.+)\])?)?\.([\w-]+)(?:\[(.+)\])?$`)
+
+// ParseTrackName splits a track name into its parts
+func ParseTrackName(name string) (*TrackPath, error) {
+
+	matches := trackNamePattern.FindStringSubmatch(name)
+	if matches == nil {
+		return nil, fmt.Errorf("animation: cannot parse track name %q", name)
+	}
+
+	return &TrackPath{
+		Directory:     matches[1],
+		NodeName:      matches[2],
+		ObjectName:    matches[3],
+		ObjectIndex:   matches[4],
+		PropertyName:  matches[5],
+		PropertyIndex: matches[6],
+	}, nil
+
+}
+
+// FindNode returns the node below root with the given name
+// or uuid, searching the bones of a skinned root first. Empty names and
+// the name or uuid of root itself return root.
+func FindNode(root objects.Node, name string) objects.Node {
+
+	o := objects.ObjectOf(root)
+	if name == "" || name == "root" || name == "." || name == o.Name || name == o.UUID {
+		return root
+	}
+
+	if mesh, ok := root.(*objects.SkinnedMesh); ok && mesh.Skeleton != nil {
+		if bone := mesh.Skeleton.GetBoneByName(name); bone != nil {
+			return bone
+		}
+	}
+
+	return findChild(root, name)
+
+}
+
+func findChild(node objects.Node, name string) objects.Node {
+
+	for _, child := range node.GetChildren() {
+
+		if o := objects.ObjectOf(child); o.Name == name || o.UUID == name {
+			return child
+		}
+		if found := findChild(child, name); found != nil {
+			return found
+		}
+
+	}
+
+	return nil
+
+}
+
+// PropertyBinding connects a track to the property of a node that it
+// animates, copying values between the property and flat buffers
+type PropertyBinding struct {
+	Path *TrackPath
+	// Node is the node found for the path
+	Node objects.Node
+
+	valueSize int
+	get       func(buffer []float64, offset int)
+	set       func(buffer []float64, offset int)
+
+	// strings holds the values of string properties, which
+	// are stored in buffers as indices into this slice
+	strings []string
+	indices map[string]int
+}
+
+// NewPropertyBinding resolves the path of a track below root. Property
+// and object names are matched against the exported fields of the
+// animated node without regard to case, so "quaternion" is bound to the
+// Quaternion of an object and "material.opacity" to the opacity of its
+// material. Properties may be numbers, booleans, strings, float64 slices
+// or any of the math3 vector, quaternion, euler or color types.
+func NewPropertyBinding(root objects.Node, trackName string) (*PropertyBinding, error) {
+
+	path, err := ParseTrackName(trackName)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &PropertyBinding{
+		Path: path,
+		Node: FindNode(root, path.NodeName),
+	}
+
+	if b.Node == nil {
+		return nil, fmt.Errorf("animation: track %q: no node named %q", trackName, path.NodeName)
+	}
+
+	if err := b.bind(); err != nil {
+		return nil, fmt.Errorf("animation: track %q: %v", trackName, err)
+	}
+
+	return b, nil
+
+}
+
+// ValueSize returns the number of buffer values used by the property
+func (b *PropertyBinding) ValueSize() int {
+
+	return b.valueSize
+
+}
+
+// GetValue copies the current value of the property into buffer at offset
+func (b *PropertyBinding) GetValue(buffer []float64, offset int) {
+
+	b.get(buffer, offset)
+
+}
+
+// SetValue sets the property to the value in buffer at offset
+func (b *PropertyBinding) SetValue(buffer []float64, offset int) {
+
+	b.set(buffer, offset)
+
+}
+
+// intern returns the index of s in the strings of this binding
+func (b *PropertyBinding) intern(s string) float64 {
+
+	index, ok := b.indices[s]
+	if !ok {
+
+		if b.indices == nil {
+			b.indices = make(map[string]int)
+		}
+		index = len(b.strings)
+		b.indices[s] = index
+		b.strings = append(b.strings, s)
+
+	}
+
+	return float64(index)
+
+}
+
+// field returns the exported field of the struct that v points to
+// whose name matches name without regard to case
+func field(v reflect.Value, name string) (reflect.Value, error) {
+
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+
+		if v.IsNil() {
+			return reflect.Value{}, fmt.Errorf("cannot find %q in a nil value", name)
+		}
+		v = v.Elem()
+
+	}
+
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("cannot find %q in a %s", name, v.Type())
+	}
+
+	f := v.FieldByNameFunc(func(n string) bool {
+		return unicode.IsUpper([]rune(n)[0]) && strings.EqualFold(n, name)
+	})
+	if !f.IsValid() {
+		return reflect.Value{}, fmt.Errorf("%s has no property %q", v.Type(), name)
+	}
+
+	return f, nil
+
+}
+
+// element returns the element of a slice at a numeric index
+func element(v reflect.Value, index string) (reflect.Value, error) {
+
+	if v.Kind() != reflect.Slice {
+		return reflect.Value{}, fmt.Errorf("cannot index a %s", v.Type())
+	}
+
+	i, err := strconv.Atoi(index)
+	if err != nil || i < 0 || i >= v.Len() {
+		return reflect.Value{}, fmt.Errorf("index %q is out of range", index)
+	}
+
+	return v.Index(i), nil
+
+}
+
+// bind finds the animated property and sets up the accessors for it
+func (b *PropertyBinding) bind() error {
+
+	path := b.Path
+	target := reflect.ValueOf(b.Node)
+
+	if path.ObjectName != "" {
+
+		var err error
+		index := path.ObjectIndex
+
+		switch path.ObjectName {
+
+		case "materials":
+			// the materials of a multi material
+			if target, err = field(target, "material"); err != nil {
+				return err
+			}
+			if target, err = field(target, "materials"); err != nil {
+				return err
+			}
+
+		case "bones":
+			mesh, ok := b.Node.(*objects.SkinnedMesh)
+			if !ok || mesh.Skeleton == nil {
+				return fmt.Errorf("%T has no skeleton", b.Node)
+			}
+			target = reflect.ValueOf(mesh.Skeleton.Bones)
+
+			// bones may be given by name
+			for i, bone := range mesh.Skeleton.Bones {
+				if bone.Name == index {
+					index = strconv.Itoa(i)
+					break
+				}
+			}
+
+		default:
+			if target, err = field(target, path.ObjectName); err != nil {
+				return err
+			}
+
+		}
+
+		if index != "" {
+			if target, err = element(target, index); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	property, err := field(target, path.PropertyName)
+	if err != nil {
+		return err
+	}
+
+	// nodes are flagged for their world matrix to be recomputed
+	touch := func() {}
+	if node, ok := target.Interface().(objects.Node); ok {
+		o := objects.ObjectOf(node)
+		touch = func() { o.MatrixWorldNeedsUpdate = true }
+	}
+
+	if path.PropertyIndex != "" {
+		return b.bindElement(target, property, touch)
+	}
+
+	return b.bindValue(property, touch)
+
+}
+
+// bindElement binds a single element of a slice or a single component
+// of a vector, morph target influences may be given by name
+func (b *PropertyBinding) bindElement(target, property reflect.Value, touch func()) error {
+
+	index := b.Path.PropertyIndex
+
+	if property.Kind() == reflect.Slice {
+
+		if strings.EqualFold(b.Path.PropertyName, "morphTargetInfluences") {
+			if dictionary, err := field(target, "morphTargetDictionary"); err == nil {
+				if i, ok := dictionary.Interface().(map[string]int)[index]; ok {
+					index = strconv.Itoa(i)
+				}
+			}
+		}
+
+		i, err := strconv.Atoi(index)
+		if err != nil || i < 0 || i >= property.Len() {
+			return fmt.Errorf("index %q of %s is out of range", b.Path.PropertyIndex, b.Path.PropertyName)
+		}
+
+		// the slice is read on each access as it may be replaced
+		element := func() reflect.Value {
+			if i < property.Len() {
+				return property.Index(i)
+			}
+			return reflect.Value{}
+		}
+
+		return b.bindScalar(element, touch)
+
+	}
+
+	component, err := field(property, index)
+	if err != nil {
+		return err
+	}
+
+	return b.bindScalar(func() reflect.Value { return component }, touch)
+
+}
+
+// bindScalar binds a number, boolean or string, value returns
+// the property or an invalid value if it no longer exists
+func (b *PropertyBinding) bindScalar(value func() reflect.Value, touch func()) error {
+
+	v := value()
+	b.valueSize = 1
+
+	switch v.Kind() {
+
+	case reflect.Float32, reflect.Float64:
+		b.get = func(buffer []float64, offset int) {
+			if v := value(); v.IsValid() {
+				buffer[offset] = v.Float()
+			}
+		}
+		b.set = func(buffer []float64, offset int) {
+			if v := value(); v.IsValid() {
+				v.SetFloat(buffer[offset])
+				touch()
+			}
+		}
+
+	case reflect.Bool:
+		b.get = func(buffer []float64, offset int) {
+			if v := value(); v.IsValid() {
+				buffer[offset] = 0
+				if v.Bool() {
+					buffer[offset] = 1
+				}
+			}
+		}
+		b.set = func(buffer []float64, offset int) {
+			if v := value(); v.IsValid() {
+				v.SetBool(buffer[offset] >= 0.5)
+				touch()
+			}
+		}
+
+	case reflect.String:
+		b.get = func(buffer []float64, offset int) {
+			if v := value(); v.IsValid() {
+				buffer[offset] = b.intern(v.String())
+			}
+		}
+		b.set = func(buffer []float64, offset int) {
+			if v, i := value(), int(buffer[offset]); v.IsValid() && i >= 0 && i < len(b.strings) {
+				v.SetString(b.strings[i])
+				touch()
+			}
+		}
+
+	default:
+		return fmt.Errorf("%s is a %s, which cannot be animated", b.Path.PropertyName, v.Type())
+
+	}
+
+	if !v.CanSet() {
+		return fmt.Errorf("%s cannot be set", b.Path.PropertyName)
+	}
+
+	return nil
+
+}
+
+// bindValue binds an entire property
+func (b *PropertyBinding) bindValue(property reflect.Value, touch func()) error {
+
+	// the property is read on each access as the value may be replaced
+	switch property.Interface().(type) {
+
+	case *math3.Vector2:
+		b.valueSize = 2
+		b.get = func(buffer []float64, offset int) {
+			property.Interface().(*math3.Vector2).ToArray(buffer, offset)
+		}
+		b.set = func(buffer []float64, offset int) {
+			property.Interface().(*math3.Vector2).FromArray(buffer, offset)
+			touch()
+		}
+
+	case *math3.Vector3:
+		b.valueSize = 3
+		b.get = func(buffer []float64, offset int) {
+			property.Interface().(*math3.Vector3).ToArray(buffer, offset)
+		}
+		b.set = func(buffer []float64, offset int) {
+			property.Interface().(*math3.Vector3).FromArray(buffer, offset)
+			touch()
+		}
+
+	case *math3.Vector4:
+		b.valueSize = 4
+		b.get = func(buffer []float64, offset int) {
+			property.Interface().(*math3.Vector4).ToArray(buffer, offset)
+		}
+		b.set = func(buffer []float64, offset int) {
+			property.Interface().(*math3.Vector4).FromArray(buffer, offset)
+			touch()
+		}
+
+	case *math3.Quaternion:
+		b.valueSize = 4
+		b.get = func(buffer []float64, offset int) {
+			property.Interface().(*math3.Quaternion).ToArray(buffer, offset)
+		}
+		b.set = func(buffer []float64, offset int) {
+			property.Interface().(*math3.Quaternion).FromArray(buffer, offset)
+			touch()
+		}
+
+	case *math3.Color:
+		b.valueSize = 3
+		b.get = func(buffer []float64, offset int) {
+			property.Interface().(*math3.Color).ToArray(buffer, offset)
+		}
+		b.set = func(buffer []float64, offset int) {
+			property.Interface().(*math3.Color).FromArray(buffer, offset)
+			touch()
+		}
+
+	case *math3.Euler:
+		b.valueSize = 3
+		b.get = func(buffer []float64, offset int) {
+			property.Interface().(*math3.Euler).ToArray(buffer, offset)
+		}
+		b.set = func(buffer []float64, offset int) {
+			property.Interface().(*math3.Euler).Set(buffer[offset], buffer[offset+1], buffer[offset+2], math3.CurrentOrder)
+			touch()
+		}
+
+	case []float64:
+		b.valueSize = property.Len()
+		b.get = func(buffer []float64, offset int) {
+			copy(buffer[offset:offset+b.valueSize], property.Interface().([]float64))
+		}
+		b.set = func(buffer []float64, offset int) {
+			copy(property.Interface().([]float64), buffer[offset:offset+b.valueSize])
+			touch()
+		}
+
+	default:
+		return b.bindScalar(func() reflect.Value { return property }, touch)
+
+	}
+
+	return nil
+
+}
@@ -0,0 +1,201 @@
+package animation_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rydrman/three.go/animation"
+	"github.com/rydrman/three.go/lights"
+	"github.com/rydrman/three.go/materials"
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/objects"
+)
+
+// testRig returns a root holding an arm mesh with two morph targets,
+// a mesh with two materials and a skinned mesh with a single bone
+func testRig() (*objects.Object, *objects.Mesh, *objects.SkinnedMesh) {
+	root := objects.NewObject()
+	root.Name = "root"
+
+	arm := objects.NewMesh(nil, materials.NewMeshStandardMaterial())
+	arm.Name = "arm"
+	arm.MorphTargetInfluences = []float64{0, 0}
+	arm.MorphTargetDictionary = map[string]int{"smile": 0, "frown": 1}
+	root.Add(arm)
+
+	panel := objects.NewMesh(nil, materials.NewMultiMaterial(materials.NewMeshBasicMaterial(), materials.NewMeshBasicMaterial()))
+	panel.Name = "panel"
+	arm.Add(panel)
+
+	hip := objects.NewBone()
+	hip.Name = "hip"
+	body := objects.NewSkinnedMesh(nil, materials.NewMeshBasicMaterial())
+	body.Name = "body"
+	body.Add(hip)
+	root.Add(body)
+	root.UpdateMatrixWorld(true)
+	body.Bind(objects.NewSkeleton([]*objects.Bone{hip}, nil), nil)
+
+	return root, arm, body
+}
+
+func TestPropertyBinding_ParseTrackName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected animation.TrackPath
+	}{
+		{"arm.quaternion", animation.TrackPath{NodeName: "arm", PropertyName: "quaternion"}},
+		{".position[x]", animation.TrackPath{PropertyName: "position", PropertyIndex: "x"}},
+		{"rig/arm.material.opacity", animation.TrackPath{Directory: "rig/", NodeName: "arm", ObjectName: "material", PropertyName: "opacity"}},
+		{"body.bones[hip].scale", animation.TrackPath{NodeName: "body", ObjectName: "bones", ObjectIndex: "hip", PropertyName: "scale"}},
+		{"arm.morphTargetInfluences[smile]", animation.TrackPath{NodeName: "arm", PropertyName: "morphTargetInfluences", PropertyIndex: "smile"}},
+	}
+
+	for _, test := range tests {
+		path, err := animation.ParseTrackName(test.name)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+		} else if *path != test.expected {
+			t.Errorf("%s: expected %+v, got %+v", test.name, test.expected, *path)
+		}
+	}
+
+	for _, name := range []string{"", "arm", "arm quaternion", "arm.position."} {
+		if _, err := animation.ParseTrackName(name); err == nil {
+			t.Errorf("%q: expected an error", name)
+		}
+	}
+}
+
+func TestPropertyBinding_FindNode(t *testing.T) {
+	root, arm, body := testRig()
+
+	if animation.FindNode(root, "") != root || animation.FindNode(root, "root") != root {
+		t.Error("expected the root for an empty name")
+	}
+	if animation.FindNode(root, "panel") != arm.GetChildren()[0] {
+		t.Error("expected a nested child by name")
+	}
+	if animation.FindNode(root, arm.UUID) != arm {
+		t.Error("expected a child by uuid")
+	}
+	if animation.FindNode(body, "hip") != body.Skeleton.Bones[0] {
+		t.Error("expected a bone of the skeleton")
+	}
+	if animation.FindNode(root, "leg") != nil {
+		t.Error("expected no node")
+	}
+}
+
+func TestPropertyBinding_Values(t *testing.T) {
+	root, arm, body := testRig()
+	light := lights.NewPointLight(nil, 1, 0, 1)
+	light.Name = "lamp"
+	root.Add(light)
+
+	buffer := make([]float64, 6)
+	set := func(path string, values ...float64) *animation.PropertyBinding {
+		binding, err := animation.NewPropertyBinding(root, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if binding.ValueSize() != len(values) {
+			t.Fatalf("%s: expected a value size of %d, got %d", path, len(values), binding.ValueSize())
+		}
+		copy(buffer[2:], values)
+		binding.SetValue(buffer, 2)
+		return binding
+	}
+
+	arm.MatrixWorldNeedsUpdate = false
+	set("arm.position", 1, 2, 3)
+	if !arm.Position.Equals(math3.NewVector3().Set(1, 2, 3)) || !arm.MatrixWorldNeedsUpdate {
+		t.Error("expected the position to be set and the node to be flagged")
+	}
+
+	set("arm.quaternion", 0, math.Sqrt2/2, 0, math.Sqrt2/2)
+	if arm.Quaternion.GetW() != math.Sqrt2/2 || math.Abs(arm.Rotation.GetY()-math.Pi/2) > 1e-6 {
+		t.Error("expected the quaternion and rotation to be set")
+	}
+
+	set(".scale[y]", 4)
+	if root.Scale.Y != 4 || root.Scale.X != 1 {
+		t.Error("expected a single component to be set")
+	}
+
+	set("arm.material.opacity", 0.5)
+	if arm.Material.(*materials.MeshStandardMaterial).Opacity != 0.5 {
+		t.Error("expected the opacity of the material")
+	}
+
+	set("arm.material.emissive", 1, 0, 1)
+	if arm.Material.(*materials.MeshStandardMaterial).Emissive.GetHex() != 0xff00ff {
+		t.Error("expected the emissive color of the material")
+	}
+
+	set("panel.materials[1].opacity", 0.25)
+	multi := arm.GetChildren()[0].(*objects.Mesh).Material.(*materials.MultiMaterial)
+	if multi.Materials[0].GetMaterial().Opacity != 1 || multi.Materials[1].GetMaterial().Opacity != 0.25 {
+		t.Error("expected the opacity of the second material")
+	}
+
+	set("body.bones[hip].position", 0, 5, 0)
+	if body.Skeleton.Bones[0].Position.Y != 5 {
+		t.Error("expected the position of the bone")
+	}
+
+	set("arm.morphTargetInfluences[frown]", 0.75)
+	set("arm.morphTargetInfluences[0]", 0.25)
+	if arm.MorphTargetInfluences[0] != 0.25 || arm.MorphTargetInfluences[1] != 0.75 {
+		t.Errorf("expected the influences by name and index, got %v", arm.MorphTargetInfluences)
+	}
+	set("arm.morphTargetInfluences", 1, 1)
+	if arm.MorphTargetInfluences[0] != 1 || arm.MorphTargetInfluences[1] != 1 {
+		t.Errorf("expected all influences, got %v", arm.MorphTargetInfluences)
+	}
+
+	set("lamp.intensity", 3)
+	set("lamp.visible", 0)
+	if light.Intensity != 3 || light.Visible {
+		t.Error("expected the light properties")
+	}
+
+	binding := set("lamp.name", 0)
+	binding.GetValue(buffer, 0)
+	if buffer[0] != 0 {
+		t.Errorf("expected the index of the current string, got %v", buffer[0])
+	}
+
+	arm.Position.Set(7, 8, 9)
+	binding = set("arm.position", 0, 0, 0)
+	arm.Position.Set(7, 8, 9)
+	binding.GetValue(buffer, 1)
+	if buffer[1] != 7 || buffer[3] != 9 {
+		t.Errorf("expected the position to be read, got %v", buffer)
+	}
+}
+
+func TestPropertyBinding_Errors(t *testing.T) {
+	root, _, _ := testRig()
+
+	tests := []string{
+		"arm",
+		"leg.position",
+		"arm.wingspan",
+		"arm.matrix",
+		"arm.drawMode",
+		"arm.morphTargetInfluences[grin]",
+		"arm.morphTargetInfluences[2]",
+		"arm.position[w]",
+		"arm.materials[0].opacity",
+		"panel.materials[2].opacity",
+		"arm.bones[hip].position",
+		"body.bones[knee].position",
+	}
+
+	for _, test := range tests {
+		if _, err := animation.NewPropertyBinding(root, test); err == nil {
+			t.Errorf("%s: expected an error", test)
+		}
+	}
+}
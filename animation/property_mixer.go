@@ -0,0 +1,143 @@
+package animation
+
+import "github.com/rydrman/three.go/math3"
+
+// propertyMixer blends the values that the actions of a mixer
+// produce for a single bound property
+type propertyMixer struct {
+	binding   *PropertyBinding
+	valueSize int
+
+	// buffer holds the incoming value of an action, two accumulators
+	// that alternate between frames and the original value of the
+	// property, each valueSize long
+	buffer []float64
+	mix    func(buffer []float64, dstOffset, srcOffset int, t float64, stride int)
+
+	cumulativeWeight float64
+
+	// useCount is the number of active actions using this mixer and
+	// referenceCount is the number of actions that were created for it
+	useCount       int
+	referenceCount int
+}
+
+func newPropertyMixer(binding *PropertyBinding, valueType ValueType) *propertyMixer {
+
+	m := &propertyMixer{
+		binding:   binding,
+		valueSize: binding.ValueSize(),
+		buffer:    make([]float64, binding.ValueSize()*4),
+		mix:       mixLerp,
+	}
+
+	switch valueType {
+	case QuaternionValue:
+		m.mix = mixSlerp
+	case BooleanValue, StringValue:
+		m.mix = mixSelect
+	}
+
+	return m
+
+}
+
+// accumulate adds the incoming value with the given weight
+// to the accumulator selected by accuIndex
+func (m *propertyMixer) accumulate(accuIndex int, weight float64) {
+
+	stride := m.valueSize
+	offset := accuIndex*stride + stride
+
+	if m.cumulativeWeight == 0 {
+
+		// accuN := incoming * weight
+		copy(m.buffer[offset:offset+stride], m.buffer[:stride])
+		m.cumulativeWeight = weight
+
+	} else {
+
+		// accuN := accuN + incoming * weight
+		m.cumulativeWeight += weight
+		m.mix(m.buffer, offset, 0, weight/m.cumulativeWeight, stride)
+
+	}
+
+}
+
+// apply sets the property to the accumulated value, mixed with the
+// original value when the total weight of the actions is less than one
+func (m *propertyMixer) apply(accuIndex int) {
+
+	stride := m.valueSize
+	offset := accuIndex*stride + stride
+	weight := m.cumulativeWeight
+
+	m.cumulativeWeight = 0
+
+	if weight < 1 {
+		// accuN := accuN + original * (1 - cumulativeWeight)
+		m.mix(m.buffer, offset, stride*3, 1-weight, stride)
+	}
+
+	// only changed values are written to the property
+	for i := stride; i != stride*2; i++ {
+		if m.buffer[i] != m.buffer[i+stride] {
+			m.binding.SetValue(m.buffer, offset)
+			break
+		}
+	}
+
+}
+
+// saveOriginalState remembers the value of the property
+// before any actions using this mixer were played
+func (m *propertyMixer) saveOriginalState() {
+
+	stride := m.valueSize
+	original := stride * 3
+
+	m.binding.GetValue(m.buffer, original)
+
+	// changes are initially detected against the original value
+	for i := stride; i != original; i++ {
+		m.buffer[i] = m.buffer[original+(i%stride)]
+	}
+
+	m.cumulativeWeight = 0
+
+}
+
+// restoreOriginalState sets the property back to the saved value
+func (m *propertyMixer) restoreOriginalState() {
+
+	m.binding.SetValue(m.buffer, m.valueSize*3)
+
+}
+
+func mixSelect(buffer []float64, dstOffset, srcOffset int, t float64, stride int) {
+
+	if t >= 0.5 {
+		copy(buffer[dstOffset:dstOffset+stride], buffer[srcOffset:srcOffset+stride])
+	}
+
+}
+
+func mixSlerp(buffer []float64, dstOffset, srcOffset int, t float64, stride int) {
+
+	for i := 0; i+4 <= stride; i += 4 {
+		math3.QuaternionSlerpFlat(buffer, dstOffset+i, buffer, dstOffset+i, buffer, srcOffset+i, t)
+	}
+
+}
+
+func mixLerp(buffer []float64, dstOffset, srcOffset int, t float64, stride int) {
+
+	s := 1 - t
+
+	for i := 0; i != stride; i++ {
+		j := dstOffset + i
+		buffer[j] = buffer[j]*s + buffer[srcOffset+i]*t
+	}
+
+}
@@ -0,0 +1,109 @@
+/*
+Package cameras contains the cameras that define how a scene
+is projected when it is rendered
+*/
+package cameras
+
+import (
+	"github.com/golang/glog"
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/objects"
+)
+
+// Camera is the common base of all cameras. Cameras look down their
+// local negative z axis and implement math3.Projector.
+type Camera struct {
+	*objects.Object
+
+	// MatrixWorldInverse is the inverse of the world matrix, and is kept
+	// up to date whenever the world matrix of the camera is updated
+	MatrixWorldInverse *math3.Matrix4
+	ProjectionMatrix   *math3.Matrix4
+
+	// singular is set while the world matrix cannot be inverted,
+	// so that it is only reported once
+	singular bool
+}
+
+// NewCamera creates a new camera with an identity projection,
+// this is usually only used as the base of another camera type
+func NewCamera() *Camera {
+
+	return &Camera{
+		Object: objects.NewObject(),
+
+		MatrixWorldInverse: math3.NewMatrix4(),
+		ProjectionMatrix:   math3.NewMatrix4(),
+	}
+
+}
+
+// GetCamera returns the base camera, allowing it to be accessed
+// from any of the specific camera types
+func (c *Camera) GetCamera() *Camera {
+
+	return c
+
+}
+
+// GetProjectionMatrix returns the projection matrix of this camera
+func (c *Camera) GetProjectionMatrix() *math3.Matrix4 {
+
+	return c.ProjectionMatrix
+
+}
+
+// GetMatrixWorldInverse returns the inverse of the world matrix of this camera
+func (c *Camera) GetMatrixWorldInverse() *math3.Matrix4 {
+
+	return c.MatrixWorldInverse
+
+}
+
+// UpdateMatrixWorld updates the world matrix of this camera and its
+// descendants along with the inverse world matrix of this camera. A
+// world matrix that cannot be inverted is reported as a warning and
+// leaves its pseudo-inverse in MatrixWorldInverse.
+func (c *Camera) UpdateMatrixWorld(force bool) {
+
+	c.Object.UpdateMatrixWorld(force)
+
+	err := c.MatrixWorldInverse.InverseOf(c.MatrixWorld)
+	if err != nil && !c.singular {
+		glog.Warningf("cameras: world matrix of camera %q: %v", c.Name, err)
+	}
+	c.singular = err != nil
+
+}
+
+// LookAt rotates this camera so that it faces the given point in local space
+func (c *Camera) LookAt(vector *math3.Vector3) {
+
+	m1 := math3.NewMatrix4().LookAt(c.Position, vector, c.Up)
+
+	c.Quaternion.SetFromRotationMatrix(m1)
+
+}
+
+// GetWorldDirection returns the direction that this camera
+// is looking in world space
+func (c *Camera) GetWorldDirection(target *math3.Vector3) *math3.Vector3 {
+
+	if target == nil {
+		target = math3.NewVector3()
+	}
+
+	return target.Set(0, 0, -1).ApplyQuaternion(c.GetWorldQuaternion(nil))
+
+}
+
+// View describes a sub region of a larger virtual viewport, used when
+// a single image is rendered in tiles or across multiple monitors
+type View struct {
+	FullWidth  float64
+	FullHeight float64
+	OffsetX    float64
+	OffsetY    float64
+	Width      float64
+	Height     float64
+}
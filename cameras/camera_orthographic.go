@@ -0,0 +1,98 @@
+package cameras
+
+// OrthographicCamera projects the scene without perspective, so that
+// the size of an object in the image does not depend on its distance
+type OrthographicCamera struct {
+	*Camera
+
+	Zoom float64
+
+	Left   float64
+	Right  float64
+	Top    float64
+	Bottom float64
+	Near   float64
+	Far    float64
+
+	// View is the sub region of the full image rendered by this
+	// camera, or nil to render the whole image
+	View *View
+}
+
+// NewOrthographicCamera creates a new orthographic camera
+// with an up to date projection matrix
+func NewOrthographicCamera(left, right, top, bottom, near, far float64) *OrthographicCamera {
+
+	c := &OrthographicCamera{
+		Camera: NewCamera(),
+
+		Zoom: 1,
+
+		Left:   left,
+		Right:  right,
+		Top:    top,
+		Bottom: bottom,
+		Near:   near,
+		Far:    far,
+	}
+
+	c.UpdateProjectionMatrix()
+
+	return c
+
+}
+
+// SetViewOffset sets this camera to render only the given sub region
+// of a larger full image, see PerspectiveCamera.SetViewOffset
+func (c *OrthographicCamera) SetViewOffset(fullWidth, fullHeight, x, y, width, height float64) {
+
+	c.View = &View{
+		FullWidth:  fullWidth,
+		FullHeight: fullHeight,
+		OffsetX:    x,
+		OffsetY:    y,
+		Width:      width,
+		Height:     height,
+	}
+
+	c.UpdateProjectionMatrix()
+
+}
+
+// ClearViewOffset removes any view offset set by SetViewOffset
+func (c *OrthographicCamera) ClearViewOffset() {
+
+	c.View = nil
+	c.UpdateProjectionMatrix()
+
+}
+
+// UpdateProjectionMatrix recomputes the projection matrix and must
+// be called after any of the parameters of this camera are changed
+func (c *OrthographicCamera) UpdateProjectionMatrix() {
+
+	dx := (c.Right - c.Left) / (2 * c.Zoom)
+	dy := (c.Top - c.Bottom) / (2 * c.Zoom)
+	cx := (c.Right + c.Left) / 2
+	cy := (c.Top + c.Bottom) / 2
+
+	left := cx - dx
+	right := cx + dx
+	top := cy + dy
+	bottom := cy - dy
+
+	if view := c.View; view != nil {
+
+		scaleW := (c.Right - c.Left) / view.FullWidth / c.Zoom
+		scaleH := (c.Top - c.Bottom) / view.FullHeight / c.Zoom
+
+		left += scaleW * view.OffsetX
+		right = left + scaleW*view.Width
+		top -= scaleH * view.OffsetY
+		bottom = top - scaleH*view.Height
+
+	}
+
+	c.ProjectionMatrix.MakeOrthographic(left, right, top, bottom, c.Near, c.Far)
+
+}
@@ -0,0 +1,167 @@
+package cameras
+
+import (
+	"math"
+
+	"github.com/rydrman/three.go/math3"
+)
+
+// PerspectiveCamera projects the scene with perspective,
+// in the same way that the human eye sees
+type PerspectiveCamera struct {
+	*Camera
+
+	// Fov is the vertical field of view in degrees
+	Fov    float64
+	Zoom   float64
+	Aspect float64
+	Near   float64
+	Far    float64
+
+	// Focus is the object distance used for stereoscopy and depth of field
+	Focus float64
+
+	// View is the sub region of the full image rendered by this
+	// camera, or nil to render the whole image
+	View *View
+
+	// FilmGauge is the height of the film in millimeters,
+	// used when converting to and from a focal length
+	FilmGauge float64
+	// FilmOffset is the horizontal film offset in the same unit as FilmGauge
+	FilmOffset float64
+}
+
+// NewPerspectiveCamera creates a new perspective camera
+// with an up to date projection matrix
+func NewPerspectiveCamera(fov, aspect, near, far float64) *PerspectiveCamera {
+
+	c := &PerspectiveCamera{
+		Camera: NewCamera(),
+
+		Fov:    fov,
+		Zoom:   1,
+		Aspect: aspect,
+		Near:   near,
+		Far:    far,
+
+		Focus: 10,
+
+		FilmGauge:  35,
+		FilmOffset: 0,
+	}
+
+	c.UpdateProjectionMatrix()
+
+	return c
+
+}
+
+// SetFocalLength sets the field of view of this camera from the given
+// focal length, using the current film gauge. The default film gauge
+// is 35, so the focal length is expected to be in mm.
+func (c *PerspectiveCamera) SetFocalLength(focalLength float64) {
+
+	// see http://www.bobatkins.com/photography/technical/field_of_view.html
+	vExtentSlope := 0.5 * c.GetFilmHeight() / focalLength
+
+	c.Fov = math3.Rad2Deg * 2 * math.Atan(vExtentSlope)
+	c.UpdateProjectionMatrix()
+
+}
+
+// GetFocalLength returns the focal length of the current field of view
+func (c *PerspectiveCamera) GetFocalLength() float64 {
+
+	vExtentSlope := math.Tan(math3.Deg2Rad * 0.5 * c.Fov)
+
+	return 0.5 * c.GetFilmHeight() / vExtentSlope
+
+}
+
+// GetEffectiveFOV returns the vertical field of view after zoom is applied
+func (c *PerspectiveCamera) GetEffectiveFOV() float64 {
+
+	return math3.Rad2Deg * 2 * math.Atan(math.Tan(math3.Deg2Rad*0.5*c.Fov)/c.Zoom)
+
+}
+
+// GetFilmWidth returns the width of the image on the film,
+// which is smaller than the film gauge for portrait images
+func (c *PerspectiveCamera) GetFilmWidth() float64 {
+
+	return c.FilmGauge * math.Min(c.Aspect, 1)
+
+}
+
+// GetFilmHeight returns the height of the image on the film,
+// which is smaller than the film gauge for landscape images
+func (c *PerspectiveCamera) GetFilmHeight() float64 {
+
+	return c.FilmGauge / math.Max(c.Aspect, 1)
+
+}
+
+// SetViewOffset sets this camera to render only the given sub region of
+// a larger full image, which is useful for multi monitor setups or when
+// rendering an image in tiles.
+//
+// For example, a 3x2 grid of 1920x1080 monitors would use a full size of
+// 5760x2160, and the monitor in the center of the top row would use:
+//
+//	camera.SetViewOffset(5760, 2160, 1920, 0, 1920, 1080)
+//
+// The aspect of the camera should be the aspect of the full image.
+func (c *PerspectiveCamera) SetViewOffset(fullWidth, fullHeight, x, y, width, height float64) {
+
+	c.Aspect = fullWidth / fullHeight
+
+	c.View = &View{
+		FullWidth:  fullWidth,
+		FullHeight: fullHeight,
+		OffsetX:    x,
+		OffsetY:    y,
+		Width:      width,
+		Height:     height,
+	}
+
+	c.UpdateProjectionMatrix()
+
+}
+
+// ClearViewOffset removes any view offset set by SetViewOffset
+func (c *PerspectiveCamera) ClearViewOffset() {
+
+	c.View = nil
+	c.UpdateProjectionMatrix()
+
+}
+
+// UpdateProjectionMatrix recomputes the projection matrix and must
+// be called after any of the parameters of this camera are changed
+func (c *PerspectiveCamera) UpdateProjectionMatrix() {
+
+	near := c.Near
+	top := near * math.Tan(math3.Deg2Rad*0.5*c.Fov) / c.Zoom
+	height := 2 * top
+	width := c.Aspect * height
+	left := -0.5 * width
+
+	if view := c.View; view != nil {
+
+		left += view.OffsetX * width / view.FullWidth
+		top -= view.OffsetY * height / view.FullHeight
+		width *= view.Width / view.FullWidth
+		height *= view.Height / view.FullHeight
+
+	}
+
+	if skew := c.FilmOffset; skew != 0 {
+
+		left += near * skew / c.GetFilmWidth()
+
+	}
+
+	c.ProjectionMatrix.MakeFrustum(left, left+width, top-height, top, near, c.Far)
+
+}
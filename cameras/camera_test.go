@@ -0,0 +1,130 @@
+package cameras_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rydrman/three.go/cameras"
+	"github.com/rydrman/three.go/math3"
+)
+
+func matrixNear(a, b *math3.Matrix4) bool {
+	tolerance := 0.0001
+	for i, ea := range a.Elements {
+		if math.Abs(ea-b.Elements[i]) > tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+func vectorNear(a, b *math3.Vector3) bool {
+	tolerance := 0.0001
+	return math.Abs(a.X-b.X) < tolerance &&
+		math.Abs(a.Y-b.Y) < tolerance &&
+		math.Abs(a.Z-b.Z) < tolerance
+}
+
+func TestPerspectiveCamera_ProjectionMatrix(t *testing.T) {
+	c := cameras.NewPerspectiveCamera(75, 16.0/9.0, 0.1, 100)
+	expected := math3.NewMatrix4().MakePerspective(75, 16.0/9.0, 0.1, 100)
+
+	if !matrixNear(c.ProjectionMatrix, expected) {
+		t.Error("projection matrix should match Matrix4.MakePerspective")
+	}
+
+	c.Zoom = 2
+	c.UpdateProjectionMatrix()
+	if math.Abs(c.GetEffectiveFOV()-math3.Rad2Deg*2*math.Atan(math.Tan(math3.Deg2Rad*37.5)/2)) > 0.0001 {
+		t.Errorf("unexpected effective fov %f", c.GetEffectiveFOV())
+	}
+}
+
+func TestPerspectiveCamera_FocalLength(t *testing.T) {
+	c := cameras.NewPerspectiveCamera(50, 1, 0.1, 100)
+	c.SetFocalLength(35)
+
+	if math.Abs(c.GetFocalLength()-35) > 0.0001 {
+		t.Errorf("expected focal length of 35, got %f", c.GetFocalLength())
+	}
+	if math.Abs(c.Fov-53.1301) > 0.0001 {
+		t.Errorf("expected fov of 53.1301, got %f", c.Fov)
+	}
+}
+
+func TestPerspectiveCamera_SetViewOffset(t *testing.T) {
+	c := cameras.NewPerspectiveCamera(60, 2, 1, 10)
+	full := c.ProjectionMatrix.Clone()
+
+	c.SetViewOffset(200, 100, 0, 0, 200, 100)
+	if !matrixNear(c.ProjectionMatrix, full) {
+		t.Error("a view covering the full image should not change the projection")
+	}
+
+	// the right half of the image should map the center and right
+	// edge of the full view onto the left and right edges of the tile
+	center, _ := math3.NewVector3().Set(0, 0, 0.5).Unproject(c)
+	edge, _ := math3.NewVector3().Set(1, 0, 0.5).Unproject(c)
+
+	c.SetViewOffset(200, 100, 100, 0, 100, 100)
+	center.Project(c)
+	edge.Project(c)
+	if math.Abs(center.X+1) > 0.0001 || math.Abs(edge.X-1) > 0.0001 {
+		t.Errorf("expected tile edges at -1 and 1, got %f and %f", center.X, edge.X)
+	}
+
+	c.ClearViewOffset()
+	if c.View != nil || !matrixNear(c.ProjectionMatrix, full) {
+		t.Error("clearing the view offset should restore the full projection")
+	}
+}
+
+func TestCamera_ProjectUnproject(t *testing.T) {
+	c := cameras.NewPerspectiveCamera(75, 1, 0.1, 100)
+	c.Position.Set(1, 2, 5)
+	c.LookAt(math3.NewVector3().Set(1, 2, 0))
+	c.UpdateMatrixWorld(false)
+
+	if !matrixNear(math3.NewMatrix4().MultiplyMatrices(c.MatrixWorld, c.MatrixWorldInverse), math3.NewMatrix4()) {
+		t.Error("inverse world matrix should be kept up to date")
+	}
+
+	target := math3.NewVector3().Set(1, 2, 0)
+	projected := target.Clone().Project(c)
+	if !vectorNear(math3.NewVector3().Set(projected.X, projected.Y, 0), math3.NewVector3()) {
+		t.Errorf("the look at target should project to the center, got %s", projected)
+	}
+
+	back, err := projected.Clone().Unproject(c)
+	if err != nil || !vectorNear(back, target) {
+		t.Errorf("unproject should invert project, got %s", back)
+	}
+
+	dir := c.GetWorldDirection(nil)
+	if !vectorNear(dir, math3.NewVector3().Set(0, 0, -1)) {
+		t.Errorf("camera should look down the negative z axis, got %s", dir)
+	}
+}
+
+func TestOrthographicCamera_ProjectionMatrix(t *testing.T) {
+	c := cameras.NewOrthographicCamera(-2, 2, 1, -1, 0.1, 10)
+	expected := math3.NewMatrix4().MakeOrthographic(-2, 2, 1, -1, 0.1, 10)
+
+	if !matrixNear(c.ProjectionMatrix, expected) {
+		t.Error("projection matrix should match Matrix4.MakeOrthographic")
+	}
+
+	c.Zoom = 2
+	c.UpdateProjectionMatrix()
+	expected.MakeOrthographic(-1, 1, 0.5, -0.5, 0.1, 10)
+	if !matrixNear(c.ProjectionMatrix, expected) {
+		t.Error("zoom should shrink the visible area")
+	}
+
+	c.Zoom = 1
+	c.SetViewOffset(400, 200, 200, 0, 200, 100)
+	expected.MakeOrthographic(0, 2, 1, 0, 0.1, 10)
+	if !matrixNear(c.ProjectionMatrix, expected) {
+		t.Error("view offset should select the top right quarter")
+	}
+}
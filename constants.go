@@ -4,6 +4,8 @@
 
 package three
 
+import "github.com/rydrman/three.go/math3"
+
 const (
 	REVISION                         string = "85"
 	MouseLeft                        int    = 0
@@ -116,9 +118,9 @@ const (
 	InterpolateDiscrete                     = 2300
 	InterpolateLinear                       = 2301
 	InterpolateSmooth                       = 2302
-	ZeroCurvatureEnding                     = 2400
-	ZeroSlopeEnding                         = 2401
-	WrapAroundEnding                        = 2402
+	ZeroCurvatureEnding                     = math3.ZeroCurvatureEnding
+	ZeroSlopeEnding                         = math3.ZeroSlopeEnding
+	WrapAroundEnding                        = math3.WrapAroundEnding
 	TrianglesDrawMode                       = 0
 	TriangleStripDrawMode                   = 1
 	TriangleFanDrawMode                     = 2
@@ -0,0 +1,408 @@
+/**
+ * Ported from three.js by @rydrman
+ */
+
+package core
+
+import (
+	"fmt"
+
+	"github.com/rydrman/three.go/math3"
+)
+
+// UpdateRange describes the part of an attribute that has changed
+// and needs to be uploaded again, a negative count means everything
+type UpdateRange struct {
+	Offset int
+	Count  int
+}
+
+// BufferAttribute stores the data for one attribute of a BufferGeometry,
+// such as vertex positions, normals or the index. The data is stored in
+// exactly one of the typed arrays, as chosen by the constructor used.
+type BufferAttribute struct {
+	UUID string
+	Name string
+
+	Float32 []float32
+	Uint16  []uint16
+	Uint32  []uint32
+
+	// ItemSize is the number of values that make up each item,
+	// for example 3 for a position made of x, y and z
+	ItemSize int
+	// Normalized marks integer data that should be mapped into the range
+	// [0, 1] or [-1, 1] when it is read by a shader
+	Normalized bool
+	// Dynamic hints that the data will be updated often,
+	// a static attribute is expected to be uploaded only once
+	Dynamic     bool
+	UpdateRange UpdateRange
+
+	// Version is incremented each time the attribute needs to be uploaded
+	Version int
+}
+
+// NewFloat32BufferAttribute creates a new attribute backed by the given array
+func NewFloat32BufferAttribute(array []float32, itemSize int) *BufferAttribute {
+
+	return &BufferAttribute{
+		UUID:        math3.GenerateUUID(),
+		Float32:     array,
+		ItemSize:    itemSize,
+		UpdateRange: UpdateRange{0, -1},
+	}
+
+}
+
+// NewUint16BufferAttribute creates a new attribute backed by the given array
+func NewUint16BufferAttribute(array []uint16, itemSize int) *BufferAttribute {
+
+	return &BufferAttribute{
+		UUID:        math3.GenerateUUID(),
+		Uint16:      array,
+		ItemSize:    itemSize,
+		UpdateRange: UpdateRange{0, -1},
+	}
+
+}
+
+// NewUint32BufferAttribute creates a new attribute backed by the given array
+func NewUint32BufferAttribute(array []uint32, itemSize int) *BufferAttribute {
+
+	return &BufferAttribute{
+		UUID:        math3.GenerateUUID(),
+		Uint32:      array,
+		ItemSize:    itemSize,
+		UpdateRange: UpdateRange{0, -1},
+	}
+
+}
+
+// NewIndexAttribute creates an index attribute for the given indices,
+// using 16 bit storage when every index fits and 32 bit otherwise
+func NewIndexAttribute(indices []int) *BufferAttribute {
+
+	for _, i := range indices {
+
+		if i > 65535 {
+
+			array := make([]uint32, len(indices))
+			for j, index := range indices {
+				array[j] = uint32(index)
+			}
+
+			return NewUint32BufferAttribute(array, 1)
+
+		}
+
+	}
+
+	array := make([]uint16, len(indices))
+	for j, index := range indices {
+		array[j] = uint16(index)
+	}
+
+	return NewUint16BufferAttribute(array, 1)
+
+}
+
+func (a *BufferAttribute) String() string {
+	return fmt.Sprintf("&BufferAttribute{Name: %q, ItemSize: %d, Count: %d}", a.Name, a.ItemSize, a.Count())
+}
+
+// Len returns the total number of values stored in this attribute
+func (a *BufferAttribute) Len() int {
+
+	switch {
+	case a.Float32 != nil:
+		return len(a.Float32)
+	case a.Uint16 != nil:
+		return len(a.Uint16)
+	default:
+		return len(a.Uint32)
+	}
+
+}
+
+// Count returns the number of items stored in this attribute
+func (a *BufferAttribute) Count() int {
+
+	return a.Len() / a.ItemSize
+
+}
+
+// SameType returns true if the other attribute
+// is backed by the same type of array as this one
+func (a *BufferAttribute) SameType(other *BufferAttribute) bool {
+
+	return (a.Float32 != nil) == (other.Float32 != nil) &&
+		(a.Uint16 != nil) == (other.Uint16 != nil) &&
+		(a.Uint32 != nil) == (other.Uint32 != nil)
+
+}
+
+// SetNeedsUpdate marks this attribute as changed so that
+// it is uploaded again the next time that it is used
+func (a *BufferAttribute) SetNeedsUpdate() {
+
+	a.Version++
+
+}
+
+// SetDynamic sets the usage hint of this attribute
+func (a *BufferAttribute) SetDynamic(dynamic bool) *BufferAttribute {
+
+	a.Dynamic = dynamic
+
+	return a
+
+}
+
+// Get returns the value at the given index of the underlying array
+func (a *BufferAttribute) Get(i int) float64 {
+
+	switch {
+	case a.Float32 != nil:
+		return float64(a.Float32[i])
+	case a.Uint16 != nil:
+		return float64(a.Uint16[i])
+	default:
+		return float64(a.Uint32[i])
+	}
+
+}
+
+// Set sets the value at the given index of the underlying array
+func (a *BufferAttribute) Set(i int, value float64) *BufferAttribute {
+
+	switch {
+	case a.Float32 != nil:
+		a.Float32[i] = float32(value)
+	case a.Uint16 != nil:
+		a.Uint16[i] = uint16(value)
+	default:
+		a.Uint32[i] = uint32(value)
+	}
+
+	return a
+
+}
+
+// GetX returns the first component of the given item
+func (a *BufferAttribute) GetX(index int) float64 {
+
+	return a.Get(index * a.ItemSize)
+
+}
+
+// GetY returns the second component of the given item
+func (a *BufferAttribute) GetY(index int) float64 {
+
+	return a.Get(index*a.ItemSize + 1)
+
+}
+
+// GetZ returns the third component of the given item
+func (a *BufferAttribute) GetZ(index int) float64 {
+
+	return a.Get(index*a.ItemSize + 2)
+
+}
+
+// GetW returns the fourth component of the given item
+func (a *BufferAttribute) GetW(index int) float64 {
+
+	return a.Get(index*a.ItemSize + 3)
+
+}
+
+// SetX sets the first component of the given item
+func (a *BufferAttribute) SetX(index int, x float64) *BufferAttribute {
+
+	return a.Set(index*a.ItemSize, x)
+
+}
+
+// SetY sets the second component of the given item
+func (a *BufferAttribute) SetY(index int, y float64) *BufferAttribute {
+
+	return a.Set(index*a.ItemSize+1, y)
+
+}
+
+// SetZ sets the third component of the given item
+func (a *BufferAttribute) SetZ(index int, z float64) *BufferAttribute {
+
+	return a.Set(index*a.ItemSize+2, z)
+
+}
+
+// SetW sets the fourth component of the given item
+func (a *BufferAttribute) SetW(index int, w float64) *BufferAttribute {
+
+	return a.Set(index*a.ItemSize+3, w)
+
+}
+
+// SetXY sets the first two components of the given item
+func (a *BufferAttribute) SetXY(index int, x, y float64) *BufferAttribute {
+
+	index *= a.ItemSize
+
+	a.Set(index, x)
+	a.Set(index+1, y)
+
+	return a
+
+}
+
+// SetXYZ sets the first three components of the given item
+func (a *BufferAttribute) SetXYZ(index int, x, y, z float64) *BufferAttribute {
+
+	index *= a.ItemSize
+
+	a.Set(index, x)
+	a.Set(index+1, y)
+	a.Set(index+2, z)
+
+	return a
+
+}
+
+// SetXYZW sets the first four components of the given item
+func (a *BufferAttribute) SetXYZW(index int, x, y, z, w float64) *BufferAttribute {
+
+	index *= a.ItemSize
+
+	a.Set(index, x)
+	a.Set(index+1, y)
+	a.Set(index+2, z)
+	a.Set(index+3, w)
+
+	return a
+
+}
+
+// GetVector3 reads the given item into target, a new vector is created
+// if target is nil. Components beyond the item size are set to zero.
+func (a *BufferAttribute) GetVector3(index int, target *math3.Vector3) *math3.Vector3 {
+
+	if target == nil {
+		target = math3.NewVector3()
+	}
+
+	target.Set(0, 0, 0)
+
+	switch {
+	case a.ItemSize >= 3:
+		target.Z = a.GetZ(index)
+		fallthrough
+	case a.ItemSize == 2:
+		target.Y = a.GetY(index)
+		fallthrough
+	case a.ItemSize == 1:
+		target.X = a.GetX(index)
+	}
+
+	return target
+
+}
+
+// SetVector3 writes as many components of the given vector as fit into
+// the given item, it satisfies the math3.Attribute interface
+func (a *BufferAttribute) SetVector3(index int, v *math3.Vector3) {
+
+	switch {
+
+	case a.ItemSize >= 3 && a.Float32 != nil:
+		v.ToArray32(a.Float32, index*a.ItemSize)
+
+	case a.ItemSize >= 3:
+		a.SetXYZ(index, v.X, v.Y, v.Z)
+
+	case a.ItemSize == 2:
+		a.SetXY(index, v.X, v.Y)
+
+	case a.ItemSize == 1:
+		a.SetX(index, v.X)
+
+	}
+
+}
+
+// CopyAt copies the item at index2 of the other attribute
+// into the item at index1 of this one
+func (a *BufferAttribute) CopyAt(index1 int, other *BufferAttribute, index2 int) *BufferAttribute {
+
+	index1 *= a.ItemSize
+	index2 *= other.ItemSize
+
+	for i := 0; i < a.ItemSize; i++ {
+
+		a.Set(index1+i, other.Get(index2+i))
+
+	}
+
+	return a
+
+}
+
+// ToFloat64 returns a copy of the values in this attribute as float64
+func (a *BufferAttribute) ToFloat64() []float64 {
+
+	array := make([]float64, a.Len())
+
+	for i := range array {
+		array[i] = a.Get(i)
+	}
+
+	return array
+
+}
+
+// CopyFloat64 copies the given values into this attribute,
+// the array must not be longer than the attribute
+func (a *BufferAttribute) CopyFloat64(array []float64) *BufferAttribute {
+
+	for i, v := range array {
+		a.Set(i, v)
+	}
+
+	return a
+
+}
+
+// Clone returns a deep copy of this attribute
+func (a *BufferAttribute) Clone() *BufferAttribute {
+
+	return (&BufferAttribute{UUID: math3.GenerateUUID()}).Copy(a)
+
+}
+
+// Copy makes this attribute a deep copy of the source attribute
+func (a *BufferAttribute) Copy(src *BufferAttribute) *BufferAttribute {
+
+	a.Name = src.Name
+
+	a.Float32 = nil
+	a.Uint16 = nil
+	a.Uint32 = nil
+
+	switch {
+	case src.Float32 != nil:
+		a.Float32 = append([]float32{}, src.Float32...)
+	case src.Uint16 != nil:
+		a.Uint16 = append([]uint16{}, src.Uint16...)
+	default:
+		a.Uint32 = append([]uint32{}, src.Uint32...)
+	}
+
+	a.ItemSize = src.ItemSize
+	a.Normalized = src.Normalized
+	a.Dynamic = src.Dynamic
+	a.UpdateRange = src.UpdateRange
+
+	return a
+
+}
@@ -0,0 +1,89 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/math3"
+)
+
+func TestBufferAttribute_Count(t *testing.T) {
+	a := core.NewFloat32BufferAttribute(make([]float32, 12), 3)
+	if a.Count() != 4 || a.Len() != 12 {
+		t.Errorf("expected 4 items of 12 values, got %d of %d", a.Count(), a.Len())
+	}
+}
+
+func TestBufferAttribute_GetSet(t *testing.T) {
+	a := core.NewFloat32BufferAttribute(make([]float32, 8), 4)
+	a.SetXYZW(1, 1, 2, 3, 4)
+	a.SetX(0, 5)
+
+	if a.GetX(1) != 1 || a.GetY(1) != 2 || a.GetZ(1) != 3 || a.GetW(1) != 4 || a.GetX(0) != 5 {
+		t.Errorf("unexpected values %v", a.Float32)
+	}
+
+	v := a.GetVector3(1, nil)
+	if !v.Equals(math3.NewVector3().Set(1, 2, 3)) {
+		t.Errorf("unexpected vector %s", v)
+	}
+
+	a.SetVector3(0, v.Set(7, 8, 9))
+	if a.Float32[0] != 7 || a.Float32[2] != 9 || a.Float32[3] != 0 {
+		t.Errorf("set vector should only change the first three components, got %v", a.Float32)
+	}
+
+	uv := core.NewFloat32BufferAttribute([]float32{1, 2, 3, 4}, 2)
+	if v := uv.GetVector3(0, nil); !v.Equals(math3.NewVector3().Set(1, 2, 0)) {
+		t.Errorf("expected the missing component to be zero, got %s", v)
+	}
+	uv.SetVector3(0, math3.NewVector3().Set(5, 6, 7))
+	if uv.Float32[0] != 5 || uv.Float32[1] != 6 || uv.Float32[2] != 3 {
+		t.Errorf("set vector should not change the next item, got %v", uv.Float32)
+	}
+}
+
+func TestBufferAttribute_Types(t *testing.T) {
+	small := core.NewIndexAttribute([]int{0, 1, 65535})
+	if small.Uint16 == nil || small.Uint32 != nil {
+		t.Error("indices that fit in 16 bits should use 16 bit storage")
+	}
+
+	large := core.NewIndexAttribute([]int{0, 1, 65536})
+	if large.Uint32 == nil || large.Get(2) != 65536 {
+		t.Error("indices that do not fit in 16 bits should use 32 bit storage")
+	}
+
+	if small.SameType(large) {
+		t.Error("attributes with different storage should not be the same type")
+	}
+}
+
+func TestBufferAttribute_Clone(t *testing.T) {
+	a := core.NewUint16BufferAttribute([]uint16{1, 2, 3}, 1)
+	a.Normalized = true
+	a.SetDynamic(true)
+
+	b := a.Clone()
+	b.Set(0, 10)
+
+	if a.Get(0) != 1 {
+		t.Error("clone should create a deep copy")
+	}
+	if !b.Normalized || !b.Dynamic || b.Count() != 3 || b.UUID == a.UUID {
+		t.Error("clone should copy all settings with a new uuid")
+	}
+}
+
+func TestBufferAttribute_NeedsUpdate(t *testing.T) {
+	a := core.NewFloat32BufferAttribute([]float32{1, 2, 3}, 3)
+	a.SetNeedsUpdate()
+	a.SetNeedsUpdate()
+
+	if a.Version != 2 {
+		t.Errorf("expected version 2, got %d", a.Version)
+	}
+	if a.UpdateRange.Count >= 0 {
+		t.Error("new attributes should update their whole range")
+	}
+}
@@ -0,0 +1,514 @@
+/**
+ * Ported from three.js by @rydrman
+ */
+
+package core
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/rydrman/three.go/math3"
+)
+
+// Group is a range of the geometry to be drawn
+// with a single material of a multi material object
+type Group struct {
+	Start         int
+	Count         int
+	MaterialIndex int
+}
+
+// DrawRange limits the part of a geometry that is drawn,
+// a negative count draws everything from the start onward
+type DrawRange struct {
+	Start int
+	Count int
+}
+
+// BufferGeometry is an efficient representation of mesh, line or point
+// geometry, storing vertex data as a set of named typed attributes
+type BufferGeometry struct {
+	UUID string
+	Name string
+
+	// Index is the optional index attribute,
+	// allowing vertices to be reused across triangles
+	Index *BufferAttribute
+	// Attributes holds the vertex data of this geometry by name,
+	// such as "position", "normal" and "uv"
+	Attributes map[string]*BufferAttribute
+	// MorphAttributes holds the morph targets of each attribute
+	MorphAttributes map[string][]*BufferAttribute
+
+	Groups    []Group
+	DrawRange DrawRange
+
+	// BoundingBox and BoundingSphere are nil
+	// until they are computed for the first time
+	BoundingBox    *math3.Box3
+	BoundingSphere *math3.Sphere
+}
+
+// NewBufferGeometry creates a new empty buffer geometry
+func NewBufferGeometry() *BufferGeometry {
+
+	return &BufferGeometry{
+		UUID: math3.GenerateUUID(),
+
+		Attributes:      make(map[string]*BufferAttribute),
+		MorphAttributes: make(map[string][]*BufferAttribute),
+
+		DrawRange: DrawRange{0, -1},
+	}
+
+}
+
+// SetIndex sets the index attribute of this geometry
+func (g *BufferGeometry) SetIndex(index *BufferAttribute) *BufferGeometry {
+
+	g.Index = index
+
+	return g
+
+}
+
+// AddAttribute sets the named attribute of this geometry
+func (g *BufferGeometry) AddAttribute(name string, attribute *BufferAttribute) *BufferGeometry {
+
+	g.Attributes[name] = attribute
+
+	return g
+
+}
+
+// GetAttribute returns the named attribute of this geometry, or nil
+func (g *BufferGeometry) GetAttribute(name string) *BufferAttribute {
+
+	return g.Attributes[name]
+
+}
+
+// RemoveAttribute removes the named attribute from this geometry
+func (g *BufferGeometry) RemoveAttribute(name string) *BufferGeometry {
+
+	delete(g.Attributes, name)
+
+	return g
+
+}
+
+// AddGroup adds a new draw group to this geometry
+func (g *BufferGeometry) AddGroup(start, count, materialIndex int) {
+
+	g.Groups = append(g.Groups, Group{
+		Start:         start,
+		Count:         count,
+		MaterialIndex: materialIndex,
+	})
+
+}
+
+// ClearGroups removes all draw groups from this geometry
+func (g *BufferGeometry) ClearGroups() {
+
+	g.Groups = nil
+
+}
+
+// SetDrawRange limits the part of this geometry that is drawn
+func (g *BufferGeometry) SetDrawRange(start, count int) {
+
+	g.DrawRange.Start = start
+	g.DrawRange.Count = count
+
+}
+
+// ApplyMatrix transforms the positions and normals of this geometry
+// by the given matrix, updating any bounds that have been computed
+func (g *BufferGeometry) ApplyMatrix(matrix *math3.Matrix4) *BufferGeometry {
+
+	if position := g.Attributes["position"]; position != nil {
+
+		matrix.ApplyToBufferAttribute(position)
+		position.SetNeedsUpdate()
+
+	}
+
+	if normal := g.Attributes["normal"]; normal != nil {
+
+		normalMatrix := math3.NewMatrix3().GetNormalMatrix(matrix)
+
+		normalMatrix.ApplyToBufferAttribute(normal)
+		normal.SetNeedsUpdate()
+
+	}
+
+	if g.BoundingBox != nil {
+
+		g.ComputeBoundingBox()
+
+	}
+
+	if g.BoundingSphere != nil {
+
+		g.ComputeBoundingSphere()
+
+	}
+
+	return g
+
+}
+
+// RotateX rotates this geometry around the x axis
+func (g *BufferGeometry) RotateX(angle float64) *BufferGeometry {
+
+	return g.ApplyMatrix(math3.NewMatrix4().MakeRotationX(angle))
+
+}
+
+// RotateY rotates this geometry around the y axis
+func (g *BufferGeometry) RotateY(angle float64) *BufferGeometry {
+
+	return g.ApplyMatrix(math3.NewMatrix4().MakeRotationY(angle))
+
+}
+
+// RotateZ rotates this geometry around the z axis
+func (g *BufferGeometry) RotateZ(angle float64) *BufferGeometry {
+
+	return g.ApplyMatrix(math3.NewMatrix4().MakeRotationZ(angle))
+
+}
+
+// Translate moves this geometry by the given amounts
+func (g *BufferGeometry) Translate(x, y, z float64) *BufferGeometry {
+
+	return g.ApplyMatrix(math3.NewMatrix4().MakeTranslation(x, y, z))
+
+}
+
+// Scale scales this geometry by the given amounts
+func (g *BufferGeometry) Scale(x, y, z float64) *BufferGeometry {
+
+	return g.ApplyMatrix(math3.NewMatrix4().MakeScale(x, y, z))
+
+}
+
+// Center moves this geometry so that its bounding
+// box is centered on the origin, returning the offset applied
+func (g *BufferGeometry) Center() *math3.Vector3 {
+
+	g.ComputeBoundingBox()
+
+	offset := g.BoundingBox.Center(nil).Negate()
+
+	g.Translate(offset.X, offset.Y, offset.Z)
+
+	return offset
+
+}
+
+// ComputeBoundingBox computes the bounding box of the position
+// attribute, an empty box is used when there are no positions
+func (g *BufferGeometry) ComputeBoundingBox() {
+
+	if g.BoundingBox == nil {
+		g.BoundingBox = math3.NewBox3()
+	}
+
+	position := g.Attributes["position"]
+
+	if position == nil || position.Count() == 0 {
+
+		g.BoundingBox.MakeEmpty()
+		return
+
+	}
+
+	g.BoundingBox.SetFromArray(position.ToFloat64())
+
+}
+
+// ComputeBoundingSphere computes the bounding sphere of the
+// position attribute, centered on its bounding box
+func (g *BufferGeometry) ComputeBoundingSphere() {
+
+	if g.BoundingSphere == nil {
+		g.BoundingSphere = math3.NewSphere()
+	}
+
+	position := g.Attributes["position"]
+
+	if position == nil || position.Count() == 0 {
+
+		g.BoundingSphere.Set(math3.NewVector3(), 0)
+		return
+
+	}
+
+	center := g.BoundingSphere.Center
+
+	math3.NewBox3().SetFromArray(position.ToFloat64()).Center(center)
+
+	// hoping to find a boundingSphere with a radius smaller than the
+	// boundingSphere of the boundingBox: sqrt(3) smaller in the best case
+
+	vector := math3.NewVector3()
+	maxRadiusSq := 0.0
+
+	for i, l := 0, position.Count(); i < l; i++ {
+
+		position.GetVector3(i, vector)
+		maxRadiusSq = math.Max(maxRadiusSq, center.DistanceToSquared(vector))
+
+	}
+
+	g.BoundingSphere.Radius = math.Sqrt(maxRadiusSq)
+
+}
+
+// ComputeVertexNormals computes smooth normals for indexed geometry by
+// averaging the normals of the faces that share each vertex, and flat
+// face normals for non-indexed geometry
+func (g *BufferGeometry) ComputeVertexNormals() {
+
+	position := g.Attributes["position"]
+	if position == nil {
+		return
+	}
+
+	count := position.Count()
+
+	normal := g.Attributes["normal"]
+	if normal == nil || normal.Count() != count {
+
+		normal = NewFloat32BufferAttribute(make([]float32, count*3), 3)
+		g.AddAttribute("normal", normal)
+
+	} else {
+
+		for i, l := 0, normal.Len(); i < l; i++ {
+			normal.Set(i, 0)
+		}
+
+	}
+
+	pA, pB, pC := math3.NewVector3(), math3.NewVector3(), math3.NewVector3()
+	nA, nB, nC := math3.NewVector3(), math3.NewVector3(), math3.NewVector3()
+	cb, ab := math3.NewVector3(), math3.NewVector3()
+
+	faceNormal := func(a, b, c int) *math3.Vector3 {
+
+		position.GetVector3(a, pA)
+		position.GetVector3(b, pB)
+		position.GetVector3(c, pC)
+
+		cb.SubVectors(pC, pB)
+		ab.SubVectors(pA, pB)
+
+		return cb.Cross(ab)
+
+	}
+
+	if g.Index != nil {
+
+		// indexed elements
+
+		index := g.Index
+
+		for i, l := 0, index.Len()-2; i < l; i += 3 {
+
+			a := int(index.Get(i))
+			b := int(index.Get(i + 1))
+			c := int(index.Get(i + 2))
+
+			n := faceNormal(a, b, c)
+
+			normal.SetVector3(a, normal.GetVector3(a, nA).Add(n))
+			normal.SetVector3(b, normal.GetVector3(b, nB).Add(n))
+			normal.SetVector3(c, normal.GetVector3(c, nC).Add(n))
+
+		}
+
+	} else {
+
+		// non-indexed elements (unconnected triangle soup)
+
+		for i := 0; i+2 < count; i += 3 {
+
+			n := faceNormal(i, i+1, i+2)
+
+			normal.SetVector3(i, n)
+			normal.SetVector3(i+1, n)
+			normal.SetVector3(i+2, n)
+
+		}
+
+	}
+
+	g.NormalizeNormals()
+
+	normal.SetNeedsUpdate()
+
+}
+
+// NormalizeNormals scales every normal of this geometry to unit length
+func (g *BufferGeometry) NormalizeNormals() {
+
+	normal := g.Attributes["normal"]
+	if normal == nil {
+		return
+	}
+
+	v1 := math3.NewVector3()
+
+	for i, l := 0, normal.Count(); i < l; i++ {
+
+		normal.GetVector3(i, v1)
+
+		if length := v1.Length(); length > 0 {
+			normal.SetVector3(i, v1.DivideScalar(length))
+		}
+
+	}
+
+}
+
+// Merge copies the attributes of the other geometry into the attributes
+// of this one, starting at the given vertex offset. Only attributes that
+// exist in this geometry are merged, and they must be large enough to
+// hold the merged data. Nothing is copied if any attribute cannot be
+// merged.
+func (g *BufferGeometry) Merge(other *BufferGeometry, offset int) error {
+
+	// check every attribute first so that a failed
+	// merge leaves this geometry untouched
+	for name, attribute1 := range g.Attributes {
+
+		attribute2 := other.Attributes[name]
+		if attribute2 == nil {
+			continue
+		}
+
+		if !attribute1.SameType(attribute2) || attribute1.ItemSize != attribute2.ItemSize {
+			return fmt.Errorf("core: cannot merge mismatched %q attributes", name)
+		}
+
+		if offset < 0 || attribute1.ItemSize*offset+attribute2.Len() > attribute1.Len() {
+			return fmt.Errorf("core: %q attribute is too small to merge at offset %d", name, offset)
+		}
+
+	}
+
+	for name, attribute1 := range g.Attributes {
+
+		attribute2 := other.Attributes[name]
+		if attribute2 == nil {
+			continue
+		}
+
+		attributeOffset := attribute1.ItemSize * offset
+
+		for j, l := 0, attribute2.Len(); j < l; j++ {
+
+			attribute1.Set(attributeOffset+j, attribute2.Get(j))
+
+		}
+
+		attribute1.SetNeedsUpdate()
+
+	}
+
+	return nil
+
+}
+
+// ToNonIndexed returns a copy of this geometry with the index
+// expanded, so that every triangle has its own vertices
+func (g *BufferGeometry) ToNonIndexed() *BufferGeometry {
+
+	if g.Index == nil {
+
+		return g.Clone()
+
+	}
+
+	geometry := NewBufferGeometry()
+	index := g.Index
+	count := index.Len()
+
+	for name, attribute := range g.Attributes {
+
+		expanded := (&BufferAttribute{UUID: math3.GenerateUUID()}).Copy(attribute)
+
+		switch {
+		case attribute.Float32 != nil:
+			expanded.Float32 = make([]float32, count*attribute.ItemSize)
+		case attribute.Uint16 != nil:
+			expanded.Uint16 = make([]uint16, count*attribute.ItemSize)
+		default:
+			expanded.Uint32 = make([]uint32, count*attribute.ItemSize)
+		}
+
+		for i := 0; i < count; i++ {
+
+			expanded.CopyAt(i, attribute, int(index.Get(i)))
+
+		}
+
+		geometry.AddAttribute(name, expanded)
+
+	}
+
+	geometry.Groups = append(geometry.Groups, g.Groups...)
+
+	return geometry
+
+}
+
+// Clone returns a deep copy of this geometry
+func (g *BufferGeometry) Clone() *BufferGeometry {
+
+	return NewBufferGeometry().Copy(g)
+
+}
+
+// Copy makes this geometry a deep copy of the source geometry
+func (g *BufferGeometry) Copy(src *BufferGeometry) *BufferGeometry {
+
+	g.Name = src.Name
+
+	g.Index = nil
+	if src.Index != nil {
+		g.Index = src.Index.Clone()
+	}
+
+	g.Attributes = make(map[string]*BufferAttribute)
+	for name, attribute := range src.Attributes {
+		g.Attributes[name] = attribute.Clone()
+	}
+
+	g.MorphAttributes = make(map[string][]*BufferAttribute)
+	for name, morphs := range src.MorphAttributes {
+		for _, morph := range morphs {
+			g.MorphAttributes[name] = append(g.MorphAttributes[name], morph.Clone())
+		}
+	}
+
+	g.Groups = append([]Group(nil), src.Groups...)
+	g.DrawRange = src.DrawRange
+
+	g.BoundingBox = nil
+	if src.BoundingBox != nil {
+		g.BoundingBox = src.BoundingBox.Clone()
+	}
+
+	g.BoundingSphere = nil
+	if src.BoundingSphere != nil {
+		g.BoundingSphere = src.BoundingSphere.Clone()
+	}
+
+	return g
+
+}
@@ -0,0 +1,159 @@
+package core_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/math3"
+)
+
+func vectorNear(a, b *math3.Vector3) bool {
+	tolerance := 0.0001
+	return math.Abs(a.X-b.X) < tolerance &&
+		math.Abs(a.Y-b.Y) < tolerance &&
+		math.Abs(a.Z-b.Z) < tolerance
+}
+
+// newQuad returns an indexed unit square in the xy plane
+func newQuad() *core.BufferGeometry {
+	g := core.NewBufferGeometry()
+	g.AddAttribute("position", core.NewFloat32BufferAttribute([]float32{
+		0, 0, 0,
+		1, 0, 0,
+		1, 1, 0,
+		0, 1, 0,
+	}, 3))
+	g.SetIndex(core.NewIndexAttribute([]int{0, 1, 2, 0, 2, 3}))
+	return g
+}
+
+func TestBufferGeometry_ComputeBoundingBox(t *testing.T) {
+	g := newQuad()
+	g.Translate(-2, -2, -2)
+	g.ComputeBoundingBox()
+
+	expected := math3.NewBox3().Set(
+		math3.NewVector3().Set(-2, -2, -2),
+		math3.NewVector3().Set(-1, -1, -2),
+	)
+	if !g.BoundingBox.Equals(expected) {
+		t.Errorf("unexpected bounding box %s", g.BoundingBox)
+	}
+
+	empty := core.NewBufferGeometry()
+	empty.ComputeBoundingBox()
+	if !empty.BoundingBox.IsEmpty() {
+		t.Error("geometry without positions should have an empty bounding box")
+	}
+}
+
+func TestBufferGeometry_ComputeBoundingSphere(t *testing.T) {
+	g := newQuad()
+	g.ComputeBoundingSphere()
+
+	if !vectorNear(g.BoundingSphere.Center, math3.NewVector3().Set(0.5, 0.5, 0)) {
+		t.Errorf("unexpected center %s", g.BoundingSphere.Center)
+	}
+	if math.Abs(g.BoundingSphere.Radius-math.Sqrt(0.5)) > 0.0001 {
+		t.Errorf("unexpected radius %f", g.BoundingSphere.Radius)
+	}
+}
+
+func TestBufferGeometry_ComputeVertexNormals(t *testing.T) {
+	g := newQuad()
+	g.ComputeVertexNormals()
+
+	normal := g.GetAttribute("normal")
+	for i := 0; i < normal.Count(); i++ {
+		if !vectorNear(normal.GetVector3(i, nil), math3.NewVector3().Set(0, 0, 1)) {
+			t.Errorf("expected normal facing +z, got %s", normal.GetVector3(i, nil))
+		}
+	}
+
+	flat := g.ToNonIndexed()
+	if flat.Index != nil || flat.GetAttribute("position").Count() != 6 {
+		t.Error("non indexed geometry should have one vertex per index")
+	}
+
+	flat.RemoveAttribute("normal")
+	flat.ComputeVertexNormals()
+	if !vectorNear(flat.GetAttribute("normal").GetVector3(5, nil), math3.NewVector3().Set(0, 0, 1)) {
+		t.Error("expected flat normals facing +z")
+	}
+}
+
+func TestBufferGeometry_ApplyMatrix(t *testing.T) {
+	g := newQuad()
+	g.ComputeVertexNormals()
+	g.ComputeBoundingBox()
+	g.RotateY(math.Pi / 2)
+
+	if !vectorNear(g.GetAttribute("normal").GetVector3(0, nil), math3.NewVector3().Set(1, 0, 0)) {
+		t.Errorf("normals should be rotated, got %s", g.GetAttribute("normal").GetVector3(0, nil))
+	}
+	if !vectorNear(g.GetAttribute("position").GetVector3(1, nil), math3.NewVector3().Set(0, 0, -1)) {
+		t.Errorf("positions should be rotated, got %s", g.GetAttribute("position").GetVector3(1, nil))
+	}
+	if !vectorNear(g.BoundingBox.Min, math3.NewVector3().Set(0, 0, -1)) {
+		t.Errorf("bounding box should be recomputed, got %s", g.BoundingBox)
+	}
+}
+
+func TestBufferGeometry_Center(t *testing.T) {
+	g := newQuad()
+	offset := g.Center()
+
+	if !vectorNear(offset, math3.NewVector3().Set(-0.5, -0.5, 0)) {
+		t.Errorf("unexpected offset %s", offset)
+	}
+	if !vectorNear(g.BoundingBox.Center(nil), math3.NewVector3()) {
+		t.Errorf("geometry should be centered, got %s", g.BoundingBox)
+	}
+}
+
+func TestBufferGeometry_Merge(t *testing.T) {
+	g := core.NewBufferGeometry()
+	g.AddAttribute("position", core.NewFloat32BufferAttribute(make([]float32, 15), 3))
+
+	if err := g.Merge(newQuad(), 1); err != nil {
+		t.Fatal(err)
+	}
+	if !vectorNear(g.GetAttribute("position").GetVector3(3, nil), math3.NewVector3().Set(1, 1, 0)) {
+		t.Error("merged positions should be written at the offset")
+	}
+
+	if err := g.Merge(newQuad(), 2); err == nil {
+		t.Error("expected an error merging past the end of the attribute")
+	}
+
+	// a failed merge leaves every attribute untouched
+	g.AddAttribute("uv", core.NewFloat32BufferAttribute(make([]float32, 10), 2))
+	other := newQuad()
+	other.AddAttribute("uv", core.NewFloat32BufferAttribute(make([]float32, 12), 3))
+	other.GetAttribute("position").SetXYZ(0, 5, 5, 5)
+	if err := g.Merge(other, 0); err == nil {
+		t.Error("expected an error merging mismatched attributes")
+	}
+	if g.GetAttribute("position").GetX(0) != 0 {
+		t.Error("positions should not be merged when another attribute fails")
+	}
+}
+
+func TestBufferGeometry_Clone(t *testing.T) {
+	g := newQuad()
+	g.AddGroup(0, 3, 0)
+	g.AddGroup(3, 3, 1)
+	g.SetDrawRange(0, 3)
+
+	c := g.Clone()
+	c.GetAttribute("position").SetX(0, 10)
+	c.Index.Set(0, 3)
+
+	if g.GetAttribute("position").GetX(0) != 0 || g.Index.Get(0) != 0 {
+		t.Error("clone should create a deep copy")
+	}
+	if len(c.Groups) != 2 || c.Groups[1].MaterialIndex != 1 || c.DrawRange.Count != 3 {
+		t.Error("clone should copy groups and draw range")
+	}
+}
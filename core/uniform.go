@@ -4,20 +4,257 @@
 
 package core
 
-type Uniform struct {
-	Value int
+import (
+	"reflect"
+	"sort"
+)
+
+// Uniform is a value passed to a shader, such as a float64,
+// *math3.Color, *math3.Vector3, *math3.Matrix4 or a slice of them
+type Uniform[T any] struct {
+	Value T
+
+	// Version is incremented each time the value changes,
+	// letting renderers skip uploading values that have not
+	Version int
+}
+
+// AnyUniform is implemented by uniforms of every type,
+// allowing them to be stored and uploaded together
+type AnyUniform interface {
+	GetValue() interface{}
+	GetVersion() int
+	CloneUniform() AnyUniform
 }
 
-func NewUniform(value int) *Uniform {
+// NewUniform creates a uniform holding the given value
+func NewUniform[T any](value T) *Uniform[T] {
 
-	return &Uniform{
+	return &Uniform[T]{
 		Value: value,
 	}
 
 }
 
-func (u *Uniform) Clone() *Uniform {
+// Set replaces the value of this uniform and marks it as changed
+func (u *Uniform[T]) Set(value T) *Uniform[T] {
+
+	u.Value = value
+	u.Version++
+
+	return u
+
+}
+
+// SetNeedsUpdate marks this uniform as changed, it must be called
+// after modifying a value such as a vector or color in place
+func (u *Uniform[T]) SetNeedsUpdate() {
+
+	u.Version++
+
+}
+
+func (u *Uniform[T]) GetValue() interface{} {
+
+	return u.Value
+
+}
+
+func (u *Uniform[T]) GetVersion() int {
+
+	return u.Version
+
+}
+
+// Clone creates a new uniform with a deep copy of the value of this one.
+// Values with a Clone method returning their own type, like those of the
+// math3 package, are cloned and slices and arrays are cloned per element.
+func (u *Uniform[T]) Clone() *Uniform[T] {
+
+	value := u.Value
+
+	if v := cloneValue(reflect.ValueOf(&value).Elem()); v.IsValid() {
+		value = v.Interface().(T)
+	}
+
+	return NewUniform(value)
+
+}
+
+func (u *Uniform[T]) CloneUniform() AnyUniform {
+
+	return u.Clone()
+
+}
+
+// cloneValue returns a deep copy of v, as described by Uniform.Clone
+func cloneValue(v reflect.Value) reflect.Value {
+
+	switch v.Kind() {
+
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+
+		if v.IsNil() {
+			return v
+		}
+
+	}
+
+	if v.Kind() == reflect.Interface {
+
+		elem := cloneValue(v.Elem())
+		out := reflect.New(v.Type()).Elem()
+		out.Set(elem)
+		return out
+
+	}
+
+	if method := v.MethodByName("Clone"); method.IsValid() {
+
+		t := method.Type()
+		if t.NumIn() == 0 && t.NumOut() == 1 && t.Out(0) == v.Type() {
+			return method.Call(nil)[0]
+		}
+
+	}
+
+	switch v.Kind() {
+
+	case reflect.Slice:
+
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(cloneValue(v.Index(i)))
+		}
+		return out
+
+	case reflect.Array:
+
+		out := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(cloneValue(v.Index(i)))
+		}
+		return out
+
+	}
+
+	return v
+
+}
+
+// UniformGroup is a named set of uniforms that are uploaded together,
+// such as all of the uniforms of a material
+type UniformGroup struct {
+	Name string
+
+	uniforms map[string]AnyUniform
+}
+
+// NewUniformGroup creates an empty uniform group
+func NewUniformGroup(name string) *UniformGroup {
+
+	return &UniformGroup{
+		Name:     name,
+		uniforms: make(map[string]AnyUniform),
+	}
+
+}
+
+// Add adds a uniform to this group, replacing any uniform of the same name
+func (g *UniformGroup) Add(name string, uniform AnyUniform) *UniformGroup {
+
+	g.uniforms[name] = uniform
+
+	return g
+
+}
+
+// Get returns the named uniform, or nil if there is none
+func (g *UniformGroup) Get(name string) AnyUniform {
+
+	return g.uniforms[name]
+
+}
+
+// Remove removes the named uniform from this group
+func (g *UniformGroup) Remove(name string) *UniformGroup {
+
+	delete(g.uniforms, name)
+
+	return g
+
+}
+
+// Len returns the number of uniforms in this group
+func (g *UniformGroup) Len() int {
+
+	return len(g.uniforms)
+
+}
+
+// Names returns the names of the uniforms in this group in sorted order
+func (g *UniformGroup) Names() []string {
+
+	names := make([]string, 0, len(g.uniforms))
+	for name := range g.uniforms {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+
+}
+
+// EachChanged calls fn for every uniform whose version differs from the
+// one recorded in uploaded, in sorted order, and records its new version.
+// Renderers keep one uploaded map per program so that only changed values
+// are sent, a new map uploads everything.
+func (g *UniformGroup) EachChanged(uploaded map[string]int, fn func(name string, uniform AnyUniform)) {
+
+	for _, name := range g.Names() {
+
+		uniform := g.uniforms[name]
+		version := uniform.GetVersion()
+
+		if last, ok := uploaded[name]; ok && last == version {
+			continue
+		}
+
+		fn(name, uniform)
+		uploaded[name] = version
+
+	}
+
+}
+
+// Clone creates a deep copy of this group and all of its uniforms
+func (g *UniformGroup) Clone() *UniformGroup {
+
+	clone := NewUniformGroup(g.Name)
+
+	for name, uniform := range g.uniforms {
+		clone.uniforms[name] = uniform.CloneUniform()
+	}
+
+	return clone
+
+}
+
+// MergeUniformGroups creates a new group holding deep copies of all of
+// the uniforms in the given groups, later groups take precedence
+func MergeUniformGroups(name string, groups ...*UniformGroup) *UniformGroup {
+
+	merged := NewUniformGroup(name)
+
+	for _, group := range groups {
+
+		for n, uniform := range group.uniforms {
+			merged.uniforms[n] = uniform.CloneUniform()
+		}
+
+	}
 
-	return NewUniform(u.Value)
+	return merged
 
 }
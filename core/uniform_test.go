@@ -0,0 +1,132 @@
+package core_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/math3"
+)
+
+func TestUniform_Types(t *testing.T) {
+	f := core.NewUniform(0.5)
+	if f.Value != 0.5 {
+		t.Errorf("expected 0.5, got %v", f.Value)
+	}
+
+	c := core.NewUniform(math3.NewColor().SetHex(0xff8000))
+	if c.Value.GetHex() != 0xff8000 {
+		t.Errorf("expected color 0xff8000, got %x", c.Value.GetHex())
+	}
+
+	m := core.NewUniform(math3.NewMatrix4().MakeTranslation(1, 2, 3))
+	if m.Value.Elements[12] != 1 {
+		t.Errorf("unexpected matrix %v", m.Value.Elements)
+	}
+
+	var uniforms []core.AnyUniform = []core.AnyUniform{f, c, m}
+	if v, ok := uniforms[0].GetValue().(float64); !ok || v != 0.5 {
+		t.Errorf("expected float value through interface, got %v", uniforms[0].GetValue())
+	}
+}
+
+func TestUniform_Version(t *testing.T) {
+	u := core.NewUniform(math3.NewVector3())
+	if u.GetVersion() != 0 {
+		t.Errorf("expected version 0, got %d", u.GetVersion())
+	}
+
+	u.Set(math3.NewVector3().Set(1, 1, 1))
+	if u.GetVersion() != 1 {
+		t.Errorf("set should increment the version, got %d", u.GetVersion())
+	}
+
+	u.Value.X = 2
+	u.SetNeedsUpdate()
+	if u.GetVersion() != 2 {
+		t.Errorf("SetNeedsUpdate should increment the version, got %d", u.GetVersion())
+	}
+}
+
+func TestUniform_Clone(t *testing.T) {
+	v := core.NewUniform(math3.NewVector3().Set(1, 2, 3))
+	vc := v.Clone()
+	v.Value.X = 5
+	if vc.Value.X != 1 {
+		t.Error("clone should deep copy math values")
+	}
+
+	a := core.NewUniform([]*math3.Vector3{math3.NewVector3().Set(1, 0, 0), nil})
+	ac := a.Clone()
+	a.Value[0].X = 5
+	if ac.Value[0].X != 1 || ac.Value[1] != nil {
+		t.Errorf("clone should deep copy arrays, got %v", ac.Value)
+	}
+
+	f := core.NewUniform([]float32{1, 2})
+	fc := f.Clone()
+	f.Value[0] = 5
+	if !reflect.DeepEqual(fc.Value, []float32{1, 2}) {
+		t.Errorf("clone should copy slices, got %v", fc.Value)
+	}
+
+	var any interface{} = math3.NewColor().SetHex(0x00ff00)
+	i := core.NewUniform(any)
+	ic := i.Clone()
+	i.Value.(*math3.Color).SetHex(0)
+	if ic.Value.(*math3.Color).GetHex() != 0x00ff00 {
+		t.Error("clone should copy values held in interfaces")
+	}
+}
+
+func TestUniformGroup(t *testing.T) {
+	diffuse := core.NewUniform(math3.NewColor())
+	opacity := core.NewUniform(1.0)
+
+	g := core.NewUniformGroup("material").
+		Add("opacity", opacity).
+		Add("diffuse", diffuse)
+
+	if g.Len() != 2 || g.Get("diffuse") != core.AnyUniform(diffuse) {
+		t.Fatal("uniforms should be added to the group")
+	}
+	if names := g.Names(); !reflect.DeepEqual(names, []string{"diffuse", "opacity"}) {
+		t.Errorf("expected sorted names, got %v", names)
+	}
+
+	collect := func(uploaded map[string]int) []string {
+		var changed []string
+		g.EachChanged(uploaded, func(name string, u core.AnyUniform) {
+			changed = append(changed, name)
+		})
+		return changed
+	}
+
+	uploaded := make(map[string]int)
+	if changed := collect(uploaded); len(changed) != 2 {
+		t.Errorf("everything should be uploaded the first time, got %v", changed)
+	}
+	if changed := collect(uploaded); len(changed) != 0 {
+		t.Errorf("nothing should be uploaded without changes, got %v", changed)
+	}
+	opacity.Set(0.5)
+	if changed := collect(uploaded); !reflect.DeepEqual(changed, []string{"opacity"}) {
+		t.Errorf("only changed uniforms should be uploaded, got %v", changed)
+	}
+
+	clone := g.Clone()
+	diffuse.Value.SetHex(0x123456)
+	if clone.Get("diffuse").GetValue().(*math3.Color).GetHex() == 0x123456 {
+		t.Error("group clone should deep copy its uniforms")
+	}
+
+	merged := core.MergeUniformGroups("merged", g, core.NewUniformGroup("").Add("opacity", core.NewUniform(0.25)))
+	if merged.Len() != 2 || merged.Get("opacity").GetValue().(float64) != 0.25 {
+		t.Error("later groups should take precedence when merging")
+	}
+
+	g.Remove("opacity")
+	if g.Get("opacity") != nil || g.Len() != 1 {
+		t.Error("uniform should be removed from the group")
+	}
+}
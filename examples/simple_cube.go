@@ -3,14 +3,20 @@ package main
 import (
 	"time"
 
+	"github.com/rydrman/three.go/cameras"
+	"github.com/rydrman/three.go/geometries"
+	"github.com/rydrman/three.go/materials"
 	"github.com/rydrman/three.go/math3"
-	"github.com/rydrman/three.go/renderers"
+	"github.com/rydrman/three.go/objects"
+	"github.com/rydrman/three.go/renderers/opengl"
 	"github.com/rydrman/three.go/scenes"
 )
 
 func main() {
 
-	win, err := renderers.NewWindowRenderer(500, 500, "Cube Example")
+	defer opengl.Terminate()
+
+	win, err := opengl.NewWindowRenderer(500, 500, "Cube Example")
 	if nil != err {
 		panic(err)
 	}
@@ -19,19 +25,19 @@ func main() {
 	scene := scenes.NewScene()
 	scene.BackgroundColor = math3.Colors("powderblue")
 
-	//geo := three.NewCubeGeometry(1, 1, 1)
-	//mat := three.NewBasicMaterial(three.MaterialProps{
-	//    Color: three.Color().FromHex(0x001111),
-	//})
+	geo := geometries.NewBoxGeometry(1, 1, 1, 1, 1, 1)
+	mat := materials.NewMeshBasicMaterial()
+	mat.Color.SetHex(0x001111)
 
-	//mesh := three.NewMesh(geo, mat)
+	mesh := objects.NewMesh(geo, mat)
 
-	//scene.Add(mesh)
+	scene.Add(mesh)
 
-	//cam := three.NewPerspectiveCamera(1)
+	cam := cameras.NewPerspectiveCamera(75, 1, 0.1, 1000)
+	cam.Position.Z = 5
 
 	for !win.ShouldClose() {
-		win.Render(scene, nil)
+		win.Render(scene, cam)
 		time.Sleep(time.Millisecond * 10)
 	}
 
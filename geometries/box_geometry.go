@@ -0,0 +1,110 @@
+package geometries
+
+import (
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/math3"
+)
+
+// NewBoxGeometry creates a box centered on the origin with the given
+// dimensions. Each side is subdivided by the given number of segments
+// and drawn with its own group, in the order +x, -x, +y, -y, +z, -z.
+func NewBoxGeometry(width, height, depth float64, widthSegments, heightSegments, depthSegments int) *core.BufferGeometry {
+
+	widthSegments = maxInt(1, widthSegments)
+	heightSegments = maxInt(1, heightSegments)
+	depthSegments = maxInt(1, depthSegments)
+
+	b := &builder{}
+
+	numberOfVertices := 0
+	groupStart := 0
+
+	buildPlane := func(u, v, w int, udir, vdir, width, height, depth float64, gridX, gridY, materialIndex int) {
+
+		segmentWidth := width / float64(gridX)
+		segmentHeight := height / float64(gridY)
+
+		widthHalf := width / 2
+		heightHalf := height / 2
+		depthHalf := depth / 2
+
+		gridX1 := gridX + 1
+		gridY1 := gridY + 1
+
+		vertexCounter := 0
+		groupCount := 0
+
+		vector := math3.NewVector3()
+
+		normal := 1.0
+		if depth < 0 {
+			normal = -1
+		}
+
+		// generate vertices, normals and uvs
+
+		for iy := 0; iy < gridY1; iy++ {
+
+			y := float64(iy)*segmentHeight - heightHalf
+
+			for ix := 0; ix < gridX1; ix++ {
+
+				x := float64(ix)*segmentWidth - widthHalf
+
+				vector.SetComponent(u, x*udir)
+				vector.SetComponent(v, y*vdir)
+				vector.SetComponent(w, depthHalf)
+				b.addVertex(vector.X, vector.Y, vector.Z)
+
+				vector.SetComponent(u, 0)
+				vector.SetComponent(v, 0)
+				vector.SetComponent(w, normal)
+				b.addNormal(vector.X, vector.Y, vector.Z)
+
+				b.addUV(float64(ix)/float64(gridX), 1-float64(iy)/float64(gridY))
+
+				vertexCounter++
+
+			}
+
+		}
+
+		// indices, each segment is made of two faces
+
+		for iy := 0; iy < gridY; iy++ {
+
+			for ix := 0; ix < gridX; ix++ {
+
+				a := numberOfVertices + ix + gridX1*iy
+				b2 := numberOfVertices + ix + gridX1*(iy+1)
+				c := numberOfVertices + (ix + 1) + gridX1*(iy+1)
+				d := numberOfVertices + (ix + 1) + gridX1*iy
+
+				b.addFace(a, b2, d)
+				b.addFace(b2, c, d)
+
+				groupCount += 6
+
+			}
+
+		}
+
+		b.addGroup(groupStart, groupCount, materialIndex)
+
+		groupStart += groupCount
+		numberOfVertices += vertexCounter
+
+	}
+
+	const x, y, z = 0, 1, 2
+
+	buildPlane(z, y, x, -1, -1, depth, height, width, depthSegments, heightSegments, 0)  // px
+	buildPlane(z, y, x, 1, -1, depth, height, -width, depthSegments, heightSegments, 1)  // nx
+	buildPlane(x, z, y, 1, 1, width, depth, height, widthSegments, depthSegments, 2)     // py
+	buildPlane(x, z, y, 1, -1, width, depth, -height, widthSegments, depthSegments, 3)   // ny
+	buildPlane(x, y, z, 1, -1, width, height, depth, widthSegments, heightSegments, 4)   // pz
+	buildPlane(x, y, z, -1, -1, width, height, -depth, widthSegments, heightSegments, 5) // nz
+
+	return b.geometry()
+
+}
@@ -0,0 +1,45 @@
+package geometries
+
+import (
+	"math"
+
+	"github.com/rydrman/three.go/core"
+)
+
+// NewCircleGeometry creates a flat circle or circular sector in the xy
+// plane facing +z, made of triangles that all meet at the center.
+// Angles are in radians, starting from the +x axis.
+func NewCircleGeometry(radius float64, segments int, thetaStart, thetaLength float64) *core.BufferGeometry {
+
+	segments = maxInt(3, segments)
+
+	b := &builder{}
+
+	// center point
+
+	b.addVertex(0, 0, 0)
+	b.addNormal(0, 0, 1)
+	b.addUV(0.5, 0.5)
+
+	for s := 0; s <= segments; s++ {
+
+		segment := thetaStart + float64(s)/float64(segments)*thetaLength
+
+		x := radius * math.Cos(segment)
+		y := radius * math.Sin(segment)
+
+		b.addVertex(x, y, 0)
+		b.addNormal(0, 0, 1)
+		b.addUV((x/radius+1)/2, (y/radius+1)/2)
+
+	}
+
+	for i := 1; i <= segments; i++ {
+
+		b.addFace(i, i+1, 0)
+
+	}
+
+	return b.geometry()
+
+}
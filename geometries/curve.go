@@ -0,0 +1,232 @@
+package geometries
+
+import (
+	"math"
+
+	"github.com/rydrman/three.go/math3"
+)
+
+// Curve is a parametric curve in 3D space
+type Curve interface {
+	// GetPoint returns the point on the curve at t in [0, 1]
+	GetPoint(t float64) *math3.Vector3
+}
+
+// CurveFunc is an adapter to allow the use of ordinary functions as
+// curves
+type CurveFunc func(t float64) *math3.Vector3
+
+// GetPoint calls f(t)
+func (f CurveFunc) GetPoint(t float64) *math3.Vector3 {
+
+	return f(t)
+
+}
+
+const (
+	// curveDivisions is the number of samples used to approximate the
+	// length of a curve
+	curveDivisions = 200
+
+	// epsilon is the difference between 1 and the next float64
+	epsilon = 2.220446049250313e-16
+)
+
+// arcLengths samples the curve and returns the cumulative length at
+// each sample point
+func arcLengths(curve Curve, divisions int) []float64 {
+
+	lengths := make([]float64, divisions+1)
+
+	last := curve.GetPoint(0)
+	sum := 0.0
+
+	for p := 1; p <= divisions; p++ {
+
+		current := curve.GetPoint(float64(p) / float64(divisions))
+		sum += current.DistanceTo(last)
+		lengths[p] = sum
+		last = current
+
+	}
+
+	return lengths
+
+}
+
+// uToT maps u, a fraction of the total length of the curve, to the
+// curve parameter t using the given arc lengths
+func uToT(lengths []float64, u float64) float64 {
+
+	il := len(lengths)
+
+	targetArcLength := u * lengths[il-1]
+
+	// binary search for the index with largest value smaller than target u distance
+
+	low, high := 0, il-1
+	i := 0
+
+	for low <= high {
+
+		i = low + (high-low)/2
+
+		comparison := lengths[i] - targetArcLength
+
+		if comparison < 0 {
+			low = i + 1
+		} else if comparison > 0 {
+			high = i - 1
+		} else {
+			high = i
+			break
+		}
+
+	}
+
+	i = high
+	if i < 0 {
+		i = 0
+	}
+
+	if lengths[i] == targetArcLength || i+1 >= il {
+		return float64(i) / float64(il-1)
+	}
+
+	// we could get finer grain at lengths, or use simple interpolation between two points
+
+	lengthBefore := lengths[i]
+	lengthAfter := lengths[i+1]
+
+	segmentLength := lengthAfter - lengthBefore
+
+	// determine where we are between the 'before' and 'after' points
+
+	segmentFraction := (targetArcLength - lengthBefore) / segmentLength
+
+	// add that fractional amount to t
+
+	return (float64(i) + segmentFraction) / float64(il-1)
+
+}
+
+// curveTangent returns the unit tangent of the curve at t
+func curveTangent(curve Curve, t float64) *math3.Vector3 {
+
+	const delta = 0.0001
+
+	t1 := math.Max(t-delta, 0)
+	t2 := math.Min(t+delta, 1)
+
+	pt1 := curve.GetPoint(t1)
+	pt2 := curve.GetPoint(t2)
+
+	return pt2.Clone().Sub(pt1).Normalize()
+
+}
+
+// ComputeFrenetFrames calculates the tangent, normal and binormal of
+// segments+1 points spaced evenly along the length of the curve. The
+// normals are propagated along the curve to minimize twisting, and
+// when closed is set the twist is distributed so the last frame
+// matches the first.
+func ComputeFrenetFrames(curve Curve, segments int, closed bool) (tangents, normals, binormals []*math3.Vector3) {
+
+	lengths := arcLengths(curve, curveDivisions)
+
+	tangents = make([]*math3.Vector3, segments+1)
+	normals = make([]*math3.Vector3, segments+1)
+	binormals = make([]*math3.Vector3, segments+1)
+
+	normal := math3.NewVector3()
+	vec := math3.NewVector3()
+	mat := math3.NewMatrix4()
+
+	// compute the tangent vectors for each segment on the curve
+
+	for i := 0; i <= segments; i++ {
+
+		u := float64(i) / float64(segments)
+
+		tangents[i] = curveTangent(curve, uToT(lengths, u))
+
+	}
+
+	// select an initial normal vector perpendicular to the first tangent vector,
+	// and in the direction of the minimum tangent xyz component
+
+	normals[0] = math3.NewVector3()
+	binormals[0] = math3.NewVector3()
+
+	min := math.MaxFloat64
+	tx := math.Abs(tangents[0].X)
+	ty := math.Abs(tangents[0].Y)
+	tz := math.Abs(tangents[0].Z)
+
+	if tx <= min {
+		min = tx
+		normal.Set(1, 0, 0)
+	}
+
+	if ty <= min {
+		min = ty
+		normal.Set(0, 1, 0)
+	}
+
+	if tz <= min {
+		normal.Set(0, 0, 1)
+	}
+
+	vec.CrossVectors(tangents[0], normal).Normalize()
+
+	normals[0].CrossVectors(tangents[0], vec)
+	binormals[0].CrossVectors(tangents[0], normals[0])
+
+	// compute the slowly-varying normal and binormal vectors for each segment on the curve
+
+	for i := 1; i <= segments; i++ {
+
+		normals[i] = normals[i-1].Clone()
+
+		binormals[i] = binormals[i-1].Clone()
+
+		vec.CrossVectors(tangents[i-1], tangents[i])
+
+		if vec.Length() > epsilon {
+
+			vec.Normalize()
+
+			theta := math.Acos(math3.Clamp(tangents[i-1].Dot(tangents[i]), -1, 1)) // clamp for floating pt errors
+
+			normals[i].ApplyMatrix4(mat.MakeRotationAxis(vec, theta))
+
+		}
+
+		binormals[i].CrossVectors(tangents[i], normals[i])
+
+	}
+
+	// if the curve is closed, postprocess the vectors so the first and last normal vectors are the same
+
+	if closed {
+
+		theta := math.Acos(math3.Clamp(normals[0].Dot(normals[segments]), -1, 1))
+		theta /= float64(segments)
+
+		if tangents[0].Dot(vec.CrossVectors(normals[0], normals[segments])) > 0 {
+			theta = -theta
+		}
+
+		for i := 1; i <= segments; i++ {
+
+			// twist a little...
+			normals[i].ApplyMatrix4(mat.MakeRotationAxis(tangents[i], theta*float64(i)))
+			binormals[i].CrossVectors(tangents[i], normals[i])
+
+		}
+
+	}
+
+	return tangents, normals, binormals
+
+}
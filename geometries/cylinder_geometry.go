@@ -0,0 +1,186 @@
+package geometries
+
+import (
+	"math"
+
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/math3"
+)
+
+// NewCylinderGeometry creates a cylinder centered on the origin along
+// the y axis. The torso is drawn with group 0 and the top and bottom
+// caps, unless openEnded is set, with groups 1 and 2. Angles are in
+// radians around the y axis starting from +z.
+func NewCylinderGeometry(radiusTop, radiusBottom, height float64, radialSegments, heightSegments int, openEnded bool, thetaStart, thetaLength float64) *core.BufferGeometry {
+
+	radialSegments = maxInt(1, radialSegments)
+	heightSegments = maxInt(1, heightSegments)
+
+	b := &builder{}
+
+	index := 0
+	halfHeight := height / 2
+	groupStart := 0
+
+	generateTorso := func() {
+
+		normal := math3.NewVector3()
+		groupCount := 0
+
+		indexArray := make([][]int, 0, heightSegments+1)
+
+		// this will be used to calculate the normal
+		slope := (radiusBottom - radiusTop) / height
+
+		// generate vertices, normals and uvs
+
+		for y := 0; y <= heightSegments; y++ {
+
+			indexRow := make([]int, 0, radialSegments+1)
+
+			v := float64(y) / float64(heightSegments)
+
+			// calculate the radius of the current row
+			radius := v*(radiusBottom-radiusTop) + radiusTop
+
+			for x := 0; x <= radialSegments; x++ {
+
+				u := float64(x) / float64(radialSegments)
+
+				theta := u*thetaLength + thetaStart
+
+				sinTheta := math.Sin(theta)
+				cosTheta := math.Cos(theta)
+
+				b.addVertex(radius*sinTheta, -v*height+halfHeight, radius*cosTheta)
+
+				normal.Set(sinTheta, slope, cosTheta).Normalize()
+				b.addNormal(normal.X, normal.Y, normal.Z)
+
+				b.addUV(u, 1-v)
+
+				indexRow = append(indexRow, index)
+				index++
+
+			}
+
+			indexArray = append(indexArray, indexRow)
+
+		}
+
+		// indices
+
+		for x := 0; x < radialSegments; x++ {
+
+			for y := 0; y < heightSegments; y++ {
+
+				a := indexArray[y][x]
+				b2 := indexArray[y+1][x]
+				c := indexArray[y+1][x+1]
+				d := indexArray[y][x+1]
+
+				b.addFace(a, b2, d)
+				b.addFace(b2, c, d)
+
+				groupCount += 6
+
+			}
+
+		}
+
+		b.addGroup(groupStart, groupCount, 0)
+		groupStart += groupCount
+
+	}
+
+	generateCap := func(top bool) {
+
+		groupCount := 0
+
+		radius := radiusBottom
+		sign := -1.0
+		materialIndex := 2
+		if top {
+			radius = radiusTop
+			sign = 1
+			materialIndex = 1
+		}
+
+		// save the index of the first center vertex
+		centerIndexStart := index
+
+		// each triangle of the cap gets its own center vertex so that
+		// the uvs can be mapped cleanly
+
+		for x := 1; x <= radialSegments; x++ {
+
+			b.addVertex(0, halfHeight*sign, 0)
+			b.addNormal(0, sign, 0)
+			b.addUV(0.5, 0.5)
+
+			index++
+
+		}
+
+		centerIndexEnd := index
+
+		for x := 0; x <= radialSegments; x++ {
+
+			u := float64(x) / float64(radialSegments)
+			theta := u*thetaLength + thetaStart
+
+			cosTheta := math.Cos(theta)
+			sinTheta := math.Sin(theta)
+
+			b.addVertex(radius*sinTheta, halfHeight*sign, radius*cosTheta)
+			b.addNormal(0, sign, 0)
+			b.addUV(cosTheta*0.5+0.5, sinTheta*0.5*sign+0.5)
+
+			index++
+
+		}
+
+		for x := 0; x < radialSegments; x++ {
+
+			c := centerIndexStart + x
+			i := centerIndexEnd + x
+
+			if top {
+				b.addFace(i, i+1, c)
+			} else {
+				b.addFace(i+1, i, c)
+			}
+
+			groupCount += 3
+
+		}
+
+		b.addGroup(groupStart, groupCount, materialIndex)
+		groupStart += groupCount
+
+	}
+
+	generateTorso()
+
+	if !openEnded {
+
+		if radiusTop > 0 {
+			generateCap(true)
+		}
+		if radiusBottom > 0 {
+			generateCap(false)
+		}
+
+	}
+
+	return b.geometry()
+
+}
+
+// NewConeGeometry creates a cone centered on the origin with its tip
+// pointing along +y, see NewCylinderGeometry
+func NewConeGeometry(radius, height float64, radialSegments, heightSegments int, openEnded bool, thetaStart, thetaLength float64) *core.BufferGeometry {
+
+	return NewCylinderGeometry(0, radius, height, radialSegments, heightSegments, openEnded, thetaStart, thetaLength)
+
+}
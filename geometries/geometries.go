@@ -0,0 +1,81 @@
+/*
+Package geometries contains generators for common primitive shapes.
+Each generator returns a core.BufferGeometry with position, normal
+and uv attributes.
+*/
+package geometries
+
+import "github.com/rydrman/three.go/core"
+
+// builder accumulates the buffers of a geometry as it is generated
+type builder struct {
+	indices  []int
+	vertices []float32
+	normals  []float32
+	uvs      []float32
+	groups   []core.Group
+}
+
+func (b *builder) addVertex(x, y, z float64) {
+
+	b.vertices = append(b.vertices, float32(x), float32(y), float32(z))
+
+}
+
+func (b *builder) addNormal(x, y, z float64) {
+
+	b.normals = append(b.normals, float32(x), float32(y), float32(z))
+
+}
+
+func (b *builder) addUV(u, v float64) {
+
+	b.uvs = append(b.uvs, float32(u), float32(v))
+
+}
+
+func (b *builder) addFace(a, c, d int) {
+
+	b.indices = append(b.indices, a, c, d)
+
+}
+
+func (b *builder) addGroup(start, count, materialIndex int) {
+
+	b.groups = append(b.groups, core.Group{
+		Start:         start,
+		Count:         count,
+		MaterialIndex: materialIndex,
+	})
+
+}
+
+// geometry creates the buffer geometry holding the generated data,
+// the index is left unset when no faces were added
+func (b *builder) geometry() *core.BufferGeometry {
+
+	g := core.NewBufferGeometry()
+
+	if len(b.indices) > 0 {
+		g.SetIndex(core.NewIndexAttribute(b.indices))
+	}
+
+	g.AddAttribute("position", core.NewFloat32BufferAttribute(b.vertices, 3))
+	g.AddAttribute("normal", core.NewFloat32BufferAttribute(b.normals, 3))
+	g.AddAttribute("uv", core.NewFloat32BufferAttribute(b.uvs, 2))
+
+	g.Groups = b.groups
+
+	return g
+
+}
+
+func maxInt(a, b int) int {
+
+	if a > b {
+		return a
+	}
+
+	return b
+
+}
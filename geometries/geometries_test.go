@@ -0,0 +1,381 @@
+package geometries_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/geometries"
+	"github.com/rydrman/three.go/math3"
+)
+
+const eps = 0.0001
+
+// checkGeometry verifies the attribute sizes and that every index and
+// normal of the geometry is valid
+func checkGeometry(t *testing.T, name string, g *core.BufferGeometry, vertices, indices int) {
+
+	position := g.GetAttribute("position")
+	normal := g.GetAttribute("normal")
+	uv := g.GetAttribute("uv")
+
+	if position == nil || normal == nil || uv == nil {
+		t.Fatalf("%s: missing attributes: %v", name, g.Attributes)
+	}
+
+	if position.Count() != vertices || normal.Count() != vertices || uv.Count() != vertices {
+		t.Errorf("%s: expected %d vertices, got position %d, normal %d, uv %d",
+			name, vertices, position.Count(), normal.Count(), uv.Count())
+	}
+
+	if indices < 0 {
+
+		if g.Index != nil {
+			t.Errorf("%s: expected no index", name)
+		}
+
+	} else {
+
+		if g.Index == nil || g.Index.Count() != indices {
+			t.Fatalf("%s: expected %d indices, got %v", name, indices, g.Index)
+		}
+
+		for i := 0; i < g.Index.Count(); i++ {
+			if v := int(g.Index.GetX(i)); v < 0 || v >= vertices {
+				t.Fatalf("%s: index %d out of range: %d", name, i, v)
+			}
+		}
+
+	}
+
+	n := math3.NewVector3()
+	for i := 0; i < normal.Count(); i++ {
+		if l := normal.GetVector3(i, n).Length(); math.Abs(l-1) > eps {
+			t.Fatalf("%s: normal %d is not unit length: %s", name, i, n)
+		}
+	}
+
+}
+
+// checkOutward verifies that every face winds counter-clockwise when
+// seen from outside, that is away from the origin
+func checkOutward(t *testing.T, name string, g *core.BufferGeometry) {
+
+	position := g.GetAttribute("position")
+
+	a, b, c := math3.NewVector3(), math3.NewVector3(), math3.NewVector3()
+	cb, ab := math3.NewVector3(), math3.NewVector3()
+
+	count := position.Count()
+	if g.Index != nil {
+		count = g.Index.Count()
+	}
+
+	vertex := func(i int) int {
+		if g.Index != nil {
+			return int(g.Index.GetX(i))
+		}
+		return i
+	}
+
+	for i := 0; i+2 < count; i += 3 {
+
+		position.GetVector3(vertex(i), a)
+		position.GetVector3(vertex(i+1), b)
+		position.GetVector3(vertex(i+2), c)
+
+		cb.SubVectors(c, b)
+		ab.SubVectors(a, b)
+		cb.Cross(ab)
+
+		if cb.LengthSq() < 1e-12 {
+			continue // degenerate face at a pole
+		}
+
+		if center := a.Add(b).Add(c); cb.Dot(center) <= 0 {
+			t.Fatalf("%s: face %d faces inward", name, i/3)
+		}
+
+	}
+
+}
+
+func TestBoxGeometry(t *testing.T) {
+
+	g := geometries.NewBoxGeometry(2, 4, 6, 1, 2, 3)
+
+	// +x and -x: 3x2 segments, +y and -y: 1x3, +z and -z: 1x2
+	vertices := 2*(4*3) + 2*(2*4) + 2*(2*3)
+	faces := 2*(3*2) + 2*(1*3) + 2*(1*2)
+	checkGeometry(t, "box", g, vertices, faces*6)
+	checkOutward(t, "box", g)
+
+	if len(g.Groups) != 6 {
+		t.Fatalf("expected 6 groups, got %v", g.Groups)
+	}
+	for i, group := range g.Groups {
+		if group.MaterialIndex != i {
+			t.Errorf("expected group %d to use material %d, got %v", i, i, group)
+		}
+	}
+
+	g.ComputeBoundingBox()
+	expected := math3.NewBox3().Set(
+		math3.NewVector3().Set(-1, -2, -3),
+		math3.NewVector3().Set(1, 2, 3),
+	)
+	if !g.BoundingBox.Equals(expected) {
+		t.Errorf("expected bounds %s, got %s", expected, g.BoundingBox)
+	}
+
+}
+
+func TestPlaneGeometry(t *testing.T) {
+
+	g := geometries.NewPlaneGeometry(2, 2, 4, 2)
+	checkGeometry(t, "plane", g, 5*3, 4*2*6)
+
+	uv := g.GetAttribute("uv")
+	if uv.GetX(0) != 0 || uv.GetY(0) != 1 {
+		t.Errorf("expected first uv to be (0, 1), got (%v, %v)", uv.GetX(0), uv.GetY(0))
+	}
+
+}
+
+func TestCircleGeometry(t *testing.T) {
+
+	g := geometries.NewCircleGeometry(1, 8, 0, math.Pi*2)
+	checkGeometry(t, "circle", g, 10, 8*3)
+
+	// fewer than 3 segments are not allowed
+	g = geometries.NewCircleGeometry(1, 1, 0, math.Pi*2)
+	checkGeometry(t, "circle", g, 5, 3*3)
+
+}
+
+func TestRingGeometry(t *testing.T) {
+
+	g := geometries.NewRingGeometry(0.5, 1, 8, 2, 0, math.Pi*2)
+	checkGeometry(t, "ring", g, 9*3, 8*2*6)
+
+	g.ComputeBoundingSphere()
+	if math.Abs(g.BoundingSphere.Radius-1) > eps {
+		t.Errorf("expected radius 1, got %v", g.BoundingSphere.Radius)
+	}
+
+}
+
+func TestSphereGeometry(t *testing.T) {
+
+	g := geometries.NewSphereGeometry(2, 8, 6, 0, math.Pi*2, 0, math.Pi)
+
+	// the faces touching the poles are single triangles
+	checkGeometry(t, "sphere", g, 9*7, (8*6*2-8*2)*3)
+	checkOutward(t, "sphere", g)
+
+	position := g.GetAttribute("position")
+	v := math3.NewVector3()
+	for i := 0; i < position.Count(); i++ {
+		if l := position.GetVector3(i, v).Length(); math.Abs(l-2) > eps {
+			t.Fatalf("vertex %d is not on the sphere: %s", i, v)
+		}
+	}
+
+	// a hemisphere keeps the faces along its open edge
+	g = geometries.NewSphereGeometry(1, 8, 3, 0, math.Pi*2, 0, math.Pi/2)
+	checkGeometry(t, "hemisphere", g, 9*4, (8*3*2-8)*3)
+
+}
+
+func TestCylinderGeometry(t *testing.T) {
+
+	g := geometries.NewCylinderGeometry(1, 2, 4, 8, 2, false, 0, math.Pi*2)
+
+	torso := 9 * 3
+	caps := 2 * (8 + 9)
+	checkGeometry(t, "cylinder", g, torso+caps, (8*2*2+8*2)*3)
+	checkOutward(t, "cylinder", g)
+
+	if len(g.Groups) != 3 {
+		t.Fatalf("expected 3 groups, got %v", g.Groups)
+	}
+
+	g = geometries.NewCylinderGeometry(1, 1, 1, 8, 1, true, 0, math.Pi*2)
+	checkGeometry(t, "open cylinder", g, 9*2, 8*2*3)
+
+	g = geometries.NewConeGeometry(1, 2, 8, 1, false, 0, math.Pi*2)
+	if len(g.Groups) != 2 {
+		t.Fatalf("expected cone to only have a bottom cap, got %v", g.Groups)
+	}
+
+	g.ComputeBoundingBox()
+	if g.BoundingBox.Max.Y != 1 || g.BoundingBox.Min.Y != -1 {
+		t.Errorf("expected cone to span y -1 to 1, got %s", g.BoundingBox)
+	}
+
+}
+
+func TestTorusGeometry(t *testing.T) {
+
+	g := geometries.NewTorusGeometry(2, 0.5, 8, 12, math.Pi*2)
+	checkGeometry(t, "torus", g, 9*13, 8*12*6)
+
+	// every vertex lies at the tube radius from the center circle
+	position := g.GetAttribute("position")
+	v := math3.NewVector3()
+	for i := 0; i < position.Count(); i++ {
+
+		position.GetVector3(i, v)
+
+		d := math.Hypot(math.Hypot(v.X, v.Y)-2, v.Z)
+		if math.Abs(d-0.5) > eps {
+			t.Fatalf("vertex %d is not on the tube: %s", i, v)
+		}
+
+	}
+
+}
+
+func TestTorusKnotGeometry(t *testing.T) {
+
+	g := geometries.NewTorusKnotGeometry(1, 0.4, 64, 8, 2, 3)
+	checkGeometry(t, "torus knot", g, 65*9, 64*8*6)
+
+}
+
+func TestPolyhedronGeometry(t *testing.T) {
+
+	cases := []struct {
+		name  string
+		new   func(radius float64, detail int) *core.BufferGeometry
+		faces int
+	}{
+		{"tetrahedron", geometries.NewTetrahedronGeometry, 4},
+		{"octahedron", geometries.NewOctahedronGeometry, 8},
+		{"icosahedron", geometries.NewIcosahedronGeometry, 20},
+		{"dodecahedron", geometries.NewDodecahedronGeometry, 36},
+	}
+
+	for _, c := range cases {
+
+		for detail := 0; detail < 3; detail++ {
+
+			g := c.new(3, detail)
+
+			faces := c.faces << uint(2*detail)
+			checkGeometry(t, c.name, g, faces*3, -1)
+			checkOutward(t, c.name, g)
+
+			position := g.GetAttribute("position")
+			v := math3.NewVector3()
+			for i := 0; i < position.Count(); i++ {
+				if l := position.GetVector3(i, v).Length(); math.Abs(l-3) > eps {
+					t.Fatalf("%s: vertex %d is not on the sphere: %s", c.name, i, v)
+				}
+			}
+
+		}
+
+	}
+
+}
+
+func TestLatheGeometry(t *testing.T) {
+
+	points := []*math3.Vector2{
+		{X: 1, Y: -1},
+		{X: 1, Y: 0},
+		{X: 1, Y: 1},
+	}
+
+	g := geometries.NewLatheGeometry(points, 12, 0, math.Pi*2)
+	checkGeometry(t, "lathe", g, 13*3, 12*2*6)
+	checkOutward(t, "lathe", g)
+
+	// the seam of a closed lathe has matching normals
+	normal := g.GetAttribute("normal")
+	n1, n2 := math3.NewVector3(), math3.NewVector3()
+	for i := range points {
+		normal.GetVector3(i, n1)
+		normal.GetVector3(12*len(points)+i, n2)
+		if n1.DistanceTo(n2) > eps {
+			t.Errorf("expected seam normals to match, got %s and %s", n1, n2)
+		}
+	}
+
+}
+
+func TestTubeGeometry(t *testing.T) {
+
+	// a helix around the y axis
+	helix := geometries.CurveFunc(func(t float64) *math3.Vector3 {
+		angle := t * math.Pi * 4
+		return math3.NewVector3().Set(math.Cos(angle)*2, t*3, math.Sin(angle)*2)
+	})
+
+	g := geometries.NewTubeGeometry(helix, 32, 0.25, 6, false)
+	checkGeometry(t, "tube", g, 33*7, 32*6*6)
+
+	// the tube surface stays a radius away from the path
+	position := g.GetAttribute("position")
+	v := math3.NewVector3()
+	for i := 0; i < position.Count(); i++ {
+
+		position.GetVector3(i, v)
+
+		closest := math.Inf(1)
+		for s := 0; s <= 1000; s++ {
+			closest = math.Min(closest, v.DistanceTo(helix(float64(s)/1000)))
+		}
+		if math.Abs(closest-0.25) > 0.01 {
+			t.Fatalf("vertex %d is %v from the path", i, closest)
+		}
+
+	}
+
+	// a closed tube ends where it started
+	circle := geometries.CurveFunc(func(t float64) *math3.Vector3 {
+		angle := t * math.Pi * 2
+		return math3.NewVector3().Set(math.Cos(angle), math.Sin(angle), 0)
+	})
+
+	g = geometries.NewTubeGeometry(circle, 16, 0.1, 4, true)
+	checkGeometry(t, "closed tube", g, 17*5, 16*4*6)
+
+	position = g.GetAttribute("position")
+	first, last := math3.NewVector3(), math3.NewVector3()
+	for j := 0; j <= 4; j++ {
+		position.GetVector3(j, first)
+		position.GetVector3(16*5+j, last)
+		if !first.Equals(last) {
+			t.Errorf("expected first and last rings to match, got %s and %s", first, last)
+		}
+	}
+
+}
+
+func TestComputeFrenetFrames(t *testing.T) {
+
+	line := geometries.CurveFunc(func(t float64) *math3.Vector3 {
+		return math3.NewVector3().Set(t, t, 0)
+	})
+
+	tangents, normals, binormals := geometries.ComputeFrenetFrames(line, 4, false)
+
+	if len(tangents) != 5 || len(normals) != 5 || len(binormals) != 5 {
+		t.Fatalf("expected 5 frames, got %d, %d, %d", len(tangents), len(normals), len(binormals))
+	}
+
+	tangent := math3.NewVector3().Set(1, 1, 0).Normalize()
+	for i := range tangents {
+
+		if tangents[i].DistanceTo(tangent) > eps {
+			t.Errorf("expected tangent %s, got %s", tangent, tangents[i])
+		}
+		if math.Abs(tangents[i].Dot(normals[i])) > eps || math.Abs(tangents[i].Dot(binormals[i])) > eps {
+			t.Errorf("expected frame %d to be orthogonal", i)
+		}
+
+	}
+
+}
@@ -0,0 +1,96 @@
+package geometries
+
+import (
+	"math"
+
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/math3"
+)
+
+// NewLatheGeometry creates a surface of revolution by rotating the
+// given profile points around the y axis. The x coordinate of each
+// point is its distance from the axis. Angles are in radians.
+func NewLatheGeometry(points []*math3.Vector2, segments int, phiStart, phiLength float64) *core.BufferGeometry {
+
+	segments = maxInt(1, segments)
+
+	// clamp phiLength so it's in range of [ 0, 2PI ]
+	phiLength = math3.Clamp(phiLength, 0, math.Pi*2)
+
+	b := &builder{}
+
+	inverseSegments := 1 / float64(segments)
+
+	// generate vertices and uvs
+
+	for i := 0; i <= segments; i++ {
+
+		phi := phiStart + float64(i)*inverseSegments*phiLength
+
+		sin := math.Sin(phi)
+		cos := math.Cos(phi)
+
+		for j, point := range points {
+
+			b.addVertex(point.X*sin, point.Y, point.X*cos)
+			b.addNormal(0, 0, 0)
+			b.addUV(float64(i)/float64(segments), float64(j)/float64(len(points)-1))
+
+		}
+
+	}
+
+	// indices
+
+	for i := 0; i < segments; i++ {
+
+		for j := 0; j < len(points)-1; j++ {
+
+			base := j + i*len(points)
+
+			a := base
+			b2 := base + len(points)
+			c := base + len(points) + 1
+			d := base + 1
+
+			b.addFace(a, b2, d)
+			b.addFace(b2, c, d)
+
+		}
+
+	}
+
+	g := b.geometry()
+
+	g.ComputeVertexNormals()
+
+	// if the geometry is closed, we need to average the normals along the seam.
+	// because the corresponding vertices are identical (but still have different UVs).
+
+	if phiLength == math.Pi*2 {
+
+		normals := g.GetAttribute("normal")
+
+		n1 := math3.NewVector3()
+		n2 := math3.NewVector3()
+
+		// this is the buffer offset for the last line of vertices
+		base := segments * len(points)
+
+		for i := range points {
+
+			normals.GetVector3(i, n1)
+			normals.GetVector3(base+i, n2)
+
+			n1.Add(n2).Normalize()
+
+			normals.SetVector3(i, n1)
+			normals.SetVector3(base+i, n1)
+
+		}
+
+	}
+
+	return g
+
+}
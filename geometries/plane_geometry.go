@@ -0,0 +1,61 @@
+package geometries
+
+import "github.com/rydrman/three.go/core"
+
+// NewPlaneGeometry creates a rectangle in the xy plane facing +z,
+// centered on the origin and subdivided by the given number of segments
+func NewPlaneGeometry(width, height float64, widthSegments, heightSegments int) *core.BufferGeometry {
+
+	widthHalf := width / 2
+	heightHalf := height / 2
+
+	gridX := maxInt(1, widthSegments)
+	gridY := maxInt(1, heightSegments)
+
+	gridX1 := gridX + 1
+	gridY1 := gridY + 1
+
+	segmentWidth := width / float64(gridX)
+	segmentHeight := height / float64(gridY)
+
+	b := &builder{}
+
+	// generate vertices, normals and uvs
+
+	for iy := 0; iy < gridY1; iy++ {
+
+		y := float64(iy)*segmentHeight - heightHalf
+
+		for ix := 0; ix < gridX1; ix++ {
+
+			x := float64(ix)*segmentWidth - widthHalf
+
+			b.addVertex(x, -y, 0)
+			b.addNormal(0, 0, 1)
+			b.addUV(float64(ix)/float64(gridX), 1-float64(iy)/float64(gridY))
+
+		}
+
+	}
+
+	// indices
+
+	for iy := 0; iy < gridY; iy++ {
+
+		for ix := 0; ix < gridX; ix++ {
+
+			a := ix + gridX1*iy
+			b2 := ix + gridX1*(iy+1)
+			c := (ix + 1) + gridX1*(iy+1)
+			d := (ix + 1) + gridX1*iy
+
+			b.addFace(a, b2, d)
+			b.addFace(b2, c, d)
+
+		}
+
+	}
+
+	return b.geometry()
+
+}
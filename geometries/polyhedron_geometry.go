@@ -0,0 +1,349 @@
+package geometries
+
+import (
+	"math"
+
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/math3"
+)
+
+// NewPolyhedronGeometry projects the faces described by vertices and
+// indices onto a sphere of the given radius. Each face is subdivided
+// into 4^detail triangles before projection, so higher detail values
+// approach a sphere.
+//
+// Unlike the other generators the result is not indexed: every face
+// keeps its own vertices so that flat faces and the uv seam can be
+// represented. Normals are flat when detail is 0 and smooth otherwise.
+func NewPolyhedronGeometry(vertices []float64, indices []int, radius float64, detail int) *core.BufferGeometry {
+
+	if detail < 0 {
+		detail = 0
+	}
+
+	var vertexBuffer []float64
+	var uvBuffer []float64
+
+	pushVertex := func(vertex *math3.Vector3) {
+
+		vertexBuffer = append(vertexBuffer, vertex.X, vertex.Y, vertex.Z)
+
+	}
+
+	subdivideFace := func(a, b, c *math3.Vector3, detail int) {
+
+		cols := 1 << uint(detail)
+
+		// we use this multidimensional array as a data structure for creating the subdivision
+		v := make([][]*math3.Vector3, cols+1)
+
+		// construct all of the vertices for this subdivision
+		for i := 0; i <= cols; i++ {
+
+			aj := a.Clone().Lerp(c, float64(i)/float64(cols))
+			bj := b.Clone().Lerp(c, float64(i)/float64(cols))
+
+			rows := cols - i
+
+			v[i] = make([]*math3.Vector3, rows+1)
+
+			for j := 0; j <= rows; j++ {
+
+				if j == 0 && i == cols {
+					v[i][j] = aj
+				} else {
+					v[i][j] = aj.Clone().Lerp(bj, float64(j)/float64(rows))
+				}
+
+			}
+
+		}
+
+		// construct all of the faces
+		for i := 0; i < cols; i++ {
+
+			for j := 0; j < 2*(cols-i)-1; j++ {
+
+				k := j / 2
+
+				if j%2 == 0 {
+
+					pushVertex(v[i][k+1])
+					pushVertex(v[i+1][k])
+					pushVertex(v[i][k])
+
+				} else {
+
+					pushVertex(v[i][k+1])
+					pushVertex(v[i+1][k+1])
+					pushVertex(v[i+1][k])
+
+				}
+
+			}
+
+		}
+
+	}
+
+	// iterate over all faces and apply a subdivison with the given detail value
+
+	a := math3.NewVector3()
+	b := math3.NewVector3()
+	c := math3.NewVector3()
+
+	for i := 0; i+2 < len(indices); i += 3 {
+
+		a.FromArray(vertices, indices[i]*3)
+		b.FromArray(vertices, indices[i+1]*3)
+		c.FromArray(vertices, indices[i+2]*3)
+
+		subdivideFace(a, b, c, detail)
+
+	}
+
+	// project every vertex onto the sphere
+
+	vertex := math3.NewVector3()
+
+	for i := 0; i < len(vertexBuffer); i += 3 {
+
+		vertex.FromArray(vertexBuffer, i)
+		vertex.Normalize().MultiplyScalar(radius)
+		vertex.ToArray(vertexBuffer, i)
+
+	}
+
+	// generate uvs
+
+	for i := 0; i < len(vertexBuffer); i += 3 {
+
+		vertex.FromArray(vertexBuffer, i)
+
+		u := azimuth(vertex)/2/math.Pi + 0.5
+		v := inclination(vertex)/math.Pi + 0.5
+
+		uvBuffer = append(uvBuffer, u, 1-v)
+
+	}
+
+	correctUVs(vertexBuffer, uvBuffer)
+	correctSeam(uvBuffer)
+
+	bld := &builder{}
+
+	for i := 0; i < len(vertexBuffer); i += 3 {
+
+		bld.addVertex(vertexBuffer[i], vertexBuffer[i+1], vertexBuffer[i+2])
+		bld.addNormal(vertexBuffer[i], vertexBuffer[i+1], vertexBuffer[i+2])
+
+	}
+
+	for i := 0; i < len(uvBuffer); i += 2 {
+
+		bld.addUV(uvBuffer[i], uvBuffer[i+1])
+
+	}
+
+	g := bld.geometry()
+
+	if detail == 0 {
+		g.ComputeVertexNormals() // flat normals
+	} else {
+		g.NormalizeNormals() // smooth normals
+	}
+
+	return g
+
+}
+
+// correctUVs fixes the uvs of vertices that lie on the poles or on the
+// wrong side of the seam for the face that they belong to
+func correctUVs(vertexBuffer, uvBuffer []float64) {
+
+	a := math3.NewVector3()
+	b := math3.NewVector3()
+	c := math3.NewVector3()
+
+	centroid := math3.NewVector3()
+
+	correctUV := func(stride int, vector *math3.Vector3, azimuth float64) {
+
+		if azimuth < 0 && uvBuffer[stride] == 1 {
+			uvBuffer[stride] = uvBuffer[stride] - 1
+		}
+
+		if vector.X == 0 && vector.Z == 0 {
+			uvBuffer[stride] = azimuth/2/math.Pi + 0.5
+		}
+
+	}
+
+	for i, j := 0, 0; i < len(vertexBuffer); i, j = i+9, j+6 {
+
+		a.FromArray(vertexBuffer, i)
+		b.FromArray(vertexBuffer, i+3)
+		c.FromArray(vertexBuffer, i+6)
+
+		centroid.Copy(a).Add(b).Add(c).DivideScalar(3)
+
+		azi := azimuth(centroid)
+
+		correctUV(j+0, a, azi)
+		correctUV(j+2, b, azi)
+		correctUV(j+4, c, azi)
+
+	}
+
+}
+
+// correctSeam shifts the uvs of faces that wrap around the uv seam so
+// that the face does not stretch over the whole texture
+func correctSeam(uvBuffer []float64) {
+
+	for i := 0; i+5 < len(uvBuffer); i += 6 {
+
+		x0 := uvBuffer[i+0]
+		x1 := uvBuffer[i+2]
+		x2 := uvBuffer[i+4]
+
+		max := math.Max(x0, math.Max(x1, x2))
+		min := math.Min(x0, math.Min(x1, x2))
+
+		// 0.9 is somewhat arbitrary
+		if max > 0.9 && min < 0.1 {
+
+			if x0 < 0.2 {
+				uvBuffer[i+0]++
+			}
+			if x1 < 0.2 {
+				uvBuffer[i+2]++
+			}
+			if x2 < 0.2 {
+				uvBuffer[i+4]++
+			}
+
+		}
+
+	}
+
+}
+
+// azimuth is the angle around the y axis of the given vector
+func azimuth(vector *math3.Vector3) float64 {
+
+	return math.Atan2(vector.Z, -vector.X)
+
+}
+
+// inclination is the angle above the xz plane of the given vector
+func inclination(vector *math3.Vector3) float64 {
+
+	return math.Atan2(-vector.Y, math.Sqrt((vector.X*vector.X)+(vector.Z*vector.Z)))
+
+}
+
+// NewTetrahedronGeometry creates a tetrahedron of the given radius,
+// see NewPolyhedronGeometry
+func NewTetrahedronGeometry(radius float64, detail int) *core.BufferGeometry {
+
+	vertices := []float64{
+		1, 1, 1, -1, -1, 1, -1, 1, -1, 1, -1, -1,
+	}
+
+	indices := []int{
+		2, 1, 0, 0, 3, 2, 1, 3, 0, 2, 3, 1,
+	}
+
+	return NewPolyhedronGeometry(vertices, indices, radius, detail)
+
+}
+
+// NewOctahedronGeometry creates an octahedron of the given radius,
+// see NewPolyhedronGeometry
+func NewOctahedronGeometry(radius float64, detail int) *core.BufferGeometry {
+
+	vertices := []float64{
+		1, 0, 0, -1, 0, 0, 0, 1, 0,
+		0, -1, 0, 0, 0, 1, 0, 0, -1,
+	}
+
+	indices := []int{
+		0, 2, 4, 0, 4, 3, 0, 3, 5,
+		0, 5, 2, 1, 2, 5, 1, 5, 3,
+		1, 3, 4, 1, 4, 2,
+	}
+
+	return NewPolyhedronGeometry(vertices, indices, radius, detail)
+
+}
+
+// NewIcosahedronGeometry creates an icosahedron of the given radius,
+// see NewPolyhedronGeometry
+func NewIcosahedronGeometry(radius float64, detail int) *core.BufferGeometry {
+
+	t := (1 + math.Sqrt(5)) / 2
+
+	vertices := []float64{
+		-1, t, 0, 1, t, 0, -1, -t, 0, 1, -t, 0,
+		0, -1, t, 0, 1, t, 0, -1, -t, 0, 1, -t,
+		t, 0, -1, t, 0, 1, -t, 0, -1, -t, 0, 1,
+	}
+
+	indices := []int{
+		0, 11, 5, 0, 5, 1, 0, 1, 7, 0, 7, 10, 0, 10, 11,
+		1, 5, 9, 5, 11, 4, 11, 10, 2, 10, 7, 6, 7, 1, 8,
+		3, 9, 4, 3, 4, 2, 3, 2, 6, 3, 6, 8, 3, 8, 9,
+		4, 9, 5, 2, 4, 11, 6, 2, 10, 8, 6, 7, 9, 8, 1,
+	}
+
+	return NewPolyhedronGeometry(vertices, indices, radius, detail)
+
+}
+
+// NewDodecahedronGeometry creates a dodecahedron of the given radius,
+// see NewPolyhedronGeometry
+func NewDodecahedronGeometry(radius float64, detail int) *core.BufferGeometry {
+
+	t := (1 + math.Sqrt(5)) / 2
+	r := 1 / t
+
+	vertices := []float64{
+
+		// (±1, ±1, ±1)
+		-1, -1, -1, -1, -1, 1,
+		-1, 1, -1, -1, 1, 1,
+		1, -1, -1, 1, -1, 1,
+		1, 1, -1, 1, 1, 1,
+
+		// (0, ±1/φ, ±φ)
+		0, -r, -t, 0, -r, t,
+		0, r, -t, 0, r, t,
+
+		// (±1/φ, ±φ, 0)
+		-r, -t, 0, -r, t, 0,
+		r, -t, 0, r, t, 0,
+
+		// (±φ, 0, ±1/φ)
+		-t, 0, -r, t, 0, -r,
+		-t, 0, r, t, 0, r,
+	}
+
+	indices := []int{
+		3, 11, 7, 3, 7, 15, 3, 15, 13,
+		7, 19, 17, 7, 17, 6, 7, 6, 15,
+		17, 4, 8, 17, 8, 10, 17, 10, 6,
+		8, 0, 16, 8, 16, 2, 8, 2, 10,
+		0, 12, 1, 0, 1, 18, 0, 18, 16,
+		6, 10, 2, 6, 2, 13, 6, 13, 15,
+		2, 16, 18, 2, 18, 3, 2, 3, 13,
+		18, 1, 9, 18, 9, 11, 18, 11, 3,
+		4, 14, 12, 4, 12, 0, 4, 0, 8,
+		11, 9, 5, 11, 5, 19, 11, 19, 7,
+		19, 5, 14, 19, 14, 4, 19, 4, 17,
+		1, 12, 14, 1, 14, 5, 1, 5, 9,
+	}
+
+	return NewPolyhedronGeometry(vertices, indices, radius, detail)
+
+}
@@ -0,0 +1,66 @@
+package geometries
+
+import (
+	"math"
+
+	"github.com/rydrman/three.go/core"
+)
+
+// NewRingGeometry creates a flat ring or ring sector in the xy plane
+// facing +z. Angles are in radians, starting from the +x axis.
+func NewRingGeometry(innerRadius, outerRadius float64, thetaSegments, phiSegments int, thetaStart, thetaLength float64) *core.BufferGeometry {
+
+	thetaSegments = maxInt(3, thetaSegments)
+	phiSegments = maxInt(1, phiSegments)
+
+	b := &builder{}
+
+	radius := innerRadius
+	radiusStep := (outerRadius - innerRadius) / float64(phiSegments)
+
+	// generate vertices, normals and uvs
+
+	for j := 0; j <= phiSegments; j++ {
+
+		for i := 0; i <= thetaSegments; i++ {
+
+			segment := thetaStart + float64(i)/float64(thetaSegments)*thetaLength
+
+			x := radius * math.Cos(segment)
+			y := radius * math.Sin(segment)
+
+			b.addVertex(x, y, 0)
+			b.addNormal(0, 0, 1)
+			b.addUV((x/outerRadius+1)/2, (y/outerRadius+1)/2)
+
+		}
+
+		radius += radiusStep
+
+	}
+
+	// indices
+
+	for j := 0; j < phiSegments; j++ {
+
+		thetaSegmentLevel := j * (thetaSegments + 1)
+
+		for i := 0; i < thetaSegments; i++ {
+
+			segment := i + thetaSegmentLevel
+
+			a := segment
+			b2 := segment + thetaSegments + 1
+			c := segment + thetaSegments + 2
+			d := segment + 1
+
+			b.addFace(a, b2, d)
+			b.addFace(b2, c, d)
+
+		}
+
+	}
+
+	return b.geometry()
+
+}
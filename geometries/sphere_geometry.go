@@ -0,0 +1,83 @@
+package geometries
+
+import (
+	"math"
+
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/math3"
+)
+
+// NewSphereGeometry creates a sphere, or a section of one, centered on
+// the origin. Phi is the horizontal angle around the y axis and theta
+// the vertical angle down from +y, both in radians.
+func NewSphereGeometry(radius float64, widthSegments, heightSegments int, phiStart, phiLength, thetaStart, thetaLength float64) *core.BufferGeometry {
+
+	widthSegments = maxInt(3, widthSegments)
+	heightSegments = maxInt(2, heightSegments)
+
+	thetaEnd := thetaStart + thetaLength
+
+	b := &builder{}
+
+	index := 0
+	grid := make([][]int, 0, heightSegments+1)
+
+	normal := math3.NewVector3()
+
+	// generate vertices, normals and uvs
+
+	for iy := 0; iy <= heightSegments; iy++ {
+
+		verticesRow := make([]int, 0, widthSegments+1)
+
+		v := float64(iy) / float64(heightSegments)
+
+		for ix := 0; ix <= widthSegments; ix++ {
+
+			u := float64(ix) / float64(widthSegments)
+
+			x := -radius * math.Cos(phiStart+u*phiLength) * math.Sin(thetaStart+v*thetaLength)
+			y := radius * math.Cos(thetaStart+v*thetaLength)
+			z := radius * math.Sin(phiStart+u*phiLength) * math.Sin(thetaStart+v*thetaLength)
+
+			b.addVertex(x, y, z)
+
+			normal.Set(x, y, z).Normalize()
+			b.addNormal(normal.X, normal.Y, normal.Z)
+
+			b.addUV(u, 1-v)
+
+			verticesRow = append(verticesRow, index)
+			index++
+
+		}
+
+		grid = append(grid, verticesRow)
+
+	}
+
+	// indices
+
+	for iy := 0; iy < heightSegments; iy++ {
+
+		for ix := 0; ix < widthSegments; ix++ {
+
+			a := grid[iy][ix+1]
+			b2 := grid[iy][ix]
+			c := grid[iy+1][ix]
+			d := grid[iy+1][ix+1]
+
+			if iy != 0 || thetaStart > 0 {
+				b.addFace(a, b2, d)
+			}
+			if iy != heightSegments-1 || thetaEnd < math.Pi {
+				b.addFace(b2, c, d)
+			}
+
+		}
+
+	}
+
+	return b.geometry()
+
+}
@@ -0,0 +1,70 @@
+package geometries
+
+import (
+	"math"
+
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/math3"
+)
+
+// NewTorusGeometry creates a torus in the xy plane centered on the
+// origin. Radius is the distance from the center to the center of the
+// tube and arc is the central angle in radians.
+func NewTorusGeometry(radius, tube float64, radialSegments, tubularSegments int, arc float64) *core.BufferGeometry {
+
+	radialSegments = maxInt(1, radialSegments)
+	tubularSegments = maxInt(1, tubularSegments)
+
+	b := &builder{}
+
+	center := math3.NewVector3()
+	vertex := math3.NewVector3()
+	normal := math3.NewVector3()
+
+	// generate vertices, normals and uvs
+
+	for j := 0; j <= radialSegments; j++ {
+
+		for i := 0; i <= tubularSegments; i++ {
+
+			u := float64(i) / float64(tubularSegments) * arc
+			v := float64(j) / float64(radialSegments) * math.Pi * 2
+
+			vertex.Set(
+				(radius+tube*math.Cos(v))*math.Cos(u),
+				(radius+tube*math.Cos(v))*math.Sin(u),
+				tube*math.Sin(v),
+			)
+			b.addVertex(vertex.X, vertex.Y, vertex.Z)
+
+			center.Set(radius*math.Cos(u), radius*math.Sin(u), 0)
+			normal.SubVectors(vertex, center).Normalize()
+			b.addNormal(normal.X, normal.Y, normal.Z)
+
+			b.addUV(float64(i)/float64(tubularSegments), float64(j)/float64(radialSegments))
+
+		}
+
+	}
+
+	// indices
+
+	for j := 1; j <= radialSegments; j++ {
+
+		for i := 1; i <= tubularSegments; i++ {
+
+			a := (tubularSegments+1)*j + i - 1
+			b2 := (tubularSegments+1)*(j-1) + i - 1
+			c := (tubularSegments+1)*(j-1) + i
+			d := (tubularSegments+1)*j + i
+
+			b.addFace(a, b2, d)
+			b.addFace(b2, c, d)
+
+		}
+
+	}
+
+	return b.geometry()
+
+}
@@ -0,0 +1,116 @@
+package geometries
+
+import (
+	"math"
+
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/math3"
+)
+
+// NewTorusKnotGeometry creates a (p, q) torus knot, a tube that winds p
+// times around its axis of rotational symmetry and q times around a
+// circle in the interior of the torus
+func NewTorusKnotGeometry(radius, tube float64, tubularSegments, radialSegments, p, q int) *core.BufferGeometry {
+
+	tubularSegments = maxInt(1, tubularSegments)
+	radialSegments = maxInt(1, radialSegments)
+
+	b := &builder{}
+
+	vertex := math3.NewVector3()
+	normal := math3.NewVector3()
+
+	P1 := math3.NewVector3()
+	P2 := math3.NewVector3()
+
+	B := math3.NewVector3()
+	T := math3.NewVector3()
+	N := math3.NewVector3()
+
+	// generate vertices, normals and uvs
+
+	for i := 0; i <= tubularSegments; i++ {
+
+		// the radian "u" is used to calculate the position on the torus curve of the current tubular segement
+		u := float64(i) / float64(tubularSegments) * float64(p) * math.Pi * 2
+
+		// now we calculate two points. P1 is our current position on the curve, P2 is a little farther ahead.
+		// these points are used to create a special "coordinate space", which is necessary to calculate the correct vertex positions
+		positionOnTorusKnot(u, p, q, radius, P1)
+		positionOnTorusKnot(u+0.01, p, q, radius, P2)
+
+		// calculate orthonormal basis
+		T.SubVectors(P2, P1)
+		N.AddVectors(P2, P1)
+		B.CrossVectors(T, N)
+		N.CrossVectors(B, T)
+
+		// normalize B, N. T can be ignored, we don't use it
+		B.Normalize()
+		N.Normalize()
+
+		for j := 0; j <= radialSegments; j++ {
+
+			// now calculate the vertices. they are nothing more than an extrusion of the torus curve.
+			// because we extrude a shape in the xy-plane, there is no need to calculate a z-value.
+			v := float64(j) / float64(radialSegments) * math.Pi * 2
+			cx := -tube * math.Cos(v)
+			cy := tube * math.Sin(v)
+
+			// now calculate the final vertex position.
+			// first we orient the extrusion with our basis vectos, then we add it to the current position on the curve
+			vertex.Set(
+				P1.X+(cx*N.X+cy*B.X),
+				P1.Y+(cx*N.Y+cy*B.Y),
+				P1.Z+(cx*N.Z+cy*B.Z),
+			)
+			b.addVertex(vertex.X, vertex.Y, vertex.Z)
+
+			// normal (P1 is always the center/origin of the extrusion, thus we can use it to calculate the normal)
+			normal.SubVectors(vertex, P1).Normalize()
+			b.addNormal(normal.X, normal.Y, normal.Z)
+
+			b.addUV(float64(i)/float64(tubularSegments), float64(j)/float64(radialSegments))
+
+		}
+
+	}
+
+	// indices
+
+	for j := 1; j <= tubularSegments; j++ {
+
+		for i := 1; i <= radialSegments; i++ {
+
+			a := (radialSegments+1)*(j-1) + (i - 1)
+			b2 := (radialSegments+1)*j + (i - 1)
+			c := (radialSegments+1)*j + i
+			d := (radialSegments+1)*(j-1) + i
+
+			b.addFace(a, b2, d)
+			b.addFace(b2, c, d)
+
+		}
+
+	}
+
+	return b.geometry()
+
+}
+
+// positionOnTorusKnot calculates the current position on the torus
+// curve for the given angle
+func positionOnTorusKnot(u float64, p, q int, radius float64, position *math3.Vector3) {
+
+	cu := math.Cos(u)
+	su := math.Sin(u)
+	quOverP := float64(q) / float64(p) * u
+	cs := math.Cos(quOverP)
+
+	position.Set(
+		radius*(2+cs)*0.5*cu,
+		radius*(2+cs)*su*0.5,
+		radius*math.Sin(quOverP)*0.5,
+	)
+
+}
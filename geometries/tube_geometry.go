@@ -0,0 +1,110 @@
+package geometries
+
+import (
+	"math"
+
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/math3"
+)
+
+// NewTubeGeometry creates a tube that extrudes a circle of the given
+// radius along path. The tube is split into tubularSegments evenly
+// spaced along the length of the path, and the last ring joins the
+// first when closed is set.
+func NewTubeGeometry(path Curve, tubularSegments int, radius float64, radialSegments int, closed bool) *core.BufferGeometry {
+
+	tubularSegments = maxInt(1, tubularSegments)
+	radialSegments = maxInt(1, radialSegments)
+
+	_, normals, binormals := ComputeFrenetFrames(path, tubularSegments, closed)
+
+	lengths := arcLengths(path, curveDivisions)
+
+	b := &builder{}
+
+	normal := math3.NewVector3()
+
+	generateSegment := func(i int) {
+
+		// we use getPointAt to sample evenly distributed points from the given path
+		P := path.GetPoint(uToT(lengths, float64(i)/float64(tubularSegments)))
+
+		// retrieve corresponding normal and binormal
+		N := normals[i]
+		B := binormals[i]
+
+		// generate normals and vertices for the current segment
+		for j := 0; j <= radialSegments; j++ {
+
+			v := float64(j) / float64(radialSegments) * math.Pi * 2
+
+			sin := math.Sin(v)
+			cos := -math.Cos(v)
+
+			normal.Set(
+				cos*N.X+sin*B.X,
+				cos*N.Y+sin*B.Y,
+				cos*N.Z+sin*B.Z,
+			).Normalize()
+			b.addNormal(normal.X, normal.Y, normal.Z)
+
+			b.addVertex(
+				P.X+radius*normal.X,
+				P.Y+radius*normal.Y,
+				P.Z+radius*normal.Z,
+			)
+
+		}
+
+	}
+
+	for i := 0; i < tubularSegments; i++ {
+
+		generateSegment(i)
+
+	}
+
+	// if the geometry is not closed, generate the last row of vertices and normals
+	// at the regular position on the given path
+	//
+	// if the geometry is closed, duplicate the first row of vertices and normals (uvs will differ)
+
+	if closed {
+		generateSegment(0)
+	} else {
+		generateSegment(tubularSegments)
+	}
+
+	// uvs
+
+	for i := 0; i <= tubularSegments; i++ {
+
+		for j := 0; j <= radialSegments; j++ {
+
+			b.addUV(float64(i)/float64(tubularSegments), float64(j)/float64(radialSegments))
+
+		}
+
+	}
+
+	// indices
+
+	for j := 1; j <= tubularSegments; j++ {
+
+		for i := 1; i <= radialSegments; i++ {
+
+			a := (radialSegments+1)*(j-1) + (i - 1)
+			b2 := (radialSegments+1)*j + (i - 1)
+			c := (radialSegments+1)*j + i
+			d := (radialSegments+1)*(j-1) + i
+
+			b.addFace(a, b2, d)
+			b.addFace(b2, c, d)
+
+		}
+
+	}
+
+	return b.geometry()
+
+}
@@ -0,0 +1,18 @@
+package lights
+
+import "github.com/rydrman/three.go/math3"
+
+// AmbientLight illuminates all objects in the scene equally
+// from every direction
+type AmbientLight struct {
+	*Light
+}
+
+// NewAmbientLight creates an ambient light of the given color and intensity
+func NewAmbientLight(color *math3.Color, intensity float64) *AmbientLight {
+
+	return &AmbientLight{
+		Light: NewLight(color, intensity),
+	}
+
+}
@@ -0,0 +1,51 @@
+package lights
+
+import (
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/objects"
+)
+
+// DirectionalLight emits parallel rays from infinitely far away, shining
+// from its position towards the position of its target
+type DirectionalLight struct {
+	*Light
+
+	// Target is the node that this light points at, it must be part of
+	// the scene if it has a parent, otherwise it is updated when needed
+	Target objects.Node
+
+	// Shadow is used when CastShadow is set, its camera is
+	// orthographic so that the shadow is cast along the light
+	Shadow *LightShadow
+}
+
+// NewDirectionalLight creates a directional light of the given color and
+// intensity that shines straight down onto the origin
+func NewDirectionalLight(color *math3.Color, intensity float64) *DirectionalLight {
+
+	l := &DirectionalLight{
+		Light:  NewLight(color, intensity),
+		Target: newTarget(),
+		Shadow: NewDirectionalLightShadow(),
+	}
+
+	l.Position.Copy(objects.DefaultUp)
+	l.UpdateMatrix()
+
+	return l
+
+}
+
+// GetDirection returns the direction in world space from
+// the target towards this light
+func (l *DirectionalLight) GetDirection(target *math3.Vector3) *math3.Vector3 {
+
+	if nil == target {
+		target = math3.NewVector3()
+	}
+
+	targetPosition(l.Target, target)
+
+	return target.SubVectors(math3.NewVector3().SetFromMatrixPosition(l.MatrixWorld), target).Normalize()
+
+}
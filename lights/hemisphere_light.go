@@ -0,0 +1,35 @@
+package lights
+
+import (
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/objects"
+)
+
+// HemisphereLight is an ambient light that fades from the sky color
+// for surfaces facing towards its position to the ground color for
+// surfaces facing away from it
+type HemisphereLight struct {
+	*Light
+
+	GroundColor *math3.Color
+}
+
+// NewHemisphereLight creates a hemisphere light above the origin,
+// the sky color is white if nil and the ground color black if nil
+func NewHemisphereLight(skyColor, groundColor *math3.Color, intensity float64) *HemisphereLight {
+
+	if nil == groundColor {
+		groundColor = math3.NewColor().SetHex(0x000000)
+	}
+
+	l := &HemisphereLight{
+		Light:       NewLight(skyColor, intensity),
+		GroundColor: groundColor.Clone(),
+	}
+
+	l.Position.Copy(objects.DefaultUp)
+	l.UpdateMatrix()
+
+	return l
+
+}
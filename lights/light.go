@@ -0,0 +1,82 @@
+/*
+Package lights contains the light sources that illuminate a scene.
+Lights are placed in the scene like any other object, renderers gather
+them into a State each frame which lit materials are shaded against.
+*/
+package lights
+
+import (
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/objects"
+)
+
+// Source is implemented by all of the light types in this package
+type Source interface {
+	objects.Node
+	GetLight() *Light
+}
+
+// Light holds the properties common to all lights
+type Light struct {
+	*objects.Object
+
+	Color     *math3.Color
+	Intensity float64
+}
+
+// NewLight creates a light with the given color and intensity,
+// white is used if color is nil
+func NewLight(color *math3.Color, intensity float64) *Light {
+
+	if nil == color {
+		color = math3.NewColor().SetHex(0xffffff)
+	}
+
+	return &Light{
+		Object: objects.NewObject(),
+
+		Color:     color.Clone(),
+		Intensity: intensity,
+	}
+
+}
+
+// GetLight returns the base light, allowing it to be accessed
+// from any of the specific light types
+func (l *Light) GetLight() *Light {
+
+	return l
+
+}
+
+// Copy copies the transform, color and intensity of src into this light
+func (l *Light) Copy(src *Light) *Light {
+
+	l.Object.Copy(src.Object)
+
+	l.Color.Copy(src.Color)
+	l.Intensity = src.Intensity
+
+	return l
+
+}
+
+// newTarget creates the default target of directional and spot lights,
+// which sits at the origin so that the light points down from above
+func newTarget() objects.Node {
+
+	return objects.NewObject()
+
+}
+
+// targetPosition returns the world position of target, updating its
+// world matrix first if it is not part of a scene
+func targetPosition(target objects.Node, v *math3.Vector3) *math3.Vector3 {
+
+	if target.GetParent() == nil {
+		target.UpdateMatrixWorld(false)
+	}
+
+	return v.SetFromMatrixPosition(target.GetMatrixWorld())
+
+}
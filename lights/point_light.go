@@ -0,0 +1,51 @@
+package lights
+
+import (
+	"math"
+
+	"github.com/rydrman/three.go/math3"
+)
+
+// PointLight emits light in every direction from a single point
+type PointLight struct {
+	*Light
+
+	// Distance is where the intensity of the light reaches zero,
+	// the light is not limited when it is 0
+	Distance float64
+	// Decay is the rate at which the light dims over Distance
+	Decay float64
+
+	// Shadow is used when CastShadow is set, it is rendered
+	// into the six faces of a cube around the light
+	Shadow *LightShadow
+}
+
+// NewPointLight creates a point light of the given color and intensity,
+// a distance of 0 gives the light unlimited range
+func NewPointLight(color *math3.Color, intensity, distance, decay float64) *PointLight {
+
+	return &PointLight{
+		Light: NewLight(color, intensity),
+
+		Distance: distance,
+		Decay:    decay,
+
+		Shadow: NewPointLightShadow(),
+	}
+
+}
+
+// GetPower returns the luminous power of this light in lumens
+func (l *PointLight) GetPower() float64 {
+
+	return l.Intensity * 4 * math.Pi
+
+}
+
+// SetPower sets the intensity of this light from a luminous power in lumens
+func (l *PointLight) SetPower(power float64) {
+
+	l.Intensity = power / (4 * math.Pi)
+
+}
@@ -0,0 +1,25 @@
+package lights
+
+import "github.com/rydrman/three.go/math3"
+
+// RectAreaLight emits light uniformly from both sides of a rectangle
+// centered on its position in its local xy plane
+type RectAreaLight struct {
+	*Light
+
+	Width  float64
+	Height float64
+}
+
+// NewRectAreaLight creates a rectangular light of the given size,
+// color and intensity
+func NewRectAreaLight(color *math3.Color, intensity, width, height float64) *RectAreaLight {
+
+	return &RectAreaLight{
+		Light: NewLight(color, intensity),
+
+		Width:  width,
+		Height: height,
+	}
+
+}
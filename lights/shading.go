@@ -0,0 +1,330 @@
+package lights
+
+import (
+	"math"
+
+	"github.com/rydrman/three.go/materials"
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/objects"
+)
+
+// Lit returns true if the given material is shaded by the lights of
+// the scene, which is the case for the lambert, phong and standard
+// materials unless their Lights flag is unset
+func Lit(material objects.Material) bool {
+
+	switch material.(type) {
+	case *materials.MeshLambertMaterial,
+		*materials.MeshPhongMaterial,
+		*materials.MeshStandardMaterial:
+		return material.GetMaterial().Lights
+	}
+
+	return false
+
+}
+
+// shadingScratch holds the temporary values of Shade,
+// which are reused to avoid allocating for every fragment
+type shadingScratch struct {
+	albedo     *math3.Color
+	emissive   *math3.Color
+	specular   *math3.Color
+	irradiance *math3.Color
+	reflected  *math3.Color
+	light      *math3.Color
+	brdf       *math3.Color
+
+	n, v, l, h *math3.Vector3
+
+	offset, closest, lightNormal *math3.Vector3
+}
+
+func newShadingScratch() *shadingScratch {
+
+	return &shadingScratch{
+		albedo:     math3.NewColor(),
+		emissive:   math3.NewColor(),
+		specular:   math3.NewColor(),
+		irradiance: math3.NewColor(),
+		reflected:  math3.NewColor(),
+		light:      math3.NewColor(),
+		brdf:       math3.NewColor(),
+
+		n: math3.NewVector3(),
+		v: math3.NewVector3(),
+		l: math3.NewVector3(),
+		h: math3.NewVector3(),
+
+		offset:      math3.NewVector3(),
+		closest:     math3.NewVector3(),
+		lightNormal: math3.NewVector3(),
+	}
+
+}
+
+// Shade returns the color of a point on a surface with the given material
+// and diffuse color when lit by the lights in this state. Position and
+// normal are in world space and view points from the surface towards the
+// camera. Shadows are applied when receiveShadow is set and shadows are
+// enabled in this state. The diffuse color is returned unchanged for
+// materials that are not lit. The result follows the non physical light units of three.js,
+// where a white light facing a white lambert surface gives white.
+// Shade reuses temporary values held by this state, so a state must
+// not be shaded from more than one goroutine at a time.
+func (s *State) Shade(material objects.Material, diffuse *math3.Color, position, normal, view *math3.Vector3, receiveShadow bool, target *math3.Color) *math3.Color {
+
+	if nil == target {
+		target = math3.NewColor()
+	}
+
+	if !Lit(material) {
+		return target.Copy(diffuse)
+	}
+
+	if nil == s.scratch {
+		s.scratch = newShadingScratch()
+	}
+
+	sc := s.scratch
+	albedo := sc.albedo.Copy(diffuse)
+	emissive := sc.emissive.SetScalar(0)
+
+	n := sc.n.Copy(normal).Normalize()
+	v := sc.v.Copy(view).Normalize()
+
+	var phong *materials.MeshPhongMaterial
+	var specular *math3.Color
+	var roughness float64
+
+	switch m := material.(type) {
+
+	case *materials.MeshLambertMaterial:
+
+		emissive.Copy(m.Emissive).MultiplyScalar(m.EmissiveIntensity)
+
+	case *materials.MeshPhongMaterial:
+
+		emissive.Copy(m.Emissive).MultiplyScalar(m.EmissiveIntensity)
+		phong = m
+
+	case *materials.MeshStandardMaterial:
+
+		emissive.Copy(m.Emissive).MultiplyScalar(m.EmissiveIntensity)
+		albedo.MultiplyScalar(1 - m.Metalness)
+
+		specular = sc.specular.SetScalar(0.04).Lerp(diffuse, m.Metalness)
+		roughness = math3.Clamp(m.Roughness, 0.04, 1)
+
+	}
+
+	irradiance := sc.irradiance.Copy(s.Ambient)
+	reflected := sc.reflected.SetScalar(0)
+
+	direct := func(color *math3.Color, l *math3.Vector3) {
+
+		dotNL := math.Max(n.Dot(l), 0)
+		if dotNL == 0 {
+			return
+		}
+
+		irradiance.R += color.R * dotNL
+		irradiance.G += color.G * dotNL
+		irradiance.B += color.B * dotNL
+
+		var brdf *math3.Color
+		switch {
+		case phong != nil:
+			brdf = specularBlinnPhong(phong.Specular, phong.Shininess, l, n, v, sc.h, sc.brdf)
+		case specular != nil:
+			brdf = specularGGX(specular, roughness, l, n, v, sc.h, sc.brdf)
+		default:
+			return
+		}
+
+		// non physical lights are scaled by pi, which the lambert
+		// diffuse term cancels out so it is only applied here
+		reflected.Add(brdf.Multiply(color).MultiplyScalar(dotNL * math.Pi))
+
+	}
+
+	// shadowFactor returns the fraction of a light that reaches position
+	shadowFactor := func(shadow *LightShadow, lightPosition *math3.Vector3) float64 {
+
+		if !receiveShadow || !s.ShadowsEnabled || shadow == nil {
+			return 1
+		}
+
+		return shadow.factor(s.ShadowType, position, lightPosition)
+
+	}
+
+	l := sc.l
+	color := sc.light
+
+	for _, light := range s.Directional {
+
+		color.Copy(light.Color).MultiplyScalar(shadowFactor(light.Shadow, nil))
+		direct(color, light.Direction)
+
+	}
+
+	for _, light := range s.Point {
+
+		l.SubVectors(light.Position, position)
+		distance := l.Length()
+		l.Normalize()
+
+		color.Copy(light.Color).MultiplyScalar(attenuation(distance, light.Distance, light.Decay))
+		color.MultiplyScalar(shadowFactor(light.Shadow, light.Position))
+		direct(color, l)
+
+	}
+
+	for _, light := range s.Spot {
+
+		l.SubVectors(light.Position, position)
+		distance := l.Length()
+		l.Normalize()
+
+		angleCos := l.Dot(light.Direction)
+		if angleCos <= light.ConeCos {
+			continue
+		}
+
+		effect := smoothstep(light.ConeCos, light.PenumbraCos, angleCos)
+		color.Copy(light.Color).MultiplyScalar(effect * attenuation(distance, light.Distance, light.Decay))
+		color.MultiplyScalar(shadowFactor(light.Shadow, light.Position))
+		direct(color, l)
+
+	}
+
+	for _, light := range s.RectArea {
+
+		direct(rectAreaIrradiance(light, position, sc), l)
+
+	}
+
+	for _, light := range s.Hemisphere {
+
+		weight := 0.5*n.Dot(light.Direction) + 0.5
+		irradiance.Add(color.Copy(light.GroundColor).Lerp(light.SkyColor, weight))
+
+	}
+
+	return target.Copy(albedo).Multiply(irradiance).Add(reflected).Add(emissive)
+
+}
+
+// attenuation returns the fraction of a punctual light that reaches a
+// point at the given distance, cutoff is the range of the light or 0
+func attenuation(distance, cutoff, decay float64) float64 {
+
+	if cutoff > 0 && decay > 0 {
+		return math.Pow(math3.Clamp(1-distance/cutoff, 0, 1), decay)
+	}
+
+	return 1
+
+}
+
+// rectAreaIrradiance approximates the light that reaches position from an
+// area light as coming from the closest point of its rectangle, scaled by
+// the portion of the hemisphere above position that the rectangle covers.
+// The color is returned in sc.light and the direction towards it in sc.l.
+func rectAreaIrradiance(light *RectAreaState, position *math3.Vector3, sc *shadingScratch) *math3.Color {
+
+	offset := sc.offset.SubVectors(position, light.Position)
+	closest := sc.closest.Copy(light.Position)
+
+	for _, half := range [2]*math3.Vector3{light.HalfWidth, light.HalfHeight} {
+
+		if lengthSq := half.LengthSq(); lengthSq > 0 {
+			closest.AddScaledVector(half, math3.Clamp(offset.Dot(half)/lengthSq, -1, 1))
+		}
+
+	}
+
+	l := sc.l.SubVectors(closest, position)
+	distanceSq := l.LengthSq()
+	l.Normalize()
+
+	area := 4 * light.HalfWidth.Length() * light.HalfHeight.Length()
+	lightNormal := sc.lightNormal.CrossVectors(light.HalfWidth, light.HalfHeight).Normalize()
+	cosLight := math.Abs(l.Dot(lightNormal))
+
+	factor := area * cosLight / (math.Pi*distanceSq + area)
+	if area == 0 {
+		factor = 0
+	}
+
+	return sc.light.Copy(light.Color).MultiplyScalar(factor)
+
+}
+
+// specularBlinnPhong returns the normalized blinn-phong specular
+// reflectance of a phong material for light arriving from l in target,
+// h receives the half vector
+func specularBlinnPhong(specular *math3.Color, shininess float64, l, n, v, h *math3.Vector3, target *math3.Color) *math3.Color {
+
+	h.AddVectors(l, v).Normalize()
+	dotNH := math3.Clamp(n.Dot(h), 0, 1)
+	dotLH := math3.Clamp(l.Dot(h), 0, 1)
+
+	d := (shininess*0.5 + 1) * math.Pow(dotNH, shininess) / math.Pi
+
+	return fresnelSchlick(specular, dotLH, target).MultiplyScalar(0.25 * d)
+
+}
+
+// specularGGX returns the specular reflectance of a standard material
+// using the GGX distribution and a height correlated smith term in
+// target, h receives the half vector
+func specularGGX(specular *math3.Color, roughness float64, l, n, v, h *math3.Vector3, target *math3.Color) *math3.Color {
+
+	alpha := roughness * roughness
+	alpha2 := alpha * alpha
+
+	h.AddVectors(l, v).Normalize()
+	dotNL := math3.Clamp(n.Dot(l), 0, 1)
+	dotNV := math3.Clamp(n.Dot(v), 0, 1)
+	dotNH := math3.Clamp(n.Dot(h), 0, 1)
+	dotLH := math3.Clamp(l.Dot(h), 0, 1)
+
+	gv := dotNL * math.Sqrt(alpha2+(1-alpha2)*dotNV*dotNV)
+	gl := dotNV * math.Sqrt(alpha2+(1-alpha2)*dotNL*dotNL)
+	g := 0.5 / math.Max(gv+gl, 1e-6)
+
+	denom := dotNH*dotNH*(alpha2-1) + 1
+	d := alpha2 / (math.Pi * denom * denom)
+
+	return fresnelSchlick(specular, dotLH, target).MultiplyScalar(g * d)
+
+}
+
+// fresnelSchlick returns the spherical gaussian approximation
+// of the schlick fresnel term in target
+func fresnelSchlick(specular *math3.Color, dotLH float64, target *math3.Color) *math3.Color {
+
+	fresnel := math.Exp2((-5.55473*dotLH - 6.98316) * dotLH)
+
+	return target.SetScalar(1).Sub(specular).MultiplyScalar(fresnel).Add(specular)
+
+}
+
+// smoothstep returns a smooth interpolation from 0 to 1 as x moves
+// from edge0 to edge1
+func smoothstep(edge0, edge1, x float64) float64 {
+
+	if edge0 == edge1 {
+		if x < edge0 {
+			return 0
+		}
+		return 1
+	}
+
+	t := math3.Clamp((x-edge0)/(edge1-edge0), 0, 1)
+
+	return t * t * (3 - 2*t)
+
+}
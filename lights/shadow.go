@@ -0,0 +1,375 @@
+package lights
+
+import (
+	"math"
+
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/cameras"
+	"github.com/rydrman/three.go/math3"
+)
+
+// ShadowCamera is implemented by the cameras that shadow maps
+// can be rendered with
+type ShadowCamera interface {
+	math3.Projector
+	GetCamera() *cameras.Camera
+	UpdateProjectionMatrix()
+}
+
+// LightShadow holds the settings and shadow map of a light that can
+// cast shadows. Shadows are only rendered for lights with CastShadow set
+// onto objects with ReceiveShadow set.
+type LightShadow struct {
+	// Camera is the view that the shadow map is rendered from,
+	// it is positioned by Update each time the shadow is rendered
+	Camera ShadowCamera
+
+	// Bias is added to the depth of each fragment before it is compared
+	// against the shadow map, small negative values reduce shadow acne
+	Bias float64
+	// Radius scales the area sampled by the PCF filters, in texels
+	Radius float64
+	// MapSize is the width and height of the shadow map in texels
+	MapSize *math3.Vector2
+
+	// Map is rendered by the renderer, it is nil until the
+	// light has cast shadows for the first time
+	Map *ShadowMap
+	// Matrix transforms world positions into the texture
+	// coordinates and depth of the first face of Map
+	Matrix *math3.Matrix4
+}
+
+// ShadowMap holds the depth of a scene as seen from a light
+type ShadowMap struct {
+	Width  int
+	Height int
+
+	// Faces holds the depth rendered for each view in rows from the top,
+	// with values in the range [0, 1]. Directional and spot lights have a
+	// single face, point lights have the six faces of a cube ordered
+	// +x, -x, +y, -y, +z, -z.
+	Faces [][]float64
+	// ViewProjections are the matrices that each face is rendered with
+	ViewProjections []*math3.Matrix4
+
+	// near and far are the clipping planes of cube faces,
+	// used to turn their depth back into a distance
+	near float64
+	far  float64
+}
+
+// cubeDirections and cubeUps orient the camera for each
+// of the faces of a point light shadow map
+var (
+	cubeDirections = [6]*math3.Vector3{
+		{X: 1, Y: 0, Z: 0}, {X: -1, Y: 0, Z: 0},
+		{X: 0, Y: 1, Z: 0}, {X: 0, Y: -1, Z: 0},
+		{X: 0, Y: 0, Z: 1}, {X: 0, Y: 0, Z: -1},
+	}
+	cubeUps = [6]*math3.Vector3{
+		{X: 0, Y: 1, Z: 0}, {X: 0, Y: 1, Z: 0},
+		{X: 0, Y: 0, Z: -1}, {X: 0, Y: 0, Z: 1},
+		{X: 0, Y: 1, Z: 0}, {X: 0, Y: 1, Z: 0},
+	}
+)
+
+// NewLightShadow creates a shadow rendered with the given camera
+// into a 512 x 512 shadow map
+func NewLightShadow(camera ShadowCamera) *LightShadow {
+
+	return &LightShadow{
+		Camera: camera,
+
+		Bias:    0,
+		Radius:  1,
+		MapSize: math3.NewVector2().Set(512, 512),
+
+		Matrix: math3.NewMatrix4(),
+	}
+
+}
+
+// NewDirectionalLightShadow creates a shadow for a directional light,
+// which covers a 10 x 10 area around the target of the light
+func NewDirectionalLightShadow() *LightShadow {
+
+	return NewLightShadow(cameras.NewOrthographicCamera(-5, 5, 5, -5, 0.5, 500))
+
+}
+
+// NewSpotLightShadow creates a shadow for a spot light, the field of
+// view of its camera follows the angle of the light
+func NewSpotLightShadow() *LightShadow {
+
+	return NewLightShadow(cameras.NewPerspectiveCamera(50, 1, 0.5, 500))
+
+}
+
+// NewPointLightShadow creates a shadow for a point light, which is
+// rendered into the six faces of a cube around the light
+func NewPointLightShadow() *LightShadow {
+
+	return NewLightShadow(cameras.NewPerspectiveCamera(90, 1, 0.5, 500))
+
+}
+
+// Update positions the camera of this shadow for the given light,
+// allocates Map and computes the matrices that each of its faces
+// must be rendered with. The world matrix of the light should be up
+// to date.
+func (s *LightShadow) Update(light Source) {
+
+	camera := s.Camera.GetCamera()
+	position := math3.NewVector3().SetFromMatrixPosition(light.GetLight().MatrixWorld)
+	target := math3.NewVector3()
+	faces := 1
+
+	switch l := light.(type) {
+
+	case *DirectionalLight:
+
+		targetPosition(l.Target, target)
+
+	case *SpotLight:
+
+		targetPosition(l.Target, target)
+
+		if c, ok := s.Camera.(*cameras.PerspectiveCamera); ok {
+
+			c.Fov = math3.Rad2Deg * 2 * l.Angle
+			c.Aspect = s.MapSize.X / s.MapSize.Y
+			if l.Distance > 0 {
+				c.Far = l.Distance
+			}
+
+		}
+
+	case *PointLight:
+
+		faces = 6
+
+	}
+
+	s.Camera.UpdateProjectionMatrix()
+
+	width, height := int(s.MapSize.X), int(s.MapSize.Y)
+	if s.Map == nil || s.Map.Width != width || s.Map.Height != height || len(s.Map.Faces) != faces {
+		s.Map = newShadowMap(width, height, faces)
+	}
+
+	if c, ok := s.Camera.(*cameras.PerspectiveCamera); ok {
+		s.Map.near, s.Map.far = c.Near, c.Far
+	}
+
+	up := camera.Up.Clone()
+
+	for i := 0; i < faces; i++ {
+
+		camera.Position.Copy(position)
+
+		if faces == 6 {
+			camera.Up.Copy(cubeUps[i])
+			target.AddVectors(position, cubeDirections[i])
+		}
+
+		camera.LookAt(target)
+		camera.UpdateMatrixWorld(false)
+
+		s.Map.ViewProjections[i].MultiplyMatrices(camera.ProjectionMatrix, camera.MatrixWorldInverse)
+
+	}
+
+	camera.Up.Copy(up)
+
+	// maps device coordinates into the [0, 1] range of textures
+	s.Matrix.Set(
+		0.5, 0.0, 0.0, 0.5,
+		0.0, 0.5, 0.0, 0.5,
+		0.0, 0.0, 0.5, 0.5,
+		0.0, 0.0, 0.0, 1.0,
+	).Multiply(s.Map.ViewProjections[0])
+
+}
+
+func newShadowMap(width, height, faces int) *ShadowMap {
+
+	m := &ShadowMap{
+		Width:  width,
+		Height: height,
+	}
+
+	for i := 0; i < faces; i++ {
+		m.Faces = append(m.Faces, make([]float64, width*height))
+		m.ViewProjections = append(m.ViewProjections, math3.NewMatrix4())
+	}
+
+	return m
+
+}
+
+// factor returns the fraction of the light that reaches position, from
+// 0 when it is fully shadowed to 1 when it is fully lit, filtering the
+// shadow map with one of the three.*ShadowMap constants. Positions
+// outside of the shadow camera are not shadowed.
+func (s *LightShadow) factor(filter int, position, lightPosition *math3.Vector3) float64 {
+
+	m := s.Map
+	if m == nil {
+		return 1
+	}
+
+	if len(m.Faces) == 6 {
+		return s.cubeFactor(filter, position, lightPosition)
+	}
+
+	coord := position.Clone().ApplyProjection(s.Matrix)
+
+	if coord.X < 0 || coord.X > 1 || coord.Y < 0 || coord.Y > 1 || coord.Z > 1 {
+		return 1
+	}
+
+	return s.filter(filter, m.Faces[0], coord.X, coord.Y, coord.Z+s.Bias, nil)
+
+}
+
+// cubeFactor looks up the face of a point light shadow map that
+// position falls in, comparing distances along the axis of the face
+// rather than depths so that the bias is linear
+func (s *LightShadow) cubeFactor(filter int, position, lightPosition *math3.Vector3) float64 {
+
+	m := s.Map
+	d := math3.NewVector3().SubVectors(position, lightPosition)
+	ax, ay, az := math.Abs(d.X), math.Abs(d.Y), math.Abs(d.Z)
+
+	face, distance := 0, ax
+	switch {
+	case ax >= ay && ax >= az:
+		if d.X < 0 {
+			face = 1
+		}
+	case ay >= az:
+		face, distance = 2, ay
+		if d.Y < 0 {
+			face = 3
+		}
+	default:
+		face, distance = 4, az
+		if d.Z < 0 {
+			face = 5
+		}
+	}
+
+	if distance == 0 || m.far <= 0 {
+		return 1
+	}
+
+	coord := position.Clone().ApplyProjection(m.ViewProjections[face])
+
+	return s.filter(filter, m.Faces[face], coord.X*0.5+0.5, coord.Y*0.5+0.5, distance/m.far+s.Bias, m.linearize)
+
+}
+
+// linearize converts a depth rendered by a cube face into
+// a distance along its axis relative to the far plane
+func (m *ShadowMap) linearize(depth float64) float64 {
+
+	z := depth*2 - 1
+
+	return 2 * m.near / ((m.far + m.near) - z*(m.far-m.near))
+
+}
+
+// filter compares depth against the given face of the shadow map at
+// the texture coordinates u, v, converting the stored depths with
+// convert if it is not nil
+func (s *LightShadow) filter(filter int, face []float64, u, v, depth float64, convert func(float64) float64) float64 {
+
+	m := s.Map
+
+	// compare returns 1 if the texel at x, y is not closer than depth
+	compare := func(x, y int) float64 {
+
+		x = int(math3.Clamp(float64(x), 0, float64(m.Width-1)))
+		y = int(math3.Clamp(float64(y), 0, float64(m.Height-1)))
+
+		stored := face[y*m.Width+x]
+		if convert != nil {
+			stored = convert(stored)
+		}
+
+		if depth <= stored {
+			return 1
+		}
+
+		return 0
+
+	}
+
+	// texels are stored from the top while v goes up
+	nearest := func(u, v float64) float64 {
+
+		return compare(int(math.Floor(u*float64(m.Width))), int(math.Floor((1-v)*float64(m.Height))))
+
+	}
+
+	bilinear := func(u, v float64) float64 {
+
+		x := u*float64(m.Width) - 0.5
+		y := (1-v)*float64(m.Height) - 0.5
+		fx, fy := math.Floor(x), math.Floor(y)
+		tx, ty := x-fx, y-fy
+		ix, iy := int(fx), int(fy)
+
+		top := compare(ix, iy)*(1-tx) + compare(ix+1, iy)*tx
+		bottom := compare(ix, iy+1)*(1-tx) + compare(ix+1, iy+1)*tx
+
+		return top*(1-ty) + bottom*ty
+
+	}
+
+	sample := nearest
+
+	switch filter {
+
+	case three.PCFSoftShadowMap:
+
+		sample = bilinear
+		fallthrough
+
+	case three.PCFShadowMap:
+
+		dx := s.Radius / float64(m.Width)
+		dy := s.Radius / float64(m.Height)
+
+		sum := 0.0
+		for _, oy := range []float64{-dy, 0, dy} {
+			for _, ox := range []float64{-dx, 0, dx} {
+				sum += sample(u+ox, v+oy)
+			}
+		}
+
+		return sum / 9
+
+	}
+
+	return sample(u, v)
+
+}
+
+// ShadowOf returns the shadow of the given light,
+// or nil if it is not a type of light that casts shadows
+func ShadowOf(source Source) *LightShadow {
+
+	switch l := source.(type) {
+	case *DirectionalLight:
+		return l.Shadow
+	case *SpotLight:
+		return l.Shadow
+	case *PointLight:
+		return l.Shadow
+	}
+
+	return nil
+
+}
@@ -0,0 +1,103 @@
+package lights_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/lights"
+	"github.com/rydrman/three.go/materials"
+	"github.com/rydrman/three.go/math3"
+)
+
+func TestLightShadow_Update(t *testing.T) {
+	spot := lights.NewSpotLight(nil, 1, 20, math.Pi/6, 0, 1)
+	spot.Shadow.MapSize.Set(32, 16)
+	spot.UpdateMatrixWorld(false)
+	spot.Shadow.Update(spot)
+
+	if m := spot.Shadow.Map; m.Width != 32 || m.Height != 16 || len(m.Faces) != 1 || len(m.Faces[0]) != 32*16 {
+		t.Errorf("unexpected spot shadow map %dx%d with %d faces", m.Width, m.Height, len(m.Faces))
+	}
+
+	// the target of the light is in the middle of the map
+	coord := math3.NewVector3().ApplyProjection(spot.Shadow.Matrix)
+	if math.Abs(coord.X-0.5) > 1e-3 || math.Abs(coord.Y-0.5) > 1e-3 {
+		t.Errorf("expected the target at the center of the shadow map, got %v", coord)
+	}
+
+	point := lights.NewPointLight(nil, 1, 0, 1)
+	point.Shadow.MapSize.Set(8, 8)
+	point.UpdateMatrixWorld(false)
+	point.Shadow.Update(point)
+
+	if m := point.Shadow.Map; len(m.Faces) != 6 || len(m.ViewProjections) != 6 {
+		t.Errorf("expected a cube shadow map for point lights, got %d faces", len(m.Faces))
+	}
+
+	if lights.ShadowOf(lights.NewAmbientLight(nil, 1)) != nil {
+		t.Error("ambient lights should not have shadows")
+	}
+}
+
+func TestLightShadow_Filter(t *testing.T) {
+	light := lights.NewDirectionalLight(nil, 1)
+	light.Position.Set(0, 0, 5)
+	light.UpdateMatrixWorld(false)
+
+	// one texel per unit, with the left half of the map occluded
+	shadow := light.Shadow
+	shadow.MapSize.Set(10, 10)
+	shadow.Update(light)
+	for i := range shadow.Map.Faces[0] {
+		if i%10 >= 5 {
+			shadow.Map.Faces[0][i] = 1
+		}
+	}
+
+	state := lights.NewState()
+	state.ShadowsEnabled = true
+	state.Directional = append(state.Directional, &lights.DirectionalState{
+		Color:     math3.NewColor().SetRGB(1, 1, 1),
+		Direction: math3.NewVector3().Set(0, 0, 1),
+		Shadow:    shadow,
+	})
+
+	material := materials.NewMeshLambertMaterial()
+	white := math3.NewColor().SetRGB(1, 1, 1)
+	normal := math3.NewVector3().Set(0, 0, 1)
+
+	shade := func(x float64, receive bool) float64 {
+		return state.Shade(material, white, math3.NewVector3().Set(x, 0, 0), normal, normal, receive, nil).R
+	}
+
+	tests := []struct {
+		filter   int
+		x        float64
+		expected float64
+	}{
+		{three.BasicShadowMap, -0.5, 0},
+		{three.BasicShadowMap, 0.5, 1},
+		{three.BasicShadowMap, 20, 1},
+		{three.PCFShadowMap, 0, 2.0 / 3.0},
+		{three.PCFShadowMap, -2.5, 0},
+		{three.PCFSoftShadowMap, 0, 0.5},
+		{three.PCFSoftShadowMap, 2.5, 1},
+	}
+
+	for _, test := range tests {
+		state.ShadowType = test.filter
+		if actual := shade(test.x, true); math.Abs(actual-test.expected) > 1e-9 {
+			t.Errorf("filter %d at %.1f: expected %f, got %f", test.filter, test.x, test.expected, actual)
+		}
+	}
+
+	if shade(-0.5, false) != 1 {
+		t.Error("expected no shadow when not receiving shadows")
+	}
+
+	state.ShadowsEnabled = false
+	if shade(-0.5, true) != 1 {
+		t.Error("expected no shadow when shadows are disabled")
+	}
+}
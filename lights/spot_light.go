@@ -0,0 +1,84 @@
+package lights
+
+import (
+	"math"
+
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/objects"
+)
+
+// SpotLight emits a cone of light from its position
+// towards the position of its target
+type SpotLight struct {
+	*Light
+
+	// Target is the node that this light points at, it must be part of
+	// the scene if it has a parent, otherwise it is updated when needed
+	Target objects.Node
+
+	// Shadow is used when CastShadow is set
+	Shadow *LightShadow
+
+	// Distance is where the intensity of the light reaches zero,
+	// the light is not limited when it is 0
+	Distance float64
+	// Angle is the maximum angle of the cone from its axis in radians,
+	// it should be no more than Pi / 2
+	Angle float64
+	// Penumbra is the fraction of the cone, between 0 and 1,
+	// over which the light fades out towards its edge
+	Penumbra float64
+	// Decay is the rate at which the light dims over Distance
+	Decay float64
+}
+
+// NewSpotLight creates a spot light of the given color and intensity that
+// shines straight down onto the origin, a distance of 0 gives the light
+// unlimited range
+func NewSpotLight(color *math3.Color, intensity, distance, angle, penumbra, decay float64) *SpotLight {
+
+	l := &SpotLight{
+		Light:  NewLight(color, intensity),
+		Target: newTarget(),
+		Shadow: NewSpotLightShadow(),
+
+		Distance: distance,
+		Angle:    angle,
+		Penumbra: penumbra,
+		Decay:    decay,
+	}
+
+	l.Position.Copy(objects.DefaultUp)
+	l.UpdateMatrix()
+
+	return l
+
+}
+
+// GetPower returns the luminous power of this light in lumens
+func (l *SpotLight) GetPower() float64 {
+
+	return l.Intensity * math.Pi
+
+}
+
+// SetPower sets the intensity of this light from a luminous power in lumens
+func (l *SpotLight) SetPower(power float64) {
+
+	l.Intensity = power / math.Pi
+
+}
+
+// GetDirection returns the direction in world space from
+// the target towards this light
+func (l *SpotLight) GetDirection(target *math3.Vector3) *math3.Vector3 {
+
+	if nil == target {
+		target = math3.NewVector3()
+	}
+
+	targetPosition(l.Target, target)
+
+	return target.SubVectors(math3.NewVector3().SetFromMatrixPosition(l.MatrixWorld), target).Normalize()
+
+}
@@ -0,0 +1,198 @@
+package lights
+
+import (
+	"math"
+
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/objects"
+)
+
+// State holds the visible lights of a scene gathered for a single frame,
+// with their colors premultiplied by their intensities and their
+// positions and directions in world space
+type State struct {
+	// Ambient is the sum of all of the ambient lights in the scene
+	Ambient *math3.Color
+
+	// ShadowsEnabled applies the shadows of lights that cast them, it
+	// is set by renderers once the shadow maps have been rendered.
+	// ShadowType is one of the three.*ShadowMap constants.
+	ShadowsEnabled bool
+	ShadowType     int
+
+	Directional []*DirectionalState
+	Point       []*PointState
+	Spot        []*SpotState
+	Hemisphere  []*HemisphereState
+	RectArea    []*RectAreaState
+
+	scratch *shadingScratch
+}
+
+// DirectionalState describes a directional light, Direction points from
+// the surface towards the light
+type DirectionalState struct {
+	Color     *math3.Color
+	Direction *math3.Vector3
+	// Shadow is nil if the light does not cast shadows
+	Shadow *LightShadow
+}
+
+// PointState describes a point light
+type PointState struct {
+	Color    *math3.Color
+	Position *math3.Vector3
+	Distance float64
+	Decay    float64
+	// Shadow is nil if the light does not cast shadows
+	Shadow *LightShadow
+}
+
+// SpotState describes a spot light, Direction points from its
+// target towards the light
+type SpotState struct {
+	Color     *math3.Color
+	Position  *math3.Vector3
+	Direction *math3.Vector3
+	Distance  float64
+	Decay     float64
+	// ConeCos is the cosine of the angle of the cone and
+	// PenumbraCos the cosine of the angle where it starts to fade
+	ConeCos     float64
+	PenumbraCos float64
+	// Shadow is nil if the light does not cast shadows
+	Shadow *LightShadow
+}
+
+// HemisphereState describes a hemisphere light, Direction
+// points towards the sky
+type HemisphereState struct {
+	SkyColor    *math3.Color
+	GroundColor *math3.Color
+	Direction   *math3.Vector3
+}
+
+// RectAreaState describes a rectangular area light, HalfWidth and
+// HalfHeight span half of the rectangle from its center
+type RectAreaState struct {
+	Color      *math3.Color
+	Position   *math3.Vector3
+	HalfWidth  *math3.Vector3
+	HalfHeight *math3.Vector3
+}
+
+// NewState creates an empty light state
+func NewState() *State {
+
+	return &State{
+		Ambient: math3.NewColor().SetScalar(0),
+	}
+
+}
+
+// Setup replaces the contents of this state with the visible lights found
+// in the given scene, lights below hidden nodes are hidden as well. The
+// world matrices of the scene should be up to date.
+func (s *State) Setup(scene objects.Node) *State {
+
+	s.Ambient.Set(0, 0, 0)
+	s.Directional = s.Directional[:0]
+	s.Point = s.Point[:0]
+	s.Spot = s.Spot[:0]
+	s.Hemisphere = s.Hemisphere[:0]
+	s.RectArea = s.RectArea[:0]
+
+	objects.TraverseVisible(scene, func(node objects.Node) {
+
+		if source, ok := node.(Source); ok {
+			s.add(source)
+		}
+
+	})
+
+	return s
+
+}
+
+// Empty returns true if this state contains no lights
+func (s *State) Empty() bool {
+
+	return s.Ambient.R == 0 && s.Ambient.G == 0 && s.Ambient.B == 0 &&
+		len(s.Directional) == 0 &&
+		len(s.Point) == 0 &&
+		len(s.Spot) == 0 &&
+		len(s.Hemisphere) == 0 &&
+		len(s.RectArea) == 0
+
+}
+
+func (s *State) add(source Source) {
+
+	light := source.GetLight()
+	shadow := func(s *LightShadow) *LightShadow {
+		if light.CastShadow {
+			return s
+		}
+		return nil
+	}
+
+	color := light.Color.Clone().MultiplyScalar(light.Intensity)
+	position := math3.NewVector3().SetFromMatrixPosition(light.MatrixWorld)
+
+	switch l := source.(type) {
+
+	case *AmbientLight:
+
+		s.Ambient.Add(color)
+
+	case *DirectionalLight:
+
+		s.Directional = append(s.Directional, &DirectionalState{
+			Color:     color,
+			Direction: l.GetDirection(nil),
+			Shadow:    shadow(l.Shadow),
+		})
+
+	case *PointLight:
+
+		s.Point = append(s.Point, &PointState{
+			Color:    color,
+			Position: position,
+			Distance: l.Distance,
+			Decay:    l.Decay,
+			Shadow:   shadow(l.Shadow),
+		})
+
+	case *SpotLight:
+
+		s.Spot = append(s.Spot, &SpotState{
+			Color:       color,
+			Position:    position,
+			Direction:   l.GetDirection(nil),
+			Distance:    l.Distance,
+			Decay:       l.Decay,
+			ConeCos:     math.Cos(l.Angle),
+			PenumbraCos: math.Cos(l.Angle * (1 - l.Penumbra)),
+			Shadow:      shadow(l.Shadow),
+		})
+
+	case *HemisphereLight:
+
+		s.Hemisphere = append(s.Hemisphere, &HemisphereState{
+			SkyColor:    color,
+			GroundColor: l.GroundColor.Clone().MultiplyScalar(l.Intensity),
+			Direction:   position.Normalize(),
+		})
+
+	case *RectAreaLight:
+
+		s.RectArea = append(s.RectArea, &RectAreaState{
+			Color:      color,
+			Position:   position,
+			HalfWidth:  math3.NewVector3().Set(1, 0, 0).TransformDirection(l.MatrixWorld).MultiplyScalar(l.Width * 0.5),
+			HalfHeight: math3.NewVector3().Set(0, 1, 0).TransformDirection(l.MatrixWorld).MultiplyScalar(l.Height * 0.5),
+		})
+
+	}
+
+}
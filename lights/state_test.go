@@ -0,0 +1,205 @@
+package lights_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rydrman/three.go/lights"
+	"github.com/rydrman/three.go/materials"
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/objects"
+	"github.com/rydrman/three.go/scenes"
+)
+
+func colorNear(a *math3.Color, r, g, b float64) bool {
+	return math.Abs(a.R-r) < 1e-6 && math.Abs(a.G-g) < 1e-6 && math.Abs(a.B-b) < 1e-6
+}
+
+func TestState_Setup(t *testing.T) {
+	scene := scenes.NewScene()
+
+	scene.Add(lights.NewAmbientLight(math3.NewColor().SetRGB(1, 0, 0), 0.5))
+	scene.Add(lights.NewAmbientLight(math3.NewColor().SetRGB(0, 1, 0), 0.25))
+	scene.Add(lights.NewDirectionalLight(nil, 1))
+	scene.Add(lights.NewPointLight(nil, 2, 10, 2))
+	scene.Add(lights.NewSpotLight(nil, 1, 0, math.Pi/3, 0.5, 1))
+	scene.Add(lights.NewHemisphereLight(nil, nil, 1))
+	scene.Add(lights.NewRectAreaLight(nil, 1, 4, 2))
+
+	hidden := lights.NewPointLight(nil, 1, 0, 1)
+	hidden.Visible = false
+	scene.Add(hidden)
+
+	group := objects.NewObject()
+	group.Visible = false
+	group.Add(lights.NewDirectionalLight(nil, 1))
+	scene.Add(group)
+
+	scene.UpdateMatrixWorld(false)
+	state := lights.NewState().Setup(scene)
+
+	if !colorNear(state.Ambient, 0.5, 0.25, 0) {
+		t.Errorf("expected ambient lights to be summed, got %v", state.Ambient)
+	}
+	if len(state.Directional) != 1 || len(state.Point) != 1 || len(state.Spot) != 1 ||
+		len(state.Hemisphere) != 1 || len(state.RectArea) != 1 {
+		t.Fatalf("expected one of each visible light, got %+v", state)
+	}
+
+	if !state.Directional[0].Direction.Equals(math3.NewVector3().Set(0, 1, 0)) {
+		t.Errorf("expected directional light to point up, got %v", state.Directional[0].Direction)
+	}
+	if !colorNear(state.Point[0].Color, 2, 2, 2) {
+		t.Errorf("expected color to include intensity, got %v", state.Point[0].Color)
+	}
+	if spot := state.Spot[0]; math.Abs(spot.ConeCos-0.5) > 1e-9 || math.Abs(spot.PenumbraCos-math.Cos(math.Pi/6)) > 1e-9 {
+		t.Errorf("unexpected spot cone %f, %f", spot.ConeCos, spot.PenumbraCos)
+	}
+	if area := state.RectArea[0]; !area.HalfWidth.Equals(math3.NewVector3().Set(2, 0, 0)) ||
+		!area.HalfHeight.Equals(math3.NewVector3().Set(0, 1, 0)) {
+		t.Errorf("unexpected area light size %v, %v", area.HalfWidth, area.HalfHeight)
+	}
+
+	if state.Setup(scenes.NewScene()); !state.Empty() {
+		t.Error("expected state to be reset")
+	}
+}
+
+func TestState_ShadeLambert(t *testing.T) {
+	state := lights.NewState()
+	material := materials.NewMeshLambertMaterial()
+	material.Emissive.SetRGB(0, 0, 0.25)
+
+	diffuse := math3.NewColor().SetRGB(1, 0.5, 0)
+	position := math3.NewVector3()
+	normal := math3.NewVector3().Set(0, 1, 0)
+	view := math3.NewVector3().Set(0, 0, 1)
+
+	if c := state.Shade(material, diffuse, position, normal, view, false, nil); !colorNear(c, 0, 0, 0.25) {
+		t.Errorf("expected only emissive without lights, got %v", c)
+	}
+
+	light := lights.NewDirectionalLight(nil, 1)
+	light.Position.Set(1, 1, 0)
+	light.UpdateMatrixWorld(false)
+	state.Directional = append(state.Directional, &lights.DirectionalState{
+		Color:     light.Color,
+		Direction: light.GetDirection(nil),
+	})
+
+	c := state.Shade(material, diffuse, position, normal, view, false, nil)
+	if !colorNear(c, math.Sqrt(0.5), math.Sqrt(0.5)*0.5, 0.25) {
+		t.Errorf("expected lambert falloff, got %v", c)
+	}
+
+	basic := materials.NewMeshBasicMaterial()
+	if c := state.Shade(basic, diffuse, position, normal, view, false, nil); !colorNear(c, 1, 0.5, 0) {
+		t.Errorf("expected unlit material to keep its color, got %v", c)
+	}
+}
+
+func TestState_ShadePunctual(t *testing.T) {
+	state := lights.NewState()
+	material := materials.NewMeshLambertMaterial()
+
+	white := math3.NewColor().SetRGB(1, 1, 1)
+	normal := math3.NewVector3().Set(0, 1, 0)
+	view := math3.NewVector3().Set(0, 1, 0)
+
+	state.Point = append(state.Point, &lights.PointState{
+		Color:    white,
+		Position: math3.NewVector3().Set(0, 4, 0),
+		Distance: 8,
+		Decay:    2,
+	})
+
+	c := state.Shade(material, white, math3.NewVector3(), normal, view, false, nil)
+	if !colorNear(c, 0.25, 0.25, 0.25) {
+		t.Errorf("expected point light attenuation, got %v", c)
+	}
+
+	state.Point = nil
+	state.Spot = append(state.Spot, &lights.SpotState{
+		Color:       white,
+		Position:    math3.NewVector3().Set(0, 1, 0),
+		Direction:   math3.NewVector3().Set(0, 1, 0),
+		ConeCos:     math.Cos(math.Pi / 4),
+		PenumbraCos: math.Cos(math.Pi / 8),
+	})
+
+	if c := state.Shade(material, white, math3.NewVector3(), normal, view, false, nil); !colorNear(c, 1, 1, 1) {
+		t.Errorf("expected full light inside the cone, got %v", c)
+	}
+	if c := state.Shade(material, white, math3.NewVector3().Set(2, 0, 0), normal, view, false, nil); !colorNear(c, 0, 0, 0) {
+		t.Errorf("expected no light outside the cone, got %v", c)
+	}
+	if c := state.Shade(material, white, math3.NewVector3().Set(math.Tan(math.Pi*3/16), 0, 0), normal, view, false, nil); c.R <= 0 || c.R >= 1 {
+		t.Errorf("expected partial light in the penumbra, got %v", c)
+	}
+}
+
+func TestState_ShadeHemisphere(t *testing.T) {
+	state := lights.NewState()
+	state.Hemisphere = append(state.Hemisphere, &lights.HemisphereState{
+		SkyColor:    math3.NewColor().SetRGB(0, 0, 1),
+		GroundColor: math3.NewColor().SetRGB(0, 1, 0),
+		Direction:   math3.NewVector3().Set(0, 1, 0),
+	})
+
+	material := materials.NewMeshLambertMaterial()
+	white := math3.NewColor().SetRGB(1, 1, 1)
+	view := math3.NewVector3().Set(0, 0, 1)
+
+	if c := state.Shade(material, white, math3.NewVector3(), math3.NewVector3().Set(0, 1, 0), view, false, nil); !colorNear(c, 0, 0, 1) {
+		t.Errorf("expected sky color facing up, got %v", c)
+	}
+	if c := state.Shade(material, white, math3.NewVector3(), math3.NewVector3().Set(1, 0, 0), view, false, nil); !colorNear(c, 0, 0.5, 0.5) {
+		t.Errorf("expected a blend facing sideways, got %v", c)
+	}
+}
+
+func TestState_ShadeSpecular(t *testing.T) {
+	state := lights.NewState()
+	state.Directional = append(state.Directional, &lights.DirectionalState{
+		Color:     math3.NewColor().SetRGB(1, 1, 1),
+		Direction: math3.NewVector3().Set(0, 1, 0),
+	})
+
+	black := math3.NewColor().SetScalar(0)
+	normal := math3.NewVector3().Set(0, 1, 0)
+	mirror := math3.NewVector3().Set(0, 1, 0)
+	grazing := math3.NewVector3().Set(1, 0.2, 0)
+
+	phong := materials.NewMeshPhongMaterial()
+	highlight := state.Shade(phong, black, math3.NewVector3(), normal, mirror, false, nil)
+	off := state.Shade(phong, black, math3.NewVector3(), normal, grazing, false, nil)
+	if highlight.R <= off.R || highlight.R <= 0 {
+		t.Errorf("expected a phong highlight along the reflection, got %v and %v", highlight, off)
+	}
+
+	// F * G * D * pi for a light along the normal, as in three.js
+	phong.Specular.SetScalar(0.1)
+	phong.Shininess = 30
+	fresnel := 0.1 + 0.9*math.Exp2(-5.55473-6.98316)
+	expected := fresnel * 0.25 * 16
+	if c := state.Shade(phong, black, math3.NewVector3(), normal, mirror, false, nil); !colorNear(c, expected, expected, expected) {
+		t.Errorf("expected a highlight of %v, got %v", expected, c)
+	}
+
+	standard := materials.NewMeshStandardMaterial()
+	standard.Metalness = 1
+	standard.Roughness = 0.2
+	highlight = state.Shade(standard, math3.NewColor().SetRGB(1, 1, 1), math3.NewVector3(), normal, mirror, false, nil)
+	off = state.Shade(standard, math3.NewColor().SetRGB(1, 1, 1), math3.NewVector3(), normal, grazing, false, nil)
+	if highlight.R <= off.R || highlight.R <= 1 {
+		t.Errorf("expected a bright metallic highlight, got %v and %v", highlight, off)
+	}
+
+	// shading reuses its temporaries rather than allocating per fragment
+	position, target := math3.NewVector3(), math3.NewColor()
+	if allocs := testing.AllocsPerRun(10, func() {
+		state.Shade(standard, black, position, normal, grazing, false, target)
+	}); allocs != 0 {
+		t.Errorf("expected shading to not allocate, got %v allocations", allocs)
+	}
+}
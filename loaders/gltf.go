@@ -0,0 +1,352 @@
+package loaders
+
+import (
+	"fmt"
+
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/objects"
+	"github.com/rydrman/three.go/scenes"
+)
+
+// GLTF holds everything that was loaded from a glTF asset
+type GLTF struct {
+	// Scene is the default scene of the asset, or the
+	// first scene if the asset does not name a default
+	Scene  *scenes.Scene
+	Scenes []*scenes.Scene
+
+	// Nodes holds the object created for each node of the asset by index
+	Nodes   []objects.Node
+	Cameras []math3.Projector
+
+	Animations []*GLTFAnimation
+}
+
+// GLTFAnimation is a set of channels that animate the nodes of an asset
+type GLTFAnimation struct {
+	Name string
+	// Duration is the time of the last keyframe in any channel
+	Duration float64
+
+	Channels []*GLTFChannel
+}
+
+// GLTFChannel holds the keyframes that animate a single
+// property of one of the nodes of an asset
+type GLTFChannel struct {
+	Target objects.Node
+	// Path is the animated property of the target, one of
+	// "translation", "rotation", "scale" or "weights"
+	Path string
+	// Interpolation is one of "LINEAR", "STEP" or "CUBICSPLINE"
+	Interpolation string
+
+	// Times holds the time of each keyframe in seconds, and Values
+	// holds ItemSize values for each keyframe. Cubic spline values
+	// are stored as an in tangent, value and out tangent per keyframe.
+	Times    []float64
+	Values   []float64
+	ItemSize int
+}
+
+// GLTFAccessorError reports an accessor of a glTF asset whose data is
+// missing or invalid, it is wrapped in the errors returned by GLTFLoader
+type GLTFAccessorError struct {
+	Accessor int
+	Reason   string
+}
+
+func (e *GLTFAccessorError) Error() string {
+
+	return fmt.Sprintf("accessor %d: %s", e.Accessor, e.Reason)
+
+}
+
+// glTF constants that are used by both the loader and the exporter
+const (
+	gltfByte          = 5120
+	gltfUnsignedByte  = 5121
+	gltfShort         = 5122
+	gltfUnsignedShort = 5123
+	gltfUnsignedInt   = 5125
+	gltfFloat         = 5126
+
+	gltfArrayBuffer        = 34962
+	gltfElementArrayBuffer = 34963
+
+	gltfNearest              = 9728
+	gltfLinear               = 9729
+	gltfNearestMipmapNearest = 9984
+	gltfLinearMipmapNearest  = 9985
+	gltfNearestMipmapLinear  = 9986
+	gltfLinearMipmapLinear   = 9987
+
+	gltfClampToEdge    = 33071
+	gltfMirroredRepeat = 33648
+	gltfRepeat         = 10497
+
+	gltfPoints        = 0
+	gltfLines         = 1
+	gltfLineLoop      = 2
+	gltfLineStrip     = 3
+	gltfTriangles     = 4
+	gltfTriangleStrip = 5
+	gltfTriangleFan   = 6
+
+	glbMagic     = 0x46546c67
+	glbJSONChunk = 0x4e4f534a
+	glbBINChunk  = 0x004e4942
+
+	// gltfMaxAccessorValues limits the size of accessors without a buffer
+	// view, which are only sized by their count and would otherwise allow
+	// a tiny document to allocate any amount of memory
+	gltfMaxAccessorValues = 1 << 24
+)
+
+// gltfComponentSizes is the size in bytes of each component type
+var gltfComponentSizes = map[int]int{
+	gltfByte:          1,
+	gltfUnsignedByte:  1,
+	gltfShort:         2,
+	gltfUnsignedShort: 2,
+	gltfUnsignedInt:   4,
+	gltfFloat:         4,
+}
+
+// gltfItemSizes is the number of components of each accessor type
+var gltfItemSizes = map[string]int{
+	"SCALAR": 1,
+	"VEC2":   2,
+	"VEC3":   3,
+	"VEC4":   4,
+	"MAT2":   4,
+	"MAT3":   9,
+	"MAT4":   16,
+}
+
+// gltfAttributeNames maps glTF attribute semantics onto the
+// attribute names used by buffer geometries
+var gltfAttributeNames = map[string]string{
+	"POSITION":   "position",
+	"NORMAL":     "normal",
+	"TANGENT":    "tangent",
+	"TEXCOORD_0": "uv",
+	"TEXCOORD_1": "uv2",
+	"COLOR_0":    "color",
+	"JOINTS_0":   "skinIndex",
+	"WEIGHTS_0":  "skinWeight",
+}
+
+// The types below mirror the JSON schema of glTF 2.0, only the
+// properties that are used by this package are included
+
+type gltfDocument struct {
+	Asset gltfAsset `json:"asset"`
+	Scene *int      `json:"scene,omitempty"`
+
+	Scenes      []gltfScene      `json:"scenes,omitempty"`
+	Nodes       []gltfNode       `json:"nodes,omitempty"`
+	Meshes      []gltfMesh       `json:"meshes,omitempty"`
+	Materials   []gltfMaterial   `json:"materials,omitempty"`
+	Textures    []gltfTexture    `json:"textures,omitempty"`
+	Images      []gltfImage      `json:"images,omitempty"`
+	Samplers    []gltfSampler    `json:"samplers,omitempty"`
+	Cameras     []gltfCamera     `json:"cameras,omitempty"`
+	Skins       []gltfSkin       `json:"skins,omitempty"`
+	Animations  []gltfAnimation  `json:"animations,omitempty"`
+	Accessors   []gltfAccessor   `json:"accessors,omitempty"`
+	BufferViews []gltfBufferView `json:"bufferViews,omitempty"`
+	Buffers     []gltfBuffer     `json:"buffers,omitempty"`
+
+	ExtensionsUsed     []string `json:"extensionsUsed,omitempty"`
+	ExtensionsRequired []string `json:"extensionsRequired,omitempty"`
+}
+
+type gltfAsset struct {
+	Version    string `json:"version"`
+	MinVersion string `json:"minVersion,omitempty"`
+	Generator  string `json:"generator,omitempty"`
+}
+
+type gltfScene struct {
+	Name  string `json:"name,omitempty"`
+	Nodes []int  `json:"nodes,omitempty"`
+}
+
+type gltfNode struct {
+	Name     string `json:"name,omitempty"`
+	Children []int  `json:"children,omitempty"`
+
+	Mesh   *int `json:"mesh,omitempty"`
+	Camera *int `json:"camera,omitempty"`
+	Skin   *int `json:"skin,omitempty"`
+
+	Matrix      []float64 `json:"matrix,omitempty"`
+	Translation []float64 `json:"translation,omitempty"`
+	Rotation    []float64 `json:"rotation,omitempty"`
+	Scale       []float64 `json:"scale,omitempty"`
+
+	Weights []float64 `json:"weights,omitempty"`
+}
+
+type gltfMesh struct {
+	Name       string          `json:"name,omitempty"`
+	Primitives []gltfPrimitive `json:"primitives"`
+	Weights    []float64       `json:"weights,omitempty"`
+	Extras     *gltfMeshExtras `json:"extras,omitempty"`
+}
+
+type gltfMeshExtras struct {
+	TargetNames []string `json:"targetNames,omitempty"`
+}
+
+type gltfPrimitive struct {
+	Attributes map[string]int   `json:"attributes"`
+	Indices    *int             `json:"indices,omitempty"`
+	Material   *int             `json:"material,omitempty"`
+	Mode       *int             `json:"mode,omitempty"`
+	Targets    []map[string]int `json:"targets,omitempty"`
+}
+
+type gltfMaterial struct {
+	Name string `json:"name,omitempty"`
+
+	PBRMetallicRoughness *gltfPBRMetallicRoughness `json:"pbrMetallicRoughness,omitempty"`
+
+	NormalTexture    *gltfTextureInfo `json:"normalTexture,omitempty"`
+	OcclusionTexture *gltfTextureInfo `json:"occlusionTexture,omitempty"`
+	EmissiveTexture  *gltfTextureInfo `json:"emissiveTexture,omitempty"`
+	EmissiveFactor   []float64        `json:"emissiveFactor,omitempty"`
+
+	AlphaMode   string   `json:"alphaMode,omitempty"`
+	AlphaCutoff *float64 `json:"alphaCutoff,omitempty"`
+	DoubleSided bool     `json:"doubleSided,omitempty"`
+}
+
+type gltfPBRMetallicRoughness struct {
+	BaseColorFactor  []float64        `json:"baseColorFactor,omitempty"`
+	BaseColorTexture *gltfTextureInfo `json:"baseColorTexture,omitempty"`
+
+	MetallicFactor           *float64         `json:"metallicFactor,omitempty"`
+	RoughnessFactor          *float64         `json:"roughnessFactor,omitempty"`
+	MetallicRoughnessTexture *gltfTextureInfo `json:"metallicRoughnessTexture,omitempty"`
+}
+
+type gltfTextureInfo struct {
+	Index    int `json:"index"`
+	TexCoord int `json:"texCoord,omitempty"`
+
+	// Scale is only used by normal textures
+	// and Strength by occlusion textures
+	Scale    *float64 `json:"scale,omitempty"`
+	Strength *float64 `json:"strength,omitempty"`
+}
+
+type gltfTexture struct {
+	Name    string `json:"name,omitempty"`
+	Sampler *int   `json:"sampler,omitempty"`
+	Source  *int   `json:"source,omitempty"`
+}
+
+type gltfImage struct {
+	Name       string `json:"name,omitempty"`
+	URI        string `json:"uri,omitempty"`
+	MimeType   string `json:"mimeType,omitempty"`
+	BufferView *int   `json:"bufferView,omitempty"`
+}
+
+type gltfSampler struct {
+	MagFilter int  `json:"magFilter,omitempty"`
+	MinFilter int  `json:"minFilter,omitempty"`
+	WrapS     *int `json:"wrapS,omitempty"`
+	WrapT     *int `json:"wrapT,omitempty"`
+}
+
+type gltfCamera struct {
+	Name         string                  `json:"name,omitempty"`
+	Type         string                  `json:"type"`
+	Perspective  *gltfPerspectiveCamera  `json:"perspective,omitempty"`
+	Orthographic *gltfOrthographicCamera `json:"orthographic,omitempty"`
+}
+
+type gltfPerspectiveCamera struct {
+	AspectRatio *float64 `json:"aspectRatio,omitempty"`
+	YFov        float64  `json:"yfov"`
+	ZFar        *float64 `json:"zfar,omitempty"`
+	ZNear       float64  `json:"znear"`
+}
+
+type gltfOrthographicCamera struct {
+	XMag  float64 `json:"xmag"`
+	YMag  float64 `json:"ymag"`
+	ZFar  float64 `json:"zfar"`
+	ZNear float64 `json:"znear"`
+}
+
+type gltfSkin struct {
+	Name                string `json:"name,omitempty"`
+	InverseBindMatrices *int   `json:"inverseBindMatrices,omitempty"`
+	Skeleton            *int   `json:"skeleton,omitempty"`
+	Joints              []int  `json:"joints"`
+}
+
+type gltfAnimation struct {
+	Name     string                 `json:"name,omitempty"`
+	Channels []gltfAnimationChannel `json:"channels"`
+	Samplers []gltfAnimationSampler `json:"samplers"`
+}
+
+type gltfAnimationChannel struct {
+	Sampler int `json:"sampler"`
+	Target  struct {
+		Node *int   `json:"node,omitempty"`
+		Path string `json:"path"`
+	} `json:"target"`
+}
+
+type gltfAnimationSampler struct {
+	Input         int    `json:"input"`
+	Output        int    `json:"output"`
+	Interpolation string `json:"interpolation,omitempty"`
+}
+
+type gltfAccessor struct {
+	Name          string      `json:"name,omitempty"`
+	BufferView    *int        `json:"bufferView,omitempty"`
+	ByteOffset    int         `json:"byteOffset,omitempty"`
+	ComponentType int         `json:"componentType"`
+	Normalized    bool        `json:"normalized,omitempty"`
+	Count         int         `json:"count"`
+	Type          string      `json:"type"`
+	Min           []float64   `json:"min,omitempty"`
+	Max           []float64   `json:"max,omitempty"`
+	Sparse        *gltfSparse `json:"sparse,omitempty"`
+}
+
+type gltfSparse struct {
+	Count   int `json:"count"`
+	Indices struct {
+		BufferView    int `json:"bufferView"`
+		ByteOffset    int `json:"byteOffset,omitempty"`
+		ComponentType int `json:"componentType"`
+	} `json:"indices"`
+	Values struct {
+		BufferView int `json:"bufferView"`
+		ByteOffset int `json:"byteOffset,omitempty"`
+	} `json:"values"`
+}
+
+type gltfBufferView struct {
+	Name       string `json:"name,omitempty"`
+	Buffer     int    `json:"buffer"`
+	ByteOffset int    `json:"byteOffset,omitempty"`
+	ByteLength int    `json:"byteLength"`
+	ByteStride int    `json:"byteStride,omitempty"`
+	Target     int    `json:"target,omitempty"`
+}
+
+type gltfBuffer struct {
+	Name       string `json:"name,omitempty"`
+	URI        string `json:"uri,omitempty"`
+	ByteLength int    `json:"byteLength"`
+}
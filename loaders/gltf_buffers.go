@@ -0,0 +1,343 @@
+package loaders
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io/fs"
+	"math"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// splitGLB returns the JSON and binary chunks of a GLB file
+func splitGLB(data []byte) (content, bin []byte, err error) {
+
+	if len(data) < 12 {
+		return nil, nil, fmt.Errorf("loaders: glTF: GLB header is truncated")
+	}
+
+	if version := binary.LittleEndian.Uint32(data[4:]); version != 2 {
+		return nil, nil, fmt.Errorf("loaders: glTF: unsupported GLB version %d", version)
+	}
+
+	length := int(binary.LittleEndian.Uint32(data[8:]))
+	if length > len(data) {
+		return nil, nil, fmt.Errorf("loaders: glTF: GLB has %d bytes, expected %d", len(data), length)
+	}
+
+	for offset := 12; offset+8 <= length; {
+
+		chunkLength := int(binary.LittleEndian.Uint32(data[offset:]))
+		chunkType := binary.LittleEndian.Uint32(data[offset+4:])
+		offset += 8
+
+		if chunkLength < 0 || offset+chunkLength > length {
+			return nil, nil, fmt.Errorf("loaders: glTF: GLB chunk exceeds the file length")
+		}
+
+		chunk := data[offset : offset+chunkLength]
+		offset += chunkLength
+
+		switch {
+		case chunkType == glbJSONChunk && content == nil:
+			content = chunk
+		case chunkType == glbBINChunk && bin == nil:
+			bin = chunk
+		}
+
+	}
+
+	if content == nil {
+		return nil, nil, fmt.Errorf("loaders: glTF: GLB has no JSON chunk")
+	}
+
+	return content, bin, nil
+
+}
+
+// readAccessor decodes the values of an accessor, including any sparse
+// substitutions, reporting a GLTFAccessorError if the data is invalid
+func (p *gltfParser) readAccessor(index int) (*gltfAccessorData, error) {
+
+	if data, ok := p.accessors[index]; ok {
+		return data, nil
+	}
+
+	fail := func(format string, args ...interface{}) error {
+		return &GLTFAccessorError{index, fmt.Sprintf(format, args...)}
+	}
+
+	if index < 0 || index >= len(p.doc.Accessors) {
+		return nil, fail("does not exist")
+	}
+
+	a := p.doc.Accessors[index]
+
+	itemSize, ok := gltfItemSizes[a.Type]
+	if !ok {
+		return nil, fail("unknown type %q", a.Type)
+	}
+
+	componentSize, ok := gltfComponentSizes[a.ComponentType]
+	if !ok {
+		return nil, fail("unknown component type %d", a.ComponentType)
+	}
+
+	if a.Count < 1 {
+		return nil, fail("count must be at least 1, got %d", a.Count)
+	}
+
+	var values []float64
+
+	if a.BufferView != nil {
+
+		view, err := p.readBufferView(*a.BufferView)
+		if err != nil {
+			return nil, fail("%v", err)
+		}
+
+		elementSize := componentSize * itemSize
+		stride := p.doc.BufferViews[*a.BufferView].ByteStride
+		if stride == 0 {
+			stride = elementSize
+		}
+
+		if stride < elementSize {
+			return nil, fail("byte stride %d is smaller than its %d byte elements", stride, elementSize)
+		}
+
+		// check how many elements fit before computing the size
+		// needed, which could overflow for a huge count or offset
+		fits := 0
+		if a.ByteOffset >= 0 && a.ByteOffset <= len(view)-elementSize {
+			fits = (len(view)-a.ByteOffset-elementSize)/stride + 1
+		}
+		if a.Count > fits {
+			return nil, fail("has %d elements but buffer view %d only fits %d", a.Count, *a.BufferView, fits)
+		}
+
+		values = make([]float64, a.Count*itemSize)
+
+		for i := 0; i < a.Count; i++ {
+			for j := 0; j < itemSize; j++ {
+
+				offset := a.ByteOffset + i*stride + j*componentSize
+				values[i*itemSize+j] = readComponent(view[offset:], a.ComponentType, a.Normalized)
+
+			}
+		}
+
+	} else {
+
+		if a.Count > gltfMaxAccessorValues/itemSize {
+			return nil, fail("has %d elements without a buffer view, at most %d are allowed", a.Count, gltfMaxAccessorValues/itemSize)
+		}
+
+		values = make([]float64, a.Count*itemSize)
+
+	}
+
+	if s := a.Sparse; s != nil {
+
+		if s.Count < 1 || s.Count > a.Count {
+			return nil, fail("sparse count %d is out of range of %d elements", s.Count, a.Count)
+		}
+
+		indexSize := gltfComponentSizes[s.Indices.ComponentType]
+		if s.Indices.ComponentType != gltfUnsignedByte && s.Indices.ComponentType != gltfUnsignedShort && s.Indices.ComponentType != gltfUnsignedInt {
+			return nil, fail("sparse indices have an invalid component type %d", s.Indices.ComponentType)
+		}
+
+		indices, err := p.readBufferView(s.Indices.BufferView)
+		if err != nil {
+			return nil, fail("%v", err)
+		}
+		substitutes, err := p.readBufferView(s.Values.BufferView)
+		if err != nil {
+			return nil, fail("%v", err)
+		}
+
+		// compare against the remaining length, as adding
+		// to a huge offset could overflow
+		if s.Indices.ByteOffset < 0 || s.Indices.ByteOffset > len(indices) || s.Count > (len(indices)-s.Indices.ByteOffset)/indexSize {
+			return nil, fail("sparse indices exceed their buffer view")
+		}
+		if s.Values.ByteOffset < 0 || s.Values.ByteOffset > len(substitutes) || s.Count > (len(substitutes)-s.Values.ByteOffset)/(itemSize*componentSize) {
+			return nil, fail("sparse values exceed their buffer view")
+		}
+
+		for i := 0; i < s.Count; i++ {
+
+			element := int(readComponent(indices[s.Indices.ByteOffset+i*indexSize:], s.Indices.ComponentType, false))
+			if element >= a.Count {
+				return nil, fail("sparse index %d is out of range of %d elements", element, a.Count)
+			}
+
+			for j := 0; j < itemSize; j++ {
+
+				offset := s.Values.ByteOffset + (i*itemSize+j)*componentSize
+				values[element*itemSize+j] = readComponent(substitutes[offset:], a.ComponentType, a.Normalized)
+
+			}
+
+		}
+
+	}
+
+	data := &gltfAccessorData{
+		values:        values,
+		itemSize:      itemSize,
+		componentType: a.ComponentType,
+	}
+
+	p.accessors[index] = data
+
+	return data, nil
+
+}
+
+// readComponent decodes a single little endian component, mapping
+// normalized integers into the [0, 1] or [-1, 1] range
+func readComponent(b []byte, componentType int, normalized bool) float64 {
+
+	switch componentType {
+
+	case gltfByte:
+		v := float64(int8(b[0]))
+		if normalized {
+			return math.Max(v/127, -1)
+		}
+		return v
+
+	case gltfUnsignedByte:
+		v := float64(b[0])
+		if normalized {
+			return v / 255
+		}
+		return v
+
+	case gltfShort:
+		v := float64(int16(binary.LittleEndian.Uint16(b)))
+		if normalized {
+			return math.Max(v/32767, -1)
+		}
+		return v
+
+	case gltfUnsignedShort:
+		v := float64(binary.LittleEndian.Uint16(b))
+		if normalized {
+			return v / 65535
+		}
+		return v
+
+	case gltfUnsignedInt:
+		return float64(binary.LittleEndian.Uint32(b))
+
+	}
+
+	return float64(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+
+}
+
+func (p *gltfParser) readBufferView(index int) ([]byte, error) {
+
+	if index < 0 || index >= len(p.doc.BufferViews) {
+		return nil, fmt.Errorf("buffer view %d does not exist", index)
+	}
+
+	view := p.doc.BufferViews[index]
+
+	buffer, err := p.readBuffer(view.Buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	if view.ByteOffset < 0 || view.ByteLength < 0 || view.ByteOffset > len(buffer) || view.ByteLength > len(buffer)-view.ByteOffset {
+		return nil, fmt.Errorf("buffer view %d exceeds the %d bytes of buffer %d", index, len(buffer), view.Buffer)
+	}
+
+	return buffer[view.ByteOffset : view.ByteOffset+view.ByteLength], nil
+
+}
+
+func (p *gltfParser) readBuffer(index int) ([]byte, error) {
+
+	if data, ok := p.buffers[index]; ok {
+		return data, nil
+	}
+
+	if index < 0 || index >= len(p.doc.Buffers) {
+		return nil, fmt.Errorf("buffer %d does not exist", index)
+	}
+
+	b := p.doc.Buffers[index]
+
+	var data []byte
+	var err error
+
+	switch {
+
+	case b.URI == "":
+		// only the first buffer of a GLB file may refer to its binary chunk
+		if index != 0 || p.bin == nil {
+			return nil, fmt.Errorf("buffer %d has no data", index)
+		}
+		data = p.bin
+
+	case strings.HasPrefix(b.URI, "data:"):
+		data, err = decodeDataURI(b.URI)
+
+	default:
+		data, err = p.readFile(b.URI)
+
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("buffer %d: %v", index, err)
+	}
+
+	if len(data) < b.ByteLength {
+		return nil, fmt.Errorf("buffer %d has %d bytes, expected %d", index, len(data), b.ByteLength)
+	}
+
+	p.buffers[index] = data
+
+	return data, nil
+
+}
+
+// readFile reads a file referenced by a relative URI
+func (p *gltfParser) readFile(uri string) ([]byte, error) {
+
+	if p.fsys == nil {
+		return nil, fmt.Errorf("cannot open %q without a file system", uri)
+	}
+
+	name, err := url.PathUnescape(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	return fs.ReadFile(p.fsys, path.Clean(path.Join(p.dir, name)))
+
+}
+
+// decodeDataURI returns the data embedded in a data URI
+func decodeDataURI(uri string) ([]byte, error) {
+
+	comma := strings.IndexByte(uri, ',')
+	if comma < 0 {
+		return nil, fmt.Errorf("invalid data URI")
+	}
+
+	if strings.HasSuffix(uri[:comma], ";base64") {
+		return base64.StdEncoding.DecodeString(uri[comma+1:])
+	}
+
+	data, err := url.PathUnescape(uri[comma+1:])
+
+	return []byte(data), err
+
+}
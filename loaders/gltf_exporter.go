@@ -0,0 +1,1173 @@
+package loaders
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"math"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/cameras"
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/materials"
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/objects"
+	"github.com/rydrman/three.go/scenes"
+	"github.com/rydrman/three.go/textures"
+)
+
+// GLTFExporter writes scenes as glTF 2.0 assets
+type GLTFExporter struct {
+	// Binary writes a single GLB file instead of JSON
+	Binary bool
+	// EmbedBuffers stores the binary data of JSON output in a data URI,
+	// otherwise it is written to the file BufferName through Creator
+	EmbedBuffers bool
+	BufferName   string
+	Creator      Creator
+
+	// TrimVertices leaves out the vertices of indexed geometries that
+	// are not used by any triangle, line or point that is drawn
+	TrimVertices bool
+
+	// Animations are written along with the scene, their
+	// channels must target nodes of the exported scene
+	Animations []*GLTFAnimation
+}
+
+// NewGLTFExporter creates an exporter that writes
+// JSON with its binary data embedded
+func NewGLTFExporter() *GLTFExporter {
+
+	return &GLTFExporter{
+		EmbedBuffers: true,
+		BufferName:   "scene.bin",
+	}
+
+}
+
+// Export writes scene and the animations of this exporter to w. Meshes,
+// points and lines are written with their geometry and materials, cameras
+// are kept and every other object is written as an empty node. Textures
+// are embedded as PNG images, or referenced by their source file if they
+// have no image.
+func (e *GLTFExporter) Export(scene *scenes.Scene, w io.Writer) error {
+
+	x := &gltfWriter{
+		exporter: e,
+
+		nodes:      make(map[objects.Node]int),
+		meshes:     make(map[gltfMeshKey]int),
+		materials:  make(map[objects.Material]int),
+		textures:   make(map[*textures.Texture]int),
+		images:     make(map[image.Image]int),
+		attributes: make(map[*core.BufferAttribute]int),
+		skins:      make(map[*objects.Skeleton]int),
+	}
+
+	if err := x.writeScene(scene); err != nil {
+		return fmt.Errorf("loaders: glTF: %w", err)
+	}
+
+	return x.output(w)
+
+}
+
+// gltfMeshKey identifies the objects that can share a single glTF mesh
+type gltfMeshKey struct {
+	geometry *core.BufferGeometry
+	material objects.Material
+	mode     int
+}
+
+// gltfSkinnedNode is a skinned mesh whose skin is written
+// once every node of the scene has an index
+type gltfSkinnedNode struct {
+	node int
+	mesh *objects.SkinnedMesh
+}
+
+// gltfWriter holds the state of a single export
+type gltfWriter struct {
+	exporter *GLTFExporter
+
+	doc gltfDocument
+	bin bytes.Buffer
+
+	nodes      map[objects.Node]int
+	meshes     map[gltfMeshKey]int
+	materials  map[objects.Material]int
+	textures   map[*textures.Texture]int
+	images     map[image.Image]int
+	attributes map[*core.BufferAttribute]int
+	skins      map[*objects.Skeleton]int
+
+	skinned []gltfSkinnedNode
+}
+
+func (w *gltfWriter) writeScene(scene *scenes.Scene) error {
+
+	s := gltfScene{Name: scene.Name}
+
+	for _, child := range scene.GetChildren() {
+
+		index, err := w.writeNode(child)
+		if err != nil {
+			return err
+		}
+		s.Nodes = append(s.Nodes, index)
+
+	}
+
+	w.doc.Scenes = []gltfScene{s}
+	w.doc.Scene = new(int)
+
+	for _, skinned := range w.skinned {
+
+		skin, err := w.writeSkin(skinned.mesh.Skeleton)
+		if err != nil {
+			return fmt.Errorf("node %d: %w", skinned.node, err)
+		}
+		w.doc.Nodes[skinned.node].Skin = &skin
+
+	}
+
+	for i, animation := range w.exporter.Animations {
+
+		if err := w.writeAnimation(animation); err != nil {
+			return fmt.Errorf("animation %d: %w", i, err)
+		}
+
+	}
+
+	return nil
+
+}
+
+func (w *gltfWriter) writeNode(node objects.Node) (int, error) {
+
+	object := objects.ObjectOf(node)
+
+	index := len(w.doc.Nodes)
+	w.doc.Nodes = append(w.doc.Nodes, gltfNode{})
+	w.nodes[node] = index
+
+	n := gltfNode{Name: object.Name}
+
+	if object.MatrixAutoUpdate {
+		object.UpdateMatrix()
+	}
+
+	position := math3.NewVector3()
+	quaternion := math3.NewQuaternion()
+	scale := math3.NewVector3()
+	object.Matrix.Decompose(position, quaternion, scale)
+
+	if position.X != 0 || position.Y != 0 || position.Z != 0 {
+		n.Translation = []float64{position.X, position.Y, position.Z}
+	}
+	if quaternion.GetX() != 0 || quaternion.GetY() != 0 || quaternion.GetZ() != 0 || quaternion.GetW() != 1 {
+		n.Rotation = []float64{quaternion.GetX(), quaternion.GetY(), quaternion.GetZ(), quaternion.GetW()}
+	}
+	if scale.X != 1 || scale.Y != 1 || scale.Z != 1 {
+		n.Scale = []float64{scale.X, scale.Y, scale.Z}
+	}
+
+	var err error
+
+	switch o := node.(type) {
+
+	case *objects.Mesh:
+		err = w.writeMeshNode(&n, o, o.Geometry, o.Material, meshMode(o.DrawMode))
+
+	case *objects.SkinnedMesh:
+		err = w.writeMeshNode(&n, o.Mesh, o.Geometry, o.Material, meshMode(o.DrawMode))
+		if o.Skeleton != nil {
+			w.skinned = append(w.skinned, gltfSkinnedNode{index, o})
+		}
+
+	case *objects.Points:
+		err = w.writeMeshNode(&n, nil, o.Geometry, o.Material, gltfPoints)
+
+	case *objects.Line:
+		err = w.writeMeshNode(&n, nil, o.Geometry, o.Material, gltfLineStrip)
+
+	case *objects.LineLoop:
+		err = w.writeMeshNode(&n, nil, o.Geometry, o.Material, gltfLineLoop)
+
+	case *objects.LineSegments:
+		err = w.writeMeshNode(&n, nil, o.Geometry, o.Material, gltfLines)
+
+	case *cameras.PerspectiveCamera, *cameras.OrthographicCamera:
+		camera := w.writeCamera(o)
+		n.Camera = &camera
+
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("node %d: %w", index, err)
+	}
+
+	for _, child := range node.GetChildren() {
+
+		childIndex, err := w.writeNode(child)
+		if err != nil {
+			return 0, err
+		}
+		n.Children = append(n.Children, childIndex)
+
+	}
+
+	w.doc.Nodes[index] = n
+
+	return index, nil
+
+}
+
+func meshMode(drawMode int) int {
+
+	switch drawMode {
+	case three.TriangleStripDrawMode:
+		return gltfTriangleStrip
+	case three.TriangleFanDrawMode:
+		return gltfTriangleFan
+	}
+
+	return gltfTriangles
+
+}
+
+// writeMeshNode adds the mesh drawing geometry with material to n,
+// reusing the mesh of any earlier node with the same geometry and material
+func (w *gltfWriter) writeMeshNode(n *gltfNode, mesh *objects.Mesh, geometry *core.BufferGeometry, material objects.Material, mode int) error {
+
+	var weights []float64
+	if mesh != nil && len(geometry.MorphAttributes) > 0 {
+		weights = mesh.MorphTargetInfluences
+	}
+
+	key := gltfMeshKey{geometry, material, mode}
+
+	if index, ok := w.meshes[key]; ok {
+
+		n.Mesh = &index
+		if !equalFloats(weights, w.doc.Meshes[index].Weights) {
+			n.Weights = weights
+		}
+		return nil
+
+	}
+
+	primitives, err := w.writePrimitives(geometry, material, mode)
+	if err != nil {
+		return err
+	}
+
+	m := gltfMesh{
+		Name:       geometry.Name,
+		Primitives: primitives,
+		Weights:    weights,
+	}
+
+	if names := morphTargetNames(geometry); names != nil {
+		m.Extras = &gltfMeshExtras{TargetNames: names}
+	}
+
+	index := len(w.doc.Meshes)
+	w.doc.Meshes = append(w.doc.Meshes, m)
+	w.meshes[key] = index
+	n.Mesh = &index
+
+	return nil
+
+}
+
+func equalFloats(a, b []float64) bool {
+
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+
+}
+
+// morphTargetNames returns the names of the morph targets of
+// geometry, or nil if they do not all have names
+func morphTargetNames(geometry *core.BufferGeometry) []string {
+
+	var names []string
+
+	for _, name := range sortedKeys(geometry.MorphAttributes) {
+
+		targets := geometry.MorphAttributes[name]
+		for i, target := range targets {
+
+			if target.Name == "" {
+				return nil
+			}
+			if i >= len(names) {
+				names = append(names, target.Name)
+			}
+
+		}
+
+	}
+
+	return names
+
+}
+
+func sortedKeys(m map[string][]*core.BufferAttribute) []string {
+
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+
+}
+
+// writePrimitives writes a primitive for each group of a geometry drawn
+// with a multi material, or a single primitive for the whole geometry
+func (w *gltfWriter) writePrimitives(geometry *core.BufferGeometry, material objects.Material, mode int) ([]gltfPrimitive, error) {
+
+	position := geometry.GetAttribute("position")
+	if position == nil {
+		return nil, fmt.Errorf("geometry has no position attribute")
+	}
+
+	count := position.Count()
+	if geometry.Index != nil {
+		count = geometry.Index.Count()
+	}
+
+	start, end := geometry.DrawRange.Start, count
+	if start < 0 {
+		start = 0
+	}
+	if geometry.DrawRange.Count >= 0 && start+geometry.DrawRange.Count < end {
+		end = start + geometry.DrawRange.Count
+	}
+
+	groups := []core.Group{{Start: start, Count: end - start}}
+
+	multi, isMulti := material.(*materials.MultiMaterial)
+	if isMulti && len(geometry.Groups) > 0 {
+		groups = geometry.Groups
+	}
+
+	var primitives []gltfPrimitive
+
+	for _, group := range groups {
+
+		groupMaterial := material
+		if isMulti {
+
+			groupMaterial = multi.Get(group.MaterialIndex)
+			if groupMaterial == nil {
+				continue
+			}
+
+		}
+
+		groupStart, groupEnd := group.Start, group.Start+group.Count
+		if groupStart < start {
+			groupStart = start
+		}
+		if groupEnd > end {
+			groupEnd = end
+		}
+		if groupEnd <= groupStart {
+			continue
+		}
+
+		primitive, err := w.writePrimitive(geometry, groupStart, groupEnd, mode)
+		if err != nil {
+			return nil, err
+		}
+
+		if groupMaterial != nil {
+
+			index, err := w.writeMaterial(groupMaterial)
+			if err != nil {
+				return nil, err
+			}
+			primitive.Material = &index
+
+		}
+
+		primitives = append(primitives, primitive)
+
+	}
+
+	if len(primitives) == 0 {
+		return nil, fmt.Errorf("geometry has nothing to draw")
+	}
+
+	return primitives, nil
+
+}
+
+// gltfSemantics maps the attribute names of buffer geometries onto glTF
+// attribute semantics, other attributes are written as custom attributes
+var gltfSemantics = func() map[string]string {
+
+	semantics := make(map[string]string)
+	for semantic, name := range gltfAttributeNames {
+		semantics[name] = semantic
+	}
+
+	return semantics
+
+}()
+
+func attributeSemantic(name string) string {
+
+	if semantic, ok := gltfSemantics[name]; ok {
+		return semantic
+	}
+
+	// application specific semantics must start with an underscore
+	return "_" + strings.ToUpper(name)
+
+}
+
+// writePrimitive writes the elements from start to end of geometry
+func (w *gltfWriter) writePrimitive(geometry *core.BufferGeometry, start, end int, mode int) (gltfPrimitive, error) {
+
+	primitive := gltfPrimitive{
+		Attributes: make(map[string]int),
+	}
+
+	if mode != gltfTriangles {
+		primitive.Mode = &mode
+	}
+
+	position := geometry.GetAttribute("position")
+
+	// vertices lists the vertices that are written, nil writes them all
+	var vertices []int
+	var indices []int
+
+	if geometry.Index != nil {
+
+		indices = make([]int, end-start)
+		for i := range indices {
+			indices[i] = int(geometry.Index.GetX(start + i))
+		}
+
+		if w.exporter.TrimVertices {
+
+			remap := make(map[int]int)
+			for i, index := range indices {
+
+				if _, ok := remap[index]; !ok {
+					remap[index] = len(vertices)
+					vertices = append(vertices, index)
+				}
+				indices[i] = remap[index]
+
+			}
+
+		}
+
+	} else if start != 0 || end != position.Count() {
+
+		vertices = make([]int, end-start)
+		for i := range vertices {
+			vertices[i] = start + i
+		}
+
+	}
+
+	for _, name := range sortedAttributeNames(geometry.Attributes) {
+
+		semantic := attributeSemantic(name)
+
+		accessor, err := w.writeAttribute(geometry.Attributes[name], vertices, semantic)
+		if err != nil {
+			return primitive, err
+		}
+		primitive.Attributes[semantic] = accessor
+
+	}
+
+	if indices != nil {
+
+		vertexCount := position.Count()
+		if vertices != nil {
+			vertexCount = len(vertices)
+		}
+
+		values := make([]float64, len(indices))
+		componentType := gltfUnsignedShort
+
+		for i, index := range indices {
+
+			if index < 0 || index >= vertexCount {
+				return primitive, fmt.Errorf("index %d is out of range of %d vertices", index, vertexCount)
+			}
+			if index >= 65535 {
+				componentType = gltfUnsignedInt
+			}
+			values[i] = float64(index)
+
+		}
+
+		accessor := w.writeAccessor(values, 1, componentType, gltfElementArrayBuffer, false)
+		primitive.Indices = &accessor
+
+	}
+
+	for _, name := range sortedKeys(geometry.MorphAttributes) {
+
+		base := geometry.GetAttribute(name)
+		semantic := attributeSemantic(name)
+
+		for i, morph := range geometry.MorphAttributes[name] {
+
+			if base == nil || morph.Count() != base.Count() || morph.ItemSize != base.ItemSize {
+				return primitive, fmt.Errorf("morph target %d of %q does not match its attribute", i, name)
+			}
+
+			// glTF stores the displacement of each vertex from the base attribute
+			displacement := core.NewFloat32BufferAttribute(make([]float32, base.Count()*base.ItemSize), base.ItemSize)
+			for j := range displacement.Float32 {
+				displacement.Float32[j] = float32(morph.Get(j) - base.Get(j))
+			}
+
+			accessor, err := w.writeAttribute(displacement, vertices, semantic)
+			if err != nil {
+				return primitive, err
+			}
+
+			for len(primitive.Targets) <= i {
+				primitive.Targets = append(primitive.Targets, make(map[string]int))
+			}
+			primitive.Targets[i][semantic] = accessor
+
+		}
+
+	}
+
+	return primitive, nil
+
+}
+
+func sortedAttributeNames(attributes map[string]*core.BufferAttribute) []string {
+
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+
+}
+
+// writeAttribute writes the given vertices of attribute, or all of them if
+// vertices is nil, accessors of whole attributes are shared between primitives
+func (w *gltfWriter) writeAttribute(attribute *core.BufferAttribute, vertices []int, semantic string) (int, error) {
+
+	if vertices == nil {
+		if accessor, ok := w.attributes[attribute]; ok {
+			return accessor, nil
+		}
+	}
+
+	itemSize := attribute.ItemSize
+	if itemSize < 1 || itemSize > 4 {
+		return 0, fmt.Errorf("attribute %s has an unsupported item size of %d", semantic, itemSize)
+	}
+
+	scale := 1.0
+	if attribute.Normalized {
+		switch {
+		case attribute.Uint16 != nil:
+			scale = 1.0 / math.MaxUint16
+		case attribute.Uint32 != nil:
+			scale = 1.0 / math.MaxUint32
+		}
+	}
+
+	count := attribute.Count()
+	if vertices != nil {
+		count = len(vertices)
+	}
+
+	values := make([]float64, count*itemSize)
+	for i := 0; i < count; i++ {
+
+		vertex := i
+		if vertices != nil {
+			vertex = vertices[i]
+		}
+
+		for j := 0; j < itemSize; j++ {
+			values[i*itemSize+j] = attribute.Get(vertex*itemSize+j) * scale
+		}
+
+	}
+
+	componentType := gltfFloat
+	if strings.HasPrefix(semantic, "JOINTS_") {
+		componentType = gltfUnsignedShort
+	}
+
+	accessor := w.writeAccessor(values, itemSize, componentType, gltfArrayBuffer, semantic == "POSITION")
+
+	if vertices == nil {
+		w.attributes[attribute] = accessor
+	}
+
+	return accessor, nil
+
+}
+
+// gltfTypes is the accessor type of each item size
+var gltfTypes = map[int]string{
+	1:  "SCALAR",
+	2:  "VEC2",
+	3:  "VEC3",
+	4:  "VEC4",
+	16: "MAT4",
+}
+
+// writeAccessor appends values to the binary buffer in a new buffer view,
+// bounds are only written when required as they are for positions and
+// animation inputs
+func (w *gltfWriter) writeAccessor(values []float64, itemSize, componentType, target int, bounds bool) int {
+
+	w.align()
+	offset := w.bin.Len()
+
+	var scratch [4]byte
+	for _, v := range values {
+
+		switch componentType {
+		case gltfUnsignedShort:
+			binary.LittleEndian.PutUint16(scratch[:], uint16(v))
+			w.bin.Write(scratch[:2])
+		case gltfUnsignedInt:
+			binary.LittleEndian.PutUint32(scratch[:], uint32(v))
+			w.bin.Write(scratch[:])
+		default:
+			binary.LittleEndian.PutUint32(scratch[:], math.Float32bits(float32(v)))
+			w.bin.Write(scratch[:])
+		}
+
+	}
+
+	view := len(w.doc.BufferViews)
+	w.doc.BufferViews = append(w.doc.BufferViews, gltfBufferView{
+		Buffer:     0,
+		ByteOffset: offset,
+		ByteLength: w.bin.Len() - offset,
+		Target:     target,
+	})
+
+	accessor := gltfAccessor{
+		BufferView:    &view,
+		ComponentType: componentType,
+		Count:         len(values) / itemSize,
+		Type:          gltfTypes[itemSize],
+	}
+
+	if bounds && len(values) > 0 {
+
+		accessor.Min = make([]float64, itemSize)
+		accessor.Max = make([]float64, itemSize)
+
+		for j := 0; j < itemSize; j++ {
+
+			// bounds are compared against the values as they are stored
+			accessor.Min[j] = float64(float32(values[j]))
+			accessor.Max[j] = accessor.Min[j]
+
+			for i := j; i < len(values); i += itemSize {
+				v := float64(float32(values[i]))
+				accessor.Min[j] = math.Min(accessor.Min[j], v)
+				accessor.Max[j] = math.Max(accessor.Max[j], v)
+			}
+
+		}
+
+	}
+
+	index := len(w.doc.Accessors)
+	w.doc.Accessors = append(w.doc.Accessors, accessor)
+
+	return index
+
+}
+
+// align pads the binary buffer to a multiple of four bytes
+func (w *gltfWriter) align() {
+
+	for w.bin.Len()%4 != 0 {
+		w.bin.WriteByte(0)
+	}
+
+}
+
+// writeMaterial converts a material to the metallic-roughness model,
+// materials other than standard materials are approximated
+func (w *gltfWriter) writeMaterial(material objects.Material) (int, error) {
+
+	if index, ok := w.materials[material]; ok {
+		return index, nil
+	}
+
+	base := material.GetMaterial()
+
+	m := gltfMaterial{Name: base.Name}
+	pbr := &gltfPBRMetallicRoughness{}
+
+	color := math3.NewColor()
+	emissive := math3.NewColor().SetHex(0x000000)
+	metalness, roughness := 0.0, 1.0
+
+	var err error
+	var baseMap, emissiveMap, normalMap *textures.Texture
+	var normalScale *math3.Vector2
+
+	switch t := material.(type) {
+
+	case *materials.MeshStandardMaterial:
+		color.Copy(t.Color)
+		emissive.Copy(t.Emissive).MultiplyScalar(t.EmissiveIntensity)
+		metalness, roughness = t.Metalness, t.Roughness
+		baseMap, emissiveMap, normalMap = t.Map, t.EmissiveMap, t.NormalMap
+		normalScale = t.NormalScale
+
+		metallicRoughness := t.RoughnessMap
+		if metallicRoughness == nil {
+			metallicRoughness = t.MetalnessMap
+		}
+		if pbr.MetallicRoughnessTexture, err = w.writeTexture(metallicRoughness); err != nil {
+			return 0, err
+		}
+
+		if m.OcclusionTexture, err = w.writeTexture(t.AoMap); err != nil {
+			return 0, err
+		}
+		if m.OcclusionTexture != nil && t.AoMapIntensity != 1 {
+			strength := t.AoMapIntensity
+			m.OcclusionTexture.Strength = &strength
+		}
+
+	case *materials.MeshPhongMaterial:
+		color.Copy(t.Color)
+		emissive.Copy(t.Emissive).MultiplyScalar(t.EmissiveIntensity)
+		// a common approximation of the roughness of a specular exponent
+		roughness = math.Sqrt(2 / (t.Shininess + 2))
+		baseMap, emissiveMap, normalMap = t.Map, t.EmissiveMap, t.NormalMap
+		normalScale = t.NormalScale
+
+	case *materials.MeshLambertMaterial:
+		color.Copy(t.Color)
+		emissive.Copy(t.Emissive).MultiplyScalar(t.EmissiveIntensity)
+
+	case *materials.MeshBasicMaterial:
+		color.Copy(t.Color)
+
+	case *materials.LineBasicMaterial:
+		color.Copy(t.Color)
+
+	case *materials.PointsMaterial:
+		color.Copy(t.Color)
+
+	default:
+		glog.Warningf("loaders: glTF export does not support %T, it is written as a white material", material)
+
+	}
+
+	opacity := 1.0
+	if base.Transparent {
+		opacity = base.Opacity
+	}
+
+	pbr.BaseColorFactor = []float64{color.R, color.G, color.B, opacity}
+	pbr.MetallicFactor = &metalness
+	pbr.RoughnessFactor = &roughness
+
+	if pbr.BaseColorTexture, err = w.writeTexture(baseMap); err != nil {
+		return 0, err
+	}
+	m.PBRMetallicRoughness = pbr
+
+	if emissive.R != 0 || emissive.G != 0 || emissive.B != 0 {
+		m.EmissiveFactor = []float64{emissive.R, emissive.G, emissive.B}
+	}
+	if m.EmissiveTexture, err = w.writeTexture(emissiveMap); err != nil {
+		return 0, err
+	}
+
+	if m.NormalTexture, err = w.writeTexture(normalMap); err != nil {
+		return 0, err
+	}
+	if m.NormalTexture != nil && normalScale != nil && normalScale.X != 1 {
+		scale := normalScale.X
+		m.NormalTexture.Scale = &scale
+	}
+
+	switch {
+	case base.Transparent:
+		m.AlphaMode = "BLEND"
+	case base.AlphaTest > 0:
+		cutoff := base.AlphaTest
+		m.AlphaMode = "MASK"
+		m.AlphaCutoff = &cutoff
+	}
+
+	m.DoubleSided = base.Side == three.DoubleSide
+
+	index := len(w.doc.Materials)
+	w.doc.Materials = append(w.doc.Materials, m)
+	w.materials[material] = index
+
+	return index, nil
+
+}
+
+// invertMap returns the keys of m by their values
+func invertMap(m map[int]int) map[int]int {
+
+	inverse := make(map[int]int, len(m))
+	for key, value := range m {
+		inverse[value] = key
+	}
+
+	return inverse
+
+}
+
+var (
+	threeFilters   = invertMap(gltfFilters)
+	threeWrappings = invertMap(gltfWrappings)
+)
+
+// writeTexture writes a texture along with its image and sampler,
+// it returns nil if the texture is nil or has nothing to refer to
+func (w *gltfWriter) writeTexture(texture *textures.Texture) (*gltfTextureInfo, error) {
+
+	if texture == nil || (texture.Image == nil && texture.SourceFile == "") {
+		return nil, nil
+	}
+
+	if index, ok := w.textures[texture]; ok {
+		return &gltfTextureInfo{Index: index}, nil
+	}
+
+	source, err := w.writeImage(texture)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapS, wrapT := threeWrappings[texture.WrapS], threeWrappings[texture.WrapT]
+	sampler := gltfSampler{
+		MagFilter: threeFilters[texture.MagFilter],
+		MinFilter: threeFilters[texture.MinFilter],
+		WrapS:     &wrapS,
+		WrapT:     &wrapT,
+	}
+
+	// textures with the same settings share a sampler
+	samplerIndex := -1
+	for i, s := range w.doc.Samplers {
+		if s.MagFilter == sampler.MagFilter && s.MinFilter == sampler.MinFilter && *s.WrapS == wrapS && *s.WrapT == wrapT {
+			samplerIndex = i
+			break
+		}
+	}
+	if samplerIndex < 0 {
+		samplerIndex = len(w.doc.Samplers)
+		w.doc.Samplers = append(w.doc.Samplers, sampler)
+	}
+
+	index := len(w.doc.Textures)
+	w.doc.Textures = append(w.doc.Textures, gltfTexture{
+		Name:    texture.Name,
+		Sampler: &samplerIndex,
+		Source:  &source,
+	})
+	w.textures[texture] = index
+
+	return &gltfTextureInfo{Index: index}, nil
+
+}
+
+// writeImage embeds the image of a texture as a PNG, or
+// refers to its source file if the texture has no image
+func (w *gltfWriter) writeImage(texture *textures.Texture) (int, error) {
+
+	if texture.Image == nil {
+
+		index := len(w.doc.Images)
+		w.doc.Images = append(w.doc.Images, gltfImage{
+			Name: texture.Name,
+			URI:  (&url.URL{Path: filepathToSlash(texture.SourceFile)}).EscapedPath(),
+		})
+
+		return index, nil
+
+	}
+
+	if index, ok := w.images[texture.Image]; ok {
+		return index, nil
+	}
+
+	w.align()
+	offset := w.bin.Len()
+
+	if err := png.Encode(&w.bin, texture.Image); err != nil {
+		return 0, fmt.Errorf("texture %q: %v", texture.Name, err)
+	}
+
+	view := len(w.doc.BufferViews)
+	w.doc.BufferViews = append(w.doc.BufferViews, gltfBufferView{
+		Buffer:     0,
+		ByteOffset: offset,
+		ByteLength: w.bin.Len() - offset,
+	})
+
+	index := len(w.doc.Images)
+	w.doc.Images = append(w.doc.Images, gltfImage{
+		Name:       texture.Name,
+		MimeType:   "image/png",
+		BufferView: &view,
+	})
+	w.images[texture.Image] = index
+
+	return index, nil
+
+}
+
+func (w *gltfWriter) writeCamera(node objects.Node) int {
+
+	camera := gltfCamera{}
+
+	switch c := node.(type) {
+
+	case *cameras.PerspectiveCamera:
+		aspect, far := c.Aspect, c.Far
+		camera.Name = c.Name
+		camera.Type = "perspective"
+		camera.Perspective = &gltfPerspectiveCamera{
+			AspectRatio: &aspect,
+			YFov:        c.Fov * math3.Deg2Rad,
+			ZNear:       c.Near,
+			ZFar:        &far,
+		}
+
+	case *cameras.OrthographicCamera:
+		camera.Name = c.Name
+		camera.Type = "orthographic"
+		camera.Orthographic = &gltfOrthographicCamera{
+			XMag:  (c.Right - c.Left) / (2 * c.Zoom),
+			YMag:  (c.Top - c.Bottom) / (2 * c.Zoom),
+			ZNear: c.Near,
+			ZFar:  c.Far,
+		}
+
+	}
+
+	index := len(w.doc.Cameras)
+	w.doc.Cameras = append(w.doc.Cameras, camera)
+
+	return index
+
+}
+
+// writeSkin writes the joints and inverse bind matrices of
+// skeleton, the bones must all be part of the exported scene
+func (w *gltfWriter) writeSkin(skeleton *objects.Skeleton) (int, error) {
+
+	if index, ok := w.skins[skeleton]; ok {
+		return index, nil
+	}
+
+	skin := gltfSkin{}
+	inverses := make([]float64, 0, len(skeleton.Bones)*16)
+
+	for i, bone := range skeleton.Bones {
+
+		joint, ok := w.nodes[bone]
+		if !ok {
+			return 0, fmt.Errorf("bone %d of the skeleton is not part of the exported scene", i)
+		}
+
+		skin.Joints = append(skin.Joints, joint)
+		inverses = append(inverses, skeleton.BoneInverses[i].Elements...)
+
+	}
+
+	if len(inverses) > 0 {
+		accessor := w.writeAccessor(inverses, 16, gltfFloat, 0, false)
+		skin.InverseBindMatrices = &accessor
+	}
+
+	index := len(w.doc.Skins)
+	w.doc.Skins = append(w.doc.Skins, skin)
+	w.skins[skeleton] = index
+
+	return index, nil
+
+}
+
+func (w *gltfWriter) writeAnimation(animation *GLTFAnimation) error {
+
+	a := gltfAnimation{Name: animation.Name}
+
+	for i, channel := range animation.Channels {
+
+		node, ok := w.nodes[channel.Target]
+		if !ok {
+			return fmt.Errorf("channel %d targets a node that is not part of the exported scene", i)
+		}
+
+		if len(channel.Times) == 0 || channel.ItemSize < 1 {
+			return fmt.Errorf("channel %d has no keyframes", i)
+		}
+
+		// weights are written as scalars, one for each morph target
+		itemSize := channel.ItemSize
+		if channel.Path == "weights" {
+			itemSize = 1
+		}
+
+		if _, ok := gltfTypes[itemSize]; !ok || len(channel.Values)%itemSize != 0 {
+			return fmt.Errorf("channel %d has an invalid item size of %d", i, channel.ItemSize)
+		}
+
+		sampler := gltfAnimationSampler{
+			Input:         w.writeAccessor(channel.Times, 1, gltfFloat, 0, true),
+			Output:        w.writeAccessor(channel.Values, itemSize, gltfFloat, 0, false),
+			Interpolation: channel.Interpolation,
+		}
+
+		c := gltfAnimationChannel{Sampler: len(a.Samplers)}
+		c.Target.Node = &node
+		c.Target.Path = channel.Path
+
+		a.Samplers = append(a.Samplers, sampler)
+		a.Channels = append(a.Channels, c)
+
+	}
+
+	w.doc.Animations = append(w.doc.Animations, a)
+
+	return nil
+
+}
+
+// output writes the document along with its binary buffer
+func (w *gltfWriter) output(out io.Writer) error {
+
+	e := w.exporter
+
+	w.doc.Asset = gltfAsset{
+		Version:   "2.0",
+		Generator: "three.go",
+	}
+
+	w.align()
+
+	if w.bin.Len() > 0 {
+
+		buffer := gltfBuffer{ByteLength: w.bin.Len()}
+
+		switch {
+
+		case e.Binary:
+
+		case e.EmbedBuffers:
+			buffer.URI = "data:application/octet-stream;base64," + base64.StdEncoding.EncodeToString(w.bin.Bytes())
+
+		default:
+			if e.Creator == nil {
+				return fmt.Errorf("loaders: glTF: cannot write %q without a creator", e.BufferName)
+			}
+
+			file, err := e.Creator.Create(e.BufferName)
+			if err != nil {
+				return err
+			}
+
+			_, err = file.Write(w.bin.Bytes())
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+
+			buffer.URI = (&url.URL{Path: e.BufferName}).EscapedPath()
+
+		}
+
+		w.doc.Buffers = []gltfBuffer{buffer}
+
+	}
+
+	content, err := json.Marshal(&w.doc)
+	if err != nil {
+		return err
+	}
+
+	if !e.Binary {
+		_, err = out.Write(content)
+		return err
+	}
+
+	// chunks are padded to four bytes, with spaces for the JSON chunk
+	for len(content)%4 != 0 {
+		content = append(content, ' ')
+	}
+
+	length := 12 + 8 + len(content)
+	if w.bin.Len() > 0 {
+		length += 8 + w.bin.Len()
+	}
+
+	header := []uint32{glbMagic, 2, uint32(length), uint32(len(content)), glbJSONChunk}
+	if err := binary.Write(out, binary.LittleEndian, header); err != nil {
+		return err
+	}
+	if _, err := out.Write(content); err != nil {
+		return err
+	}
+
+	if w.bin.Len() > 0 {
+
+		if err := binary.Write(out, binary.LittleEndian, []uint32{uint32(w.bin.Len()), glbBINChunk}); err != nil {
+			return err
+		}
+		if _, err := out.Write(w.bin.Bytes()); err != nil {
+			return err
+		}
+
+	}
+
+	return nil
+
+}
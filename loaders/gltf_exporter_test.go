@@ -0,0 +1,306 @@
+package loaders_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/cameras"
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/loaders"
+	"github.com/rydrman/three.go/materials"
+	"github.com/rydrman/three.go/objects"
+	"github.com/rydrman/three.go/scenes"
+	"github.com/rydrman/three.go/textures"
+)
+
+// testExportScene returns a scene with a textured mesh drawn with two
+// materials, a skinned mesh, a camera and an animation of the mesh
+func testExportScene() (*scenes.Scene, *loaders.GLTFAnimation) {
+	scene := scenes.NewScene()
+	scene.Name = "exported"
+
+	// a quad with an unused fifth vertex
+	geometry := core.NewBufferGeometry()
+	geometry.AddAttribute("position", core.NewFloat32BufferAttribute([]float32{
+		0, 0, 0, 1, 0, 0, 1, 1, 0, 0, 1, 0, 5, 5, 5,
+	}, 3))
+	geometry.SetIndex(core.NewIndexAttribute([]int{0, 1, 2, 0, 2, 3}))
+	geometry.AddGroup(0, 3, 0)
+	geometry.AddGroup(3, 3, 1)
+
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(1, 1, color.RGBA{255, 0, 0, 255})
+
+	red := materials.NewMeshStandardMaterial()
+	red.Name = "red"
+	red.Color.SetHex(0xff0000)
+	red.Metalness, red.Roughness = 0.25, 0.75
+	red.Map = textures.NewTexture(img)
+	red.Map.WrapS = three.RepeatWrapping
+	blue := materials.NewMeshStandardMaterial()
+	blue.Name = "blue"
+	blue.Color.SetHex(0x0000ff)
+	blue.Side = three.DoubleSide
+
+	quad := objects.NewMesh(geometry, materials.NewMultiMaterial(red, blue))
+	quad.Name = "quad"
+	quad.Position.Set(1, 2, 3)
+	quad.Scale.Set(2, 2, 2)
+	scene.Add(quad)
+
+	hip, knee := objects.NewBone(), objects.NewBone()
+	hip.Name, knee.Name = "hip", "knee"
+	knee.Position.Set(0, 1, 0)
+	hip.Add(knee)
+	scene.Add(hip)
+
+	skinGeometry := core.NewBufferGeometry()
+	skinGeometry.AddAttribute("position", core.NewFloat32BufferAttribute([]float32{0, 0, 0, 1, 0, 0, 0, 1, 0}, 3))
+	skinGeometry.AddAttribute("skinIndex", core.NewUint16BufferAttribute([]uint16{0, 1, 0, 0, 0, 1, 0, 0, 1, 0, 0, 0}, 4))
+	skinGeometry.AddAttribute("skinWeight", core.NewFloat32BufferAttribute([]float32{1, 0, 0, 0, 0.5, 0.5, 0, 0, 1, 0, 0, 0}, 4))
+	body := objects.NewSkinnedMesh(skinGeometry, materials.NewMeshStandardMaterial())
+	body.Name = "body"
+	scene.Add(body)
+
+	camera := cameras.NewPerspectiveCamera(60, 2, 0.5, 100)
+	camera.Name = "eye"
+	camera.Position.Set(0, 0, 5)
+	scene.Add(camera)
+
+	scene.UpdateMatrixWorld(true)
+	body.Bind(objects.NewSkeleton([]*objects.Bone{hip, knee}, nil), nil)
+
+	animation := &loaders.GLTFAnimation{
+		Name:     "slide",
+		Duration: 2,
+		Channels: []*loaders.GLTFChannel{{
+			Target:        quad,
+			Path:          "translation",
+			Interpolation: "STEP",
+			Times:         []float64{0, 2},
+			Values:        []float64{1, 2, 3, 4, 5, 6},
+			ItemSize:      3,
+		}},
+	}
+
+	return scene, animation
+}
+
+func exportTestScene(t *testing.T, exporter *loaders.GLTFExporter) []byte {
+	scene, animation := testExportScene()
+	exporter.Animations = []*loaders.GLTFAnimation{animation}
+	var b bytes.Buffer
+	if err := exporter.Export(scene, &b); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+func checkExportedGLTF(t *testing.T, gltf *loaders.GLTF) {
+	if gltf.Scene == nil || gltf.Scene.Name != "exported" || len(gltf.Scene.GetChildren()) != 4 {
+		t.Fatal("expected the scene with its four children")
+	}
+
+	quad := gltf.Scene.GetChildren()[0].(*objects.Object)
+	if quad.Name != "quad" || quad.Position.Z != 3 || quad.Scale.X != 2 {
+		t.Errorf("expected the transform of the quad, got %v and %v", quad.Position, quad.Scale)
+	}
+	parts := quad.GetChildren()
+	if len(parts) != 2 {
+		t.Fatalf("expected a part for each material, got %d", len(parts))
+	}
+
+	red := parts[0].(*objects.Mesh).Material.(*materials.MeshStandardMaterial)
+	if red.Name != "red" || red.Color.R != 1 || red.Color.B != 0 || red.Metalness != 0.25 || red.Roughness != 0.75 {
+		t.Errorf("unexpected material %+v", red)
+	}
+	if red.Map == nil || red.Map.Image == nil || red.Map.WrapS != three.RepeatWrapping || red.Map.WrapT != three.ClampToEdgeWrapping {
+		t.Fatal("expected the embedded texture with its wrapping")
+	}
+	if r, _, _, _ := red.Map.Image.At(1, 1).RGBA(); r != 0xffff {
+		t.Error("expected the pixels of the texture")
+	}
+	blue := parts[1].(*objects.Mesh).Material.(*materials.MeshStandardMaterial)
+	if blue.Name != "blue" || blue.Side != three.DoubleSide {
+		t.Error("expected the double sided second material")
+	}
+
+	second := parts[1].(*objects.Mesh).Geometry
+	if second.Index.Count() != 3 || second.Index.GetX(2) != 3 || second.GetAttribute("position").GetY(3) != 1 {
+		t.Error("expected the second group as its own primitive")
+	}
+
+	body := gltf.Scene.GetChildren()[2].(*objects.SkinnedMesh)
+	if body.Skeleton == nil || len(body.Skeleton.Bones) != 2 || body.Skeleton.Bones[1].Name != "knee" {
+		t.Fatal("expected the skinned mesh to be bound to its bones")
+	}
+	if body.Geometry.GetAttribute("skinIndex").GetY(1) != 1 || body.Geometry.GetAttribute("skinWeight").GetY(1) != 0.5 {
+		t.Error("expected the joints and weights")
+	}
+	if inverse := body.Skeleton.BoneInverses[1].Elements; inverse[13] != -1 {
+		t.Errorf("expected the inverse bind matrix of the knee, got %v", inverse)
+	}
+
+	camera := gltf.Cameras[0].(*cameras.PerspectiveCamera)
+	if math.Abs(camera.Fov-60) > 1e-9 || camera.Aspect != 2 || camera.Near != 0.5 || camera.Position.Z != 5 {
+		t.Errorf("unexpected camera %+v", camera)
+	}
+
+	if len(gltf.Animations) != 1 {
+		t.Fatal("expected one animation")
+	}
+	channel := gltf.Animations[0].Channels[0]
+	if gltf.Animations[0].Name != "slide" || channel.Target != quad || channel.Interpolation != "STEP" || channel.Values[5] != 6 {
+		t.Errorf("unexpected channel %+v", channel)
+	}
+}
+
+func TestGLTFExporter_Embedded(t *testing.T) {
+	data := exportTestScene(t, loaders.NewGLTFExporter())
+	if !bytes.Contains(data, []byte("data:application/octet-stream;base64,")) {
+		t.Error("expected the buffer to be embedded")
+	}
+
+	gltf, err := loaders.NewGLTFLoader(nil).Parse(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkExportedGLTF(t, gltf)
+}
+
+func TestGLTFExporter_Binary(t *testing.T) {
+	exporter := loaders.NewGLTFExporter()
+	exporter.Binary = true
+	data := exportTestScene(t, exporter)
+	if string(data[:4]) != "glTF" || len(data)%4 != 0 {
+		t.Error("expected an aligned GLB file")
+	}
+
+	gltf, err := loaders.NewGLTFLoader(nil).Parse(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkExportedGLTF(t, gltf)
+}
+
+type mapFileWriter struct {
+	bytes.Buffer
+	fsys fstest.MapFS
+	name string
+}
+
+func (w *mapFileWriter) Close() error {
+	w.fsys[w.name] = &fstest.MapFile{Data: w.Bytes()}
+	return nil
+}
+
+func TestGLTFExporter_ExternalBuffer(t *testing.T) {
+	fsys := fstest.MapFS{}
+	exporter := loaders.NewGLTFExporter()
+	exporter.EmbedBuffers = false
+	exporter.BufferName = "scene data.bin"
+	exporter.Creator = loaders.CreatorFunc(func(name string) (io.WriteCloser, error) {
+		return &mapFileWriter{fsys: fsys, name: "assets/" + name}, nil
+	})
+	fsys["assets/scene.gltf"] = &fstest.MapFile{Data: exportTestScene(t, exporter)}
+
+	if _, ok := fsys["assets/scene data.bin"]; !ok {
+		t.Fatal("expected the buffer to be created")
+	}
+	gltf, err := loaders.NewGLTFLoader(fsys).Load("assets/scene.gltf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkExportedGLTF(t, gltf)
+
+	exporter.Creator = nil
+	scene, _ := testExportScene()
+	if err := exporter.Export(scene, io.Discard); err == nil {
+		t.Error("expected an error without a creator")
+	}
+}
+
+func TestGLTFExporter_TrimVertices(t *testing.T) {
+	count := func(data []byte) int {
+		var doc struct {
+			Accessors []struct {
+				Count int
+				Min   []float64
+			}
+		}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			t.Fatal(err)
+		}
+		// the first accessor is the position of the first primitive
+		return doc.Accessors[0].Count
+	}
+
+	if n := count(exportTestScene(t, loaders.NewGLTFExporter())); n != 5 {
+		t.Errorf("expected all five vertices, got %d", n)
+	}
+
+	exporter := loaders.NewGLTFExporter()
+	exporter.TrimVertices = true
+	data := exportTestScene(t, exporter)
+	if n := count(data); n != 3 {
+		t.Errorf("expected the three vertices of the first group, got %d", n)
+	}
+
+	gltf, err := loaders.NewGLTFLoader(nil).Parse(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second := gltf.Scene.GetChildren()[0].GetChildren()[1].(*objects.Mesh).Geometry
+	position, corner := second.GetAttribute("position"), int(second.Index.GetX(1))
+	if position.Count() != 3 || position.GetX(corner) != 1 || position.GetY(corner) != 1 {
+		t.Error("expected the indices to be remapped onto the remaining vertices")
+	}
+}
+
+func TestGLTFExporter_Errors(t *testing.T) {
+	scene, animation := testExportScene()
+	exporter := loaders.NewGLTFExporter()
+
+	exporter.Animations = []*loaders.GLTFAnimation{{Channels: []*loaders.GLTFChannel{{
+		Target: objects.NewObject(), Path: "scale", Times: []float64{0}, Values: []float64{1, 1, 1}, ItemSize: 3,
+	}}}}
+	if err := exporter.Export(scene, io.Discard); err == nil || !strings.Contains(err.Error(), "not part of the exported scene") {
+		t.Errorf("expected an error for a channel outside of the scene, got %v", err)
+	}
+
+	animation.Channels[0].Values = animation.Channels[0].Values[:4]
+	exporter.Animations = []*loaders.GLTFAnimation{animation}
+	if err := exporter.Export(scene, io.Discard); err == nil {
+		t.Error("expected an error for values that do not fit the item size")
+	}
+
+	exporter.Animations = nil
+	empty := objects.NewMesh(core.NewBufferGeometry(), materials.NewMeshBasicMaterial())
+	scene.Add(empty)
+	if err := exporter.Export(scene, io.Discard); err == nil {
+		t.Error("expected an error for a geometry without positions")
+	}
+
+	errWrite := errors.New("write failed")
+	scene.Remove(empty)
+	if err := exporter.Export(scene, failingWriter{errWrite}); !errors.Is(err, errWrite) {
+		t.Errorf("expected the write error, got %v", err)
+	}
+}
+
+type failingWriter struct {
+	err error
+}
+
+func (w failingWriter) Write([]byte) (int, error) {
+	return 0, w.err
+}
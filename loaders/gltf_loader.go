@@ -0,0 +1,1120 @@
+package loaders
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"image"
+	"io"
+	"io/fs"
+	"math"
+	"path"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/cameras"
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/materials"
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/objects"
+	"github.com/rydrman/three.go/scenes"
+	"github.com/rydrman/three.go/textures"
+)
+
+// GLTFLoader reads glTF 2.0 assets, either as JSON with external or
+// data URI buffers or as a single binary GLB file
+type GLTFLoader struct {
+	// FS opens the external buffers and images of an asset, relative
+	// to the directory of the loaded file. It may be nil if all of the
+	// resources of the assets being loaded are embedded.
+	FS fs.FS
+}
+
+// NewGLTFLoader creates a loader that opens files from fsys
+func NewGLTFLoader(fsys fs.FS) *GLTFLoader {
+
+	return &GLTFLoader{
+		FS: fsys,
+	}
+
+}
+
+// Load reads the named .gltf or .glb file from the file system of this loader
+func (l *GLTFLoader) Load(name string) (*GLTF, error) {
+
+	if l.FS == nil {
+		return nil, fmt.Errorf("loaders: cannot open %q without a file system", name)
+	}
+
+	data, err := fs.ReadFile(l.FS, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.parse(data, path.Dir(name))
+
+}
+
+// Parse reads a glTF asset from r, external
+// URIs are resolved from the root of the file system
+func (l *GLTFLoader) Parse(r io.Reader) (*GLTF, error) {
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.parse(data, ".")
+
+}
+
+func (l *GLTFLoader) parse(data []byte, dir string) (*GLTF, error) {
+
+	p := &gltfParser{
+		fsys: l.FS,
+		dir:  dir,
+
+		buffers:    make(map[int][]byte),
+		accessors:  make(map[int]*gltfAccessorData),
+		textures:   make(map[int]*textures.Texture),
+		materials:  make(map[gltfMaterialKey]*materials.MeshStandardMaterial),
+		geometries: make(map[[2]int]*core.BufferGeometry),
+
+		joints:        make(map[int]bool),
+		skinnedMeshes: make(map[int][]*objects.SkinnedMesh),
+	}
+
+	content := data
+
+	if len(data) >= 4 && binary.LittleEndian.Uint32(data) == glbMagic {
+
+		var err error
+		if content, p.bin, err = splitGLB(data); err != nil {
+			return nil, err
+		}
+
+	}
+
+	if err := json.Unmarshal(content, &p.doc); err != nil {
+		return nil, fmt.Errorf("loaders: glTF: %v", err)
+	}
+
+	if !strings.HasPrefix(p.doc.Asset.Version, "2.") {
+		return nil, fmt.Errorf("loaders: glTF: unsupported version %q", p.doc.Asset.Version)
+	}
+
+	// no extensions are supported yet
+	if len(p.doc.ExtensionsRequired) > 0 {
+		return nil, fmt.Errorf("loaders: glTF: unsupported required extension %q", p.doc.ExtensionsRequired[0])
+	}
+
+	result, err := p.parse()
+	if err != nil {
+		return nil, fmt.Errorf("loaders: glTF: %w", err)
+	}
+
+	return result, nil
+
+}
+
+// gltfAccessorData holds the decoded values of an accessor,
+// normalized integers are already mapped into their float range
+type gltfAccessorData struct {
+	values        []float64
+	itemSize      int
+	componentType int
+}
+
+// gltfMaterialKey identifies a material along with the
+// variations that the primitives using it require
+type gltfMaterialKey struct {
+	index        int
+	vertexColors bool
+	skinning     bool
+	morphTargets bool
+	morphNormals bool
+}
+
+// gltfParser holds the state of loading a single asset,
+// resources are cached so that they are only decoded once
+type gltfParser struct {
+	doc  gltfDocument
+	fsys fs.FS
+	dir  string
+	bin  []byte
+
+	buffers    map[int][]byte
+	accessors  map[int]*gltfAccessorData
+	textures   map[int]*textures.Texture
+	materials  map[gltfMaterialKey]*materials.MeshStandardMaterial
+	geometries map[[2]int]*core.BufferGeometry
+
+	joints        map[int]bool
+	skinnedMeshes map[int][]*objects.SkinnedMesh
+
+	result *GLTF
+}
+
+func (p *gltfParser) parse() (*GLTF, error) {
+
+	p.result = &GLTF{
+		Nodes: make([]objects.Node, len(p.doc.Nodes)),
+	}
+
+	for i, skin := range p.doc.Skins {
+		for _, joint := range skin.Joints {
+
+			if joint < 0 || joint >= len(p.doc.Nodes) {
+				return nil, fmt.Errorf("skin %d: joint node %d does not exist", i, joint)
+			}
+			p.joints[joint] = true
+
+		}
+	}
+
+	for i := range p.doc.Nodes {
+
+		node, err := p.createNode(i)
+		if err != nil {
+			return nil, fmt.Errorf("node %d: %w", i, err)
+		}
+		p.result.Nodes[i] = node
+
+	}
+
+	isChild := make([]bool, len(p.doc.Nodes))
+
+	for i, node := range p.doc.Nodes {
+		for _, child := range node.Children {
+
+			if child < 0 || child >= len(p.doc.Nodes) {
+				return nil, fmt.Errorf("node %d: child node %d does not exist", i, child)
+			}
+			if isChild[child] || child == i {
+				return nil, fmt.Errorf("node %d: node %d has more than one parent", i, child)
+			}
+
+			isChild[child] = true
+
+		}
+	}
+
+	// with a single parent each, nodes that cannot be reached from
+	// the nodes without a parent must be part of a cycle
+	reached := make([]bool, len(p.doc.Nodes))
+	var reach func(i int)
+	reach = func(i int) {
+		reached[i] = true
+		for _, child := range p.doc.Nodes[i].Children {
+			reach(child)
+		}
+	}
+	for i := range p.doc.Nodes {
+		if !isChild[i] {
+			reach(i)
+		}
+	}
+	for i := range p.doc.Nodes {
+		if !reached[i] {
+			return nil, fmt.Errorf("node %d: node is its own ancestor", i)
+		}
+	}
+
+	for i, node := range p.doc.Nodes {
+		for _, child := range node.Children {
+			p.result.Nodes[i].Add(p.result.Nodes[child])
+		}
+	}
+
+	if err := p.createScenes(isChild); err != nil {
+		return nil, err
+	}
+
+	if err := p.bindSkins(); err != nil {
+		return nil, err
+	}
+
+	for i := range p.doc.Animations {
+
+		animation, err := p.loadAnimation(i)
+		if err != nil {
+			return nil, fmt.Errorf("animation %d: %w", i, err)
+		}
+		p.result.Animations = append(p.result.Animations, animation)
+
+	}
+
+	return p.result, nil
+
+}
+
+// createScenes adds the root nodes of each scene to a new scene, an asset
+// without scenes gets a single scene holding all of its root nodes
+func (p *gltfParser) createScenes(isChild []bool) error {
+
+	docScenes := p.doc.Scenes
+
+	if len(docScenes) == 0 && len(p.doc.Nodes) > 0 {
+
+		var roots []int
+		for i := range p.doc.Nodes {
+			if !isChild[i] {
+				roots = append(roots, i)
+			}
+		}
+
+		docScenes = []gltfScene{{Nodes: roots}}
+
+	}
+
+	for i, s := range docScenes {
+
+		scene := scenes.NewScene()
+		scene.Name = s.Name
+
+		for _, n := range s.Nodes {
+
+			if n < 0 || n >= len(p.doc.Nodes) {
+				return fmt.Errorf("scene %d: node %d does not exist", i, n)
+			}
+			if isChild[n] {
+				return fmt.Errorf("scene %d: node %d is not a root node", i, n)
+			}
+			if p.result.Nodes[n].GetParent() != nil {
+				glog.Warningf("loaders: glTF node %d is used by more than one scene, it is only added to the first", n)
+				continue
+			}
+
+			scene.Add(p.result.Nodes[n])
+
+		}
+
+		scene.UpdateMatrixWorld(true)
+		p.result.Scenes = append(p.result.Scenes, scene)
+
+	}
+
+	if p.doc.Scene != nil {
+
+		if *p.doc.Scene < 0 || *p.doc.Scene >= len(p.result.Scenes) {
+			return fmt.Errorf("default scene %d does not exist", *p.doc.Scene)
+		}
+		p.result.Scene = p.result.Scenes[*p.doc.Scene]
+
+	} else if len(p.result.Scenes) > 0 {
+
+		p.result.Scene = p.result.Scenes[0]
+
+	}
+
+	return nil
+
+}
+
+func (p *gltfParser) createNode(index int) (objects.Node, error) {
+
+	n := p.doc.Nodes[index]
+
+	var parts []objects.Node
+
+	if n.Mesh != nil {
+
+		mesh, err := p.loadMesh(*n.Mesh, index)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, mesh)
+
+	}
+
+	if n.Camera != nil {
+
+		camera, err := p.loadCamera(*n.Camera)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, camera)
+
+	}
+
+	var node objects.Node
+
+	switch {
+
+	case p.joints[index]:
+		node = objects.NewBone()
+
+	case len(parts) == 1:
+		node = parts[0]
+		parts = nil
+
+	default:
+		node = objects.NewObject()
+
+	}
+
+	for _, part := range parts {
+		node.Add(part)
+	}
+
+	object := objects.ObjectOf(node)
+	object.Name = n.Name
+
+	if len(n.Matrix) > 0 {
+
+		if len(n.Matrix) != 16 {
+			return nil, fmt.Errorf("matrix has %d values, expected 16", len(n.Matrix))
+		}
+		object.ApplyMatrix(math3.NewMatrix4().FromArray(n.Matrix))
+
+	}
+
+	if len(n.Translation) > 0 {
+
+		if len(n.Translation) != 3 {
+			return nil, fmt.Errorf("translation has %d values, expected 3", len(n.Translation))
+		}
+		object.Position.Set(n.Translation[0], n.Translation[1], n.Translation[2])
+
+	}
+
+	if len(n.Rotation) > 0 {
+
+		if len(n.Rotation) != 4 {
+			return nil, fmt.Errorf("rotation has %d values, expected 4", len(n.Rotation))
+		}
+		object.Quaternion.Set(n.Rotation[0], n.Rotation[1], n.Rotation[2], n.Rotation[3])
+
+	}
+
+	if len(n.Scale) > 0 {
+
+		if len(n.Scale) != 3 {
+			return nil, fmt.Errorf("scale has %d values, expected 3", len(n.Scale))
+		}
+		object.Scale.Set(n.Scale[0], n.Scale[1], n.Scale[2])
+
+	}
+
+	return node, nil
+
+}
+
+// loadMesh creates the objects that draw a mesh for the given node,
+// meshes with more than one primitive are grouped under a new object
+func (p *gltfParser) loadMesh(index, node int) (objects.Node, error) {
+
+	if index < 0 || index >= len(p.doc.Meshes) {
+		return nil, fmt.Errorf("mesh %d does not exist", index)
+	}
+
+	m := p.doc.Meshes[index]
+	skinned := p.doc.Nodes[node].Skin != nil
+
+	weights := m.Weights
+	if len(p.doc.Nodes[node].Weights) > 0 {
+		weights = p.doc.Nodes[node].Weights
+	}
+
+	var parts []objects.Node
+
+	for i, primitive := range m.Primitives {
+
+		geometry, err := p.loadGeometry(index, i)
+		if err != nil {
+			return nil, err
+		}
+
+		material, err := p.loadMaterial(primitive, geometry, skinned)
+		if err != nil {
+			return nil, fmt.Errorf("mesh %d: primitive %d: %w", index, i, err)
+		}
+
+		mode := gltfTriangles
+		if primitive.Mode != nil {
+			mode = *primitive.Mode
+		}
+
+		var part objects.Node
+
+		switch mode {
+
+		case gltfTriangles, gltfTriangleStrip, gltfTriangleFan:
+
+			var mesh *objects.Mesh
+
+			if skinned && geometry.GetAttribute("skinIndex") != nil {
+
+				skinnedMesh := objects.NewSkinnedMesh(geometry, material)
+				p.skinnedMeshes[node] = append(p.skinnedMeshes[node], skinnedMesh)
+				mesh, part = skinnedMesh.Mesh, skinnedMesh
+
+			} else {
+
+				mesh = objects.NewMesh(geometry, material)
+				part = mesh
+
+			}
+
+			switch mode {
+			case gltfTriangleStrip:
+				mesh.SetDrawMode(three.TriangleStripDrawMode)
+			case gltfTriangleFan:
+				mesh.SetDrawMode(three.TriangleFanDrawMode)
+			}
+
+			if len(geometry.MorphAttributes) > 0 {
+				mesh.UpdateMorphTargets()
+				copy(mesh.MorphTargetInfluences, weights)
+			}
+
+		case gltfPoints:
+
+			points := materials.NewPointsMaterial()
+			points.Color.Copy(material.Color)
+			points.VertexColors = material.VertexColors
+			part = objects.NewPoints(geometry, points)
+
+		case gltfLines, gltfLineLoop, gltfLineStrip:
+
+			lines := materials.NewLineBasicMaterial()
+			lines.Color.Copy(material.Color)
+			lines.VertexColors = material.VertexColors
+
+			switch mode {
+			case gltfLines:
+				part = objects.NewLineSegments(geometry, lines)
+			case gltfLineLoop:
+				part = objects.NewLineLoop(geometry, lines)
+			default:
+				part = objects.NewLine(geometry, lines)
+			}
+
+		default:
+
+			return nil, fmt.Errorf("mesh %d: primitive %d: unknown mode %d", index, i, mode)
+
+		}
+
+		objects.ObjectOf(part).Name = m.Name
+		parts = append(parts, part)
+
+	}
+
+	if len(parts) == 1 {
+		return parts[0], nil
+	}
+
+	group := objects.NewObject()
+	group.Name = m.Name
+
+	for _, part := range parts {
+		group.Add(part)
+	}
+
+	return group, nil
+
+}
+
+// loadGeometry creates the geometry of a primitive, which is
+// shared by every node that uses the same mesh
+func (p *gltfParser) loadGeometry(mesh, index int) (*core.BufferGeometry, error) {
+
+	key := [2]int{mesh, index}
+	if geometry, ok := p.geometries[key]; ok {
+		return geometry, nil
+	}
+
+	m := p.doc.Meshes[mesh]
+	primitive := m.Primitives[index]
+	geometry := core.NewBufferGeometry()
+
+	for semantic, accessor := range primitive.Attributes {
+
+		data, err := p.readAccessor(accessor)
+		if err != nil {
+			return nil, err
+		}
+
+		name, ok := gltfAttributeNames[semantic]
+		if !ok {
+			name = semantic
+		}
+
+		geometry.AddAttribute(name, core.NewFloat32BufferAttribute(toFloat32(data.values), data.itemSize))
+
+	}
+
+	if primitive.Indices != nil {
+
+		data, err := p.readAccessor(*primitive.Indices)
+		if err != nil {
+			return nil, err
+		}
+
+		if data.itemSize != 1 || data.componentType == gltfFloat || data.componentType == gltfByte || data.componentType == gltfShort {
+			return nil, &GLTFAccessorError{*primitive.Indices, "indices must be unsigned integer scalars"}
+		}
+
+		vertices := math.MaxInt32
+		if position := geometry.GetAttribute("position"); position != nil {
+			vertices = position.Count()
+		}
+
+		indices := make([]int, len(data.values))
+		for i, v := range data.values {
+
+			if int(v) >= vertices {
+				return nil, &GLTFAccessorError{*primitive.Indices, fmt.Sprintf("index %d is out of range of %d vertices", int(v), vertices)}
+			}
+			indices[i] = int(v)
+
+		}
+
+		geometry.SetIndex(core.NewIndexAttribute(indices))
+
+	}
+
+	if err := p.loadMorphTargets(geometry, m, primitive); err != nil {
+		return nil, err
+	}
+
+	p.geometries[key] = geometry
+
+	return geometry, nil
+
+}
+
+// loadMorphTargets adds the morph targets of a primitive to its
+// geometry, glTF targets hold displacements while morph attributes
+// hold absolute values so each target is added to its base attribute
+func (p *gltfParser) loadMorphTargets(geometry *core.BufferGeometry, mesh gltfMesh, primitive gltfPrimitive) error {
+
+	var names []string
+	if mesh.Extras != nil {
+		names = mesh.Extras.TargetNames
+	}
+
+	for _, semantic := range []string{"POSITION", "NORMAL"} {
+
+		used := false
+		for _, target := range primitive.Targets {
+			_, ok := target[semantic]
+			used = used || ok
+		}
+
+		if !used {
+			continue
+		}
+
+		name := gltfAttributeNames[semantic]
+		base := geometry.GetAttribute(name)
+
+		for i, target := range primitive.Targets {
+
+			if base == nil {
+				return fmt.Errorf("morph target %d has a %s without a base attribute", i, semantic)
+			}
+
+			values := base.ToFloat64()
+
+			if accessor, ok := target[semantic]; ok {
+
+				data, err := p.readAccessor(accessor)
+				if err != nil {
+					return err
+				}
+				if len(data.values) != len(values) {
+					return &GLTFAccessorError{accessor, fmt.Sprintf("morph target has %d values, expected %d", len(data.values), len(values))}
+				}
+
+				for j := range values {
+					values[j] += data.values[j]
+				}
+
+			}
+
+			morph := core.NewFloat32BufferAttribute(toFloat32(values), base.ItemSize)
+			morph.Name = fmt.Sprintf("morphTarget%d", i)
+			if i < len(names) {
+				morph.Name = names[i]
+			}
+
+			geometry.MorphAttributes[name] = append(geometry.MorphAttributes[name], morph)
+
+		}
+
+	}
+
+	return nil
+
+}
+
+func toFloat32(values []float64) []float32 {
+
+	array := make([]float32, len(values))
+	for i, v := range values {
+		array[i] = float32(v)
+	}
+
+	return array
+
+}
+
+// loadMaterial returns the material of a primitive, cloned
+// for the vertex colors, skinning and morphs that it needs
+func (p *gltfParser) loadMaterial(primitive gltfPrimitive, geometry *core.BufferGeometry, skinned bool) (*materials.MeshStandardMaterial, error) {
+
+	key := gltfMaterialKey{index: -1}
+	if primitive.Material != nil {
+		key.index = *primitive.Material
+	}
+
+	base, ok := p.materials[key]
+	if !ok {
+
+		var err error
+		if base, err = p.createMaterial(key.index); err != nil {
+			return nil, err
+		}
+		p.materials[key] = base
+
+	}
+
+	key.vertexColors = geometry.GetAttribute("color") != nil
+	key.skinning = skinned && geometry.GetAttribute("skinIndex") != nil
+	key.morphTargets = len(geometry.MorphAttributes["position"]) > 0
+	key.morphNormals = len(geometry.MorphAttributes["normal"]) > 0
+
+	if material, ok := p.materials[key]; ok {
+		return material, nil
+	}
+
+	material := base.Clone()
+	if key.vertexColors {
+		material.VertexColors = three.VertexColors
+	}
+	material.Skinning = key.skinning
+	material.MorphTargets = key.morphTargets
+	material.MorphNormals = key.morphNormals
+
+	p.materials[key] = material
+
+	return material, nil
+
+}
+
+// createMaterial creates a standard material from the metallic-roughness
+// parameters of a glTF material, index -1 gives the default material
+func (p *gltfParser) createMaterial(index int) (*materials.MeshStandardMaterial, error) {
+
+	material := materials.NewMeshStandardMaterial()
+	material.Metalness = 1
+	material.Roughness = 1
+
+	if index < 0 {
+		return material, nil
+	}
+
+	if index >= len(p.doc.Materials) {
+		return nil, fmt.Errorf("material %d does not exist", index)
+	}
+
+	m := p.doc.Materials[index]
+	material.Name = m.Name
+
+	var err error
+
+	if pbr := m.PBRMetallicRoughness; pbr != nil {
+
+		if f := pbr.BaseColorFactor; len(f) == 4 {
+			material.Color.SetRGB(f[0], f[1], f[2])
+			material.Opacity = f[3]
+		}
+		if pbr.MetallicFactor != nil {
+			material.Metalness = *pbr.MetallicFactor
+		}
+		if pbr.RoughnessFactor != nil {
+			material.Roughness = *pbr.RoughnessFactor
+		}
+
+		if material.Map, err = p.textureInfo(pbr.BaseColorTexture); err != nil {
+			return nil, err
+		}
+
+		// roughness is stored in the green channel and metalness in the blue
+		if material.RoughnessMap, err = p.textureInfo(pbr.MetallicRoughnessTexture); err != nil {
+			return nil, err
+		}
+		material.MetalnessMap = material.RoughnessMap
+
+	}
+
+	if material.NormalMap, err = p.textureInfo(m.NormalTexture); err != nil {
+		return nil, err
+	}
+	if m.NormalTexture != nil && m.NormalTexture.Scale != nil {
+		material.NormalScale.Set(*m.NormalTexture.Scale, *m.NormalTexture.Scale)
+	}
+
+	if material.AoMap, err = p.textureInfo(m.OcclusionTexture); err != nil {
+		return nil, err
+	}
+	if m.OcclusionTexture != nil && m.OcclusionTexture.Strength != nil {
+		material.AoMapIntensity = *m.OcclusionTexture.Strength
+	}
+
+	if f := m.EmissiveFactor; len(f) == 3 {
+		material.Emissive.SetRGB(f[0], f[1], f[2])
+	}
+	if material.EmissiveMap, err = p.textureInfo(m.EmissiveTexture); err != nil {
+		return nil, err
+	}
+
+	switch m.AlphaMode {
+
+	case "", "OPAQUE":
+		material.Opacity = 1
+
+	case "BLEND":
+		material.Transparent = true
+
+	case "MASK":
+		material.AlphaTest = 0.5
+		if m.AlphaCutoff != nil {
+			material.AlphaTest = *m.AlphaCutoff
+		}
+
+	default:
+		return nil, fmt.Errorf("material %d: unknown alpha mode %q", index, m.AlphaMode)
+
+	}
+
+	if m.DoubleSided {
+		material.Side = three.DoubleSide
+	}
+
+	return material, nil
+
+}
+
+func (p *gltfParser) textureInfo(info *gltfTextureInfo) (*textures.Texture, error) {
+
+	if info == nil {
+		return nil, nil
+	}
+
+	if info.TexCoord != 0 {
+		glog.Warningf("loaders: glTF texture %d uses unsupported texture coordinates %d", info.Index, info.TexCoord)
+	}
+
+	return p.loadTexture(info.Index)
+
+}
+
+// gltfFilters maps glTF sampler filters onto the three.*Filter constants
+var gltfFilters = map[int]int{
+	gltfNearest:              three.NearestFilter,
+	gltfLinear:               three.LinearFilter,
+	gltfNearestMipmapNearest: three.NearestMipMapNearestFilter,
+	gltfLinearMipmapNearest:  three.LinearMipMapNearestFilter,
+	gltfNearestMipmapLinear:  three.NearestMipMapLinearFilter,
+	gltfLinearMipmapLinear:   three.LinearMipMapLinearFilter,
+}
+
+// gltfWrappings maps glTF sampler wrap modes onto the three.*Wrapping constants
+var gltfWrappings = map[int]int{
+	gltfClampToEdge:    three.ClampToEdgeWrapping,
+	gltfMirroredRepeat: three.MirroredRepeatWrapping,
+	gltfRepeat:         three.RepeatWrapping,
+}
+
+// loadTexture creates a texture along with its sampler settings, a
+// texture whose image cannot be read is kept without an image
+func (p *gltfParser) loadTexture(index int) (*textures.Texture, error) {
+
+	if texture, ok := p.textures[index]; ok {
+		return texture, nil
+	}
+
+	if index < 0 || index >= len(p.doc.Textures) {
+		return nil, fmt.Errorf("texture %d does not exist", index)
+	}
+
+	t := p.doc.Textures[index]
+
+	texture := textures.NewTexture(nil)
+	texture.Name = t.Name
+	texture.WrapS = three.RepeatWrapping
+	texture.WrapT = three.RepeatWrapping
+	// uv coordinates of glTF start at the top of the image
+	texture.FlipY = false
+
+	if t.Source != nil {
+
+		if *t.Source < 0 || *t.Source >= len(p.doc.Images) {
+			return nil, fmt.Errorf("texture %d: image %d does not exist", index, *t.Source)
+		}
+
+		img, err := p.loadImage(p.doc.Images[*t.Source])
+		if err != nil {
+			glog.Warningf("loaders: glTF image %d: %v", *t.Source, err)
+		}
+
+		texture.Image = img
+		texture.SourceFile = p.doc.Images[*t.Source].URI
+		if strings.HasPrefix(texture.SourceFile, "data:") {
+			texture.SourceFile = ""
+		}
+
+	}
+
+	if t.Sampler != nil {
+
+		if *t.Sampler < 0 || *t.Sampler >= len(p.doc.Samplers) {
+			return nil, fmt.Errorf("texture %d: sampler %d does not exist", index, *t.Sampler)
+		}
+
+		s := p.doc.Samplers[*t.Sampler]
+
+		if filter, ok := gltfFilters[s.MagFilter]; ok {
+			texture.MagFilter = filter
+		}
+		if filter, ok := gltfFilters[s.MinFilter]; ok {
+			texture.MinFilter = filter
+		}
+		if s.WrapS != nil {
+			if wrap, ok := gltfWrappings[*s.WrapS]; ok {
+				texture.WrapS = wrap
+			}
+		}
+		if s.WrapT != nil {
+			if wrap, ok := gltfWrappings[*s.WrapT]; ok {
+				texture.WrapT = wrap
+			}
+		}
+
+	}
+
+	p.textures[index] = texture
+
+	return texture, nil
+
+}
+
+func (p *gltfParser) loadImage(i gltfImage) (image.Image, error) {
+
+	var data []byte
+	var err error
+
+	switch {
+	case i.BufferView != nil:
+		data, err = p.readBufferView(*i.BufferView)
+	case strings.HasPrefix(i.URI, "data:"):
+		data, err = decodeDataURI(i.URI)
+	default:
+		data, err = p.readFile(i.URI)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+
+	return img, err
+
+}
+
+func (p *gltfParser) loadCamera(index int) (objects.Node, error) {
+
+	if index < 0 || index >= len(p.doc.Cameras) {
+		return nil, fmt.Errorf("camera %d does not exist", index)
+	}
+
+	c := p.doc.Cameras[index]
+
+	var camera interface {
+		objects.Node
+		math3.Projector
+	}
+
+	switch {
+
+	case c.Type == "perspective" && c.Perspective != nil:
+
+		aspect := 1.0
+		if c.Perspective.AspectRatio != nil {
+			aspect = *c.Perspective.AspectRatio
+		}
+
+		// an infinite projection uses a distant far plane instead
+		far := 2e6
+		if c.Perspective.ZFar != nil {
+			far = *c.Perspective.ZFar
+		}
+
+		perspective := cameras.NewPerspectiveCamera(c.Perspective.YFov*math3.Rad2Deg, aspect, c.Perspective.ZNear, far)
+		perspective.Name = c.Name
+		camera = perspective
+
+	case c.Type == "orthographic" && c.Orthographic != nil:
+
+		o := c.Orthographic
+
+		orthographic := cameras.NewOrthographicCamera(-o.XMag, o.XMag, o.YMag, -o.YMag, o.ZNear, o.ZFar)
+		orthographic.Name = c.Name
+		camera = orthographic
+
+	default:
+
+		return nil, fmt.Errorf("camera %d has an invalid type %q", index, c.Type)
+
+	}
+
+	p.result.Cameras = append(p.result.Cameras, camera)
+
+	return camera, nil
+
+}
+
+// bindSkins binds the skinned meshes of each node to a skeleton
+// of its joints, using the world matrices of the default pose
+func (p *gltfParser) bindSkins() error {
+
+	for i, n := range p.doc.Nodes {
+
+		if n.Skin == nil {
+			continue
+		}
+		if *n.Skin < 0 || *n.Skin >= len(p.doc.Skins) {
+			return fmt.Errorf("node %d: skin %d does not exist", i, *n.Skin)
+		}
+
+		skin := p.doc.Skins[*n.Skin]
+
+		bones := make([]*objects.Bone, len(skin.Joints))
+		inverses := make([]*math3.Matrix4, len(skin.Joints))
+
+		for j, joint := range skin.Joints {
+			bones[j] = p.result.Nodes[joint].(*objects.Bone)
+			inverses[j] = math3.NewMatrix4()
+		}
+
+		if skin.InverseBindMatrices != nil {
+
+			data, err := p.readAccessor(*skin.InverseBindMatrices)
+			if err != nil {
+				return err
+			}
+			if data.itemSize != 16 || len(data.values) < len(bones)*16 {
+				return &GLTFAccessorError{*skin.InverseBindMatrices, fmt.Sprintf("expected a matrix for each of %d joints", len(bones))}
+			}
+
+			for j := range inverses {
+				inverses[j].FromArray(data.values[j*16 : j*16+16])
+			}
+
+		}
+
+		skeleton := objects.NewSkeleton(bones, inverses)
+
+		for _, mesh := range p.skinnedMeshes[i] {
+			mesh.Bind(skeleton, nil)
+		}
+
+	}
+
+	return nil
+
+}
+
+// gltfPathSizes is the number of values for each keyframe of the
+// paths that can be animated, weights depend on the target
+var gltfPathSizes = map[string]int{
+	"translation": 3,
+	"rotation":    4,
+	"scale":       3,
+	"weights":     0,
+}
+
+func (p *gltfParser) loadAnimation(index int) (*GLTFAnimation, error) {
+
+	a := p.doc.Animations[index]
+	animation := &GLTFAnimation{Name: a.Name}
+
+	for i, c := range a.Channels {
+
+		if c.Target.Node == nil {
+			continue
+		}
+		if *c.Target.Node < 0 || *c.Target.Node >= len(p.doc.Nodes) {
+			return nil, fmt.Errorf("channel %d: node %d does not exist", i, *c.Target.Node)
+		}
+		if c.Sampler < 0 || c.Sampler >= len(a.Samplers) {
+			return nil, fmt.Errorf("channel %d: sampler %d does not exist", i, c.Sampler)
+		}
+
+		itemSize, ok := gltfPathSizes[c.Target.Path]
+		if !ok {
+			return nil, fmt.Errorf("channel %d: unknown path %q", i, c.Target.Path)
+		}
+
+		s := a.Samplers[c.Sampler]
+
+		interpolation := s.Interpolation
+		if interpolation == "" {
+			interpolation = "LINEAR"
+		}
+
+		perKeyframe := 1
+		switch interpolation {
+		case "LINEAR", "STEP":
+		case "CUBICSPLINE":
+			perKeyframe = 3
+		default:
+			return nil, fmt.Errorf("channel %d: unknown interpolation %q", i, interpolation)
+		}
+
+		input, err := p.readAccessor(s.Input)
+		if err != nil {
+			return nil, err
+		}
+		if input.itemSize != 1 {
+			return nil, &GLTFAccessorError{s.Input, "animation input must be scalar"}
+		}
+
+		output, err := p.readAccessor(s.Output)
+		if err != nil {
+			return nil, err
+		}
+
+		keyframes := len(input.values) * perKeyframe
+		if itemSize == 0 && len(output.values)%keyframes == 0 {
+			itemSize = len(output.values) / keyframes
+		}
+		if itemSize == 0 || len(output.values) != keyframes*itemSize {
+			return nil, &GLTFAccessorError{s.Output, fmt.Sprintf("animation output does not match the %d keyframes of its input", len(input.values))}
+		}
+
+		animation.Channels = append(animation.Channels, &GLTFChannel{
+			Target:        p.result.Nodes[*c.Target.Node],
+			Path:          c.Target.Path,
+			Interpolation: interpolation,
+
+			Times:    input.values,
+			Values:   output.values,
+			ItemSize: itemSize,
+		})
+
+		if last := input.values[len(input.values)-1]; last > animation.Duration {
+			animation.Duration = last
+		}
+
+	}
+
+	return animation, nil
+
+}
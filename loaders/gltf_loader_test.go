@@ -0,0 +1,363 @@
+package loaders_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/cameras"
+	"github.com/rydrman/three.go/loaders"
+	"github.com/rydrman/three.go/materials"
+	"github.com/rydrman/three.go/objects"
+)
+
+// testGLTFBuffer returns the binary data used by testGLTF
+func testGLTFBuffer() []byte {
+	var b bytes.Buffer
+	write := func(values ...interface{}) {
+		for _, v := range values {
+			binary.Write(&b, binary.LittleEndian, v)
+		}
+	}
+	// 0: positions
+	write(float32(0), float32(0), float32(0), float32(1), float32(0), float32(0), float32(0), float32(1), float32(0))
+	// 36: indices, padded to 4 bytes
+	write(uint16(0), uint16(1), uint16(2), uint16(0))
+	// 44: morph target displacements
+	write(float32(0), float32(0), float32(1), float32(0), float32(0), float32(1), float32(0), float32(0), float32(1))
+	// 80: joints
+	for i := 0; i < 3; i++ {
+		write(uint16(0), uint16(1), uint16(0), uint16(0))
+	}
+	// 104: weights
+	for i := 0; i < 3; i++ {
+		write(float32(0.5), float32(0.5), float32(0), float32(0))
+	}
+	// 152: inverse bind matrices, the second joint is one unit up
+	write(float32(1), float32(0), float32(0), float32(0), float32(0), float32(1), float32(0), float32(0), float32(0), float32(0), float32(1), float32(0), float32(0), float32(0), float32(0), float32(1))
+	write(float32(1), float32(0), float32(0), float32(0), float32(0), float32(1), float32(0), float32(0), float32(0), float32(0), float32(1), float32(0), float32(0), float32(-1), float32(0), float32(1))
+	// 280: animation times and translations
+	write(float32(0), float32(1))
+	write(float32(0), float32(0), float32(0), float32(0), float32(2), float32(0))
+	return b.Bytes()
+}
+
+type object map[string]interface{}
+
+// testGLTF returns a document exercising most of the loader, its
+// buffer is given the uri if it is not empty
+func testGLTF(uri string) object {
+	buffer := object{"byteLength": 312}
+	if uri != "" {
+		buffer["uri"] = uri
+	}
+	view := func(offset, length int) object {
+		return object{"buffer": 0, "byteOffset": offset, "byteLength": length}
+	}
+	accessor := func(view, componentType, count int, kind string) object {
+		return object{"bufferView": view, "componentType": componentType, "count": count, "type": kind}
+	}
+	return object{
+		"asset":  object{"version": "2.0"},
+		"scene":  0,
+		"scenes": []object{{"name": "main", "nodes": []int{0}}},
+		"nodes": []object{
+			{"name": "root", "children": []int{1, 2, 4}, "translation": []float64{1, 2, 3}},
+			{"name": "body", "mesh": 0, "skin": 0},
+			{"name": "hip", "children": []int{3}},
+			{"name": "knee", "translation": []float64{0, 1, 0}},
+			{"name": "eye", "camera": 0, "matrix": []float64{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 5, 1}},
+		},
+		"meshes": []object{{
+			"name": "triangle",
+			"primitives": []object{{
+				"attributes": object{"POSITION": 0, "JOINTS_0": 3, "WEIGHTS_0": 4},
+				"indices":    1,
+				"material":   0,
+				"targets":    []object{{"POSITION": 2}},
+			}},
+			"weights": []float64{0.5},
+			"extras":  object{"targetNames": []string{"raise"}},
+		}},
+		"materials": []object{{
+			"name":                 "red",
+			"pbrMetallicRoughness": object{"baseColorFactor": []float64{1, 0, 0, 0.5}, "metallicFactor": 0.25},
+			"alphaMode":            "BLEND",
+			"doubleSided":          true,
+		}},
+		"cameras": []object{{
+			"type":        "perspective",
+			"perspective": object{"yfov": 1, "znear": 0.1, "zfar": 100, "aspectRatio": 2},
+		}},
+		"skins": []object{{"joints": []int{2, 3}, "inverseBindMatrices": 5}},
+		"animations": []object{{
+			"name":     "lift",
+			"channels": []object{{"sampler": 0, "target": object{"node": 0, "path": "translation"}}},
+			"samplers": []object{{"input": 6, "output": 7}},
+		}},
+		"accessors": []object{
+			accessor(0, 5126, 3, "VEC3"),
+			accessor(1, 5123, 3, "SCALAR"),
+			accessor(2, 5126, 3, "VEC3"),
+			accessor(3, 5123, 3, "VEC4"),
+			accessor(4, 5126, 3, "VEC4"),
+			accessor(5, 5126, 2, "MAT4"),
+			accessor(6, 5126, 2, "SCALAR"),
+			accessor(7, 5126, 2, "VEC3"),
+		},
+		"bufferViews": []object{
+			view(0, 36), view(36, 6), view(44, 36), view(80, 24),
+			view(104, 48), view(152, 128), view(280, 8), view(288, 24),
+		},
+		"buffers": []object{buffer},
+	}
+}
+
+func encodeJSON(t *testing.T, doc object) []byte {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func encodeGLB(t *testing.T, doc object, bin []byte) []byte {
+	content := encodeJSON(t, doc)
+	for len(content)%4 != 0 {
+		content = append(content, ' ')
+	}
+	var b bytes.Buffer
+	binary.Write(&b, binary.LittleEndian, []uint32{0x46546c67, 2, uint32(12 + 8 + len(content) + 8 + len(bin))})
+	binary.Write(&b, binary.LittleEndian, []uint32{uint32(len(content)), 0x4e4f534a})
+	b.Write(content)
+	binary.Write(&b, binary.LittleEndian, []uint32{uint32(len(bin)), 0x004e4942})
+	b.Write(bin)
+	return b.Bytes()
+}
+
+func checkTestGLTF(t *testing.T, gltf *loaders.GLTF) {
+	if gltf.Scene == nil || gltf.Scene.Name != "main" {
+		t.Fatal("expected the default scene to be loaded")
+	}
+
+	root := gltf.Nodes[0].(*objects.Object)
+	if root.GetParent() == nil || len(root.GetChildren()) != 3 {
+		t.Fatal("expected the root node to be in the scene with three children")
+	}
+	if root.Position.X != 1 || root.Position.Y != 2 || root.Position.Z != 3 {
+		t.Errorf("expected the node translation, got %v", root.Position)
+	}
+
+	body, ok := gltf.Nodes[1].(*objects.SkinnedMesh)
+	if !ok {
+		t.Fatalf("expected a skinned mesh, got %T", gltf.Nodes[1])
+	}
+	if body.Name != "body" || body.Geometry.Index.Count() != 3 {
+		t.Error("expected the named and indexed mesh")
+	}
+	if body.Geometry.GetAttribute("skinIndex").GetY(0) != 1 || body.Geometry.GetAttribute("skinWeight").GetX(2) != 0.5 {
+		t.Error("expected the joints and weights attributes")
+	}
+
+	morphs := body.Geometry.MorphAttributes["position"]
+	if len(morphs) != 1 || morphs[0].GetX(1) != 1 || morphs[0].GetZ(1) != 1 {
+		t.Fatal("expected the morph target to be added to the base positions")
+	}
+	if len(body.MorphTargetInfluences) != 1 || body.MorphTargetInfluences[0] != 0.5 || body.MorphTargetDictionary["raise"] != 0 {
+		t.Errorf("expected the morph weights and names, got %v and %v", body.MorphTargetInfluences, body.MorphTargetDictionary)
+	}
+
+	if body.Skeleton == nil || len(body.Skeleton.Bones) != 2 || body.Skeleton.Bones[1].Name != "knee" {
+		t.Fatal("expected the mesh to be bound to a skeleton of the joints")
+	}
+	// the inverse bind matrices ignore the translation of the root node
+	body.Skeleton.Update()
+	offsets := body.Skeleton.BoneMatrices
+	if offsets[12] != 1 || offsets[13] != 2 || offsets[14] != 3 || offsets[28] != 1 || offsets[29] != 2 || offsets[30] != 3 {
+		t.Errorf("expected both bones to be offset by the root node, got %v", offsets)
+	}
+
+	material := body.Material.(*materials.MeshStandardMaterial)
+	if material.Name != "red" || material.Color.R != 1 || material.Color.G != 0 || material.Opacity != 0.5 {
+		t.Errorf("expected the base color factor, got %v with opacity %f", material.Color, material.Opacity)
+	}
+	if material.Metalness != 0.25 || material.Roughness != 1 {
+		t.Errorf("expected the metallic and default roughness factors, got %f and %f", material.Metalness, material.Roughness)
+	}
+	if !material.Transparent || material.Side != three.DoubleSide || !material.Skinning || !material.MorphTargets {
+		t.Error("expected a transparent, double sided, skinned and morphed material")
+	}
+
+	if len(gltf.Cameras) != 1 {
+		t.Fatal("expected one camera")
+	}
+	camera := gltf.Cameras[0].(*cameras.PerspectiveCamera)
+	if math.Abs(camera.Fov-57.29578) > 1e-4 || camera.Aspect != 2 || camera.Far != 100 {
+		t.Errorf("unexpected camera fov %f, aspect %f or far %f", camera.Fov, camera.Aspect, camera.Far)
+	}
+	if camera.Position.Z != 5 || camera.Name != "eye" {
+		t.Error("expected the camera node to be positioned by its matrix")
+	}
+
+	if len(gltf.Animations) != 1 {
+		t.Fatal("expected one animation")
+	}
+	animation := gltf.Animations[0]
+	channel := animation.Channels[0]
+	if animation.Name != "lift" || animation.Duration != 1 || channel.Target != gltf.Nodes[0] {
+		t.Error("expected the animation to target the root node")
+	}
+	if channel.Interpolation != "LINEAR" || channel.ItemSize != 3 || channel.Values[4] != 2 {
+		t.Errorf("unexpected channel %+v", channel)
+	}
+}
+
+func TestGLTFLoader_DataURI(t *testing.T) {
+	uri := "data:application/octet-stream;base64," + base64.StdEncoding.EncodeToString(testGLTFBuffer())
+	gltf, err := loaders.NewGLTFLoader(nil).Parse(bytes.NewReader(encodeJSON(t, testGLTF(uri))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTestGLTF(t, gltf)
+}
+
+func TestGLTFLoader_ExternalBuffer(t *testing.T) {
+	fsys := fstest.MapFS{
+		"assets/model.gltf":     {Data: encodeJSON(t, testGLTF("model%20data.bin"))},
+		"assets/model data.bin": {Data: testGLTFBuffer()},
+		"assets/escape.gltf":    {Data: encodeJSON(t, testGLTF("../secret.bin"))},
+		"secret.bin":            {Data: testGLTFBuffer()},
+	}
+
+	gltf, err := loaders.NewGLTFLoader(fsys).Load("assets/model.gltf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTestGLTF(t, gltf)
+
+	if _, err := loaders.NewGLTFLoader(fsys).Load("assets/escape.gltf"); err != nil {
+		t.Error("paths relative to the root of the file system should be allowed")
+	}
+	if _, err := loaders.NewGLTFLoader(fsys).Parse(bytes.NewReader(encodeJSON(t, testGLTF("../secret.bin")))); err == nil {
+		t.Error("paths outside of the file system should not be opened")
+	}
+}
+
+func TestGLTFLoader_Binary(t *testing.T) {
+	glb := encodeGLB(t, testGLTF(""), testGLTFBuffer())
+	gltf, err := loaders.NewGLTFLoader(nil).Parse(bytes.NewReader(glb))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTestGLTF(t, gltf)
+
+	if _, err := loaders.NewGLTFLoader(nil).Parse(bytes.NewReader(glb[:len(glb)-4])); err == nil {
+		t.Error("expected an error for a truncated file")
+	}
+}
+
+func TestGLTFLoader_AccessorErrors(t *testing.T) {
+	bin := testGLTFBuffer()
+	tests := []struct {
+		name     string
+		accessor int
+		modify   func(doc object)
+	}{
+		{"count exceeds the buffer view", 0, func(doc object) {
+			doc["accessors"].([]object)[0]["count"] = 100
+		}},
+		{"huge count", 0, func(doc object) {
+			doc["accessors"].([]object)[0]["count"] = 100000000000000
+		}},
+		{"huge count without a buffer view", 0, func(doc object) {
+			delete(doc["accessors"].([]object)[0], "bufferView")
+			doc["accessors"].([]object)[0]["type"] = "MAT4"
+			doc["accessors"].([]object)[0]["count"] = 4000000000
+		}},
+		{"huge buffer view offset", 0, func(doc object) {
+			doc["bufferViews"].([]object)[0]["byteOffset"] = math.MaxInt64 - 10
+		}},
+		{"huge byte offset", 0, func(doc object) {
+			doc["accessors"].([]object)[0]["byteOffset"] = math.MaxInt64 - 10
+		}},
+		{"huge sparse indices offset", 0, func(doc object) {
+			doc["accessors"].([]object)[0]["sparse"] = object{
+				"count":   1,
+				"indices": object{"bufferView": 1, "componentType": 5123, "byteOffset": math.MaxInt64 - 10},
+				"values":  object{"bufferView": 0},
+			}
+		}},
+		{"huge sparse values offset", 0, func(doc object) {
+			doc["accessors"].([]object)[0]["sparse"] = object{
+				"count":   1,
+				"indices": object{"bufferView": 1, "componentType": 5123},
+				"values":  object{"bufferView": 0, "byteOffset": math.MaxInt64 - 10},
+			}
+		}},
+		{"unknown type", 4, func(doc object) {
+			doc["accessors"].([]object)[4]["type"] = "VEC5"
+		}},
+		{"missing buffer view", 2, func(doc object) {
+			doc["accessors"].([]object)[2]["bufferView"] = 20
+		}},
+		{"index out of range", 1, func(doc object) {
+			doc["accessors"].([]object)[0]["count"] = 2
+		}},
+		{"float indices", 1, func(doc object) {
+			doc["accessors"].([]object)[1]["componentType"] = 5126
+			doc["accessors"].([]object)[1]["count"] = 1
+		}},
+		{"missing accessor", 9, func(doc object) {
+			doc["meshes"].([]object)[0]["primitives"].([]object)[0]["attributes"].(object)["NORMAL"] = 9
+		}},
+		{"mismatched animation output", 7, func(doc object) {
+			doc["accessors"].([]object)[7]["count"] = 1
+		}},
+	}
+
+	for _, test := range tests {
+		doc := testGLTF("")
+		test.modify(doc)
+
+		_, err := loaders.NewGLTFLoader(nil).Parse(bytes.NewReader(encodeGLB(t, doc, bin)))
+		var accessorError *loaders.GLTFAccessorError
+		if !errors.As(err, &accessorError) {
+			t.Errorf("%s: expected an accessor error, got %v", test.name, err)
+			continue
+		}
+		if accessorError.Accessor != test.accessor {
+			t.Errorf("%s: expected accessor %d to be reported, got %v", test.name, test.accessor, err)
+		}
+	}
+}
+
+func TestGLTFLoader_Errors(t *testing.T) {
+	tests := []func(doc object){
+		func(doc object) { doc["asset"] = object{"version": "1.0"} },
+		func(doc object) { doc["extensionsRequired"] = []string{"KHR_draco_mesh_compression"} },
+		func(doc object) { doc["nodes"].([]object)[2]["children"] = []int{3, 3} },
+		func(doc object) { doc["scene"] = 3 },
+		func(doc object) { doc["materials"].([]object)[0]["alphaMode"] = "SOMETIMES" },
+		func(doc object) { doc["cameras"].([]object)[0]["type"] = "fisheye" },
+	}
+
+	for i, modify := range tests {
+		doc := testGLTF("")
+		modify(doc)
+		if _, err := loaders.NewGLTFLoader(nil).Parse(bytes.NewReader(encodeGLB(t, doc, testGLTFBuffer()))); err == nil {
+			t.Errorf("%d: expected an error", i)
+		}
+	}
+
+	cycle := `{"asset": {"version": "2.0"}, "nodes": [{}, {"children": [2]}, {"children": [1]}]}`
+	if _, err := loaders.NewGLTFLoader(nil).Parse(strings.NewReader(cycle)); err == nil || !strings.Contains(err.Error(), "ancestor") {
+		t.Error("expected an error for a cycle of nodes")
+	}
+}
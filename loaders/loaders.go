@@ -0,0 +1,134 @@
+/*
+Package loaders reads and writes scenes, meshes and materials in common
+file formats. Files referenced by the file being loaded, such as material
+libraries and textures, are opened through a Resolver so that they
+can come from any source, including embedded file systems and archives.
+Files written alongside an exported file are created through a Creator.
+*/
+package loaders
+
+import (
+	"fmt"
+	"image"
+	// decoders for the image formats that textures are commonly stored in
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/golang/glog"
+	"github.com/rydrman/three.go/textures"
+)
+
+// Resolver opens the files referenced by a file being loaded,
+// names are given exactly as they appear in the referencing file
+type Resolver interface {
+	Open(name string) (io.ReadCloser, error)
+}
+
+// ResolverFunc allows an ordinary function to be used as a Resolver
+type ResolverFunc func(name string) (io.ReadCloser, error)
+
+// Open calls f with the given name
+func (f ResolverFunc) Open(name string) (io.ReadCloser, error) {
+
+	return f(name)
+
+}
+
+// FSResolver returns a resolver that opens files from fsys relative to
+// the directory dir, which is usually the directory of the file being
+// loaded. Names are cleaned so that files outside of fsys cannot be opened.
+func FSResolver(fsys fs.FS, dir string) Resolver {
+
+	return ResolverFunc(func(name string) (io.ReadCloser, error) {
+
+		name = path.Clean(path.Join(dir, filepathToSlash(name)))
+
+		return fsys.Open(name)
+
+	})
+
+}
+
+// Creator creates the additional files written by an exporter,
+// names are given exactly as they are referenced by the exported file
+type Creator interface {
+	Create(name string) (io.WriteCloser, error)
+}
+
+// CreatorFunc allows an ordinary function to be used as a Creator
+type CreatorFunc func(name string) (io.WriteCloser, error)
+
+// Create calls f with the given name
+func (f CreatorFunc) Create(name string) (io.WriteCloser, error) {
+
+	return f(name)
+
+}
+
+// DirCreator returns a creator that creates files in the directory dir
+// of the local file system, names that leave the directory are rejected
+func DirCreator(dir string) Creator {
+
+	return CreatorFunc(func(name string) (io.WriteCloser, error) {
+
+		clean := path.Clean(filepathToSlash(name))
+		if !fs.ValidPath(clean) {
+			return nil, fmt.Errorf("loaders: invalid file name %q", name)
+		}
+
+		return os.Create(filepath.Join(dir, filepath.FromSlash(clean)))
+
+	})
+
+}
+
+// filepathToSlash converts the windows separators that some
+// exporters write into file references into forward slashes
+func filepathToSlash(name string) string {
+
+	b := []byte(name)
+	for i, c := range b {
+		if c == '\\' {
+			b[i] = '/'
+		}
+	}
+
+	return string(b)
+
+}
+
+// loadTexture creates a texture from the named image, the image of the
+// texture is left nil with a warning if it cannot be opened or decoded
+func loadTexture(resolver Resolver, name string) *textures.Texture {
+
+	texture := textures.NewTexture(nil)
+	texture.Name = name
+	texture.SourceFile = name
+
+	if resolver == nil {
+		return texture
+	}
+
+	file, err := resolver.Open(name)
+	if err != nil {
+		glog.Warningf("loaders: cannot open texture %q: %v", name, err)
+		return texture
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		glog.Warningf("loaders: cannot decode texture %q: %v", name, err)
+		return texture
+	}
+
+	texture.Image = img
+
+	return texture
+
+}
@@ -0,0 +1,323 @@
+package loaders
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/materials"
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/textures"
+)
+
+// MaterialLibrary holds the materials defined in a Wavefront MTL file
+type MaterialLibrary struct {
+	// Materials holds each material by name
+	Materials map[string]*materials.MeshPhongMaterial
+	// Names lists the materials in the order they were defined
+	Names []string
+}
+
+// NewMaterialLibrary creates an empty material library
+func NewMaterialLibrary() *MaterialLibrary {
+
+	return &MaterialLibrary{
+		Materials: make(map[string]*materials.MeshPhongMaterial),
+	}
+
+}
+
+// Get returns the named material, or nil if it is not in this library
+func (l *MaterialLibrary) Get(name string) *materials.MeshPhongMaterial {
+
+	return l.Materials[name]
+
+}
+
+// MTLLoader reads Wavefront MTL files into phong materials
+type MTLLoader struct {
+	// Resolver opens the textures referenced by the file,
+	// textures are created without images if it is nil
+	Resolver Resolver
+	// Side is given to every material that is loaded
+	Side int
+}
+
+// NewMTLLoader creates a loader that opens textures with resolver
+func NewMTLLoader(resolver Resolver) *MTLLoader {
+
+	return &MTLLoader{
+		Resolver: resolver,
+		Side:     three.FrontSide,
+	}
+
+}
+
+// Load opens and parses the named file through the resolver of this loader
+func (l *MTLLoader) Load(name string) (*MaterialLibrary, error) {
+
+	if l.Resolver == nil {
+		return nil, fmt.Errorf("loaders: cannot open %q without a resolver", name)
+	}
+
+	file, err := l.Resolver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return l.Parse(file)
+
+}
+
+// Parse reads the materials defined in r. Diffuse, specular and emissive
+// colors, shininess, transparency and the texture maps that phong
+// materials support are used, everything else is ignored.
+func (l *MTLLoader) Parse(r io.Reader) (*MaterialLibrary, error) {
+
+	library := NewMaterialLibrary()
+	var material *materials.MeshPhongMaterial
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+
+	for scanner.Scan() {
+
+		lineNumber++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		key := strings.ToLower(fields[0])
+		value := strings.TrimSpace(line[len(fields[0]):])
+		args := fields[1:]
+
+		if key == "newmtl" {
+
+			material = materials.NewMeshPhongMaterial()
+			material.Name = value
+			material.Side = l.Side
+
+			library.Materials[value] = material
+			library.Names = append(library.Names, value)
+			continue
+
+		}
+
+		if material == nil {
+			return nil, fmt.Errorf("loaders: line %d: %q appears before newmtl", lineNumber, fields[0])
+		}
+
+		var err error
+
+		switch key {
+
+		case "kd":
+			err = parseColor(args, material.Color)
+
+		case "ks":
+			err = parseColor(args, material.Specular)
+
+		case "ke":
+			err = parseColor(args, material.Emissive)
+
+		case "ns":
+			material.Shininess, err = parseFloat(args, 0)
+
+		case "d":
+			var d float64
+			if d, err = parseFloat(args, 0); err == nil && d < 1 {
+				material.Opacity = d
+				material.Transparent = true
+			}
+
+		case "tr":
+			var tr float64
+			if tr, err = parseFloat(args, 0); err == nil && tr > 0 {
+				material.Opacity = 1 - tr
+				material.Transparent = true
+			}
+
+		case "map_kd":
+			material.Map = l.parseTexture(args)
+
+		case "map_ks":
+			material.SpecularMap = l.parseTexture(args)
+
+		case "map_ke":
+			material.EmissiveMap = l.parseTexture(args)
+
+		case "norm", "map_kn":
+			material.NormalMap = l.parseTexture(args)
+
+		case "map_bump", "bump":
+			material.BumpMap = l.parseTexture(args)
+			if scale, ok := textureOption(args, "-bm"); ok {
+				material.BumpScale = scale[0]
+			}
+
+		case "map_d":
+			material.AlphaMap = l.parseTexture(args)
+			material.Transparent = true
+
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("loaders: line %d: %v", lineNumber, err)
+		}
+
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return library, nil
+
+}
+
+// textureOptionArgs is the largest number of values taken by each of
+// the options that can precede the file name of a texture map, the
+// trailing values of vector options can be left out
+var textureOptionArgs = map[string]int{
+	"-blendu":  1,
+	"-blendv":  1,
+	"-boost":   1,
+	"-mm":      2,
+	"-o":       3,
+	"-s":       3,
+	"-t":       3,
+	"-texres":  1,
+	"-clamp":   1,
+	"-bm":      1,
+	"-imfchan": 1,
+	"-type":    1,
+}
+
+// parseTexture loads the texture of a map statement, applying
+// its scale, offset and clamp options
+func (l *MTLLoader) parseTexture(args []string) *textures.Texture {
+
+	i := 0
+	for i < len(args) && strings.HasPrefix(args[i], "-") {
+
+		count := textureOptionArgs[strings.ToLower(args[i])]
+		i++
+
+		// only numeric values can be left out
+		for j := 0; j < count && i < len(args); j, i = j+1, i+1 {
+			if _, err := strconv.ParseFloat(args[i], 64); err != nil && j > 0 {
+				break
+			}
+		}
+
+	}
+
+	if i >= len(args) {
+		return nil
+	}
+
+	texture := loadTexture(l.Resolver, strings.Join(args[i:], " "))
+	texture.WrapS = three.RepeatWrapping
+	texture.WrapT = three.RepeatWrapping
+
+	if scale, ok := textureOption(args, "-s"); ok {
+		texture.Repeat.Set(scale[0], scale[1])
+	}
+	if offset, ok := textureOption(args, "-o"); ok {
+		texture.Offset.Set(offset[0], offset[1])
+	}
+	if clamp := optionValue(args, "-clamp"); clamp == "on" {
+		texture.WrapS = three.ClampToEdgeWrapping
+		texture.WrapT = three.ClampToEdgeWrapping
+	}
+
+	return texture
+
+}
+
+// textureOption returns the numeric values of the given option of a
+// texture map statement, missing values of vector options default to 1
+func textureOption(args []string, option string) ([]float64, bool) {
+
+	for i := 0; i < len(args); i++ {
+
+		if !strings.EqualFold(args[i], option) {
+			continue
+		}
+
+		values := make([]float64, textureOptionArgs[option])
+		for j := range values {
+			values[j] = 1
+		}
+
+		for j := 0; j < len(values) && i+1+j < len(args); j++ {
+
+			v, err := strconv.ParseFloat(args[i+1+j], 64)
+			if err != nil {
+				break
+			}
+			values[j] = v
+
+		}
+
+		return values, true
+
+	}
+
+	return nil, false
+
+}
+
+// optionValue returns the first value of the given
+// option of a texture map statement
+func optionValue(args []string, option string) string {
+
+	for i := 0; i+1 < len(args); i++ {
+		if strings.EqualFold(args[i], option) {
+			return strings.ToLower(args[i+1])
+		}
+	}
+
+	return ""
+
+}
+
+func parseColor(args []string, color *math3.Color) error {
+
+	if len(args) < 3 {
+		return fmt.Errorf("expected three color components, got %d", len(args))
+	}
+
+	var rgb [3]float64
+	for i := range rgb {
+
+		v, err := strconv.ParseFloat(args[i], 64)
+		if err != nil {
+			return err
+		}
+		rgb[i] = v
+
+	}
+
+	color.SetRGB(rgb[0], rgb[1], rgb[2])
+
+	return nil
+
+}
+
+func parseFloat(args []string, i int) (float64, error) {
+
+	if i >= len(args) {
+		return 0, fmt.Errorf("expected at least %d values, got %d", i+1, len(args))
+	}
+
+	return strconv.ParseFloat(args[i], 64)
+
+}
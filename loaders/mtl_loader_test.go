@@ -0,0 +1,88 @@
+package loaders_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/loaders"
+)
+
+func TestMTLLoader_Parse(t *testing.T) {
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, image.NewRGBA(image.Rect(0, 0, 2, 2))); err != nil {
+		t.Fatal(err)
+	}
+
+	opened := []string{}
+	resolver := loaders.ResolverFunc(func(name string) (io.ReadCloser, error) {
+		opened = append(opened, name)
+		return io.NopCloser(bytes.NewReader(encoded.Bytes())), nil
+	})
+
+	library, err := loaders.NewMTLLoader(resolver).Parse(strings.NewReader(`
+# a material with every supported statement
+newmtl shiny metal
+Kd 0.5 0.5 0.5
+Ks 1 1 1
+Ke 0.1 0 0
+Ns 200
+Tr 0.25
+map_Kd -o 0.5 0.5 -clamp on diffuse map.png
+map_Ks specular.png
+map_Ke emissive.png
+map_d alpha.png
+bump -bm 0.2 bump.png
+norm normal.png
+
+newmtl plain
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(library.Names) != 2 || library.Names[0] != "shiny metal" || library.Names[1] != "plain" {
+		t.Errorf("expected materials in definition order, got %v", library.Names)
+	}
+
+	m := library.Get("shiny metal")
+	if m.Color.R != 0.5 || m.Specular.G != 1 || m.Emissive.R != 0.1 || m.Shininess != 200 {
+		t.Errorf("unexpected colors %v, %v, %v or shininess %f", m.Color, m.Specular, m.Emissive, m.Shininess)
+	}
+	if !m.Transparent || m.Opacity != 0.75 {
+		t.Errorf("expected Tr to set the opacity, got %f", m.Opacity)
+	}
+
+	if m.Map == nil || m.Map.SourceFile != "diffuse map.png" || m.Map.Image == nil {
+		t.Fatalf("expected the diffuse map to be loaded, got %+v", m.Map)
+	}
+	if m.Map.Offset.X != 0.5 || m.Map.WrapS != three.ClampToEdgeWrapping {
+		t.Error("expected the map options to be applied")
+	}
+	if m.SpecularMap == nil || m.EmissiveMap == nil || m.AlphaMap == nil || m.NormalMap == nil {
+		t.Error("expected every map to be loaded")
+	}
+	if m.BumpMap == nil || m.BumpScale != 0.2 {
+		t.Error("expected the bump map and its scale")
+	}
+	if len(opened) != 6 {
+		t.Errorf("expected each texture to be opened through the resolver, got %v", opened)
+	}
+
+	if plain := library.Get("plain"); plain.Transparent || plain.Map != nil {
+		t.Error("expected the second material to have default values")
+	}
+}
+
+func TestMTLLoader_Errors(t *testing.T) {
+	if _, err := loaders.NewMTLLoader(nil).Parse(strings.NewReader("Kd 1 1 1")); err == nil {
+		t.Error("expected an error for statements before newmtl")
+	}
+	if _, err := loaders.NewMTLLoader(nil).Parse(strings.NewReader("newmtl a\nKd 1 1")); err == nil {
+		t.Error("expected an error for incomplete colors")
+	}
+}
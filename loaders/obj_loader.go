@@ -0,0 +1,572 @@
+package loaders
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/materials"
+	"github.com/rydrman/three.go/objects"
+)
+
+// OBJLoader reads Wavefront OBJ files into meshes and line segments
+type OBJLoader struct {
+	// Resolver opens the material libraries named by mtllib
+	// statements and the textures that they reference
+	Resolver Resolver
+	// Materials are used for usemtl statements before any material
+	// library that the file loads, it may be nil
+	Materials *MaterialLibrary
+}
+
+// NewOBJLoader creates a loader that opens referenced files with resolver
+func NewOBJLoader(resolver Resolver) *OBJLoader {
+
+	return &OBJLoader{
+		Resolver: resolver,
+	}
+
+}
+
+// Load opens and parses the named file through the resolver of this loader
+func (l *OBJLoader) Load(name string) (*objects.Object, error) {
+
+	if l.Resolver == nil {
+		return nil, fmt.Errorf("loaders: cannot open %q without a resolver", name)
+	}
+
+	file, err := l.Resolver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return l.Parse(file)
+
+}
+
+// objGroup is a run of faces in an object that share a material
+type objGroup struct {
+	material string
+	smooth   bool
+	start    int
+}
+
+// objObject collects the geometry of one o or g statement
+type objObject struct {
+	name string
+
+	positions []float32
+	normals   []float32
+	uvs       []float32
+	colors    []float32
+	lines     []float32
+
+	// missingNormals and missingUVs are set when any face vertex
+	// lacks a normal or texture coordinate, in which case the
+	// normals are computed and the uvs are left out
+	missingNormals bool
+	missingUVs     bool
+
+	groups []objGroup
+}
+
+// objParser holds the state of a single call to Parse
+type objParser struct {
+	loader    *OBJLoader
+	libraries []*MaterialLibrary
+
+	vertices []float32
+	colors   []float32
+	normals  []float32
+	uvs      []float32
+
+	objects []*objObject
+	object  *objObject
+
+	material string
+	smooth   bool
+}
+
+// Parse reads the geometry in r. Each o or g statement starts a new
+// child of the returned object, the faces of a child are drawn as one
+// mesh with a geometry group for each usemtl statement and its line
+// elements as separate line segments. Polygons are triangulated as fans.
+func (l *OBJLoader) Parse(r io.Reader) (*objects.Object, error) {
+
+	p := &objParser{
+		loader: l,
+		smooth: true,
+	}
+
+	if l.Materials != nil {
+		p.libraries = append(p.libraries, l.Materials)
+	}
+
+	p.startObject("")
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	line := ""
+
+	for scanner.Scan() {
+
+		lineNumber++
+
+		// a trailing backslash continues a statement on the next line
+		text := strings.TrimSpace(scanner.Text())
+		if strings.HasSuffix(text, "\\") {
+			line += text[:len(text)-1] + " "
+			continue
+		}
+		line += text
+
+		if err := p.parseLine(line); err != nil {
+			return nil, fmt.Errorf("loaders: line %d: %v", lineNumber, err)
+		}
+		line = ""
+
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return p.build(), nil
+
+}
+
+func (p *objParser) parseLine(line string) error {
+
+	// continued lines may leave nothing but whitespace
+	fields := strings.Fields(line)
+	if len(fields) == 0 || fields[0][0] == '#' {
+		return nil
+	}
+
+	args := fields[1:]
+
+	switch fields[0] {
+
+	case "v":
+		values, err := parseFloats(args, 3)
+		if err != nil {
+			return err
+		}
+		p.vertices = append(p.vertices, values[0], values[1], values[2])
+		if len(values) >= 6 {
+			// pad the colors of any earlier vertices that had none
+			for len(p.colors) < len(p.vertices)-3 {
+				p.colors = append(p.colors, 1)
+			}
+			p.colors = append(p.colors, values[3], values[4], values[5])
+		}
+
+	case "vn":
+		values, err := parseFloats(args, 3)
+		if err != nil {
+			return err
+		}
+		p.normals = append(p.normals, values[0], values[1], values[2])
+
+	case "vt":
+		values, err := parseFloats(args, 2)
+		if err != nil {
+			return err
+		}
+		p.uvs = append(p.uvs, values[0], values[1])
+
+	case "f":
+		return p.addFace(args)
+
+	case "l":
+		return p.addLine(args)
+
+	case "o", "g":
+		p.startObject(strings.TrimSpace(line[len(fields[0]):]))
+
+	case "usemtl":
+		p.material = strings.TrimSpace(line[len(fields[0]):])
+		p.startGroup()
+
+	case "mtllib":
+		p.loadLibrary(strings.TrimSpace(line[len(fields[0]):]))
+
+	case "s":
+		p.smooth = len(args) > 0 && args[0] != "off" && args[0] != "0"
+		p.startGroup()
+
+	default:
+		glog.Warningf("loaders: unsupported OBJ statement %q", fields[0])
+
+	}
+
+	return nil
+
+}
+
+// startObject begins a new child object, renaming the current one
+// instead if nothing has been added to it yet
+func (p *objParser) startObject(name string) {
+
+	if p.object != nil && len(p.object.positions) == 0 && len(p.object.lines) == 0 {
+		p.object.name = name
+		return
+	}
+
+	p.object = &objObject{name: name}
+	p.objects = append(p.objects, p.object)
+	p.startGroup()
+
+}
+
+// startGroup begins a new run of faces with the current material and
+// smoothing, replacing the last group of the object if it is empty
+func (p *objParser) startGroup() {
+
+	group := objGroup{
+		material: p.material,
+		smooth:   p.smooth,
+		start:    len(p.object.positions) / 3,
+	}
+
+	groups := p.object.groups
+	if n := len(groups); n > 0 {
+
+		last := groups[n-1]
+		if last.material == group.material && last.smooth == group.smooth {
+			return
+		}
+		if last.start == group.start {
+			groups = groups[:n-1]
+		}
+
+	}
+
+	p.object.groups = append(groups, group)
+
+}
+
+func (p *objParser) loadLibrary(name string) {
+
+	loader := NewMTLLoader(p.loader.Resolver)
+
+	library, err := loader.Load(name)
+	if err != nil {
+		glog.Warningf("loaders: cannot load material library %q: %v", name, err)
+		return
+	}
+
+	p.libraries = append(p.libraries, library)
+
+}
+
+// resolveIndex converts a one based or negative relative
+// index into a zero based index of count elements
+func resolveIndex(value string, count int) (int, error) {
+
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case i > 0 && i <= count:
+		return i - 1, nil
+	case i < 0 && -i <= count:
+		return count + i, nil
+	}
+
+	return 0, fmt.Errorf("index %d out of range of %d elements", i, count)
+
+}
+
+// objVertex holds the resolved indices of one face vertex,
+// a missing texture coordinate or normal is given as -1
+type objVertex struct {
+	position, uv, normal int
+}
+
+func (p *objParser) parseVertex(value string) (objVertex, error) {
+
+	v := objVertex{-1, -1, -1}
+	parts := strings.Split(value, "/")
+
+	var err error
+	if v.position, err = resolveIndex(parts[0], len(p.vertices)/3); err != nil {
+		return v, err
+	}
+	if len(parts) > 1 && parts[1] != "" {
+		if v.uv, err = resolveIndex(parts[1], len(p.uvs)/2); err != nil {
+			return v, err
+		}
+	}
+	if len(parts) > 2 && parts[2] != "" {
+		if v.normal, err = resolveIndex(parts[2], len(p.normals)/3); err != nil {
+			return v, err
+		}
+	}
+
+	return v, nil
+
+}
+
+func (p *objParser) addFace(args []string) error {
+
+	if len(args) < 3 {
+		return fmt.Errorf("face has %d vertices, at least 3 are needed", len(args))
+	}
+
+	vertices := make([]objVertex, len(args))
+	for i, arg := range args {
+
+		v, err := p.parseVertex(arg)
+		if err != nil {
+			return err
+		}
+		vertices[i] = v
+
+	}
+
+	for i := 1; i+1 < len(vertices); i++ {
+
+		p.addVertex(vertices[0])
+		p.addVertex(vertices[i])
+		p.addVertex(vertices[i+1])
+
+	}
+
+	return nil
+
+}
+
+func (p *objParser) addVertex(v objVertex) {
+
+	o := p.object
+
+	o.positions = append(o.positions, p.vertices[v.position*3:v.position*3+3]...)
+
+	if len(p.colors) > 0 {
+		for len(p.colors) < len(p.vertices) {
+			p.colors = append(p.colors, 1)
+		}
+		// faces added before the first colored vertex are white
+		for len(o.colors) < len(o.positions)-3 {
+			o.colors = append(o.colors, 1)
+		}
+		o.colors = append(o.colors, p.colors[v.position*3:v.position*3+3]...)
+	}
+
+	if v.normal < 0 {
+		o.missingNormals = true
+	} else {
+		o.normals = append(o.normals, p.normals[v.normal*3:v.normal*3+3]...)
+	}
+
+	if v.uv < 0 {
+		o.missingUVs = true
+	} else {
+		o.uvs = append(o.uvs, p.uvs[v.uv*2:v.uv*2+2]...)
+	}
+
+}
+
+// addLine expands a polyline into separate segments
+func (p *objParser) addLine(args []string) error {
+
+	if len(args) < 2 {
+		return fmt.Errorf("line has %d vertices, at least 2 are needed", len(args))
+	}
+
+	var previous []float32
+	for _, arg := range args {
+
+		v, err := p.parseVertex(arg)
+		if err != nil {
+			return err
+		}
+
+		position := p.vertices[v.position*3 : v.position*3+3]
+		if previous != nil {
+			p.object.lines = append(p.object.lines, previous...)
+			p.object.lines = append(p.object.lines, position...)
+		}
+		previous = position
+
+	}
+
+	return nil
+
+}
+
+// findMaterial returns the named material from the most recently
+// loaded library that defines it
+func (p *objParser) findMaterial(name string) *materials.MeshPhongMaterial {
+
+	for i := len(p.libraries) - 1; i >= 0; i-- {
+		if material := p.libraries[i].Get(name); material != nil {
+			return material
+		}
+	}
+
+	return nil
+
+}
+
+// meshMaterial returns the material to draw a group with,
+// library materials are cloned when the group changes them
+func (p *objParser) meshMaterial(group objGroup, vertexColors bool) *materials.MeshPhongMaterial {
+
+	material := p.findMaterial(group.material)
+	if material == nil {
+		material = materials.NewMeshPhongMaterial()
+		material.Name = group.material
+	} else if !group.smooth || vertexColors {
+		material = material.Clone()
+	}
+
+	if !group.smooth {
+		material.Shading = three.FlatShading
+	}
+	if vertexColors {
+		material.VertexColors = three.VertexColors
+	}
+
+	return material
+
+}
+
+func (p *objParser) build() *objects.Object {
+
+	container := objects.NewObject()
+
+	for _, o := range p.objects {
+
+		if len(o.positions) > 0 {
+			container.Add(p.buildMesh(o))
+		}
+
+		if len(o.lines) > 0 {
+
+			geometry := core.NewBufferGeometry()
+			geometry.AddAttribute("position", core.NewFloat32BufferAttribute(o.lines, 3))
+
+			material := materials.NewLineBasicMaterial()
+			if len(o.groups) > 0 {
+				if library := p.findMaterial(o.groups[len(o.groups)-1].material); library != nil {
+					material.Color.Copy(library.Color)
+				}
+			}
+
+			lines := objects.NewLineSegments(geometry, material)
+			lines.Name = o.name
+			container.Add(lines)
+
+		}
+
+	}
+
+	return container
+
+}
+
+func (p *objParser) buildMesh(o *objObject) *objects.Mesh {
+
+	geometry := core.NewBufferGeometry()
+	geometry.AddAttribute("position", core.NewFloat32BufferAttribute(o.positions, 3))
+
+	vertexColors := len(o.colors) > 0
+	if vertexColors {
+		geometry.AddAttribute("color", core.NewFloat32BufferAttribute(o.colors, 3))
+	}
+	if !o.missingUVs {
+		geometry.AddAttribute("uv", core.NewFloat32BufferAttribute(o.uvs, 2))
+	}
+	if o.missingNormals {
+		geometry.ComputeVertexNormals()
+	} else {
+		geometry.AddAttribute("normal", core.NewFloat32BufferAttribute(o.normals, 3))
+	}
+
+	// drop the groups that ended up without any faces
+	count := len(o.positions) / 3
+	var groups []objGroup
+	for i, group := range o.groups {
+
+		end := count
+		if i+1 < len(o.groups) {
+			end = o.groups[i+1].start
+		}
+		if end > group.start {
+			groups = append(groups, group)
+		}
+
+	}
+
+	var material objects.Material
+
+	if len(groups) == 1 {
+
+		material = p.meshMaterial(groups[0], vertexColors)
+
+	} else {
+
+		multi := materials.NewMultiMaterial()
+		indices := make(map[objGroup]int)
+
+		for i, group := range groups {
+
+			end := count
+			if i+1 < len(groups) {
+				end = groups[i+1].start
+			}
+
+			key := objGroup{material: group.material, smooth: group.smooth}
+			index, ok := indices[key]
+			if !ok {
+				index = len(multi.Materials)
+				indices[key] = index
+				multi.Materials = append(multi.Materials, p.meshMaterial(group, vertexColors))
+			}
+
+			geometry.AddGroup(group.start, end-group.start, index)
+
+		}
+
+		material = multi
+
+	}
+
+	mesh := objects.NewMesh(geometry, material)
+	mesh.Name = o.name
+
+	return mesh
+
+}
+
+// parseFloats parses all of args, at least min of which are required
+func parseFloats(args []string, min int) ([]float32, error) {
+
+	if len(args) < min {
+		return nil, fmt.Errorf("expected at least %d values, got %d", min, len(args))
+	}
+
+	values := make([]float32, len(args))
+	for i, arg := range args {
+
+		v, err := strconv.ParseFloat(arg, 32)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = float32(v)
+
+	}
+
+	return values, nil
+
+}
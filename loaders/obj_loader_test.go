@@ -0,0 +1,190 @@
+package loaders_test
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/loaders"
+	"github.com/rydrman/three.go/materials"
+	"github.com/rydrman/three.go/objects"
+)
+
+const testOBJ = `# two objects sharing vertices
+mtllib scene.mtl
+v 0 0 0
+v 1 0 0
+v 1 1 0
+v 0 1 0
+vn 0 0 1
+vt 0 0
+vt 1 0
+vt 1 1
+vt 0 1
+
+o quad
+usemtl red
+f 1/1/1 2/2/1 3/3/1 4/4/1
+
+o tri
+usemtl red
+f -4//-1 -3//-1 -2//-1
+usemtl blue
+s off
+f 1 3 4
+l 1 2 3
+`
+
+const testMTL = `newmtl red
+Kd 1 0 0
+Ks 0.5 0.5 0.5
+Ns 10
+
+newmtl blue
+Kd 0 0 1
+d 0.5
+map_Kd -s 2 2 1 textures\blue.png
+`
+
+func TestOBJLoader_Parse(t *testing.T) {
+	fsys := fstest.MapFS{
+		"models/scene.obj": {Data: []byte(testOBJ)},
+		"models/scene.mtl": {Data: []byte(testMTL)},
+	}
+
+	loader := loaders.NewOBJLoader(loaders.FSResolver(fsys, "models"))
+	root, err := loader.Load("scene.obj")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	children := root.GetChildren()
+	if len(children) != 3 {
+		t.Fatalf("expected a mesh for each object and one line segments, got %d children", len(children))
+	}
+
+	quad := children[0].(*objects.Mesh)
+	if quad.Name != "quad" {
+		t.Errorf("expected the first object to be named quad, got %q", quad.Name)
+	}
+	if count := quad.Geometry.GetAttribute("position").Count(); count != 6 {
+		t.Errorf("expected the quad to be triangulated into 6 vertices, got %d", count)
+	}
+	if quad.Geometry.GetAttribute("uv") == nil {
+		t.Error("expected texture coordinates for the quad")
+	}
+	red, ok := quad.Material.(*materials.MeshPhongMaterial)
+	if !ok {
+		t.Fatalf("expected a single phong material, got %T", quad.Material)
+	}
+	if red.Name != "red" || red.Color.R != 1 || red.Color.B != 0 || red.Shininess != 10 {
+		t.Errorf("expected the red material from the library, got %+v", red)
+	}
+
+	tri := children[1].(*objects.Mesh)
+	position := tri.Geometry.GetAttribute("position")
+	if position.Count() != 6 {
+		t.Fatalf("expected two triangles, got %d vertices", position.Count())
+	}
+	if position.GetX(0) != 0 || position.GetX(1) != 1 || position.GetY(2) != 1 {
+		t.Error("negative indices should be relative to the end of the vertex list")
+	}
+	if tri.Geometry.GetAttribute("uv") != nil {
+		t.Error("uvs should be left out when faces are missing them")
+	}
+	if tri.Geometry.GetAttribute("normal") == nil {
+		t.Error("normals should be computed when faces are missing them")
+	}
+
+	groups := tri.Geometry.Groups
+	expected := []core.Group{{Start: 0, Count: 3, MaterialIndex: 0}, {Start: 3, Count: 3, MaterialIndex: 1}}
+	if len(groups) != len(expected) || groups[0] != expected[0] || groups[1] != expected[1] {
+		t.Errorf("expected groups %v, got %v", expected, groups)
+	}
+
+	multi, ok := tri.Material.(*materials.MultiMaterial)
+	if !ok {
+		t.Fatalf("expected a multi material, got %T", tri.Material)
+	}
+	if multi.Get(0) != red {
+		t.Error("library materials should be shared between objects")
+	}
+	blue := multi.Get(1).(*materials.MeshPhongMaterial)
+	if blue.Shading != three.FlatShading {
+		t.Error("faces after s off should be flat shaded")
+	}
+	if !blue.Transparent || blue.Opacity != 0.5 {
+		t.Errorf("expected a transparent material, got opacity %f", blue.Opacity)
+	}
+	if blue.Map == nil || blue.Map.SourceFile != "textures\\blue.png" || blue.Map.Repeat.X != 2 {
+		t.Errorf("expected a repeated diffuse map, got %+v", blue.Map)
+	}
+
+	lines := children[2].(*objects.LineSegments)
+	if count := lines.Geometry.GetAttribute("position").Count(); count != 4 {
+		t.Errorf("expected the polyline to be expanded into 2 segments, got %d vertices", count)
+	}
+}
+
+func TestOBJLoader_VertexColors(t *testing.T) {
+	root, err := loaders.NewOBJLoader(nil).Parse(strings.NewReader(`
+v 0 0 0 1 0 0
+v 1 0 0 0 1 0
+v 0 1 0 0 0 1
+f 1 2 3
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mesh := root.GetChildren()[0].(*objects.Mesh)
+	color := mesh.Geometry.GetAttribute("color")
+	if color == nil || color.GetY(1) != 1 || color.GetZ(2) != 1 {
+		t.Fatal("expected the vertex colors to be kept")
+	}
+	if mesh.Material.GetMaterial().VertexColors != three.VertexColors {
+		t.Error("expected the material to use vertex colors")
+	}
+
+	root, err = loaders.NewOBJLoader(nil).Parse(strings.NewReader(`
+v -1 -1 0
+v 1 -1 0
+v 1 1 0
+f 1 2 3
+v 0 0 0 1 0 0
+v 1 0 0 0 1 0
+v 0 1 0 0 0 1
+f 4 5 6
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mesh = root.GetChildren()[0].(*objects.Mesh)
+	color = mesh.Geometry.GetAttribute("color")
+	if color == nil || color.Count() != 6 || color.GetY(0) != 1 || color.GetY(3) != 0 || color.GetY(4) != 1 {
+		t.Error("expected the faces before the first colored vertex to be white")
+	}
+}
+
+func TestOBJLoader_Errors(t *testing.T) {
+	tests := []string{
+		"v 0 0 0\nf 1 2",
+		"v 0 0 0\nf 1 2 3",
+		"v 0 0 0\nv 0 0 0\nv 0 0 0\nf 1 2 0",
+		"v 0 0 x",
+		"l 1",
+	}
+	for _, test := range tests {
+		if _, err := loaders.NewOBJLoader(nil).Parse(strings.NewReader(test)); err == nil {
+			t.Errorf("expected an error parsing %q", test)
+		}
+	}
+
+	// a continuation that leaves only whitespace is an empty line
+	if _, err := loaders.NewOBJLoader(nil).Parse(strings.NewReader("\\\n ")); err != nil {
+		t.Errorf("expected a blank continued line to be skipped, got %v", err)
+	}
+}
@@ -0,0 +1,538 @@
+package loaders
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+
+	"github.com/golang/glog"
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/lights"
+	"github.com/rydrman/three.go/materials"
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/objects"
+	"github.com/rydrman/three.go/textures"
+)
+
+// ToJSON serializes node and its descendants in the JSON object format 4
+// of three.js, which ObjectLoader and the ObjectLoader of three.js read.
+// Geometries, materials and textures that are shared between objects are
+// written once, and images are embedded as PNG data URLs unless a texture
+// only has a source file. Skeletons and light targets are written as
+// references to the uuids of objects in the tree.
+func ToJSON(node objects.Node) ([]byte, error) {
+
+	w := &objectWriter{
+		geometries: make(map[*core.BufferGeometry]bool),
+		materials:  make(map[objects.Material]bool),
+		textures:   make(map[*textures.Texture]bool),
+		images:     make(map[image.Image]string),
+		skeletons:  make(map[*objects.Skeleton]string),
+		nodes:      make(map[objects.Node]bool),
+	}
+
+	object, err := w.writeObject(node)
+	if err != nil {
+		return nil, fmt.Errorf("loaders: JSON: %v", err)
+	}
+
+	for _, target := range w.targets {
+		if w.nodes[target.node] {
+			target.props["target"] = objects.ObjectOf(target.node).UUID
+		}
+	}
+
+	for _, skinned := range w.skinned {
+		if err := w.writeSkeleton(skinned.props, skinned.mesh); err != nil {
+			return nil, fmt.Errorf("loaders: JSON: %v", err)
+		}
+	}
+
+	if w.err != nil {
+		return nil, fmt.Errorf("loaders: JSON: %v", w.err)
+	}
+
+	doc := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"version":   objectFormatVersion,
+			"type":      "Object",
+			"generator": objectFormatGenerator,
+		},
+		"object": object,
+	}
+
+	for key, elements := range map[string][]map[string]interface{}{
+		"geometries": w.doc.geometries,
+		"materials":  w.doc.materials,
+		"textures":   w.doc.textures,
+		"images":     w.doc.images,
+		"skeletons":  w.doc.skeletons,
+	} {
+		if len(elements) > 0 {
+			doc[key] = elements
+		}
+	}
+
+	return json.Marshal(doc)
+
+}
+
+// objectReference is an object property that refers to
+// another object, written once the whole tree is known
+type objectReference struct {
+	props map[string]interface{}
+	node  objects.Node
+}
+
+// skinnedReference is a skinned mesh whose skeleton
+// is written once the whole tree is known
+type skinnedReference struct {
+	props map[string]interface{}
+	mesh  *objects.SkinnedMesh
+}
+
+// objectWriter holds the state of a single call to ToJSON
+type objectWriter struct {
+	doc struct {
+		geometries []map[string]interface{}
+		materials  []map[string]interface{}
+		textures   []map[string]interface{}
+		images     []map[string]interface{}
+		skeletons  []map[string]interface{}
+	}
+
+	geometries map[*core.BufferGeometry]bool
+	materials  map[objects.Material]bool
+	textures   map[*textures.Texture]bool
+	images     map[image.Image]string
+	skeletons  map[*objects.Skeleton]string
+	nodes      map[objects.Node]bool
+
+	targets []objectReference
+	skinned []skinnedReference
+
+	// err is the first error of writing a texture, which
+	// is written while writing the fields of a material
+	err error
+}
+
+func (w *objectWriter) writeObject(node objects.Node) (map[string]interface{}, error) {
+
+	o := objects.ObjectOf(node)
+	w.nodes[node] = true
+
+	if o.MatrixAutoUpdate {
+		o.UpdateMatrix()
+	}
+
+	kind, fields := objectFields(node)
+
+	props := map[string]interface{}{
+		"uuid":   o.UUID,
+		"type":   kind,
+		"matrix": o.Matrix.ToArray(nil, 0),
+	}
+	if o.Name != "" {
+		props["name"] = o.Name
+	}
+	writeFields(props, fields, w.writeTexture)
+
+	var geometry *core.BufferGeometry
+	var material objects.Material
+
+	switch t := node.(type) {
+
+	case *objects.Mesh:
+		geometry, material = t.Geometry, t.Material
+
+	case *objects.SkinnedMesh:
+		geometry, material = t.Geometry, t.Material
+		props["bindMatrix"] = t.BindMatrix.ToArray(nil, 0)
+		if t.Skeleton != nil {
+			w.skinned = append(w.skinned, skinnedReference{props, t})
+		}
+
+	case *objects.Line:
+		geometry, material = t.Geometry, t.Material
+
+	case *objects.LineLoop:
+		geometry, material = t.Geometry, t.Material
+
+	case *objects.LineSegments:
+		geometry, material = t.Geometry, t.Material
+
+	case *objects.Points:
+		geometry, material = t.Geometry, t.Material
+
+	case *objects.Sprite:
+		material = t.Material
+
+	case *lights.DirectionalLight:
+		if t.Target != nil {
+			w.targets = append(w.targets, objectReference{props, t.Target})
+		}
+
+	case *lights.SpotLight:
+		if t.Target != nil {
+			w.targets = append(w.targets, objectReference{props, t.Target})
+		}
+
+	}
+
+	if geometry != nil {
+		props["geometry"] = w.writeGeometry(geometry)
+	}
+
+	if multi, ok := material.(*materials.MultiMaterial); ok {
+
+		uuids := make([]string, len(multi.Materials))
+		for i, m := range multi.Materials {
+
+			uuid, err := w.writeMaterial(m)
+			if err != nil {
+				return nil, err
+			}
+			uuids[i] = uuid
+
+		}
+		props["material"] = uuids
+
+	} else if material != nil {
+
+		uuid, err := w.writeMaterial(material)
+		if err != nil {
+			return nil, err
+		}
+		props["material"] = uuid
+
+	}
+
+	var children []interface{}
+	for _, child := range node.GetChildren() {
+
+		c, err := w.writeObject(child)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, c)
+
+	}
+	if children != nil {
+		props["children"] = children
+	}
+
+	return props, nil
+
+}
+
+// typedArrayName returns the name of the typed array that
+// three.js uses for each type of attribute data
+func typedArrayName(attribute *core.BufferAttribute) (string, interface{}) {
+
+	switch {
+	case attribute.Uint16 != nil:
+		return "Uint16Array", attribute.Uint16
+	case attribute.Uint32 != nil:
+		return "Uint32Array", attribute.Uint32
+	}
+
+	array := attribute.Float32
+	if array == nil {
+		array = []float32{}
+	}
+
+	return "Float32Array", array
+
+}
+
+func writeAttribute(attribute *core.BufferAttribute) map[string]interface{} {
+
+	kind, array := typedArrayName(attribute)
+
+	props := map[string]interface{}{
+		"itemSize":   attribute.ItemSize,
+		"type":       kind,
+		"array":      array,
+		"normalized": attribute.Normalized,
+	}
+	if attribute.Name != "" {
+		props["name"] = attribute.Name
+	}
+
+	return props
+
+}
+
+func (w *objectWriter) writeGeometry(geometry *core.BufferGeometry) string {
+
+	if w.geometries[geometry] {
+		return geometry.UUID
+	}
+	w.geometries[geometry] = true
+
+	data := map[string]interface{}{}
+
+	attributes := make(map[string]interface{}, len(geometry.Attributes))
+	for name, attribute := range geometry.Attributes {
+		attributes[name] = writeAttribute(attribute)
+	}
+	data["attributes"] = attributes
+
+	if geometry.Index != nil {
+		kind, array := typedArrayName(geometry.Index)
+		data["index"] = map[string]interface{}{"type": kind, "array": array}
+	}
+
+	if len(geometry.MorphAttributes) > 0 {
+
+		morphs := make(map[string]interface{}, len(geometry.MorphAttributes))
+		for name, targets := range geometry.MorphAttributes {
+
+			list := make([]interface{}, len(targets))
+			for i, target := range targets {
+				list[i] = writeAttribute(target)
+			}
+			morphs[name] = list
+
+		}
+		data["morphAttributes"] = morphs
+
+	}
+
+	if len(geometry.Groups) > 0 {
+
+		groups := make([]interface{}, len(geometry.Groups))
+		for i, group := range geometry.Groups {
+			groups[i] = map[string]interface{}{
+				"start":         group.Start,
+				"count":         group.Count,
+				"materialIndex": group.MaterialIndex,
+			}
+		}
+		data["groups"] = groups
+
+	}
+
+	if sphere := geometry.BoundingSphere; sphere != nil {
+		data["boundingSphere"] = map[string]interface{}{
+			"center": sphere.Center.ToArray(nil, 0),
+			"radius": sphere.Radius,
+		}
+	}
+
+	props := map[string]interface{}{
+		"uuid": geometry.UUID,
+		"type": "BufferGeometry",
+		"data": data,
+	}
+	if geometry.Name != "" {
+		props["name"] = geometry.Name
+	}
+
+	w.doc.geometries = append(w.doc.geometries, props)
+
+	return geometry.UUID
+
+}
+
+func (w *objectWriter) writeMaterial(material objects.Material) (string, error) {
+
+	base := material.GetMaterial()
+
+	if w.materials[material] {
+		return base.UUID, nil
+	}
+
+	kind, fields, ok := materialFields(material)
+	if !ok {
+		return "", fmt.Errorf("material %q: %T is not supported", base.Name, material)
+	}
+	w.materials[material] = true
+
+	props := map[string]interface{}{
+		"uuid": base.UUID,
+		"type": kind,
+	}
+	if base.Name != "" {
+		props["name"] = base.Name
+	}
+	writeFields(props, fields, w.writeTexture)
+
+	if shader, ok := material.(*materials.ShaderMaterial); ok {
+
+		if len(shader.Defines) > 0 {
+			props["defines"] = shader.Defines
+		}
+		props["uniforms"] = w.writeUniforms(shader.Uniforms)
+
+	}
+
+	w.doc.materials = append(w.doc.materials, props)
+
+	return base.UUID, nil
+
+}
+
+// writeUniforms writes the values of uniforms along with the type
+// names of three.js, uniforms of other types are left out
+func (w *objectWriter) writeUniforms(uniforms *core.UniformGroup) map[string]interface{} {
+
+	props := make(map[string]interface{})
+	if uniforms == nil {
+		return props
+	}
+
+	for _, name := range uniforms.Names() {
+
+		var uniform map[string]interface{}
+
+		switch v := uniforms.Get(name).GetValue().(type) {
+		case float64:
+			uniform = map[string]interface{}{"value": v}
+		case int:
+			uniform = map[string]interface{}{"value": v}
+		case bool:
+			uniform = map[string]interface{}{"value": v}
+		case *math3.Color:
+			uniform = map[string]interface{}{"type": "c", "value": v.GetHex()}
+		case *math3.Vector2:
+			uniform = map[string]interface{}{"type": "v2", "value": v.ToArray(nil, 0)}
+		case *math3.Vector3:
+			uniform = map[string]interface{}{"type": "v3", "value": v.ToArray(nil, 0)}
+		case *math3.Vector4:
+			uniform = map[string]interface{}{"type": "v4", "value": v.ToArray(nil, 0)}
+		case *math3.Matrix3:
+			uniform = map[string]interface{}{"type": "m3", "value": v.ToArray(nil, 0)}
+		case *math3.Matrix4:
+			uniform = map[string]interface{}{"type": "m4", "value": v.ToArray(nil, 0)}
+		case *textures.Texture:
+			uniform = map[string]interface{}{"type": "t", "value": w.writeTexture(v)}
+		default:
+			glog.Warningf("loaders: JSON does not support uniform %q of type %T", name, v)
+			continue
+		}
+
+		props[name] = uniform
+
+	}
+
+	return props
+
+}
+
+func (w *objectWriter) writeTexture(texture *textures.Texture) string {
+
+	if w.textures[texture] {
+		return texture.UUID
+	}
+	w.textures[texture] = true
+
+	props := map[string]interface{}{
+		"uuid":      texture.UUID,
+		"mapping":   texture.Mapping,
+		"repeat":    []float64{texture.Repeat.X, texture.Repeat.Y},
+		"offset":    []float64{texture.Offset.X, texture.Offset.Y},
+		"wrap":      []int{texture.WrapS, texture.WrapT},
+		"minFilter": texture.MinFilter,
+		"magFilter": texture.MagFilter,
+		"format":    texture.Format,
+		"flipY":     texture.FlipY,
+	}
+	if texture.Name != "" {
+		props["name"] = texture.Name
+	}
+
+	if image, err := w.writeImage(texture); err != nil {
+		if w.err == nil {
+			w.err = fmt.Errorf("texture %q: %v", texture.Name, err)
+		}
+	} else if image != "" {
+		props["image"] = image
+	}
+
+	w.doc.textures = append(w.doc.textures, props)
+
+	return texture.UUID
+
+}
+
+// writeImage embeds the image of texture as a PNG, or refers to its
+// source file if it has no image. Images have no uuid of their own,
+// so they take the uuid of the first texture that uses them.
+func (w *objectWriter) writeImage(texture *textures.Texture) (string, error) {
+
+	if texture.Image == nil {
+
+		if texture.SourceFile == "" {
+			return "", nil
+		}
+
+		w.doc.images = append(w.doc.images, map[string]interface{}{
+			"uuid": texture.UUID,
+			"url":  filepathToSlash(texture.SourceFile),
+		})
+
+		return texture.UUID, nil
+
+	}
+
+	if uuid, ok := w.images[texture.Image]; ok {
+		return uuid, nil
+	}
+
+	var b bytes.Buffer
+	if err := png.Encode(&b, texture.Image); err != nil {
+		return "", err
+	}
+
+	w.images[texture.Image] = texture.UUID
+	w.doc.images = append(w.doc.images, map[string]interface{}{
+		"uuid": texture.UUID,
+		"url":  "data:image/png;base64," + base64.StdEncoding.EncodeToString(b.Bytes()),
+	})
+
+	return texture.UUID, nil
+
+}
+
+// writeSkeleton writes the skeleton of mesh, its bones must all be part
+// of the tree being written. Skeletons have no uuid of their own, so
+// they take the uuid of the first mesh that is bound to them.
+func (w *objectWriter) writeSkeleton(props map[string]interface{}, mesh *objects.SkinnedMesh) error {
+
+	skeleton := mesh.Skeleton
+
+	uuid, ok := w.skeletons[skeleton]
+	if !ok {
+
+		bones := make([]string, len(skeleton.Bones))
+		inverses := make([][]float64, len(skeleton.Bones))
+
+		for i, bone := range skeleton.Bones {
+
+			if !w.nodes[bone] {
+				return fmt.Errorf("object %q: bone %d of the skeleton is not part of the tree", mesh.Name, i)
+			}
+			bones[i] = bone.UUID
+			inverses[i] = skeleton.BoneInverses[i].ToArray(nil, 0)
+
+		}
+
+		uuid = mesh.UUID
+		w.skeletons[skeleton] = uuid
+		w.doc.skeletons = append(w.doc.skeletons, map[string]interface{}{
+			"uuid":         uuid,
+			"bones":        bones,
+			"boneInverses": inverses,
+		})
+
+	}
+
+	props["skeleton"] = uuid
+
+	return nil
+
+}
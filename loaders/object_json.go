@@ -0,0 +1,418 @@
+package loaders
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/rydrman/three.go/cameras"
+	"github.com/rydrman/three.go/lights"
+	"github.com/rydrman/three.go/materials"
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/objects"
+	"github.com/rydrman/three.go/scenes"
+	"github.com/rydrman/three.go/textures"
+)
+
+// The version of the three.js JSON object format that is written,
+// documents of any version 4 are read
+const (
+	objectFormatVersion   = 4.5
+	objectFormatGenerator = "three.go"
+)
+
+// jsonProps holds the properties of an element of a
+// JSON object document as they appear in the document
+type jsonProps map[string]json.RawMessage
+
+// jsonField binds a key of the JSON object format to a field, which must
+// be a pointer to a float64, int, bool, string, *math3.Color (stored as
+// a hex value), *math3.Vector2 (stored as an array) or *textures.Texture
+// (stored as the uuid of the texture)
+type jsonField struct {
+	key   string
+	value interface{}
+}
+
+// jsonReader reads the properties of an element into fields, keeping
+// the first error so that many properties can be read before checking
+type jsonReader struct {
+	props jsonProps
+	err   error
+
+	// textures resolves the uuids of texture properties
+	textures map[string]*textures.Texture
+}
+
+// read unmarshals the value of key into v, it returns
+// false if the key is missing or cannot be read
+func (r *jsonReader) read(key string, v interface{}) bool {
+
+	raw, ok := r.props[key]
+	if !ok || r.err != nil {
+		return false
+	}
+
+	if err := json.Unmarshal(raw, v); err != nil {
+		r.err = fmt.Errorf("%s: %v", key, err)
+		return false
+	}
+
+	return true
+
+}
+
+// readFields reads the value of each field that is present, leaving
+// the others with the values that they already hold
+func (r *jsonReader) readFields(fields []jsonField) {
+
+	for _, f := range fields {
+
+		switch v := f.value.(type) {
+
+		case *math3.Color:
+			var hex int
+			if r.read(f.key, &hex) {
+				v.SetHex(hex)
+			}
+
+		case *math3.Vector2:
+			var array []float64
+			if r.read(f.key, &array) {
+				if len(array) != 2 {
+					r.err = fmt.Errorf("%s: expected 2 values, got %d", f.key, len(array))
+					return
+				}
+				v.FromArray(array, 0)
+			}
+
+		case **textures.Texture:
+			var uuid string
+			if r.read(f.key, &uuid) {
+				texture, ok := r.textures[uuid]
+				if !ok {
+					r.err = fmt.Errorf("%s: texture %q does not exist", f.key, uuid)
+					return
+				}
+				*v = texture
+			}
+
+		default:
+			r.read(f.key, v)
+
+		}
+
+	}
+
+}
+
+// writeFields stores the value of each field in props, textures are
+// written through texture and left out if they are nil
+func writeFields(props map[string]interface{}, fields []jsonField, texture func(*textures.Texture) string) {
+
+	for _, f := range fields {
+
+		switch v := f.value.(type) {
+
+		case *float64:
+			props[f.key] = *v
+		case *int:
+			props[f.key] = *v
+		case *bool:
+			props[f.key] = *v
+		case *string:
+			if *v != "" {
+				props[f.key] = *v
+			}
+		case *math3.Color:
+			props[f.key] = v.GetHex()
+		case *math3.Vector2:
+			props[f.key] = []float64{v.X, v.Y}
+		case **textures.Texture:
+			if *v != nil {
+				props[f.key] = texture(*v)
+			}
+
+		}
+
+	}
+
+}
+
+// wireframeFields are the wireframe properties of mesh materials
+func wireframeFields(wireframe *bool, linewidth *float64, linecap, linejoin *string) []jsonField {
+
+	return []jsonField{
+		{"wireframe", wireframe},
+		{"wireframeLinewidth", linewidth},
+		{"wireframeLinecap", linecap},
+		{"wireframeLinejoin", linejoin},
+	}
+
+}
+
+// materialFields returns the properties of material by their names
+// in three.js, it returns false for materials that are not supported
+func materialFields(material objects.Material) (string, []jsonField, bool) {
+
+	m := material.GetMaterial()
+
+	fields := []jsonField{
+		{"fog", &m.Fog},
+		{"lights", &m.Lights},
+		{"side", &m.Side},
+		{"shading", &m.Shading},
+		{"vertexColors", &m.VertexColors},
+		{"opacity", &m.Opacity},
+		{"transparent", &m.Transparent},
+		{"blending", &m.Blending},
+		{"blendSrc", &m.BlendSrc},
+		{"blendDst", &m.BlendDst},
+		{"blendEquation", &m.BlendEquation},
+		{"blendSrcAlpha", &m.BlendSrcAlpha},
+		{"blendDstAlpha", &m.BlendDstAlpha},
+		{"blendEquationAlpha", &m.BlendEquationAlpha},
+		{"depthFunc", &m.DepthFunc},
+		{"depthTest", &m.DepthTest},
+		{"depthWrite", &m.DepthWrite},
+		{"colorWrite", &m.ColorWrite},
+		{"clipIntersection", &m.ClipIntersection},
+		{"clipShadows", &m.ClipShadows},
+		{"precision", &m.Precision},
+		{"polygonOffset", &m.PolygonOffset},
+		{"polygonOffsetFactor", &m.PolygonOffsetFactor},
+		{"polygonOffsetUnits", &m.PolygonOffsetUnits},
+		{"alphaTest", &m.AlphaTest},
+		{"premultipliedAlpha", &m.PremultipliedAlpha},
+		{"visible", &m.Visible},
+	}
+
+	switch t := material.(type) {
+
+	case *materials.MeshBasicMaterial:
+		fields = append(fields, []jsonField{
+			{"color", t.Color},
+			{"combine", &t.Combine},
+			{"reflectivity", &t.Reflectivity},
+			{"refractionRatio", &t.RefractionRatio},
+			{"skinning", &t.Skinning},
+			{"morphTargets", &t.MorphTargets},
+		}...)
+		fields = append(fields, wireframeFields(&t.Wireframe, &t.WireframeLinewidth, &t.WireframeLinecap, &t.WireframeLinejoin)...)
+		return "MeshBasicMaterial", fields, true
+
+	case *materials.MeshLambertMaterial:
+		fields = append(fields, []jsonField{
+			{"color", t.Color},
+			{"emissive", t.Emissive},
+			{"emissiveIntensity", &t.EmissiveIntensity},
+			{"skinning", &t.Skinning},
+			{"morphTargets", &t.MorphTargets},
+			{"morphNormals", &t.MorphNormals},
+		}...)
+		fields = append(fields, wireframeFields(&t.Wireframe, &t.WireframeLinewidth, &t.WireframeLinecap, &t.WireframeLinejoin)...)
+		return "MeshLambertMaterial", fields, true
+
+	case *materials.MeshPhongMaterial:
+		fields = append(fields, []jsonField{
+			{"color", t.Color},
+			{"specular", t.Specular},
+			{"shininess", &t.Shininess},
+			{"emissive", t.Emissive},
+			{"emissiveIntensity", &t.EmissiveIntensity},
+			{"map", &t.Map},
+			{"specularMap", &t.SpecularMap},
+			{"emissiveMap", &t.EmissiveMap},
+			{"alphaMap", &t.AlphaMap},
+			{"bumpMap", &t.BumpMap},
+			{"bumpScale", &t.BumpScale},
+			{"normalMap", &t.NormalMap},
+			{"normalScale", t.NormalScale},
+			{"displacementScale", &t.DisplacementScale},
+			{"displacementBias", &t.DisplacementBias},
+			{"combine", &t.Combine},
+			{"reflectivity", &t.Reflectivity},
+			{"refractionRatio", &t.RefractionRatio},
+			{"skinning", &t.Skinning},
+			{"morphTargets", &t.MorphTargets},
+			{"morphNormals", &t.MorphNormals},
+		}...)
+		fields = append(fields, wireframeFields(&t.Wireframe, &t.WireframeLinewidth, &t.WireframeLinecap, &t.WireframeLinejoin)...)
+		return "MeshPhongMaterial", fields, true
+
+	case *materials.MeshStandardMaterial:
+		fields = append(fields, []jsonField{
+			{"color", t.Color},
+			{"roughness", &t.Roughness},
+			{"metalness", &t.Metalness},
+			{"emissive", t.Emissive},
+			{"emissiveIntensity", &t.EmissiveIntensity},
+			{"map", &t.Map},
+			{"roughnessMap", &t.RoughnessMap},
+			{"metalnessMap", &t.MetalnessMap},
+			{"emissiveMap", &t.EmissiveMap},
+			{"alphaMap", &t.AlphaMap},
+			{"aoMap", &t.AoMap},
+			{"aoMapIntensity", &t.AoMapIntensity},
+			{"bumpMap", &t.BumpMap},
+			{"bumpScale", &t.BumpScale},
+			{"normalMap", &t.NormalMap},
+			{"normalScale", t.NormalScale},
+			{"displacementScale", &t.DisplacementScale},
+			{"displacementBias", &t.DisplacementBias},
+			{"envMapIntensity", &t.EnvMapIntensity},
+			{"refractionRatio", &t.RefractionRatio},
+			{"skinning", &t.Skinning},
+			{"morphTargets", &t.MorphTargets},
+			{"morphNormals", &t.MorphNormals},
+		}...)
+		fields = append(fields, wireframeFields(&t.Wireframe, &t.WireframeLinewidth, &t.WireframeLinecap, &t.WireframeLinejoin)...)
+		return "MeshStandardMaterial", fields, true
+
+	case *materials.LineBasicMaterial:
+		fields = append(fields, []jsonField{
+			{"color", t.Color},
+			{"linewidth", &t.Linewidth},
+			{"linecap", &t.Linecap},
+			{"linejoin", &t.Linejoin},
+		}...)
+		return "LineBasicMaterial", fields, true
+
+	case *materials.PointsMaterial:
+		fields = append(fields, []jsonField{
+			{"color", t.Color},
+			{"size", &t.Size},
+			{"sizeAttenuation", &t.SizeAttenuation},
+		}...)
+		return "PointsMaterial", fields, true
+
+	case *materials.ShaderMaterial:
+		fields = append(fields, []jsonField{
+			{"vertexShader", &t.VertexShader},
+			{"fragmentShader", &t.FragmentShader},
+			{"linewidth", &t.Linewidth},
+			{"wireframe", &t.Wireframe},
+			{"wireframeLinewidth", &t.WireframeLinewidth},
+			{"clipping", &t.Clipping},
+			{"skinning", &t.Skinning},
+			{"morphTargets", &t.MorphTargets},
+			{"morphNormals", &t.MorphNormals},
+		}...)
+		return "ShaderMaterial", fields, true
+
+	}
+
+	return "", nil, false
+
+}
+
+// objectFields returns the type of node by its name in three.js, along
+// with the properties of that type and those shared by every object
+func objectFields(node objects.Node) (string, []jsonField) {
+
+	o := objects.ObjectOf(node)
+
+	fields := []jsonField{
+		{"castShadow", &o.CastShadow},
+		{"receiveShadow", &o.ReceiveShadow},
+		{"visible", &o.Visible},
+		{"frustumCulled", &o.FrustumCulled},
+		{"matrixAutoUpdate", &o.MatrixAutoUpdate},
+	}
+
+	lightFields := func(light *lights.Light) []jsonField {
+		return append(fields, jsonField{"color", light.Color}, jsonField{"intensity", &light.Intensity})
+	}
+
+	shadowFields := func(shadow *lights.LightShadow) []jsonField {
+		if shadow == nil {
+			return nil
+		}
+		return []jsonField{
+			{"shadowBias", &shadow.Bias},
+			{"shadowRadius", &shadow.Radius},
+			{"shadowMapSize", shadow.MapSize},
+		}
+	}
+
+	switch t := node.(type) {
+
+	case *scenes.Scene:
+		return "Scene", append(fields, jsonField{"background", t.BackgroundColor})
+
+	case *objects.Mesh:
+		return "Mesh", append(fields, jsonField{"drawMode", &t.DrawMode})
+
+	case *objects.SkinnedMesh:
+		return "SkinnedMesh", append(fields, jsonField{"drawMode", &t.DrawMode}, jsonField{"bindMode", &t.BindMode})
+
+	case *objects.Bone:
+		return "Bone", fields
+
+	case *objects.Line:
+		return "Line", fields
+
+	case *objects.LineLoop:
+		return "LineLoop", fields
+
+	case *objects.LineSegments:
+		return "LineSegments", fields
+
+	case *objects.Points:
+		return "Points", fields
+
+	case *objects.Sprite:
+		return "Sprite", fields
+
+	case *cameras.PerspectiveCamera:
+		return "PerspectiveCamera", append(fields, []jsonField{
+			{"fov", &t.Fov},
+			{"zoom", &t.Zoom},
+			{"aspect", &t.Aspect},
+			{"near", &t.Near},
+			{"far", &t.Far},
+			{"focus", &t.Focus},
+			{"filmGauge", &t.FilmGauge},
+			{"filmOffset", &t.FilmOffset},
+		}...)
+
+	case *cameras.OrthographicCamera:
+		return "OrthographicCamera", append(fields, []jsonField{
+			{"zoom", &t.Zoom},
+			{"left", &t.Left},
+			{"right", &t.Right},
+			{"top", &t.Top},
+			{"bottom", &t.Bottom},
+			{"near", &t.Near},
+			{"far", &t.Far},
+		}...)
+
+	case *lights.AmbientLight:
+		return "AmbientLight", lightFields(t.Light)
+
+	case *lights.DirectionalLight:
+		return "DirectionalLight", append(lightFields(t.Light), shadowFields(t.Shadow)...)
+
+	case *lights.PointLight:
+		fields = append(lightFields(t.Light), jsonField{"distance", &t.Distance}, jsonField{"decay", &t.Decay})
+		return "PointLight", append(fields, shadowFields(t.Shadow)...)
+
+	case *lights.SpotLight:
+		fields = append(lightFields(t.Light), []jsonField{
+			{"distance", &t.Distance},
+			{"angle", &t.Angle},
+			{"penumbra", &t.Penumbra},
+			{"decay", &t.Decay},
+		}...)
+		return "SpotLight", append(fields, shadowFields(t.Shadow)...)
+
+	case *lights.HemisphereLight:
+		return "HemisphereLight", append(lightFields(t.Light), jsonField{"groundColor", t.GroundColor})
+
+	case *lights.RectAreaLight:
+		return "RectAreaLight", append(lightFields(t.Light), jsonField{"width", &t.Width}, jsonField{"height", &t.Height})
+
+	}
+
+	return "Object3D", fields
+
+}
@@ -0,0 +1,1085 @@
+package loaders
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/rydrman/three.go/cameras"
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/geometries"
+	"github.com/rydrman/three.go/lights"
+	"github.com/rydrman/three.go/materials"
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/objects"
+	"github.com/rydrman/three.go/scenes"
+	"github.com/rydrman/three.go/textures"
+)
+
+// ObjectLoader reads scenes and objects in the JSON object format 4 of
+// three.js, as written by ToJSON and by the toJSON methods of three.js
+type ObjectLoader struct {
+	// Resolver opens the images that are not embedded in the document
+	Resolver Resolver
+}
+
+// NewObjectLoader creates a loader that opens referenced images with resolver
+func NewObjectLoader(resolver Resolver) *ObjectLoader {
+
+	return &ObjectLoader{
+		Resolver: resolver,
+	}
+
+}
+
+// Load opens and parses the named file through the resolver of this loader
+func (l *ObjectLoader) Load(name string) (objects.Node, error) {
+
+	if l.Resolver == nil {
+		return nil, fmt.Errorf("loaders: cannot open %q without a resolver", name)
+	}
+
+	file, err := l.Resolver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return l.Parse(file)
+
+}
+
+// Parse reads a document from r and returns its object, which is a
+// *scenes.Scene for scenes. Objects, geometries, materials and textures
+// keep their uuids. Geometries may be buffer geometries or any of the
+// shapes of the geometries package, and images that cannot be opened
+// leave their textures without an image.
+func (l *ObjectLoader) Parse(r io.Reader) (objects.Node, error) {
+
+	var doc struct {
+		Metadata struct {
+			Version float64 `json:"version"`
+			Type    string  `json:"type"`
+		} `json:"metadata"`
+
+		Geometries []jsonProps `json:"geometries"`
+		Materials  []jsonProps `json:"materials"`
+		Textures   []jsonProps `json:"textures"`
+		Images     []jsonProps `json:"images"`
+		Skeletons  []jsonProps `json:"skeletons"`
+
+		Object jsonProps `json:"object"`
+	}
+
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("loaders: JSON: %v", err)
+	}
+
+	if !strings.EqualFold(doc.Metadata.Type, "Object") || doc.Metadata.Version < 4 || doc.Metadata.Version >= 5 {
+		return nil, fmt.Errorf("loaders: JSON: unsupported document type %q version %v", doc.Metadata.Type, doc.Metadata.Version)
+	}
+	if doc.Object == nil {
+		return nil, fmt.Errorf("loaders: JSON: document has no object")
+	}
+
+	p := &objectParser{
+		resolver:   l.Resolver,
+		images:     make(map[string]*objectImage),
+		textures:   make(map[string]*textures.Texture),
+		materials:  make(map[string]objects.Material),
+		geometries: make(map[string]*core.BufferGeometry),
+		skeletons:  make(map[string]jsonProps),
+		nodes:      make(map[string]objects.Node),
+	}
+
+	node, err := p.parse(doc.Images, doc.Textures, doc.Materials, doc.Geometries, doc.Skeletons, doc.Object)
+	if err != nil {
+		return nil, fmt.Errorf("loaders: JSON: %v", err)
+	}
+
+	return node, nil
+
+}
+
+// objectImage is an image of a document along with its url
+type objectImage struct {
+	image image.Image
+	url   string
+}
+
+// objectParser holds the state of a single call to Parse
+type objectParser struct {
+	resolver Resolver
+
+	images     map[string]*objectImage
+	textures   map[string]*textures.Texture
+	materials  map[string]objects.Material
+	geometries map[string]*core.BufferGeometry
+	skeletons  map[string]jsonProps
+	nodes      map[string]objects.Node
+
+	// references are resolved once every object has been created
+	skinned []jsonReference
+	targets []jsonReference
+}
+
+// jsonReference is an object that refers to other objects by uuid
+type jsonReference struct {
+	node  objects.Node
+	props jsonProps
+}
+
+func (p *objectParser) parse(images, textureList, materialList, geometryList, skeletons []jsonProps, object jsonProps) (objects.Node, error) {
+
+	for i, props := range images {
+		if err := p.parseImage(props); err != nil {
+			return nil, fmt.Errorf("image %d: %v", i, err)
+		}
+	}
+
+	for i, props := range textureList {
+		if err := p.parseTexture(props); err != nil {
+			return nil, fmt.Errorf("texture %d: %v", i, err)
+		}
+	}
+
+	for i, props := range materialList {
+
+		material, err := p.parseMaterial(props)
+		if err != nil {
+			return nil, fmt.Errorf("material %d: %v", i, err)
+		}
+		p.materials[material.GetMaterial().UUID] = material
+
+	}
+
+	for i, props := range geometryList {
+
+		geometry, err := p.parseGeometry(props)
+		if err != nil {
+			return nil, fmt.Errorf("geometry %d: %v", i, err)
+		}
+		p.geometries[geometry.UUID] = geometry
+
+	}
+
+	for i, props := range skeletons {
+
+		var uuid string
+		if err := json.Unmarshal(props["uuid"], &uuid); err != nil {
+			return nil, fmt.Errorf("skeleton %d: uuid: %v", i, err)
+		}
+		p.skeletons[uuid] = props
+
+	}
+
+	root, err := p.parseObject(object)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.resolveReferences(root); err != nil {
+		return nil, err
+	}
+
+	return root, nil
+
+}
+
+func (p *objectParser) parseImage(props jsonProps) error {
+
+	r := &jsonReader{props: props}
+
+	var uuid, url string
+	r.read("uuid", &uuid)
+	r.read("url", &url)
+	if r.err != nil {
+		return r.err
+	}
+
+	img := &objectImage{url: url}
+	p.images[uuid] = img
+
+	if strings.HasPrefix(url, "data:") {
+
+		data, err := decodeDataURI(url)
+		if err != nil {
+			return err
+		}
+		if img.image, _, err = image.Decode(bytes.NewReader(data)); err != nil {
+			return err
+		}
+		img.url = ""
+		return nil
+
+	}
+
+	img.image = loadTexture(p.resolver, url).Image
+
+	return nil
+
+}
+
+func (p *objectParser) parseTexture(props jsonProps) error {
+
+	texture := textures.NewTexture(nil)
+
+	r := &jsonReader{props: props}
+	r.read("uuid", &texture.UUID)
+	r.read("name", &texture.Name)
+	r.readFields([]jsonField{
+		{"mapping", &texture.Mapping},
+		{"repeat", texture.Repeat},
+		{"offset", texture.Offset},
+		{"minFilter", &texture.MinFilter},
+		{"magFilter", &texture.MagFilter},
+		{"format", &texture.Format},
+		{"flipY", &texture.FlipY},
+	})
+
+	var wrap []int
+	if r.read("wrap", &wrap) {
+		if len(wrap) != 2 {
+			return fmt.Errorf("wrap: expected 2 values, got %d", len(wrap))
+		}
+		texture.WrapS, texture.WrapT = wrap[0], wrap[1]
+	}
+
+	var uuid string
+	if r.read("image", &uuid) {
+
+		img, ok := p.images[uuid]
+		if !ok {
+			return fmt.Errorf("image %q does not exist", uuid)
+		}
+		texture.Image = img.image
+		texture.SourceFile = img.url
+
+	}
+
+	if r.err != nil {
+		return r.err
+	}
+
+	p.textures[texture.UUID] = texture
+
+	return nil
+
+}
+
+// newMaterial creates a material of the named type, the types of three.js
+// that have no equivalent here are replaced by their closest match
+func newMaterial(kind string) (objects.Material, error) {
+
+	switch kind {
+
+	case "MeshBasicMaterial":
+		return materials.NewMeshBasicMaterial(), nil
+
+	case "MeshLambertMaterial":
+		return materials.NewMeshLambertMaterial(), nil
+
+	case "MeshPhongMaterial":
+		return materials.NewMeshPhongMaterial(), nil
+
+	case "MeshToonMaterial":
+		glog.Warningf("loaders: %s is loaded as a MeshPhongMaterial", kind)
+		return materials.NewMeshPhongMaterial(), nil
+
+	case "MeshStandardMaterial":
+		return materials.NewMeshStandardMaterial(), nil
+
+	case "MeshPhysicalMaterial":
+		glog.Warningf("loaders: %s is loaded as a MeshStandardMaterial", kind)
+		return materials.NewMeshStandardMaterial(), nil
+
+	case "LineBasicMaterial":
+		return materials.NewLineBasicMaterial(), nil
+
+	case "LineDashedMaterial":
+		glog.Warningf("loaders: %s is loaded as a LineBasicMaterial", kind)
+		return materials.NewLineBasicMaterial(), nil
+
+	case "PointsMaterial":
+		return materials.NewPointsMaterial(), nil
+
+	case "ShaderMaterial", "RawShaderMaterial":
+		return materials.NewShaderMaterial(nil), nil
+
+	}
+
+	return nil, fmt.Errorf("unsupported type %q", kind)
+
+}
+
+func (p *objectParser) parseMaterial(props jsonProps) (objects.Material, error) {
+
+	r := &jsonReader{props: props, textures: p.textures}
+
+	var kind string
+	r.read("type", &kind)
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	// multi materials were written with their materials nested before r85
+	if kind == "MultiMaterial" {
+
+		var list []jsonProps
+		r.read("materials", &list)
+
+		multi := materials.NewMultiMaterial()
+		for i, m := range list {
+
+			material, err := p.parseMaterial(m)
+			if err != nil {
+				return nil, fmt.Errorf("material %d: %v", i, err)
+			}
+			multi.Materials = append(multi.Materials, material)
+
+		}
+
+		r.read("uuid", &multi.UUID)
+		r.read("name", &multi.Name)
+
+		return multi, r.err
+
+	}
+
+	material, err := newMaterial(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	base := material.GetMaterial()
+	r.read("uuid", &base.UUID)
+	r.read("name", &base.Name)
+
+	_, fields, _ := materialFields(material)
+	r.readFields(fields)
+
+	if shader, ok := material.(*materials.ShaderMaterial); ok {
+
+		var defines map[string]interface{}
+		r.read("defines", &defines)
+		for name, value := range defines {
+			shader.Defines[name] = fmt.Sprint(value)
+		}
+
+		var uniforms map[string]jsonProps
+		r.read("uniforms", &uniforms)
+		for name, uniform := range uniforms {
+			if err := p.parseUniform(shader.Uniforms, name, uniform); err != nil {
+				return nil, fmt.Errorf("uniform %q: %v", name, err)
+			}
+		}
+
+	}
+
+	return material, r.err
+
+}
+
+// parseUniform adds a uniform to uniforms, the type names
+// of three.js are used to tell vectors and matrices apart
+func (p *objectParser) parseUniform(uniforms *core.UniformGroup, name string, props jsonProps) error {
+
+	r := &jsonReader{props: props}
+
+	var kind string
+	r.read("type", &kind)
+
+	switch kind {
+
+	case "c":
+		var hex int
+		if r.read("value", &hex); r.err != nil {
+			return r.err
+		}
+		uniforms.Add(name, core.NewUniform(math3.NewColor().SetHex(hex)))
+
+	case "t":
+		var uuid string
+		if r.read("value", &uuid); r.err != nil {
+			return r.err
+		}
+		texture, ok := p.textures[uuid]
+		if !ok {
+			return fmt.Errorf("texture %q does not exist", uuid)
+		}
+		uniforms.Add(name, core.NewUniform(texture))
+
+	case "v2", "v3", "v4", "m3", "m4":
+		var array []float64
+		if r.read("value", &array); r.err != nil {
+			return r.err
+		}
+
+		sizes := map[string]int{"v2": 2, "v3": 3, "v4": 4, "m3": 9, "m4": 16}
+		if len(array) != sizes[kind] {
+			return fmt.Errorf("expected %d values, got %d", sizes[kind], len(array))
+		}
+
+		switch kind {
+		case "v2":
+			uniforms.Add(name, core.NewUniform(math3.NewVector2().FromArray(array, 0)))
+		case "v3":
+			uniforms.Add(name, core.NewUniform(math3.NewVector3().FromArray(array, 0)))
+		case "v4":
+			uniforms.Add(name, core.NewUniform(math3.NewVector4().FromArray(array, 0)))
+		case "m3":
+			uniforms.Add(name, core.NewUniform(math3.NewMatrix3().FromArray(array)))
+		case "m4":
+			uniforms.Add(name, core.NewUniform(math3.NewMatrix4().FromArray(array)))
+		}
+
+	default:
+		var value interface{}
+		r.read("value", &value)
+
+		switch v := value.(type) {
+		case float64:
+			uniforms.Add(name, core.NewUniform(v))
+		case bool:
+			uniforms.Add(name, core.NewUniform(v))
+		default:
+			if r.err == nil {
+				glog.Warningf("loaders: skipping uniform %q of unknown type %q", name, kind)
+			}
+		}
+
+	}
+
+	return r.err
+
+}
+
+// jsonAttribute is a buffer attribute of a geometry,
+// array is stored as the typed array named by Type
+type jsonAttribute struct {
+	Name       string    `json:"name"`
+	ItemSize   int       `json:"itemSize"`
+	Type       string    `json:"type"`
+	Array      []float64 `json:"array"`
+	Normalized bool      `json:"normalized"`
+}
+
+// typedArrayScales normalizes the values of the
+// integer typed arrays that have no attribute type
+var typedArrayScales = map[string]float64{
+	"Int8Array":         math.MaxInt8,
+	"Uint8Array":        math.MaxUint8,
+	"Uint8ClampedArray": math.MaxUint8,
+	"Int16Array":        math.MaxInt16,
+	"Int32Array":        math.MaxInt32,
+}
+
+// buildAttribute creates a buffer attribute, integer data other than
+// 16 and 32 bit unsigned integers is converted to float32, normalizing
+// the values of normalized attributes
+func (a *jsonAttribute) buildAttribute() (*core.BufferAttribute, error) {
+
+	if a.ItemSize < 1 || len(a.Array)%a.ItemSize != 0 {
+		return nil, fmt.Errorf("%d values do not fit an item size of %d", len(a.Array), a.ItemSize)
+	}
+
+	var attribute *core.BufferAttribute
+
+	switch a.Type {
+
+	case "Uint16Array":
+		array := make([]uint16, len(a.Array))
+		for i, v := range a.Array {
+			array[i] = uint16(v)
+		}
+		attribute = core.NewUint16BufferAttribute(array, a.ItemSize)
+		attribute.Normalized = a.Normalized
+
+	case "Uint32Array":
+		array := make([]uint32, len(a.Array))
+		for i, v := range a.Array {
+			array[i] = uint32(v)
+		}
+		attribute = core.NewUint32BufferAttribute(array, a.ItemSize)
+		attribute.Normalized = a.Normalized
+
+	case "Float32Array", "Float64Array", "Int8Array", "Uint8Array", "Uint8ClampedArray", "Int16Array", "Int32Array":
+		scale := 1.0
+		if a.Normalized && typedArrayScales[a.Type] != 0 {
+			scale = 1 / typedArrayScales[a.Type]
+		}
+		array := make([]float32, len(a.Array))
+		for i, v := range a.Array {
+			array[i] = float32(v * scale)
+		}
+		attribute = core.NewFloat32BufferAttribute(array, a.ItemSize)
+
+	default:
+		return nil, fmt.Errorf("unknown array type %q", a.Type)
+
+	}
+
+	attribute.Name = a.Name
+
+	return attribute, nil
+
+}
+
+func (p *objectParser) parseGeometry(props jsonProps) (*core.BufferGeometry, error) {
+
+	r := &jsonReader{props: props}
+
+	var kind string
+	r.read("type", &kind)
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	var geometry *core.BufferGeometry
+	var err error
+
+	if kind == "BufferGeometry" {
+		geometry, err = parseBufferGeometry(r)
+	} else {
+		geometry, err = parseShapeGeometry(r, kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r.read("uuid", &geometry.UUID)
+	r.read("name", &geometry.Name)
+
+	return geometry, r.err
+
+}
+
+func parseBufferGeometry(r *jsonReader) (*core.BufferGeometry, error) {
+
+	var data struct {
+		Index *struct {
+			Type  string `json:"type"`
+			Array []int  `json:"array"`
+		} `json:"index"`
+		Attributes      map[string]*jsonAttribute   `json:"attributes"`
+		MorphAttributes map[string][]*jsonAttribute `json:"morphAttributes"`
+		Groups          []core.Group                `json:"groups"`
+		BoundingSphere  *struct {
+			Center []float64 `json:"center"`
+			Radius float64   `json:"radius"`
+		} `json:"boundingSphere"`
+	}
+	if !r.read("data", &data) {
+		if r.err == nil {
+			r.err = fmt.Errorf("buffer geometry has no data")
+		}
+		return nil, r.err
+	}
+
+	geometry := core.NewBufferGeometry()
+
+	for name, a := range data.Attributes {
+
+		attribute, err := a.buildAttribute()
+		if err != nil {
+			return nil, fmt.Errorf("attribute %s: %v", name, err)
+		}
+		geometry.AddAttribute(name, attribute)
+
+	}
+
+	for name, targets := range data.MorphAttributes {
+		for i, a := range targets {
+
+			attribute, err := a.buildAttribute()
+			if err != nil {
+				return nil, fmt.Errorf("morph target %d of %s: %v", i, name, err)
+			}
+			geometry.MorphAttributes[name] = append(geometry.MorphAttributes[name], attribute)
+
+		}
+	}
+
+	if data.Index != nil {
+
+		if position := geometry.GetAttribute("position"); position != nil {
+			for _, index := range data.Index.Array {
+				if index < 0 || index >= position.Count() {
+					return nil, fmt.Errorf("index %d is out of range of %d vertices", index, position.Count())
+				}
+			}
+		}
+
+		index := core.NewIndexAttribute(data.Index.Array)
+		if data.Index.Type == "Uint32Array" && index.Uint16 != nil {
+			array := make([]uint32, len(data.Index.Array))
+			for i, v := range data.Index.Array {
+				array[i] = uint32(v)
+			}
+			index = core.NewUint32BufferAttribute(array, 1)
+		}
+		geometry.SetIndex(index)
+
+	}
+
+	geometry.Groups = data.Groups
+
+	if sphere := data.BoundingSphere; sphere != nil && len(sphere.Center) == 3 {
+		geometry.BoundingSphere = math3.NewSphere().Set(math3.NewVector3().FromArray(sphere.Center, 0), sphere.Radius)
+	}
+
+	return geometry, nil
+
+}
+
+// parseShapeGeometry creates the geometries that three.js stores as their
+// parameters, using the defaults of three.js for any missing parameters
+func parseShapeGeometry(r *jsonReader, kind string) (*core.BufferGeometry, error) {
+
+	float := func(key string, value float64) float64 {
+		r.read(key, &value)
+		return value
+	}
+	integer := func(key string, value int) int {
+		r.read(key, &value)
+		return value
+	}
+	boolean := func(key string, value bool) bool {
+		r.read(key, &value)
+		return value
+	}
+
+	var geometry *core.BufferGeometry
+
+	switch strings.TrimSuffix(strings.TrimSuffix(kind, "Geometry"), "Buffer") {
+
+	case "Box":
+		geometry = geometries.NewBoxGeometry(
+			float("width", 1), float("height", 1), float("depth", 1),
+			integer("widthSegments", 1), integer("heightSegments", 1), integer("depthSegments", 1),
+		)
+
+	case "Plane":
+		geometry = geometries.NewPlaneGeometry(
+			float("width", 1), float("height", 1),
+			integer("widthSegments", 1), integer("heightSegments", 1),
+		)
+
+	case "Circle":
+		geometry = geometries.NewCircleGeometry(
+			float("radius", 50), integer("segments", 8),
+			float("thetaStart", 0), float("thetaLength", 2*math.Pi),
+		)
+
+	case "Cylinder":
+		geometry = geometries.NewCylinderGeometry(
+			float("radiusTop", 20), float("radiusBottom", 20), float("height", 100),
+			integer("radialSegments", 8), integer("heightSegments", 1), boolean("openEnded", false),
+			float("thetaStart", 0), float("thetaLength", 2*math.Pi),
+		)
+
+	case "Cone":
+		geometry = geometries.NewConeGeometry(
+			float("radius", 20), float("height", 100),
+			integer("radialSegments", 8), integer("heightSegments", 1), boolean("openEnded", false),
+			float("thetaStart", 0), float("thetaLength", 2*math.Pi),
+		)
+
+	case "Sphere":
+		geometry = geometries.NewSphereGeometry(
+			float("radius", 50), integer("widthSegments", 8), integer("heightSegments", 6),
+			float("phiStart", 0), float("phiLength", 2*math.Pi),
+			float("thetaStart", 0), float("thetaLength", math.Pi),
+		)
+
+	case "Ring":
+		geometry = geometries.NewRingGeometry(
+			float("innerRadius", 20), float("outerRadius", 50),
+			integer("thetaSegments", 8), integer("phiSegments", 1),
+			float("thetaStart", 0), float("thetaLength", 2*math.Pi),
+		)
+
+	case "Torus":
+		geometry = geometries.NewTorusGeometry(
+			float("radius", 100), float("tube", 40),
+			integer("radialSegments", 8), integer("tubularSegments", 6), float("arc", 2*math.Pi),
+		)
+
+	case "TorusKnot":
+		geometry = geometries.NewTorusKnotGeometry(
+			float("radius", 100), float("tube", 40),
+			integer("tubularSegments", 64), integer("radialSegments", 8), integer("p", 2), integer("q", 3),
+		)
+
+	case "Tetrahedron":
+		geometry = geometries.NewTetrahedronGeometry(float("radius", 1), integer("detail", 0))
+
+	case "Octahedron":
+		geometry = geometries.NewOctahedronGeometry(float("radius", 1), integer("detail", 0))
+
+	case "Icosahedron":
+		geometry = geometries.NewIcosahedronGeometry(float("radius", 1), integer("detail", 0))
+
+	case "Dodecahedron":
+		geometry = geometries.NewDodecahedronGeometry(float("radius", 1), integer("detail", 0))
+
+	case "Lathe":
+		var points []*math3.Vector2
+		r.read("points", &points)
+		geometry = geometries.NewLatheGeometry(
+			points, integer("segments", 12), float("phiStart", 0), float("phiLength", 2*math.Pi),
+		)
+
+	default:
+		return nil, fmt.Errorf("unsupported type %q", kind)
+
+	}
+
+	return geometry, r.err
+
+}
+
+// newObject creates an object of the named type with the geometry and
+// material that it uses, types that are not supported become objects
+func newObject(kind string, geometry *core.BufferGeometry, material objects.Material) objects.Node {
+
+	switch kind {
+
+	case "Scene":
+		return scenes.NewScene()
+
+	case "Object3D", "Group":
+		return objects.NewObject()
+
+	case "Mesh":
+		if material == nil {
+			material = materials.NewMeshBasicMaterial()
+		}
+		return objects.NewMesh(geometry, material)
+
+	case "SkinnedMesh":
+		if material == nil {
+			material = materials.NewMeshBasicMaterial()
+		}
+		return objects.NewSkinnedMesh(geometry, material)
+
+	case "Bone":
+		return objects.NewBone()
+
+	case "Line", "LineLoop", "LineSegments":
+		if material == nil {
+			material = materials.NewLineBasicMaterial()
+		}
+		switch kind {
+		case "LineLoop":
+			return objects.NewLineLoop(geometry, material)
+		case "LineSegments":
+			return objects.NewLineSegments(geometry, material)
+		}
+		return objects.NewLine(geometry, material)
+
+	case "Points":
+		if material == nil {
+			material = materials.NewPointsMaterial()
+		}
+		return objects.NewPoints(geometry, material)
+
+	case "Sprite":
+		return objects.NewSprite(material)
+
+	case "PerspectiveCamera":
+		return cameras.NewPerspectiveCamera(50, 1, 0.1, 2000)
+
+	case "OrthographicCamera":
+		return cameras.NewOrthographicCamera(-1, 1, 1, -1, 0.1, 2000)
+
+	case "AmbientLight":
+		return lights.NewAmbientLight(math3.NewColor(), 1)
+
+	case "DirectionalLight":
+		return lights.NewDirectionalLight(math3.NewColor(), 1)
+
+	case "PointLight":
+		return lights.NewPointLight(math3.NewColor(), 1, 0, 1)
+
+	case "SpotLight":
+		return lights.NewSpotLight(math3.NewColor(), 1, 0, math.Pi/3, 0, 1)
+
+	case "HemisphereLight":
+		return lights.NewHemisphereLight(math3.NewColor(), math3.NewColor(), 1)
+
+	case "RectAreaLight":
+		return lights.NewRectAreaLight(math3.NewColor(), 1, 10, 10)
+
+	}
+
+	glog.Warningf("loaders: %s is loaded as an Object3D", kind)
+
+	return objects.NewObject()
+
+}
+
+func (p *objectParser) parseObject(props jsonProps) (objects.Node, error) {
+
+	r := &jsonReader{props: props, textures: p.textures}
+
+	var kind, uuid, name string
+	r.read("type", &kind)
+	r.read("uuid", &uuid)
+	r.read("name", &name)
+
+	var geometry *core.BufferGeometry
+	var geometryUUID string
+	if r.read("geometry", &geometryUUID) {
+
+		var ok bool
+		if geometry, ok = p.geometries[geometryUUID]; !ok {
+			return nil, fmt.Errorf("object %q: geometry %q does not exist", name, geometryUUID)
+		}
+
+	}
+
+	material, err := p.objectMaterial(props["material"])
+	if err != nil {
+		return nil, fmt.Errorf("object %q: %v", name, err)
+	}
+
+	node := newObject(kind, geometry, material)
+	o := objects.ObjectOf(node)
+
+	if uuid != "" {
+		o.UUID = uuid
+	}
+	o.Name = name
+
+	_, fields := objectFields(node)
+	r.readFields(fields)
+
+	var matrix []float64
+	if r.read("matrix", &matrix) {
+
+		if len(matrix) != 16 {
+			return nil, fmt.Errorf("object %q: matrix has %d values, expected 16", name, len(matrix))
+		}
+		o.ApplyMatrix(math3.NewMatrix4().FromArray(matrix))
+
+	}
+
+	switch t := node.(type) {
+
+	case *objects.Mesh:
+		if len(t.Geometry.MorphAttributes) > 0 {
+			t.UpdateMorphTargets()
+		}
+
+	case *objects.SkinnedMesh:
+		if len(t.Geometry.MorphAttributes) > 0 {
+			t.UpdateMorphTargets()
+		}
+		if _, ok := props["skeleton"]; ok {
+			p.skinned = append(p.skinned, jsonReference{node: t, props: props})
+		}
+
+	case *cameras.PerspectiveCamera:
+		t.UpdateProjectionMatrix()
+
+	case *cameras.OrthographicCamera:
+		t.UpdateProjectionMatrix()
+
+	case *lights.DirectionalLight, *lights.SpotLight:
+		if _, ok := props["target"]; ok {
+			p.targets = append(p.targets, jsonReference{node: node, props: props})
+		}
+
+	}
+
+	if r.err != nil {
+		return nil, fmt.Errorf("object %q: %v", name, r.err)
+	}
+
+	if _, exists := p.nodes[o.UUID]; exists {
+		return nil, fmt.Errorf("object %q: uuid %s is used more than once", name, o.UUID)
+	}
+	p.nodes[o.UUID] = node
+
+	var children []jsonProps
+	r.read("children", &children)
+	if r.err != nil {
+		return nil, fmt.Errorf("object %q: %v", name, r.err)
+	}
+
+	for _, child := range children {
+
+		c, err := p.parseObject(child)
+		if err != nil {
+			return nil, err
+		}
+		node.Add(c)
+
+	}
+
+	return node, nil
+
+}
+
+// objectMaterial returns the material of an object, which is
+// either the uuid of a material or a list of uuids
+func (p *objectParser) objectMaterial(raw json.RawMessage) (objects.Material, error) {
+
+	if raw == nil {
+		return nil, nil
+	}
+
+	var uuids []string
+	if err := json.Unmarshal(raw, &uuids); err != nil {
+
+		var uuid string
+		if err := json.Unmarshal(raw, &uuid); err != nil {
+			return nil, fmt.Errorf("material: %v", err)
+		}
+		uuids = []string{uuid}
+
+	}
+
+	multi := materials.NewMultiMaterial()
+	for _, uuid := range uuids {
+
+		material, ok := p.materials[uuid]
+		if !ok {
+			return nil, fmt.Errorf("material %q does not exist", uuid)
+		}
+		multi.Materials = append(multi.Materials, material)
+
+	}
+
+	if raw[0] != '[' {
+		return multi.Materials[0], nil
+	}
+
+	return multi, nil
+
+}
+
+// resolveReferences binds skinned meshes to their skeletons and
+// points lights at their targets once every object exists
+func (p *objectParser) resolveReferences(root objects.Node) error {
+
+	root.UpdateMatrixWorld(true)
+
+	for _, target := range p.targets {
+
+		o := objects.ObjectOf(target.node)
+
+		var uuid string
+		if err := json.Unmarshal(target.props["target"], &uuid); err != nil {
+			return fmt.Errorf("object %q: target: %v", o.Name, err)
+		}
+
+		node, ok := p.nodes[uuid]
+		if !ok {
+			return fmt.Errorf("object %q: target %q does not exist", o.Name, uuid)
+		}
+
+		switch t := target.node.(type) {
+		case *lights.DirectionalLight:
+			t.Target = node
+		case *lights.SpotLight:
+			t.Target = node
+		}
+
+	}
+
+	skeletons := make(map[string]*objects.Skeleton)
+
+	for _, skinned := range p.skinned {
+
+		mesh := skinned.node.(*objects.SkinnedMesh)
+
+		r := &jsonReader{props: skinned.props}
+
+		var uuid string
+		var bindMatrix []float64
+		r.read("skeleton", &uuid)
+		r.read("bindMatrix", &bindMatrix)
+		if r.err != nil {
+			return fmt.Errorf("object %q: %v", mesh.Name, r.err)
+		}
+
+		skeleton, ok := skeletons[uuid]
+		if !ok {
+
+			var err error
+			if skeleton, err = p.parseSkeleton(uuid); err != nil {
+				return fmt.Errorf("object %q: %v", mesh.Name, err)
+			}
+			skeletons[uuid] = skeleton
+
+		}
+
+		var matrix *math3.Matrix4
+		if len(bindMatrix) == 16 {
+			matrix = math3.NewMatrix4().FromArray(bindMatrix)
+		}
+		mesh.Bind(skeleton, matrix)
+
+	}
+
+	return nil
+
+}
+
+func (p *objectParser) parseSkeleton(uuid string) (*objects.Skeleton, error) {
+
+	props, ok := p.skeletons[uuid]
+	if !ok {
+		return nil, fmt.Errorf("skeleton %q does not exist", uuid)
+	}
+
+	r := &jsonReader{props: props}
+
+	var boneUUIDs []string
+	var inverses [][]float64
+	r.read("bones", &boneUUIDs)
+	r.read("boneInverses", &inverses)
+	if r.err != nil {
+		return nil, fmt.Errorf("skeleton %q: %v", uuid, r.err)
+	}
+
+	bones := make([]*objects.Bone, len(boneUUIDs))
+	for i, boneUUID := range boneUUIDs {
+
+		bone, ok := p.nodes[boneUUID].(*objects.Bone)
+		if !ok {
+			return nil, fmt.Errorf("skeleton %q: bone %q does not exist", uuid, boneUUID)
+		}
+		bones[i] = bone
+
+	}
+
+	var boneInverses []*math3.Matrix4
+	if inverses != nil {
+
+		if len(inverses) != len(bones) {
+			return nil, fmt.Errorf("skeleton %q: %d inverses for %d bones", uuid, len(inverses), len(bones))
+		}
+
+		boneInverses = make([]*math3.Matrix4, len(inverses))
+		for i, inverse := range inverses {
+
+			if len(inverse) != 16 {
+				return nil, fmt.Errorf("skeleton %q: inverse %d has %d values, expected 16", uuid, i, len(inverse))
+			}
+			boneInverses[i] = math3.NewMatrix4().FromArray(inverse)
+
+		}
+
+	}
+
+	return objects.NewSkeleton(bones, boneInverses), nil
+
+}
@@ -0,0 +1,238 @@
+package loaders_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/cameras"
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/lights"
+	"github.com/rydrman/three.go/loaders"
+	"github.com/rydrman/three.go/materials"
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/objects"
+	"github.com/rydrman/three.go/scenes"
+)
+
+func TestObjectLoader_RoundTrip(t *testing.T) {
+	scene, _ := testExportScene()
+	scene.BackgroundColor = math3.NewColor().SetHex(0x336699)
+
+	quad := findByName(scene, "quad").(*objects.Mesh)
+	copied := objects.NewMesh(quad.Geometry, materials.NewMeshPhongMaterial())
+	copied.Name = "copy"
+	copied.MatrixAutoUpdate = false
+	copied.Matrix.MakeTranslation(0, 0, -4)
+	scene.Add(copied)
+
+	shader := materials.NewShaderMaterial(nil)
+	shader.Name = "shader"
+	shader.Defines["STEPS"] = "4"
+	shader.Uniforms.Add("tint", core.NewUniform(math3.NewColor().SetHex(0x00ff00)))
+	shader.Uniforms.Add("amount", core.NewUniform(0.5))
+	copied.Material = shader
+
+	sun := lights.NewDirectionalLight(math3.NewColor().SetHex(0xffeedd), 0.8)
+	sun.Name = "sun"
+	sun.Target = quad
+	sun.Shadow.Bias = -0.001
+	scene.Add(sun)
+
+	data, err := loaders.ToJSON(scene)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := loaders.ToJSON(scene)
+	if err != nil || !bytes.Equal(data, again) {
+		t.Error("expected the output to be deterministic")
+	}
+
+	node, err := loaders.NewObjectLoader(nil).Parse(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, ok := node.(*scenes.Scene)
+	if !ok {
+		t.Fatalf("expected a scene, got %T", node)
+	}
+	if loaded.UUID != scene.UUID || loaded.Name != "exported" || loaded.BackgroundColor.GetHex() != 0x336699 {
+		t.Error("expected the scene to keep its uuid, name and background")
+	}
+
+	loadedQuad, ok := findByName(loaded, "quad").(*objects.Mesh)
+	if !ok {
+		t.Fatal("expected the quad mesh")
+	}
+	if loadedQuad.UUID != quad.UUID || !loadedQuad.Matrix.Equals(quad.Matrix) || !loadedQuad.Position.Equals(quad.Position) {
+		t.Error("expected the quad to keep its uuid and transform")
+	}
+	if loadedQuad.Geometry.UUID != quad.Geometry.UUID || len(loadedQuad.Geometry.Groups) != 2 || loadedQuad.Geometry.Index.Count() != 6 {
+		t.Error("expected the geometry with its index and groups")
+	}
+
+	multi, ok := loadedQuad.Material.(*materials.MultiMaterial)
+	if !ok || len(multi.Materials) != 2 {
+		t.Fatalf("expected a multi material, got %T", loadedQuad.Material)
+	}
+	red := multi.Materials[0].(*materials.MeshStandardMaterial)
+	blue := multi.Materials[1].(*materials.MeshStandardMaterial)
+	if red.Name != "red" || red.Color.GetHex() != 0xff0000 || red.Metalness != 0.25 || blue.Side != three.DoubleSide {
+		t.Error("expected the material properties")
+	}
+	if red.Map == nil || red.Map.Image == nil || red.Map.WrapS != three.RepeatWrapping || red.Map.Image.Bounds().Dx() != 2 {
+		t.Error("expected the texture with its embedded image")
+	}
+
+	loadedCopy := findByName(loaded, "copy").(*objects.Mesh)
+	if loadedCopy.Geometry != loadedQuad.Geometry {
+		t.Error("expected the geometry to be shared")
+	}
+	if loadedCopy.MatrixAutoUpdate || !loadedCopy.Matrix.Equals(copied.Matrix) {
+		t.Error("expected the fixed matrix")
+	}
+	loadedShader, ok := loadedCopy.Material.(*materials.ShaderMaterial)
+	if !ok || loadedShader.Defines["STEPS"] != "4" {
+		t.Fatal("expected the shader material with its defines")
+	}
+	if tint, ok := loadedShader.Uniforms.Get("tint").GetValue().(*math3.Color); !ok || tint.GetHex() != 0x00ff00 {
+		t.Error("expected the color uniform")
+	}
+	if amount, ok := loadedShader.Uniforms.Get("amount").GetValue().(float64); !ok || amount != 0.5 {
+		t.Error("expected the float uniform")
+	}
+
+	camera := findByName(loaded, "eye").(*cameras.PerspectiveCamera)
+	if camera.Fov != 60 || camera.Aspect != 2 || camera.Near != 0.5 || camera.Far != 100 || camera.Position.Z != 5 {
+		t.Error("expected the camera properties")
+	}
+
+	loadedSun := findByName(loaded, "sun").(*lights.DirectionalLight)
+	if loadedSun.Color.GetHex() != 0xffeedd || loadedSun.Intensity != 0.8 || loadedSun.Shadow.Bias != -0.001 {
+		t.Error("expected the light properties")
+	}
+	if loadedSun.Target != loadedQuad {
+		t.Error("expected the light to target the quad")
+	}
+
+	body := findByName(loaded, "body").(*objects.SkinnedMesh)
+	if body.Skeleton == nil || len(body.Skeleton.Bones) != 2 {
+		t.Fatal("expected the skinned mesh to be bound to its skeleton")
+	}
+	if body.Skeleton.Bones[1] != findByName(loaded, "knee") || body.Skeleton.BoneInverses[1].Elements[13] != -1 {
+		t.Error("expected the bones of the skeleton with their inverses")
+	}
+}
+
+const testObjectJSON = `{
+	"metadata": {"version": 4.5, "type": "Object", "generator": "Object3D.toJSON"},
+	"geometries": [{
+		"uuid": "7A2B6D47-5C0E-4F2D-9E8E-4E3B6A0C2C11",
+		"type": "BoxBufferGeometry",
+		"width": 2, "height": 4, "depth": 6
+	}],
+	"materials": [{
+		"uuid": "0C6C1D8B-3B52-4B60-8F5D-8D5E6B0C9E22",
+		"type": "MeshLambertMaterial",
+		"color": 16711680, "emissive": 0, "side": 2, "depthTest": false
+	}],
+	"object": {
+		"uuid": "E1C7B0F3-6A6D-4E1A-8A48-2A0F7D5F6C33",
+		"type": "Group",
+		"name": "root",
+		"matrix": [1,0,0,0,0,1,0,0,0,0,1,0,0,0,0,1],
+		"children": [{
+			"uuid": "5F3D2B1A-9C8E-4D7F-B6A5-4E3D2C1B0A44",
+			"type": "Mesh",
+			"name": "box",
+			"castShadow": true,
+			"matrix": [1,0,0,0,0,1,0,0,0,0,1,0,1,2,3,1],
+			"geometry": "7A2B6D47-5C0E-4F2D-9E8E-4E3B6A0C2C11",
+			"material": "0C6C1D8B-3B52-4B60-8F5D-8D5E6B0C9E22"
+		}, {
+			"uuid": "9B8A7C6D-5E4F-4A3B-8C2D-1E0F9A8B7C55",
+			"type": "PointLight",
+			"color": 16777215, "intensity": 2, "distance": 10, "decay": 2,
+			"matrix": [1,0,0,0,0,1,0,0,0,0,1,0,0,5,0,1]
+		}]
+	}
+}`
+
+func TestObjectLoader_Parse(t *testing.T) {
+	node, err := loaders.NewObjectLoader(nil).Parse(strings.NewReader(testObjectJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root, ok := node.(*objects.Object); !ok || root.Name != "root" || len(root.GetChildren()) != 2 {
+		t.Fatal("expected the group with its two children")
+	}
+
+	box, ok := node.GetChildren()[0].(*objects.Mesh)
+	if !ok || box.UUID != "5F3D2B1A-9C8E-4D7F-B6A5-4E3D2C1B0A44" || !box.CastShadow {
+		t.Fatal("expected the box mesh")
+	}
+	if !box.Position.Equals(math3.NewVector3().Set(1, 2, 3)) {
+		t.Errorf("expected the position from the matrix, got %v", box.Position)
+	}
+	box.Geometry.ComputeBoundingBox()
+	if !box.Geometry.BoundingBox.Max.Equals(math3.NewVector3().Set(1, 2, 3)) {
+		t.Errorf("expected the box geometry from its parameters, got %v", box.Geometry.BoundingBox)
+	}
+	material, ok := box.Material.(*materials.MeshLambertMaterial)
+	if !ok || material.Color.GetHex() != 0xff0000 || material.Side != three.DoubleSide || material.DepthTest {
+		t.Error("expected the lambert material")
+	}
+
+	light, ok := node.GetChildren()[1].(*lights.PointLight)
+	if !ok || light.Intensity != 2 || light.Distance != 10 || light.Decay != 2 || light.Position.Y != 5 {
+		t.Error("expected the point light")
+	}
+}
+
+func TestObjectLoader_Errors(t *testing.T) {
+	tests := []struct{ old, new string }{
+		{`"version": 4.5`, `"version": 3`},
+		{`"type": "Object"`, `"type": "Geometry"`},
+		{`"object"`, `"objects"`},
+		{`"type": "BoxBufferGeometry"`, `"type": "Geometry"`},
+		{`"type": "MeshLambertMaterial"`, `"type": "SpriteMaterial"`},
+		{`"geometry": "7A2B`, `"geometry": "0000`},
+		{`"material": "0C6C`, `"material": "0000`},
+		{`[1,0,0,0,0,1,0,0,0,0,1,0,0,0,0,1]`, `[1,0,0]`},
+		{`"type": "PointLight"`, `"type": "Mesh", "uuid": "5F3D2B1A-9C8E-4D7F-B6A5-4E3D2C1B0A44"`},
+		{`"type": "MeshLambertMaterial"`, `"type": "ShaderMaterial", "uniforms": {"u": {"type": "v3", "value": "x"}}`},
+		{`"type": "MeshLambertMaterial"`, `"type": "ShaderMaterial", "uniforms": {"u": {"type": "c", "value": "x"}}`},
+		{`"type": "MeshLambertMaterial"`, `"type": "ShaderMaterial", "uniforms": {"u": {"type": "t", "value": 1}}`},
+	}
+
+	for i, test := range tests {
+		data := strings.Replace(testObjectJSON, test.old, test.new, 1)
+		if _, err := loaders.NewObjectLoader(nil).Parse(strings.NewReader(data)); err == nil {
+			t.Errorf("%d: expected an error", i)
+		}
+	}
+
+	if _, err := loaders.NewObjectLoader(nil).Parse(strings.NewReader("{")); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+	if _, err := loaders.NewObjectLoader(nil).Load("scene.json"); err == nil {
+		t.Error("expected an error without a resolver")
+	}
+	if _, err := loaders.ToJSON(objects.NewMesh(core.NewBufferGeometry(), materials.NewMaterial())); err == nil {
+		t.Error("expected an error for an unsupported material")
+	}
+}
+
+// findByName returns the first object below node with the given name
+func findByName(node objects.Node, name string) objects.Node {
+	if objects.ObjectOf(node).Name == name {
+		return node
+	}
+	for _, child := range node.GetChildren() {
+		if found := findByName(child, name); found != nil {
+			return found
+		}
+	}
+	return nil
+}
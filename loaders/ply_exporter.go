@@ -0,0 +1,210 @@
+package loaders
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/golang/glog"
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/math3"
+)
+
+// PLYExporter writes geometries as PLY files
+type PLYExporter struct {
+	// Format is one of PLYASCII, PLYBinaryLittleEndian or PLYBinaryBigEndian
+	Format string
+	// Points leaves out the faces so that the
+	// vertices are written as a point cloud
+	Points bool
+}
+
+// NewPLYExporter creates an exporter that writes ASCII files
+func NewPLYExporter() *PLYExporter {
+
+	return &PLYExporter{
+		Format: PLYASCII,
+	}
+
+}
+
+// plyExportProperties lists the vertex properties
+// written for each of the common attributes
+var plyExportProperties = []struct {
+	attribute string
+	names     []string
+	kind      string
+}{
+	{"position", []string{"x", "y", "z"}, "float"},
+	{"normal", []string{"nx", "ny", "nz"}, "float"},
+	{"uv", []string{"s", "t"}, "float"},
+	{"color", []string{"red", "green", "blue"}, "uchar"},
+}
+
+// plyExportAttribute is an attribute written as vertex properties
+type plyExportAttribute struct {
+	attribute *core.BufferAttribute
+	names     []string
+	kind      string
+}
+
+// Export writes the vertices of geometry to w, along with its triangles
+// unless Points is set. Colors are written as bytes and every other
+// attribute with a single component is written as a float property of
+// the same name.
+func (e *PLYExporter) Export(geometry *core.BufferGeometry, w io.Writer) error {
+
+	order := plyByteOrder(e.Format)
+	if e.Format != PLYASCII && order == nil {
+		return fmt.Errorf("loaders: PLY: unknown format %q", e.Format)
+	}
+
+	position := geometry.GetAttribute("position")
+	if position == nil {
+		return fmt.Errorf("loaders: PLY: geometry has no position attribute")
+	}
+
+	var attributes []plyExportAttribute
+	written := make(map[string]bool)
+
+	for _, p := range plyExportProperties {
+
+		written[p.attribute] = true
+		attribute := geometry.GetAttribute(p.attribute)
+		if attribute == nil {
+			continue
+		}
+		if attribute.ItemSize < len(p.names) {
+			return fmt.Errorf("loaders: PLY: attribute %s has an item size of %d", p.attribute, attribute.ItemSize)
+		}
+		attributes = append(attributes, plyExportAttribute{attribute, p.names, p.kind})
+
+	}
+
+	for _, name := range sortedAttributeNames(geometry.Attributes) {
+
+		if written[name] {
+			continue
+		}
+
+		attribute := geometry.Attributes[name]
+		if attribute.ItemSize != 1 {
+			glog.Warningf("loaders: PLY export skips attribute %q with an item size of %d", name, attribute.ItemSize)
+			continue
+		}
+		attributes = append(attributes, plyExportAttribute{attribute, []string{name}, "float"})
+
+	}
+
+	var triangles []int
+	if !e.Points {
+
+		var err error
+		if triangles, err = triangleVertices(geometry); err != nil {
+			return fmt.Errorf("loaders: PLY: %v", err)
+		}
+
+	}
+
+	out := bufio.NewWriter(w)
+
+	fmt.Fprintf(out, "ply\nformat %s 1.0\ncomment three.go\n", e.Format)
+	fmt.Fprintf(out, "element vertex %d\n", position.Count())
+	for _, a := range attributes {
+		for _, name := range a.names {
+			fmt.Fprintf(out, "property %s %s\n", a.kind, name)
+		}
+	}
+	if !e.Points {
+		fmt.Fprintf(out, "element face %d\n", len(triangles)/3)
+		fmt.Fprint(out, "property list uchar int vertex_indices\n")
+	}
+	fmt.Fprint(out, "end_header\n")
+
+	enc := &plyEncoder{w: out, order: order}
+
+	for i := 0; i < position.Count(); i++ {
+
+		for _, a := range attributes {
+			for j := range a.names {
+
+				v := a.attribute.Get(i*a.attribute.ItemSize + j)
+				if a.kind == "uchar" {
+					v = math.Round(math3.Clamp(v, 0, 1) * math.MaxUint8)
+				}
+				enc.write(a.kind, v)
+
+			}
+		}
+		enc.endElement()
+
+	}
+
+	for i := 0; i+2 < len(triangles); i += 3 {
+
+		enc.write("uchar", 3)
+		for _, index := range triangles[i : i+3] {
+			enc.write("int", float64(index))
+		}
+		enc.endElement()
+
+	}
+
+	return out.Flush()
+
+}
+
+// plyEncoder writes the values of the body of a PLY file
+type plyEncoder struct {
+	w *bufio.Writer
+	// order is the byte order of binary files, or nil for ASCII files
+	order binary.ByteOrder
+
+	scratch   [4]byte
+	separator bool
+}
+
+// write writes v as the given type, which must be "uchar", "int" or "float"
+func (e *plyEncoder) write(kind string, v float64) {
+
+	if e.order == nil {
+
+		if e.separator {
+			e.w.WriteByte(' ')
+		}
+		e.separator = true
+
+		if kind == "float" {
+			e.w.WriteString(formatFloat32(v))
+		} else {
+			e.w.WriteString(strconv.Itoa(int(v)))
+		}
+		return
+
+	}
+
+	switch kind {
+	case "uchar":
+		e.w.WriteByte(byte(v))
+	case "int":
+		e.order.PutUint32(e.scratch[:], uint32(int32(v)))
+		e.w.Write(e.scratch[:])
+	default:
+		e.order.PutUint32(e.scratch[:], math.Float32bits(float32(v)))
+		e.w.Write(e.scratch[:])
+	}
+
+}
+
+// endElement ends the line of an element in ASCII files
+func (e *plyEncoder) endElement() {
+
+	if e.order == nil {
+		e.w.WriteByte('\n')
+		e.separator = false
+	}
+
+}
@@ -0,0 +1,558 @@
+package loaders
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/rydrman/three.go/core"
+)
+
+// The formats of PLY files
+const (
+	PLYASCII              = "ascii"
+	PLYBinaryLittleEndian = "binary_little_endian"
+	PLYBinaryBigEndian    = "binary_big_endian"
+)
+
+// PLYLoader reads ASCII and binary PLY files into geometries
+type PLYLoader struct {
+	// Resolver opens the files given to Load
+	Resolver Resolver
+}
+
+// NewPLYLoader creates a loader that opens files with resolver
+func NewPLYLoader(resolver Resolver) *PLYLoader {
+
+	return &PLYLoader{
+		Resolver: resolver,
+	}
+
+}
+
+// Load opens and parses the named file through the resolver of this loader
+func (l *PLYLoader) Load(name string) (*core.BufferGeometry, error) {
+
+	if l.Resolver == nil {
+		return nil, fmt.Errorf("loaders: cannot open %q without a resolver", name)
+	}
+
+	file, err := l.Resolver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return l.Parse(file)
+
+}
+
+// plyProperty is a property of the elements of a PLY file, types are
+// stored by their canonical names such as "uchar" rather than "uint8"
+type plyProperty struct {
+	name string
+	kind string
+	// countKind is the type of the length of list properties,
+	// it is empty for properties that are not lists
+	countKind string
+}
+
+// plyMaxListLength limits the length of list properties, as each length
+// is read from the file before the values it claims to be followed by
+const plyMaxListLength = 1 << 16
+
+type plyElement struct {
+	name       string
+	count      int
+	properties []plyProperty
+}
+
+// plyTypes maps every name of a PLY type onto its canonical name
+var plyTypes = map[string]string{
+	"char": "char", "int8": "char",
+	"uchar": "uchar", "uint8": "uchar",
+	"short": "short", "int16": "short",
+	"ushort": "ushort", "uint16": "ushort",
+	"int": "int", "int32": "int",
+	"uint": "uint", "uint32": "uint",
+	"float": "float", "float32": "float",
+	"double": "double", "float64": "double",
+}
+
+// plyTypeSizes is the size in bytes of each canonical type
+var plyTypeSizes = map[string]int{
+	"char":   1,
+	"uchar":  1,
+	"short":  2,
+	"ushort": 2,
+	"int":    4,
+	"uint":   4,
+	"float":  4,
+	"double": 8,
+}
+
+// plyColorScales normalizes the color components of each type
+var plyColorScales = map[string]float64{
+	"char":   1.0 / math.MaxInt8,
+	"uchar":  1.0 / math.MaxUint8,
+	"short":  1.0 / math.MaxInt16,
+	"ushort": 1.0 / math.MaxUint16,
+	"int":    1.0 / math.MaxInt32,
+	"uint":   1.0 / math.MaxUint32,
+	"float":  1,
+	"double": 1,
+}
+
+// plyComponent is a component of a vertex attribute
+type plyComponent struct {
+	attribute string
+	index     int
+}
+
+// plyVertexProperties maps the common vertex properties onto attributes,
+// other properties are loaded as attributes of their own
+var plyVertexProperties = map[string]plyComponent{
+	"x":         {"position", 0},
+	"y":         {"position", 1},
+	"z":         {"position", 2},
+	"nx":        {"normal", 0},
+	"ny":        {"normal", 1},
+	"nz":        {"normal", 2},
+	"red":       {"color", 0},
+	"green":     {"color", 1},
+	"blue":      {"color", 2},
+	"s":         {"uv", 0},
+	"t":         {"uv", 1},
+	"u":         {"uv", 0},
+	"v":         {"uv", 1},
+	"texture_s": {"uv", 0},
+	"texture_t": {"uv", 1},
+	"texture_u": {"uv", 0},
+	"texture_v": {"uv", 1},
+}
+
+// plyItemSizes is the item size of the attributes of plyVertexProperties
+var plyItemSizes = map[string]int{
+	"position": 3,
+	"normal":   3,
+	"color":    3,
+	"uv":       2,
+}
+
+// Parse reads a PLY file from r. Positions, normals, colors and texture
+// coordinates are loaded into the usual attributes, and every other scalar
+// vertex property is loaded as an attribute of the same name. Faces are
+// triangulated into the index, and other elements are skipped. The
+// bounding box is computed.
+func (l *PLYLoader) Parse(r io.Reader) (*core.BufferGeometry, error) {
+
+	in := bufio.NewReader(r)
+
+	format, elements, err := readPLYHeader(in)
+	if err != nil {
+		return nil, fmt.Errorf("loaders: PLY: %v", err)
+	}
+
+	geometry, err := readPLYBody(&plyDecoder{r: in, order: plyByteOrder(format)}, elements)
+	if err != nil {
+		return nil, fmt.Errorf("loaders: PLY: %v", err)
+	}
+
+	geometry.ComputeBoundingBox()
+
+	return geometry, nil
+
+}
+
+// plyByteOrder returns the byte order of binary formats, or nil for ASCII
+func plyByteOrder(format string) binary.ByteOrder {
+
+	switch format {
+	case PLYBinaryLittleEndian:
+		return binary.LittleEndian
+	case PLYBinaryBigEndian:
+		return binary.BigEndian
+	}
+
+	return nil
+
+}
+
+func readPLYHeader(in *bufio.Reader) (string, []*plyElement, error) {
+
+	var format string
+	var elements []*plyElement
+
+	for lineNumber := 1; ; lineNumber++ {
+
+		line, err := in.ReadString('\n')
+		if err != nil {
+			return "", nil, fmt.Errorf("header: %v", err)
+		}
+
+		fields := strings.Fields(line)
+		if lineNumber == 1 {
+
+			if len(fields) != 1 || fields[0] != "ply" {
+				return "", nil, fmt.Errorf("file does not start with ply")
+			}
+			continue
+
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+
+		case "format":
+			if len(fields) < 2 || (fields[1] != PLYASCII && plyByteOrder(fields[1]) == nil) {
+				return "", nil, fmt.Errorf("line %d: unknown format", lineNumber)
+			}
+			format = fields[1]
+
+		case "element":
+			if len(fields) != 3 {
+				return "", nil, fmt.Errorf("line %d: expected an element name and count", lineNumber)
+			}
+			count, err := strconv.Atoi(fields[2])
+			if err != nil || count < 0 {
+				return "", nil, fmt.Errorf("line %d: invalid element count %q", lineNumber, fields[2])
+			}
+			elements = append(elements, &plyElement{name: fields[1], count: count})
+
+		case "property":
+			if len(elements) == 0 {
+				return "", nil, fmt.Errorf("line %d: property before any element", lineNumber)
+			}
+			property, err := parsePLYProperty(fields[1:])
+			if err != nil {
+				return "", nil, fmt.Errorf("line %d: %v", lineNumber, err)
+			}
+			element := elements[len(elements)-1]
+			element.properties = append(element.properties, property)
+
+		case "comment", "obj_info":
+
+		case "end_header":
+			if format == "" {
+				return "", nil, fmt.Errorf("header has no format")
+			}
+			return format, elements, nil
+
+		default:
+			return "", nil, fmt.Errorf("line %d: unknown statement %q", lineNumber, fields[0])
+
+		}
+
+	}
+
+}
+
+func parsePLYProperty(args []string) (plyProperty, error) {
+
+	if len(args) == 4 && args[0] == "list" {
+
+		countKind, ok := plyTypes[args[1]]
+		kind, ok2 := plyTypes[args[2]]
+		if !ok || !ok2 || countKind == "float" || countKind == "double" {
+			return plyProperty{}, fmt.Errorf("invalid list types %q and %q", args[1], args[2])
+		}
+
+		return plyProperty{name: args[3], kind: kind, countKind: countKind}, nil
+
+	}
+
+	if len(args) != 2 {
+		return plyProperty{}, fmt.Errorf("expected a property type and name")
+	}
+
+	kind, ok := plyTypes[args[0]]
+	if !ok {
+		return plyProperty{}, fmt.Errorf("unknown type %q", args[0])
+	}
+
+	return plyProperty{name: args[1], kind: kind}, nil
+
+}
+
+func readPLYBody(d *plyDecoder, elements []*plyElement) (*core.BufferGeometry, error) {
+
+	geometry := core.NewBufferGeometry()
+	var indices []int
+	vertexCount := -1
+
+	for _, element := range elements {
+
+		switch element.name {
+
+		case "vertex":
+			if vertexCount >= 0 {
+				return nil, fmt.Errorf("file has more than one vertex element")
+			}
+			if err := readPLYVertices(d, element, geometry); err != nil {
+				return nil, err
+			}
+			vertexCount = element.count
+
+		case "face":
+			faces, err := readPLYFaces(d, element)
+			if err != nil {
+				return nil, err
+			}
+			indices = append(indices, faces...)
+
+		default:
+			glog.Warningf("loaders: skipping PLY element %q", element.name)
+			for i := 0; i < element.count; i++ {
+				for _, property := range element.properties {
+					if _, err := d.readProperty(property); err != nil {
+						return nil, fmt.Errorf("%s %d: %v", element.name, i, err)
+					}
+				}
+			}
+
+		}
+
+	}
+
+	if geometry.GetAttribute("position") == nil {
+		return nil, fmt.Errorf("file has no vertex positions")
+	}
+
+	if indices != nil {
+
+		for _, index := range indices {
+			if index < 0 || index >= vertexCount {
+				return nil, fmt.Errorf("index %d is out of range of %d vertices", index, vertexCount)
+			}
+		}
+		geometry.SetIndex(core.NewIndexAttribute(indices))
+
+	}
+
+	return geometry, nil
+
+}
+
+func readPLYVertices(d *plyDecoder, element *plyElement, geometry *core.BufferGeometry) error {
+
+	attributes := make(map[string][]float32)
+	itemSizes := make(map[string]int)
+	components := make([]plyComponent, len(element.properties))
+	scales := make([]float64, len(element.properties))
+
+	for i, property := range element.properties {
+
+		if property.countKind != "" {
+			glog.Warningf("loaders: skipping PLY vertex list property %q", property.name)
+			components[i].index = -1
+			continue
+		}
+
+		component, ok := plyVertexProperties[property.name]
+		if !ok {
+			component = plyComponent{property.name, 0}
+		}
+		components[i] = component
+
+		itemSize := plyItemSizes[component.attribute]
+		if itemSize == 0 {
+			itemSize = 1
+		}
+		itemSizes[component.attribute] = itemSize
+
+		scales[i] = 1
+		if component.attribute == "color" {
+			scales[i] = plyColorScales[property.kind]
+		}
+
+	}
+
+	for i := 0; i < element.count; i++ {
+
+		// the arrays grow as vertices are read, rather than being
+		// allocated up front from the count given by the header
+		for name, itemSize := range itemSizes {
+			attributes[name] = append(attributes[name], make([]float32, itemSize)...)
+		}
+
+		for j, property := range element.properties {
+
+			values, err := d.readProperty(property)
+			if err != nil {
+				return fmt.Errorf("vertex %d: %v", i, err)
+			}
+			if components[j].index < 0 {
+				continue
+			}
+
+			component := components[j]
+			itemSize := itemSizes[component.attribute]
+			attributes[component.attribute][i*itemSize+component.index] = float32(values[0] * scales[j])
+
+		}
+
+	}
+
+	names := make([]string, 0, len(itemSizes))
+	for name := range itemSizes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+
+		geometry.AddAttribute(name, core.NewFloat32BufferAttribute(attributes[name], itemSizes[name]))
+
+	}
+
+	return nil
+
+}
+
+// readPLYFaces reads the faces of element as triangle fans,
+// properties other than the vertex indices are skipped
+func readPLYFaces(d *plyDecoder, element *plyElement) ([]int, error) {
+
+	var indices []int
+
+	for i := 0; i < element.count; i++ {
+
+		for _, property := range element.properties {
+
+			values, err := d.readProperty(property)
+			if err != nil {
+				return nil, fmt.Errorf("face %d: %v", i, err)
+			}
+			if property.countKind == "" || (property.name != "vertex_indices" && property.name != "vertex_index") {
+				continue
+			}
+
+			if len(values) < 3 {
+				return nil, fmt.Errorf("face %d has %d vertices, at least 3 are needed", i, len(values))
+			}
+			for j := 1; j+1 < len(values); j++ {
+				indices = append(indices, int(values[0]), int(values[j]), int(values[j+1]))
+			}
+
+		}
+
+	}
+
+	return indices, nil
+
+}
+
+// plyDecoder reads the values of the body of a PLY file
+type plyDecoder struct {
+	r *bufio.Reader
+	// order is the byte order of binary files, or nil for ASCII files
+	order binary.ByteOrder
+
+	scratch [8]byte
+	token   []byte
+}
+
+// readProperty reads the value of a property, or all values of a list
+func (d *plyDecoder) readProperty(property plyProperty) ([]float64, error) {
+
+	if property.countKind == "" {
+
+		v, err := d.read(property.kind)
+		if err != nil {
+			return nil, err
+		}
+		return []float64{v}, nil
+
+	}
+
+	count, err := d.read(property.countKind)
+	if err != nil {
+		return nil, err
+	}
+	if count != math.Trunc(count) || count < 0 || count > plyMaxListLength {
+		return nil, fmt.Errorf("list %q has an invalid length %v", property.name, count)
+	}
+
+	values := make([]float64, int(count))
+	for i := range values {
+		if values[i], err = d.read(property.kind); err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+
+}
+
+// read reads a single value of the given canonical type
+func (d *plyDecoder) read(kind string) (float64, error) {
+
+	if d.order == nil {
+		return d.readASCII()
+	}
+
+	b := d.scratch[:plyTypeSizes[kind]]
+	if _, err := io.ReadFull(d.r, b); err != nil {
+		return 0, err
+	}
+
+	switch kind {
+	case "char":
+		return float64(int8(b[0])), nil
+	case "uchar":
+		return float64(b[0]), nil
+	case "short":
+		return float64(int16(d.order.Uint16(b))), nil
+	case "ushort":
+		return float64(d.order.Uint16(b)), nil
+	case "int":
+		return float64(int32(d.order.Uint32(b))), nil
+	case "uint":
+		return float64(d.order.Uint32(b)), nil
+	case "float":
+		return float64(math.Float32frombits(d.order.Uint32(b))), nil
+	}
+
+	return math.Float64frombits(d.order.Uint64(b)), nil
+
+}
+
+// readASCII reads the next whitespace separated value
+func (d *plyDecoder) readASCII() (float64, error) {
+
+	d.token = d.token[:0]
+
+	for {
+
+		c, err := d.r.ReadByte()
+		if err == io.EOF && len(d.token) > 0 {
+			break
+		}
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, err
+		}
+
+		if c == ' ' || c == '\t' || c == '\r' || c == '\n' {
+			if len(d.token) > 0 {
+				break
+			}
+			continue
+		}
+		d.token = append(d.token, c)
+
+	}
+
+	return strconv.ParseFloat(string(d.token), 64)
+
+}
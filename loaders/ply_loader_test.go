@@ -0,0 +1,188 @@
+package loaders_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/loaders"
+	"github.com/rydrman/three.go/math3"
+)
+
+const testPLY = `ply
+format ascii 1.0
+comment a quad and a scanned confidence value
+element vertex 4
+property float x
+property float y
+property float z
+property uchar red
+property uchar green
+property uchar blue
+property float texture_u
+property float texture_v
+property float confidence
+element face 1
+property uchar flags
+property list uchar int vertex_indices
+element edge 1
+property int vertex1
+property int vertex2
+end_header
+0 0 0 255 0 0 0 0 0.5
+2 0 0 0 255 0 1 0 0.25
+2 1 0 0 0 255 1 1 1
+0 1 -1 0 0 0 0 1 0
+7 4 0 1 2 3
+0 1
+`
+
+func checkTestPLY(t *testing.T, geometry *core.BufferGeometry) {
+	if geometry.Index == nil || geometry.Index.Count() != 6 || geometry.Index.GetX(5) != 3 {
+		t.Fatal("expected the quad to be split into two triangles")
+	}
+
+	color := geometry.GetAttribute("color")
+	if color == nil || color.GetX(0) != 1 || color.GetY(1) != 1 || color.GetZ(2) != 1 || color.GetX(3) != 0 {
+		t.Error("expected the colors to be normalized")
+	}
+	if uv := geometry.GetAttribute("uv"); uv == nil || uv.GetX(1) != 1 || uv.GetY(3) != 1 {
+		t.Error("expected the texture coordinates")
+	}
+	if confidence := geometry.GetAttribute("confidence"); confidence == nil || confidence.ItemSize != 1 || confidence.GetX(1) != 0.25 {
+		t.Error("expected the extra property as an attribute")
+	}
+
+	expected := math3.NewBox3().Set(math3.NewVector3().Set(0, 0, -1), math3.NewVector3().Set(2, 1, 0))
+	if geometry.BoundingBox == nil || !geometry.BoundingBox.Equals(expected) {
+		t.Errorf("expected the bounding box %v, got %v", expected, geometry.BoundingBox)
+	}
+}
+
+func TestPLYLoader_ASCII(t *testing.T) {
+	geometry, err := loaders.NewPLYLoader(nil).Parse(strings.NewReader(testPLY))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTestPLY(t, geometry)
+}
+
+func TestPLYLoader_Binary(t *testing.T) {
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		format := loaders.PLYBinaryLittleEndian
+		if order == binary.BigEndian {
+			format = loaders.PLYBinaryBigEndian
+		}
+
+		var b bytes.Buffer
+		b.WriteString(strings.Replace(testPLY[:strings.Index(testPLY, "end_header")], "ascii", format, 1))
+		b.WriteString("end_header\n")
+		write := func(values ...interface{}) {
+			for _, v := range values {
+				binary.Write(&b, order, v)
+			}
+		}
+		write(float32(0), float32(0), float32(0), uint8(255), uint8(0), uint8(0), float32(0), float32(0), float32(0.5))
+		write(float32(2), float32(0), float32(0), uint8(0), uint8(255), uint8(0), float32(1), float32(0), float32(0.25))
+		write(float32(2), float32(1), float32(0), uint8(0), uint8(0), uint8(255), float32(1), float32(1), float32(1))
+		write(float32(0), float32(1), float32(-1), uint8(0), uint8(0), uint8(0), float32(0), float32(1), float32(0))
+		write(uint8(7), uint8(4), int32(0), int32(1), int32(2), int32(3))
+		write(int32(0), int32(1))
+
+		geometry, err := loaders.NewPLYLoader(nil).Parse(&b)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		checkTestPLY(t, geometry)
+	}
+}
+
+func TestPLYExporter_RoundTrip(t *testing.T) {
+	source, err := loaders.NewPLYLoader(nil).Parse(strings.NewReader(testPLY))
+	if err != nil {
+		t.Fatal(err)
+	}
+	source.ComputeVertexNormals()
+
+	for _, format := range []string{loaders.PLYASCII, loaders.PLYBinaryLittleEndian, loaders.PLYBinaryBigEndian} {
+		exporter := loaders.NewPLYExporter()
+		exporter.Format = format
+		var b bytes.Buffer
+		if err := exporter.Export(source, &b); err != nil {
+			t.Fatal(err)
+		}
+
+		geometry, err := loaders.NewPLYLoader(nil).Parse(&b)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		checkTestPLY(t, geometry)
+
+		for name, attribute := range source.Attributes {
+			loaded := geometry.GetAttribute(name)
+			if loaded == nil || loaded.ItemSize != attribute.ItemSize || !floatsEqual(loaded.ToFloat64(), attribute.ToFloat64()) {
+				t.Errorf("%s: expected attribute %s to round trip", format, name)
+			}
+		}
+	}
+
+	exporter := loaders.NewPLYExporter()
+	exporter.Points = true
+	var b bytes.Buffer
+	if err := exporter.Export(testTetrahedron(), &b); err != nil {
+		t.Fatal(err)
+	}
+	geometry, err := loaders.NewPLYLoader(nil).Parse(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if geometry.Index != nil || geometry.GetAttribute("position").Count() != 4 {
+		t.Error("expected a point cloud of the vertices")
+	}
+}
+
+func floatsEqual(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPLYLoader_Errors(t *testing.T) {
+	vertices := "ply\nformat ascii 1.0\nelement vertex 3\nproperty float x\nproperty float y\nproperty float z\n"
+	tests := []string{
+		"obj\n",
+		"ply\nformat utf8 1.0\nend_header\n",
+		"ply\nproperty float x\nend_header\n",
+		"ply\nformat ascii 1.0\nelement vertex 1\nproperty quad x\nend_header\n",
+		"ply\nformat ascii 1.0\nelement vertex 1\nproperty float w\nend_header\n0\n",
+		vertices + "end_header\n0 0 0 1 1 1\n",
+		vertices + "element face 1\nproperty list uchar int vertex_indices\nend_header\n0 0 0 1 0 0 0 1 0\n3 0 1 5\n",
+		vertices + "element face 1\nproperty list uchar int vertex_indices\nend_header\n0 0 0 1 0 0 0 1 0\n2 0 1\n",
+		vertices + "element face 1\nproperty list uint int vertex_indices\nend_header\n0 0 0 1 0 0 0 1 0\n1e18 0 1 2\n",
+		vertices + "element face 1\nproperty list uint int vertex_indices\nend_header\n0 0 0 1 0 0 0 1 0\nnan 0 1 2\n",
+		vertices + "element face 1\nproperty list uint int vertex_indices\nend_header\n0 0 0 1 0 0 0 1 0\ninf 0 1 2\n",
+		vertices + "element face 1\nproperty list uint int vertex_indices\nend_header\n0 0 0 1 0 0 0 1 0\n2.5 0 1 2\n",
+		strings.Replace(vertices, "vertex 3", "vertex 100000000000", 1) + "end_header\n0 0 0 1 0 0 0 1 0\n",
+		"ply\nformat binary_little_endian 1.0\nelement face 1\nproperty list uint int vertex_indices\nend_header\n\x00\x28\x6b\xee",
+	}
+
+	for i, test := range tests {
+		if _, err := loaders.NewPLYLoader(nil).Parse(strings.NewReader(test)); err == nil {
+			t.Errorf("%d: expected an error", i)
+		}
+	}
+
+	exporter := loaders.NewPLYExporter()
+	exporter.Format = "binary"
+	if err := exporter.Export(testTetrahedron(), &bytes.Buffer{}); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
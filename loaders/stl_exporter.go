@@ -0,0 +1,183 @@
+package loaders
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/math3"
+)
+
+// STLExporter writes the triangles of geometries as STL files
+type STLExporter struct {
+	// Binary writes a binary file instead of ASCII, binary files
+	// also keep the vertex colors of the geometry as face colors
+	Binary bool
+}
+
+// NewSTLExporter creates an exporter that writes ASCII files
+func NewSTLExporter() *STLExporter {
+
+	return &STLExporter{}
+
+}
+
+// Export writes the triangles of geometry to w, each with the normal
+// computed from its vertices. Vertex colors are averaged over each face
+// and reduced to the five bits per channel that binary files can hold.
+func (e *STLExporter) Export(geometry *core.BufferGeometry, w io.Writer) error {
+
+	position := geometry.GetAttribute("position")
+	if position == nil {
+		return fmt.Errorf("loaders: STL: geometry has no position attribute")
+	}
+
+	triangles, err := triangleVertices(geometry)
+	if err != nil {
+		return fmt.Errorf("loaders: STL: %v", err)
+	}
+
+	out := bufio.NewWriter(w)
+
+	if e.Binary {
+		e.writeBinary(out, geometry, triangles)
+	} else {
+		e.writeASCII(out, geometry, triangles)
+	}
+
+	return out.Flush()
+
+}
+
+func (e *STLExporter) writeASCII(out *bufio.Writer, geometry *core.BufferGeometry, triangles []int) {
+
+	position := geometry.GetAttribute("position")
+	vertices := [3]*math3.Vector3{math3.NewVector3(), math3.NewVector3(), math3.NewVector3()}
+	normal := math3.NewVector3()
+
+	fmt.Fprintf(out, "solid %s\n", geometry.Name)
+
+	for i := 0; i+2 < len(triangles); i += 3 {
+
+		for j, vertex := range vertices {
+			position.GetVector3(triangles[i+j], vertex)
+		}
+		math3.TriangleNormal(vertices[0], vertices[1], vertices[2], normal)
+
+		fmt.Fprintf(out, "  facet normal %s\n    outer loop\n", formatVector(normal))
+		for _, vertex := range vertices {
+			fmt.Fprintf(out, "      vertex %s\n", formatVector(vertex))
+		}
+		fmt.Fprint(out, "    endloop\n  endfacet\n")
+
+	}
+
+	fmt.Fprintf(out, "endsolid %s\n", geometry.Name)
+
+}
+
+func (e *STLExporter) writeBinary(out *bufio.Writer, geometry *core.BufferGeometry, triangles []int) {
+
+	position := geometry.GetAttribute("position")
+	color := geometry.GetAttribute("color")
+
+	// binary headers must not start with "solid", which marks ASCII files
+	header := make([]byte, stlHeaderSize)
+	copy(header, "three.go")
+	if color != nil {
+		// white is the default color of faces without a color
+		copy(header[16:], []byte{'C', 'O', 'L', 'O', 'R', '=', 255, 255, 255, 255})
+	}
+	out.Write(header)
+
+	var scratch [stlTriangleSize]byte
+	binary.LittleEndian.PutUint32(scratch[:], uint32(len(triangles)/3))
+	out.Write(scratch[:4])
+
+	vertices := [3]*math3.Vector3{math3.NewVector3(), math3.NewVector3(), math3.NewVector3()}
+	normal := math3.NewVector3()
+
+	for i := 0; i+2 < len(triangles); i += 3 {
+
+		for j, vertex := range vertices {
+			position.GetVector3(triangles[i+j], vertex)
+		}
+		math3.TriangleNormal(vertices[0], vertices[1], vertices[2], normal)
+
+		values := []float64{normal.X, normal.Y, normal.Z}
+		for _, vertex := range vertices {
+			values = append(values, vertex.X, vertex.Y, vertex.Z)
+		}
+		for j, v := range values {
+			binary.LittleEndian.PutUint32(scratch[j*4:], math.Float32bits(float32(v)))
+		}
+
+		var packed uint16
+		if color != nil {
+
+			var rgb [3]float64
+			for j := 0; j < 3; j++ {
+				for k := range rgb {
+					rgb[k] += color.Get(triangles[i+j]*color.ItemSize+k) / 3
+				}
+			}
+			for k, v := range rgb {
+				packed |= uint16(math.Round(math3.Clamp(v, 0, 1)*31)) << (5 * uint(k))
+			}
+
+		}
+		binary.LittleEndian.PutUint16(scratch[48:], packed)
+
+		out.Write(scratch[:])
+
+	}
+
+}
+
+// triangleVertices returns the indices of the three vertices of
+// each triangle of geometry, using its index if it has one
+func triangleVertices(geometry *core.BufferGeometry) ([]int, error) {
+
+	count := geometry.GetAttribute("position").Count()
+
+	if geometry.Index == nil {
+
+		vertices := make([]int, count-count%3)
+		for i := range vertices {
+			vertices[i] = i
+		}
+
+		return vertices, nil
+
+	}
+
+	vertices := make([]int, geometry.Index.Count()-geometry.Index.Count()%3)
+	for i := range vertices {
+
+		vertices[i] = int(geometry.Index.GetX(i))
+		if vertices[i] < 0 || vertices[i] >= count {
+			return nil, fmt.Errorf("index %d is out of range of %d vertices", vertices[i], count)
+		}
+
+	}
+
+	return vertices, nil
+
+}
+
+func formatVector(v *math3.Vector3) string {
+
+	return formatFloat32(v.X) + " " + formatFloat32(v.Y) + " " + formatFloat32(v.Z)
+
+}
+
+// formatFloat32 formats v with the fewest digits that read back as the same float32
+func formatFloat32(v float64) string {
+
+	return strconv.FormatFloat(float64(float32(v)), 'g', -1, 32)
+
+}
@@ -0,0 +1,283 @@
+package loaders
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/math3"
+)
+
+// STLLoader reads ASCII and binary STL files into geometries
+type STLLoader struct {
+	// Resolver opens the files given to Load
+	Resolver Resolver
+}
+
+// NewSTLLoader creates a loader that opens files with resolver
+func NewSTLLoader(resolver Resolver) *STLLoader {
+
+	return &STLLoader{
+		Resolver: resolver,
+	}
+
+}
+
+// Load opens and parses the named file through the resolver of this loader
+func (l *STLLoader) Load(name string) (*core.BufferGeometry, error) {
+
+	if l.Resolver == nil {
+		return nil, fmt.Errorf("loaders: cannot open %q without a resolver", name)
+	}
+
+	file, err := l.Resolver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return l.Parse(file)
+
+}
+
+// Parse reads an STL file from r. The geometry is not indexed and has a
+// position and a normal attribute, along with a color attribute if the
+// binary file has face colors. Each solid of an ASCII file with more
+// than one solid is added as a group. The bounding box is computed.
+func (l *STLLoader) Parse(r io.Reader) (*core.BufferGeometry, error) {
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var geometry *core.BufferGeometry
+	if isBinarySTL(data) {
+		geometry, err = parseBinarySTL(data)
+	} else {
+		geometry, err = parseASCIISTL(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loaders: STL: %v", err)
+	}
+
+	geometry.ComputeBoundingBox()
+
+	return geometry, nil
+
+}
+
+const (
+	stlHeaderSize   = 80
+	stlTriangleSize = 50
+)
+
+// isBinarySTL reports whether data is a binary STL file, binary files
+// may also start with "solid" so their size is checked first
+func isBinarySTL(data []byte) bool {
+
+	if len(data) >= stlHeaderSize+4 {
+
+		count := binary.LittleEndian.Uint32(data[stlHeaderSize:])
+		if uint64(len(data)) == stlHeaderSize+4+uint64(count)*stlTriangleSize {
+			return true
+		}
+
+	}
+
+	return !bytes.HasPrefix(bytes.TrimLeft(data, " \t\r\n"), []byte("solid"))
+
+}
+
+func parseBinarySTL(data []byte) (*core.BufferGeometry, error) {
+
+	if len(data) < stlHeaderSize+4 {
+		return nil, fmt.Errorf("file of %d bytes is too short", len(data))
+	}
+
+	count := int(binary.LittleEndian.Uint32(data[stlHeaderSize:]))
+	if len(data) < stlHeaderSize+4+count*stlTriangleSize {
+		return nil, fmt.Errorf("file of %d bytes is too short for %d triangles", len(data), count)
+	}
+
+	// the Materialise format stores a default color in the header,
+	// and face colors in the attribute bytes of each triangle
+	var defaultColor *math3.Color
+	if i := bytes.Index(data[:stlHeaderSize], []byte("COLOR=")); i >= 0 && i+10 <= stlHeaderSize {
+		defaultColor = math3.NewColor().SetRGB(float64(data[i+6])/255, float64(data[i+7])/255, float64(data[i+8])/255)
+	}
+
+	positions := make([]float32, 0, count*9)
+	normals := make([]float32, 0, count*9)
+	var colors []float32
+
+	float := func(offset int) float32 {
+		return math.Float32frombits(binary.LittleEndian.Uint32(data[offset:]))
+	}
+
+	for i := 0; i < count; i++ {
+
+		offset := stlHeaderSize + 4 + i*stlTriangleSize
+
+		normal := []float32{float(offset), float(offset + 4), float(offset + 8)}
+		vertices := make([]float32, 9)
+		for j := range vertices {
+			vertices[j] = float(offset + 12 + j*4)
+		}
+		normal = faceNormal(normal, vertices)
+
+		positions = append(positions, vertices...)
+		for j := 0; j < 3; j++ {
+			normals = append(normals, normal...)
+		}
+
+		if defaultColor != nil {
+
+			r, g, b := float32(defaultColor.R), float32(defaultColor.G), float32(defaultColor.B)
+
+			packed := binary.LittleEndian.Uint16(data[offset+48:])
+			if packed&0x8000 == 0 {
+				r = float32(packed&31) / 31
+				g = float32((packed>>5)&31) / 31
+				b = float32((packed>>10)&31) / 31
+			}
+
+			for j := 0; j < 3; j++ {
+				colors = append(colors, r, g, b)
+			}
+
+		}
+
+	}
+
+	geometry := core.NewBufferGeometry()
+	geometry.AddAttribute("position", core.NewFloat32BufferAttribute(positions, 3))
+	geometry.AddAttribute("normal", core.NewFloat32BufferAttribute(normals, 3))
+	if colors != nil {
+		geometry.AddAttribute("color", core.NewFloat32BufferAttribute(colors, 3))
+	}
+
+	return geometry, nil
+
+}
+
+func parseASCIISTL(data []byte) (*core.BufferGeometry, error) {
+
+	geometry := core.NewBufferGeometry()
+
+	var positions, normals, normal, vertices []float32
+	var solids []core.Group
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNumber := 0
+
+	for scanner.Scan() {
+
+		lineNumber++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		var err error
+
+		switch fields[0] {
+
+		case "solid":
+			if len(solids) == 0 && len(fields) > 1 {
+				geometry.Name = strings.Join(fields[1:], " ")
+			}
+			solids = append(solids, core.Group{Start: len(positions) / 3, MaterialIndex: len(solids)})
+
+		case "facet":
+			if len(fields) < 2 || fields[1] != "normal" {
+				err = fmt.Errorf("expected a facet normal")
+				break
+			}
+			normal, err = parseFloats(fields[2:], 3)
+			vertices = vertices[:0]
+
+		case "vertex":
+			var vertex []float32
+			if vertex, err = parseFloats(fields[1:], 3); err == nil {
+				vertices = append(vertices, vertex[:3]...)
+			}
+
+		case "endfacet":
+			if len(vertices) != 9 || len(normal) < 3 {
+				err = fmt.Errorf("facet has %d vertices, 3 are needed", len(vertices)/3)
+				break
+			}
+			positions = append(positions, vertices...)
+			normal = faceNormal(normal[:3], vertices)
+			for j := 0; j < 3; j++ {
+				normals = append(normals, normal...)
+			}
+
+		case "endsolid":
+			if len(solids) > 0 {
+				solid := &solids[len(solids)-1]
+				solid.Count = len(positions)/3 - solid.Start
+			}
+
+		case "outer", "endloop":
+
+		default:
+			err = fmt.Errorf("unknown statement %q", fields[0])
+
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+		}
+
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(solids) == 0 {
+		return nil, fmt.Errorf("file has no solid")
+	}
+
+	// the last solid is allowed to end with the file
+	if last := &solids[len(solids)-1]; last.Count == 0 {
+		last.Count = len(positions)/3 - last.Start
+	}
+
+	geometry.AddAttribute("position", core.NewFloat32BufferAttribute(positions, 3))
+	geometry.AddAttribute("normal", core.NewFloat32BufferAttribute(normals, 3))
+
+	if len(solids) > 1 {
+		for _, solid := range solids {
+			geometry.AddGroup(solid.Start, solid.Count, solid.MaterialIndex)
+		}
+	}
+
+	return geometry, nil
+
+}
+
+// faceNormal returns normal, or the normal of the triangle
+// given by the nine values of vertices if normal is zero
+func faceNormal(normal, vertices []float32) []float32 {
+
+	if normal[0] != 0 || normal[1] != 0 || normal[2] != 0 {
+		return normal
+	}
+
+	a := math3.NewVector3().Set(float64(vertices[0]), float64(vertices[1]), float64(vertices[2]))
+	b := math3.NewVector3().Set(float64(vertices[3]), float64(vertices[4]), float64(vertices[5]))
+	c := math3.NewVector3().Set(float64(vertices[6]), float64(vertices[7]), float64(vertices[8]))
+
+	n := math3.TriangleNormal(a, b, c, nil)
+
+	return []float32{float32(n.X), float32(n.Y), float32(n.Z)}
+
+}
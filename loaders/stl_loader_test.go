@@ -0,0 +1,130 @@
+package loaders_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/loaders"
+	"github.com/rydrman/three.go/math3"
+)
+
+const testSTL = `solid first part
+  facet normal 0 0 1
+    outer loop
+      vertex 0 0 0
+      vertex 1 0 0
+      vertex 1 1 0
+    endloop
+  endfacet
+endsolid first part
+solid second
+  facet normal 0 0 0
+    outer loop
+      vertex 0 0 0
+      vertex 0 1 0
+      vertex 0 0 -2.5
+    endloop
+  endfacet
+endsolid second
+`
+
+// testTetrahedron returns an indexed tetrahedron with vertex colors
+func testTetrahedron() *core.BufferGeometry {
+	geometry := core.NewBufferGeometry()
+	geometry.Name = "tetrahedron"
+	geometry.AddAttribute("position", core.NewFloat32BufferAttribute([]float32{
+		0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0, 1.5,
+	}, 3))
+	geometry.AddAttribute("color", core.NewFloat32BufferAttribute([]float32{
+		1, 0, 0, 1, 0, 0, 1, 0, 0, 0, 0, 1,
+	}, 3))
+	geometry.SetIndex(core.NewIndexAttribute([]int{0, 2, 1, 0, 1, 3, 0, 3, 2, 1, 2, 3}))
+	return geometry
+}
+
+func TestSTLLoader_ASCII(t *testing.T) {
+	geometry, err := loaders.NewSTLLoader(nil).Parse(strings.NewReader(testSTL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if geometry.Name != "first part" || geometry.Index != nil || len(geometry.Groups) != 2 {
+		t.Fatalf("expected a named, non-indexed geometry with two groups, got %+v", geometry)
+	}
+	if geometry.Groups[1].Start != 3 || geometry.Groups[1].Count != 3 || geometry.Groups[1].MaterialIndex != 1 {
+		t.Errorf("unexpected second group %+v", geometry.Groups[1])
+	}
+
+	normal := geometry.GetAttribute("normal")
+	if normal.GetZ(2) != 1 || normal.GetX(4) != -1 {
+		t.Errorf("expected the given normal and one computed from the vertices, got %v and %v", normal.GetVector3(2, nil), normal.GetVector3(4, nil))
+	}
+
+	expected := math3.NewBox3().Set(math3.NewVector3().Set(0, 0, -2.5), math3.NewVector3().Set(1, 1, 0))
+	if geometry.BoundingBox == nil || !geometry.BoundingBox.Equals(expected) {
+		t.Errorf("expected the bounding box %v, got %v", expected, geometry.BoundingBox)
+	}
+}
+
+func TestSTLExporter_RoundTrip(t *testing.T) {
+	for _, isBinary := range []bool{false, true} {
+		exporter := loaders.NewSTLExporter()
+		exporter.Binary = isBinary
+		var b bytes.Buffer
+		if err := exporter.Export(testTetrahedron(), &b); err != nil {
+			t.Fatal(err)
+		}
+		if isBinary && bytes.HasPrefix(b.Bytes(), []byte("solid")) {
+			t.Error("binary files must not start with solid")
+		}
+
+		geometry, err := loaders.NewSTLLoader(nil).Parse(&b)
+		if err != nil {
+			t.Fatalf("binary %t: %v", isBinary, err)
+		}
+
+		position := geometry.GetAttribute("position")
+		if position.Count() != 12 || position.GetZ(5) != 1.5 {
+			t.Errorf("binary %t: expected the vertices of the four triangles", isBinary)
+		}
+		normal := geometry.GetAttribute("normal")
+		if normal.GetZ(0) != -1 || math.Abs(normal.GetX(9)-normal.GetZ(9)*1.5) > 1e-6 {
+			t.Errorf("binary %t: expected the normals of the faces, got %v and %v", isBinary, normal.GetVector3(0, nil), normal.GetVector3(9, nil))
+		}
+		if !geometry.BoundingBox.Max.Equals(math3.NewVector3().Set(1, 1, 1.5)) {
+			t.Errorf("binary %t: unexpected bounding box %v", isBinary, geometry.BoundingBox)
+		}
+
+		color := geometry.GetAttribute("color")
+		if isBinary != (color != nil) {
+			t.Fatalf("binary %t: expected only binary files to keep colors", isBinary)
+		}
+		if isBinary && (color.GetX(0) != 1 || color.GetZ(0) != 0 || color.GetZ(11) != float64(float32(10.0/31))) {
+			t.Errorf("binary %t: expected the face colors to be averaged", isBinary)
+		}
+	}
+}
+
+func TestSTLLoader_Errors(t *testing.T) {
+	header := make([]byte, 84)
+	binary.LittleEndian.PutUint32(header[80:], 2)
+
+	tests := []string{
+		"solid a\nfacet normal 0 0 1\nouter loop\nvertex 0 0 0\nendloop\nendfacet\nendsolid\n",
+		"solid a\nfacet normal 0 0\n",
+		"solid a\nvertex 0 x 0\n",
+		"solid a\nwhatever\n",
+		string(header),
+		"",
+	}
+
+	for i, test := range tests {
+		if _, err := loaders.NewSTLLoader(nil).Parse(strings.NewReader(test)); err == nil {
+			t.Errorf("%d: expected an error", i)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package materials
+
+import "github.com/rydrman/three.go/math3"
+
+// LineBasicMaterial draws lines in a flat color
+type LineBasicMaterial struct {
+	*Material
+
+	Color *math3.Color
+
+	Linewidth float64
+	Linecap   string
+	Linejoin  string
+}
+
+// NewLineBasicMaterial creates a white line material
+func NewLineBasicMaterial() *LineBasicMaterial {
+
+	m := &LineBasicMaterial{
+		Material: NewMaterial(),
+
+		Color: math3.NewColor().SetHex(0xffffff),
+
+		Linewidth: 1,
+		Linecap:   "round",
+		Linejoin:  "round",
+	}
+
+	m.Lights = false
+
+	return m
+
+}
+
+func (m *LineBasicMaterial) Clone() *LineBasicMaterial {
+
+	return NewLineBasicMaterial().Copy(m)
+
+}
+
+func (m *LineBasicMaterial) Copy(src *LineBasicMaterial) *LineBasicMaterial {
+
+	material := m.Material.Copy(src.Material)
+	color := m.Color.Copy(src.Color)
+
+	*m = *src
+	m.Material = material
+	m.Color = color
+
+	return m
+
+}
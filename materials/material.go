@@ -0,0 +1,174 @@
+/*
+Package materials describes the appearance of renderable objects.
+Every material embeds the base Material, which holds the render state
+shared by all of them.
+*/
+package materials
+
+import (
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/math3"
+)
+
+// Material holds the properties common to all materials
+type Material struct {
+	UUID string
+	Name string
+
+	// Fog and Lights select whether the material is affected
+	// by the fog and lights of the scene
+	Fog    bool
+	Lights bool
+
+	// Side defines which faces are drawn,
+	// one of three.FrontSide, three.BackSide or three.DoubleSide
+	Side int
+	// Shading is one of three.FlatShading or three.SmoothShading
+	Shading int
+	// VertexColors is one of the three.NoColors, three.FaceColors
+	// or three.VertexColors constants
+	VertexColors int
+
+	// Opacity is only applied if Transparent is set
+	Opacity     float64
+	Transparent bool
+
+	// Blending is one of the three.*Blending constants, the blend
+	// equations and factors below are only used by three.CustomBlending
+	Blending      int
+	BlendSrc      int
+	BlendDst      int
+	BlendEquation int
+	// BlendSrcAlpha, BlendDstAlpha and BlendEquationAlpha are used for
+	// the alpha channel when non zero, otherwise the rgb values apply
+	BlendSrcAlpha      int
+	BlendDstAlpha      int
+	BlendEquationAlpha int
+
+	// DepthFunc is the comparison used for depth testing,
+	// one of the three.*Depth constants
+	DepthFunc  int
+	DepthTest  bool
+	DepthWrite bool
+
+	// ClippingPlanes are planes in world space, fragments on the
+	// negative side of any plane are not drawn. When ClipIntersection
+	// is set fragments are only clipped if they are on the negative
+	// side of every plane.
+	ClippingPlanes   []*math3.Plane
+	ClipIntersection bool
+	ClipShadows      bool
+
+	ColorWrite bool
+
+	// Precision overrides the shader precision, one of "highp",
+	// "mediump" or "lowp", the renderer default is used if empty
+	Precision string
+
+	// PolygonOffset offsets the depth of each fragment by
+	// PolygonOffsetFactor times the depth slope of the triangle plus
+	// PolygonOffsetUnits times the smallest resolvable depth difference
+	PolygonOffset       bool
+	PolygonOffsetFactor float64
+	PolygonOffsetUnits  float64
+
+	// AlphaTest discards fragments with an alpha below this value
+	AlphaTest          float64
+	PremultipliedAlpha bool
+
+	Visible bool
+
+	// Version is incremented each time the material needs to
+	// be recompiled by the renderer
+	Version int
+}
+
+// NewMaterial creates a material with default values
+func NewMaterial() *Material {
+
+	return &Material{
+		UUID: math3.GenerateUUID(),
+
+		Fog:    true,
+		Lights: true,
+
+		Side:         three.FrontSide,
+		Shading:      three.SmoothShading,
+		VertexColors: three.NoColors,
+
+		Opacity: 1,
+
+		Blending:      three.NormalBlending,
+		BlendSrc:      three.SrcAlphaFactor,
+		BlendDst:      three.OneMinusSrcAlphaFactor,
+		BlendEquation: three.AddEquation,
+
+		DepthFunc:  three.LessEqualDepth,
+		DepthTest:  true,
+		DepthWrite: true,
+
+		ColorWrite: true,
+
+		Visible: true,
+	}
+
+}
+
+// GetMaterial returns the base material, allowing it to be accessed
+// from any of the specific material types
+func (m *Material) GetMaterial() *Material {
+
+	return m
+
+}
+
+// SetNeedsUpdate flags this material to be recompiled by the renderer
+func (m *Material) SetNeedsUpdate() {
+
+	m.Version++
+
+}
+
+// BlendAlpha returns the blend equation and factors used for the
+// alpha channel, falling back on the rgb values for any that are unset
+func (m *Material) BlendAlpha() (src, dst, equation int) {
+
+	src, dst, equation = m.BlendSrcAlpha, m.BlendDstAlpha, m.BlendEquationAlpha
+
+	if src == 0 {
+		src = m.BlendSrc
+	}
+	if dst == 0 {
+		dst = m.BlendDst
+	}
+	if equation == 0 {
+		equation = m.BlendEquation
+	}
+
+	return src, dst, equation
+
+}
+
+func (m *Material) Clone() *Material {
+
+	return NewMaterial().Copy(m)
+
+}
+
+// Copy copies the properties of src into this material,
+// the UUID of this material is kept
+func (m *Material) Copy(src *Material) *Material {
+
+	uuid := m.UUID
+
+	*m = *src
+	m.UUID = uuid
+
+	m.ClippingPlanes = nil
+	for _, plane := range src.ClippingPlanes {
+		m.ClippingPlanes = append(m.ClippingPlanes, plane.Clone())
+	}
+
+	return m
+
+}
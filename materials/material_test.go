@@ -0,0 +1,93 @@
+package materials_test
+
+import (
+	"testing"
+
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/materials"
+	"github.com/rydrman/three.go/math3"
+)
+
+func TestMaterial_Defaults(t *testing.T) {
+	m := materials.NewMaterial()
+
+	if m.Side != three.FrontSide || m.Blending != three.NormalBlending || m.DepthFunc != three.LessEqualDepth {
+		t.Errorf("unexpected render state %+v", m)
+	}
+	if !m.DepthTest || !m.DepthWrite || !m.ColorWrite || !m.Visible || m.Transparent {
+		t.Errorf("unexpected flags %+v", m)
+	}
+	if m.Opacity != 1 || m.UUID == "" {
+		t.Errorf("unexpected values %+v", m)
+	}
+
+	if basic := materials.NewMeshBasicMaterial(); basic.Lights {
+		t.Error("basic materials should not be lit")
+	}
+	if lambert := materials.NewMeshLambertMaterial(); !lambert.Lights {
+		t.Error("lambert materials should be lit")
+	}
+}
+
+func TestMaterial_BlendAlpha(t *testing.T) {
+	m := materials.NewMaterial()
+	m.BlendSrc = three.OneFactor
+	m.BlendDstAlpha = three.ZeroFactor
+
+	src, dst, equation := m.BlendAlpha()
+	if src != three.OneFactor || dst != three.ZeroFactor || equation != three.AddEquation {
+		t.Errorf("unexpected alpha blending %d, %d, %d", src, dst, equation)
+	}
+}
+
+func TestMaterial_Clone(t *testing.T) {
+	a := materials.NewMeshPhongMaterial()
+	a.Color.SetHex(0xff0000)
+	a.Shininess = 10
+	a.Side = three.DoubleSide
+	a.ClippingPlanes = []*math3.Plane{math3.NewPlane()}
+
+	b := a.Clone()
+	if b.UUID == a.UUID {
+		t.Error("clone should have a new uuid")
+	}
+	if b.Color.GetHex() != 0xff0000 || b.Shininess != 10 || b.Side != three.DoubleSide {
+		t.Errorf("clone should copy all properties, got %+v", b)
+	}
+
+	a.Color.SetHex(0x00ff00)
+	a.ClippingPlanes[0].Constant = 1
+	a.Opacity = 0.5
+	if b.Color.GetHex() != 0xff0000 || b.ClippingPlanes[0].Constant != 0 || b.Opacity != 1 {
+		t.Error("clone should create a deep copy")
+	}
+
+	var material interface {
+		GetMaterial() *materials.Material
+	} = b
+	if material.GetMaterial() != b.Material {
+		t.Error("every material should give access to its base material")
+	}
+}
+
+func TestShaderMaterial_Clone(t *testing.T) {
+	a := materials.NewShaderMaterial(core.NewUniformGroup("").
+		Add("time", core.NewUniform(1.0)).
+		Add("tint", core.NewUniform(math3.NewColor().SetHex(0x0000ff))))
+	a.Defines["USE_TINT"] = ""
+
+	b := a.Clone()
+	a.Uniforms.Get("tint").GetValue().(*math3.Color).SetHex(0)
+	a.Defines["OTHER"] = "1"
+
+	if b.Uniforms.Get("tint").GetValue().(*math3.Color).GetHex() != 0x0000ff {
+		t.Error("clone should deep copy uniforms")
+	}
+	if _, ok := b.Defines["USE_TINT"]; !ok || len(b.Defines) != 1 {
+		t.Errorf("clone should copy defines, got %v", b.Defines)
+	}
+	if b.VertexShader != a.VertexShader || b.Lights {
+		t.Error("clone should copy shader properties")
+	}
+}
@@ -0,0 +1,70 @@
+package materials
+
+import (
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/math3"
+)
+
+// MeshBasicMaterial draws meshes in a flat color that is
+// not affected by lights
+type MeshBasicMaterial struct {
+	*Material
+
+	Color *math3.Color
+
+	// Combine defines how an environment map is combined with the
+	// color, one of the three.*Operation constants
+	Combine         int
+	Reflectivity    float64
+	RefractionRatio float64
+
+	Wireframe          bool
+	WireframeLinewidth float64
+	WireframeLinecap   string
+	WireframeLinejoin  string
+
+	Skinning     bool
+	MorphTargets bool
+}
+
+// NewMeshBasicMaterial creates a white basic material
+func NewMeshBasicMaterial() *MeshBasicMaterial {
+
+	m := &MeshBasicMaterial{
+		Material: NewMaterial(),
+
+		Color: math3.NewColor().SetHex(0xffffff),
+
+		Combine:         three.MultiplyOperation,
+		Reflectivity:    1,
+		RefractionRatio: 0.98,
+
+		WireframeLinewidth: 1,
+		WireframeLinecap:   "round",
+		WireframeLinejoin:  "round",
+	}
+
+	m.Lights = false
+
+	return m
+
+}
+
+func (m *MeshBasicMaterial) Clone() *MeshBasicMaterial {
+
+	return NewMeshBasicMaterial().Copy(m)
+
+}
+
+func (m *MeshBasicMaterial) Copy(src *MeshBasicMaterial) *MeshBasicMaterial {
+
+	material := m.Material.Copy(src.Material)
+	color := m.Color.Copy(src.Color)
+
+	*m = *src
+	m.Material = material
+	m.Color = color
+
+	return m
+
+}
@@ -0,0 +1,62 @@
+package materials
+
+import "github.com/rydrman/three.go/math3"
+
+// MeshLambertMaterial is a non-shiny material lit per vertex
+// using the Lambertian model
+type MeshLambertMaterial struct {
+	*Material
+
+	Color *math3.Color
+
+	Emissive          *math3.Color
+	EmissiveIntensity float64
+
+	Wireframe          bool
+	WireframeLinewidth float64
+	WireframeLinecap   string
+	WireframeLinejoin  string
+
+	Skinning     bool
+	MorphTargets bool
+	MorphNormals bool
+}
+
+// NewMeshLambertMaterial creates a white lambert material
+func NewMeshLambertMaterial() *MeshLambertMaterial {
+
+	return &MeshLambertMaterial{
+		Material: NewMaterial(),
+
+		Color: math3.NewColor().SetHex(0xffffff),
+
+		Emissive:          math3.NewColor().SetHex(0x000000),
+		EmissiveIntensity: 1,
+
+		WireframeLinewidth: 1,
+		WireframeLinecap:   "round",
+		WireframeLinejoin:  "round",
+	}
+
+}
+
+func (m *MeshLambertMaterial) Clone() *MeshLambertMaterial {
+
+	return NewMeshLambertMaterial().Copy(m)
+
+}
+
+func (m *MeshLambertMaterial) Copy(src *MeshLambertMaterial) *MeshLambertMaterial {
+
+	material := m.Material.Copy(src.Material)
+	color := m.Color.Copy(src.Color)
+	emissive := m.Emissive.Copy(src.Emissive)
+
+	*m = *src
+	m.Material = material
+	m.Color = color
+	m.Emissive = emissive
+
+	return m
+
+}
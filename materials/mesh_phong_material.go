@@ -0,0 +1,105 @@
+package materials
+
+import (
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/textures"
+)
+
+// MeshPhongMaterial is a shiny material lit per pixel
+// using the Blinn-Phong model
+type MeshPhongMaterial struct {
+	*Material
+
+	Color     *math3.Color
+	Specular  *math3.Color
+	Shininess float64
+
+	Emissive          *math3.Color
+	EmissiveIntensity float64
+
+	// Map, SpecularMap and EmissiveMap are multiplied with their
+	// matching colors, AlphaMap scales the opacity by its green channel.
+	// Textures are shared rather than copied along with the material.
+	Map         *textures.Texture
+	SpecularMap *textures.Texture
+	EmissiveMap *textures.Texture
+	AlphaMap    *textures.Texture
+
+	BumpMap   *textures.Texture
+	NormalMap *textures.Texture
+
+	BumpScale         float64
+	NormalScale       *math3.Vector2
+	DisplacementScale float64
+	DisplacementBias  float64
+
+	// Combine defines how an environment map is combined with the
+	// color, one of the three.*Operation constants
+	Combine         int
+	Reflectivity    float64
+	RefractionRatio float64
+
+	Wireframe          bool
+	WireframeLinewidth float64
+	WireframeLinecap   string
+	WireframeLinejoin  string
+
+	Skinning     bool
+	MorphTargets bool
+	MorphNormals bool
+}
+
+// NewMeshPhongMaterial creates a white phong material
+func NewMeshPhongMaterial() *MeshPhongMaterial {
+
+	return &MeshPhongMaterial{
+		Material: NewMaterial(),
+
+		Color:     math3.NewColor().SetHex(0xffffff),
+		Specular:  math3.NewColor().SetHex(0x111111),
+		Shininess: 30,
+
+		Emissive:          math3.NewColor().SetHex(0x000000),
+		EmissiveIntensity: 1,
+
+		BumpScale:         1,
+		NormalScale:       math3.NewVector2().Set(1, 1),
+		DisplacementScale: 1,
+		DisplacementBias:  0,
+
+		Combine:         three.MultiplyOperation,
+		Reflectivity:    1,
+		RefractionRatio: 0.98,
+
+		WireframeLinewidth: 1,
+		WireframeLinecap:   "round",
+		WireframeLinejoin:  "round",
+	}
+
+}
+
+func (m *MeshPhongMaterial) Clone() *MeshPhongMaterial {
+
+	return NewMeshPhongMaterial().Copy(m)
+
+}
+
+func (m *MeshPhongMaterial) Copy(src *MeshPhongMaterial) *MeshPhongMaterial {
+
+	material := m.Material.Copy(src.Material)
+	color := m.Color.Copy(src.Color)
+	specular := m.Specular.Copy(src.Specular)
+	emissive := m.Emissive.Copy(src.Emissive)
+	normalScale := m.NormalScale.Copy(src.NormalScale)
+
+	*m = *src
+	m.Material = material
+	m.Color = color
+	m.Specular = specular
+	m.Emissive = emissive
+	m.NormalScale = normalScale
+
+	return m
+
+}
@@ -0,0 +1,107 @@
+package materials
+
+import (
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/textures"
+)
+
+// MeshStandardMaterial is a physically based material
+// using the metallic-roughness workflow
+type MeshStandardMaterial struct {
+	*Material
+
+	Color     *math3.Color
+	Roughness float64
+	Metalness float64
+
+	Emissive          *math3.Color
+	EmissiveIntensity float64
+
+	// Map and EmissiveMap are multiplied with their matching colors,
+	// RoughnessMap and MetalnessMap scale their factors by the green
+	// and blue channels. Textures are shared rather than copied along
+	// with the material.
+	Map          *textures.Texture
+	RoughnessMap *textures.Texture
+	MetalnessMap *textures.Texture
+	EmissiveMap  *textures.Texture
+	AlphaMap     *textures.Texture
+
+	// AoMap darkens indirect light by its red channel,
+	// scaled by AoMapIntensity
+	AoMap          *textures.Texture
+	AoMapIntensity float64
+
+	BumpMap   *textures.Texture
+	NormalMap *textures.Texture
+
+	BumpScale         float64
+	NormalScale       *math3.Vector2
+	DisplacementScale float64
+	DisplacementBias  float64
+
+	EnvMapIntensity float64
+	RefractionRatio float64
+
+	Wireframe          bool
+	WireframeLinewidth float64
+	WireframeLinecap   string
+	WireframeLinejoin  string
+
+	Skinning     bool
+	MorphTargets bool
+	MorphNormals bool
+}
+
+// NewMeshStandardMaterial creates a white standard material
+func NewMeshStandardMaterial() *MeshStandardMaterial {
+
+	return &MeshStandardMaterial{
+		Material: NewMaterial(),
+
+		Color:     math3.NewColor().SetHex(0xffffff),
+		Roughness: 0.5,
+		Metalness: 0.5,
+
+		Emissive:          math3.NewColor().SetHex(0x000000),
+		EmissiveIntensity: 1,
+
+		AoMapIntensity: 1,
+
+		BumpScale:         1,
+		NormalScale:       math3.NewVector2().Set(1, 1),
+		DisplacementScale: 1,
+		DisplacementBias:  0,
+
+		EnvMapIntensity: 1,
+		RefractionRatio: 0.98,
+
+		WireframeLinewidth: 1,
+		WireframeLinecap:   "round",
+		WireframeLinejoin:  "round",
+	}
+
+}
+
+func (m *MeshStandardMaterial) Clone() *MeshStandardMaterial {
+
+	return NewMeshStandardMaterial().Copy(m)
+
+}
+
+func (m *MeshStandardMaterial) Copy(src *MeshStandardMaterial) *MeshStandardMaterial {
+
+	material := m.Material.Copy(src.Material)
+	color := m.Color.Copy(src.Color)
+	emissive := m.Emissive.Copy(src.Emissive)
+	normalScale := m.NormalScale.Copy(src.NormalScale)
+
+	*m = *src
+	m.Material = material
+	m.Color = color
+	m.Emissive = emissive
+	m.NormalScale = normalScale
+
+	return m
+
+}
@@ -0,0 +1,37 @@
+package materials
+
+// Interface is implemented by all of the material types
+type Interface interface {
+	GetMaterial() *Material
+}
+
+// MultiMaterial draws each group of a geometry with the
+// material at the MaterialIndex of the group
+type MultiMaterial struct {
+	*Material
+
+	Materials []Interface
+}
+
+// NewMultiMaterial creates a multi material from the given materials
+func NewMultiMaterial(materials ...Interface) *MultiMaterial {
+
+	return &MultiMaterial{
+		Material: NewMaterial(),
+
+		Materials: materials,
+	}
+
+}
+
+// Get returns the material for the given group index,
+// or nil if there is no material at that index
+func (m *MultiMaterial) Get(index int) Interface {
+
+	if index < 0 || index >= len(m.Materials) {
+		return nil
+	}
+
+	return m.Materials[index]
+
+}
@@ -0,0 +1,52 @@
+package materials
+
+import "github.com/rydrman/three.go/math3"
+
+// PointsMaterial draws points as squares of a flat color
+type PointsMaterial struct {
+	*Material
+
+	Color *math3.Color
+
+	// Size is the size of each point in pixels, scaled by
+	// the distance from the camera if SizeAttenuation is set
+	Size            float64
+	SizeAttenuation bool
+}
+
+// NewPointsMaterial creates a white points material
+func NewPointsMaterial() *PointsMaterial {
+
+	m := &PointsMaterial{
+		Material: NewMaterial(),
+
+		Color: math3.NewColor().SetHex(0xffffff),
+
+		Size:            1,
+		SizeAttenuation: true,
+	}
+
+	m.Lights = false
+
+	return m
+
+}
+
+func (m *PointsMaterial) Clone() *PointsMaterial {
+
+	return NewPointsMaterial().Copy(m)
+
+}
+
+func (m *PointsMaterial) Copy(src *PointsMaterial) *PointsMaterial {
+
+	material := m.Material.Copy(src.Material)
+	color := m.Color.Copy(src.Color)
+
+	*m = *src
+	m.Material = material
+	m.Color = color
+
+	return m
+
+}
@@ -0,0 +1,83 @@
+package materials
+
+import "github.com/rydrman/three.go/core"
+
+// ShaderMaterial is drawn using custom glsl shaders,
+// which are given the uniforms of the material
+type ShaderMaterial struct {
+	*Material
+
+	// Defines are added to the shaders as #define statements
+	Defines  map[string]string
+	Uniforms *core.UniformGroup
+
+	VertexShader   string
+	FragmentShader string
+
+	Linewidth float64
+
+	Wireframe          bool
+	WireframeLinewidth float64
+
+	// Clipping selects whether the shaders support clipping planes
+	Clipping bool
+
+	Skinning     bool
+	MorphTargets bool
+	MorphNormals bool
+}
+
+// NewShaderMaterial creates a shader material with the given uniforms,
+// or an empty group if nil, that draws everything in red until its
+// shaders are replaced
+func NewShaderMaterial(uniforms *core.UniformGroup) *ShaderMaterial {
+
+	if nil == uniforms {
+		uniforms = core.NewUniformGroup("")
+	}
+
+	m := &ShaderMaterial{
+		Material: NewMaterial(),
+
+		Defines:  make(map[string]string),
+		Uniforms: uniforms,
+
+		VertexShader:   "void main() {\n\tgl_Position = projectionMatrix * modelViewMatrix * vec4( position, 1.0 );\n}",
+		FragmentShader: "void main() {\n\tgl_FragColor = vec4( 1.0, 0.0, 0.0, 1.0 );\n}",
+
+		Linewidth:          1,
+		WireframeLinewidth: 1,
+	}
+
+	m.Fog = false
+	m.Lights = false
+
+	return m
+
+}
+
+func (m *ShaderMaterial) Clone() *ShaderMaterial {
+
+	return NewShaderMaterial(nil).Copy(m)
+
+}
+
+// Copy copies the properties of src into this material,
+// including deep copies of its uniforms and defines
+func (m *ShaderMaterial) Copy(src *ShaderMaterial) *ShaderMaterial {
+
+	material := m.Material.Copy(src.Material)
+
+	*m = *src
+	m.Material = material
+
+	m.Uniforms = src.Uniforms.Clone()
+
+	m.Defines = make(map[string]string, len(src.Defines))
+	for name, value := range src.Defines {
+		m.Defines[name] = value
+	}
+
+	return m
+
+}
@@ -0,0 +1,9 @@
+package math3
+
+// Attribute is a buffer of items that can be read and
+// written as vectors, such as core.BufferAttribute
+type Attribute interface {
+	Count() int
+	GetVector3(index int, target *Vector3) *Vector3
+	SetVector3(index int, v *Vector3)
+}
@@ -0,0 +1,8 @@
+package math3
+
+// Bounded is implemented by objects that have a bounding
+// sphere, given in the local space of the object
+type Bounded interface {
+	Positioner
+	GetBoundingSphere() *Sphere
+}
@@ -46,9 +46,9 @@ func (b *Box3) SetFromArray(array []float64) *Box3 {
 	minY := math.MaxFloat64
 	minZ := math.MaxFloat64
 
-	maxX := math.SmallestNonzeroFloat64
-	maxY := math.SmallestNonzeroFloat64
-	maxZ := math.SmallestNonzeroFloat64
+	maxX := -math.MaxFloat64
+	maxY := -math.MaxFloat64
+	maxZ := -math.MaxFloat64
 
 	for i, l := 0, len(array); i < l; i += 3 {
 
@@ -339,66 +339,64 @@ func (b *Box3) IntersectsBox(other *Box3) bool {
 
 }
 
-//TODO
-/*func (b *Box3) IntersectsSphere(sphere *Sphere) bool {
+func (b *Box3) IntersectsSphere(sphere *Sphere) bool {
 
-    closestPoint := NewVector3()
+	closestPoint := NewVector3()
 
-    // Find the point on the AABB closest to the sphere center.
-    b.ClampPoint(sphere.Center, closestPoint)
+	// Find the point on the AABB closest to the sphere center.
+	b.ClampPoint(sphere.Center, closestPoint)
 
-    // If that point is inside the sphere, the AABB and sphere intersect.
-    return closestPoint.DistanceToSquared(sphere.Center) <= (sphere.Radius * sphere.Radius)
+	// If that point is inside the sphere, the AABB and sphere intersect.
+	return closestPoint.DistanceToSquared(sphere.Center) <= (sphere.Radius * sphere.Radius)
 
-}*/
+}
 
-//TODO
-/*func (b *Box3) IntersectsPlane(plane *Plane) bool {
+func (b *Box3) IntersectsPlane(plane *Plane) bool {
 
-    // We compute the minimum and maximum dot product values. If those values
-    // are on the same side (back or front) of the plane, then there is no intersection.
+	// We compute the minimum and maximum dot product values. If those values
+	// are on the same side (back or front) of the plane, then there is no intersection.
 
-    var min, max float64
+	var min, max float64
 
-    if plane.Normal.X > 0 {
+	if plane.Normal.X > 0 {
 
-        min = plane.Normal.X * b.Min.X
-        max = plane.Normal.X * b.Max.X
+		min = plane.Normal.X * b.Min.X
+		max = plane.Normal.X * b.Max.X
 
-    } else {
+	} else {
 
-        min = plane.Normal.X * b.Max.X
-        max = plane.Normal.X * b.Min.X
+		min = plane.Normal.X * b.Max.X
+		max = plane.Normal.X * b.Min.X
 
-    }
+	}
 
-    if plane.Normal.Y > 0 {
+	if plane.Normal.Y > 0 {
 
-        min += plane.Normal.Y * b.Min.Y
-        max += plane.Normal.Y * b.Max.Y
+		min += plane.Normal.Y * b.Min.Y
+		max += plane.Normal.Y * b.Max.Y
 
-    } else {
+	} else {
 
-        min += plane.Normal.Y * b.Max.Y
-        max += plane.Normal.Y * b.Min.Y
+		min += plane.Normal.Y * b.Max.Y
+		max += plane.Normal.Y * b.Min.Y
 
-    }
+	}
 
-    if plane.Normal.Z > 0 {
+	if plane.Normal.Z > 0 {
 
-        min += plane.Normal.Z * b.Min.Z
-        max += plane.Normal.Z * b.Max.Z
+		min += plane.Normal.Z * b.Min.Z
+		max += plane.Normal.Z * b.Max.Z
 
-    } else {
+	} else {
 
-        min += plane.Normal.Z * b.Max.Z
-        max += plane.Normal.Z * b.Min.Z
+		min += plane.Normal.Z * b.Max.Z
+		max += plane.Normal.Z * b.Min.Z
 
-    }
+	}
 
-    return (min <= plane.Constant && max >= plane.Constant)
+	return (min <= -plane.Constant && max >= -plane.Constant)
 
-}*/
+}
 
 func (b *Box3) ClampPoint(point, target *Vector3) *Vector3 {
 
@@ -418,21 +416,20 @@ func (b *Box3) DistanceToPoint(point *Vector3) float64 {
 
 }
 
-//TODO
-/*func (b *Box3) GetBoundingSphere(target *Sphere) *Sphere {
+func (b *Box3) GetBoundingSphere(target *Sphere) *Sphere {
 
-    v1 := NewVector3()
+	v1 := NewVector3()
 
-    if nil == target {
-        target = NewSphere()
-    }
+	if nil == target {
+		target = NewSphere()
+	}
 
-    target.Center = b.Center(nil)
-    target.Radius = b.Size(v1).Length() * 0.5
+	b.Center(target.Center)
+	target.Radius = b.Size(v1).Length() * 0.5
 
-    return target
+	return target
 
-}*/
+}
 
 func (b *Box3) Intersect(other *Box3) *Box3 {
 
@@ -6,7 +6,7 @@ import (
 	"math"
 	"testing"
 
-	math3 "github.com/rydrman/three.go/math"
+	math3 "github.com/rydrman/three.go/math3"
 )
 
 func TestNewBox3(t *testing.T) {
@@ -345,43 +345,62 @@ func TestBox3_IntersectsBox(t *testing.T) {
 	}
 }
 
-//TODO
-/*func TestBox3_IntersectsSphere(t *testing.T) {
-    a := math3.NewBox3().Set(zero3.Clone(), one3.Clone())
-    b := math3.NewSphere().Set(zero3.Clone(), 1)
-
-    if !a.IntersectsSphere(b) {
-        t.Fail()
-    }
-
-    b.Translate(math3.NewVector3().Set(2, 2, 2))
-    if a.IntersectsSphere(b) {
-        t.Fail()
-    }
-}*/
-
-//TODO
-/*func TestBox3_IntersectsPlane(t *testing.T) {
-    a := math3.NewBox3().Set( zero3.Clone(), one3.Clone() )
-    b := new THREE.Plane( math3.NewVector3().Set( 0, 1, 0 ), 1 )
-    c := new THREE.Plane( math3.NewVector3().Set( 0, 1, 0 ), 1.25 )
-    d := new THREE.Plane( math3.NewVector3().Set( 0, -1, 0 ), 1.25 )
-
-    ok( a.IntersectsPlane( b ) , "Passed!" )
-    ok( ! a.IntersectsPlane( c ) , "Passed!" )
-    ok( ! a.IntersectsPlane( d ) , "Passed!" )
-}/*
-
-//TODO
-/*func TestBox3_GetBoundingSphere(t *testing.T) {
-    a := math3.NewBox3().Set( zero3.Clone(), zero3.Clone() )
-    b := math3.NewBox3().Set( zero3.Clone(), one3.Clone() )
-    c := math3.NewBox3().Set( one3.Clone().Negate(), one3.Clone() )
-
-    ok( a.GetBoundingSphere().Equals( new THREE.Sphere( zero3, 0 ) ), "Passed!" )
-    ok( b.GetBoundingSphere().Equals( new THREE.Sphere( one3.Clone().MultiplyScalar( 0.5 ), math.Sqrt( 3 ) * 0.5 ) ), "Passed!" )
-    ok( c.GetBoundingSphere().Equals( new THREE.Sphere( zero3, math.Sqrt( 12 ) * 0.5 ) ), "Passed!" )
-}*/
+func TestBox3_IntersectsSphere(t *testing.T) {
+	a := math3.NewBox3().Set(zero3.Clone(), one3.Clone())
+	b := math3.NewSphere().Set(zero3.Clone(), 1)
+
+	if !a.IntersectsSphere(b) {
+		t.Fail()
+	}
+	if !b.IntersectsBox(a) {
+		t.Fail()
+	}
+
+	b.Translate(math3.NewVector3().Set(2, 2, 2))
+	if a.IntersectsSphere(b) {
+		t.Fail()
+	}
+}
+
+func TestBox3_IntersectsPlane(t *testing.T) {
+	a := math3.NewBox3().Set(zero3.Clone(), one3.Clone())
+	b := math3.NewPlane().Set(math3.NewVector3().Set(0, 1, 0), -0.5)
+	c := math3.NewPlane().Set(math3.NewVector3().Set(0, 1, 0), -1.25)
+	d := math3.NewPlane().Set(math3.NewVector3().Set(0, -1, 0), 1.25)
+	e := math3.NewPlane().Set(math3.NewVector3().Set(0, 1, 0), 1)
+
+	if !a.IntersectsPlane(b) {
+		t.Fail()
+	}
+	if a.IntersectsPlane(c) {
+		t.Fail()
+	}
+	if a.IntersectsPlane(d) {
+		t.Fail()
+	}
+	if a.IntersectsPlane(e) {
+		t.Fail()
+	}
+	if !b.IntersectsBox(a) {
+		t.Fail()
+	}
+}
+
+func TestBox3_GetBoundingSphere(t *testing.T) {
+	a := math3.NewBox3().Set(zero3.Clone(), zero3.Clone())
+	b := math3.NewBox3().Set(zero3.Clone(), one3.Clone())
+	c := math3.NewBox3().Set(one3.Clone().Negate(), one3.Clone())
+
+	if !a.GetBoundingSphere(nil).Equals(math3.NewSphere().Set(zero3, 0)) {
+		t.Fail()
+	}
+	if !b.GetBoundingSphere(nil).Equals(math3.NewSphere().Set(one3.Clone().MultiplyScalar(0.5), math.Sqrt(3)*0.5)) {
+		t.Fail()
+	}
+	if !c.GetBoundingSphere(nil).Equals(math3.NewSphere().Set(zero3, math.Sqrt(12)*0.5)) {
+		t.Fail()
+	}
+}
 
 func TestBox3_Intersect(t *testing.T) {
 	a := math3.NewBox3().Set(zero3.Clone(), zero3.Clone())
@@ -7,7 +7,6 @@ import (
 	"strconv"
 
 	"github.com/golang/glog"
-	"github.com/rydrman/three.go"
 )
 
 type Color struct {
@@ -213,9 +212,9 @@ func (color *Color) SetStyle(style string) *Color {
 			if size == 3 {
 
 				// #ff0
-				r, _ := strconv.ParseInt(three.CharAt(hex, 0)+three.CharAt(hex, 0), 16, 32)
-				g, _ := strconv.ParseInt(three.CharAt(hex, 1)+three.CharAt(hex, 1), 16, 32)
-				b, _ := strconv.ParseInt(three.CharAt(hex, 2)+three.CharAt(hex, 2), 16, 32)
+				r, _ := strconv.ParseInt(charAt(hex, 0)+charAt(hex, 0), 16, 32)
+				g, _ := strconv.ParseInt(charAt(hex, 1)+charAt(hex, 1), 16, 32)
+				b, _ := strconv.ParseInt(charAt(hex, 2)+charAt(hex, 2), 16, 32)
 
 				color.R = float64(r) / 255
 				color.G = float64(g) / 255
@@ -226,9 +225,9 @@ func (color *Color) SetStyle(style string) *Color {
 			} else if size == 6 {
 
 				// #ff0000
-				r, _ := strconv.ParseInt(three.CharAt(hex, 0)+three.CharAt(hex, 1), 16, 34)
-				g, _ := strconv.ParseInt(three.CharAt(hex, 2)+three.CharAt(hex, 3), 16, 32)
-				b, _ := strconv.ParseInt(three.CharAt(hex, 4)+three.CharAt(hex, 5), 16, 32)
+				r, _ := strconv.ParseInt(charAt(hex, 0)+charAt(hex, 1), 16, 34)
+				g, _ := strconv.ParseInt(charAt(hex, 2)+charAt(hex, 3), 16, 32)
+				b, _ := strconv.ParseInt(charAt(hex, 4)+charAt(hex, 5), 16, 32)
 
 				color.R = float64(r) / 255
 				color.G = float64(g) / 255
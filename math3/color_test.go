@@ -4,7 +4,7 @@ import (
 	"math"
 	"testing"
 
-	"github.com/rydrman/marshmallow"
+	mm "github.com/rydrman/three.go/math3"
 )
 
 func validateColor(c *mm.Color, r, g, b float64, t *testing.T) {
@@ -109,7 +109,7 @@ func TestColor_GetHex(t *testing.T) {
 	c := mm.NewColor().SetStyle("red")
 	res := c.GetHex()
 	if res != 0xFF0000 {
-		t.Error("expected %x, got %x", 0xFF0000, res)
+		t.Errorf("expected %x, got %x", 0xFF0000, res)
 	}
 }
 
@@ -118,7 +118,7 @@ func TestColor_SetHex(t *testing.T) {
 	c.SetHex(0xFA8072)
 	res := c.GetHex()
 	if res != 0xFA8072 {
-		t.Error("expected %x, got %x", 0xFA8072, res)
+		t.Errorf("expected %x, got %x", 0xFA8072, res)
 	}
 }
 
@@ -199,7 +199,7 @@ func TestColor_SetStyle_HexSkyBlue(t *testing.T) {
 	c.SetStyle("#87CEEB")
 	res := c.GetHex()
 	if res != 0x87CEEB {
-		t.Error("expected %x, got %x", 0x87CEEB, res)
+		t.Errorf("expected %x, got %x", 0x87CEEB, res)
 	}
 }
 
@@ -208,7 +208,7 @@ func TestColor_SetStyle_HexSkyBlueMixed(t *testing.T) {
 	c.SetStyle("#87cEeB")
 	res := c.GetHex()
 	if res != 0x87CEEB {
-		t.Error("expected %x, got %x", 0x87CEEB, res)
+		t.Errorf("expected %x, got %x", 0x87CEEB, res)
 	}
 }
 
@@ -217,7 +217,7 @@ func TestColor_SetStyle_Hex2Olive(t *testing.T) {
 	c.SetStyle("#F00")
 	res := c.GetHex()
 	if res != 0xFF0000 {
-		t.Error("expected %x, got %x", 0xFF0000, res)
+		t.Errorf("expected %x, got %x", 0xFF0000, res)
 	}
 }
 
@@ -226,7 +226,7 @@ func TestColor_SetStyle_Hex2OliveMixed(t *testing.T) {
 	c.SetStyle("#f00")
 	res := c.GetHex()
 	if res != 0xFF0000 {
-		t.Error("expected %x, got %x", 0xFF0000, res)
+		t.Errorf("expected %x, got %x", 0xFF0000, res)
 	}
 }
 
@@ -235,7 +235,7 @@ func TestColor_SetStyle_ColorName(t *testing.T) {
 	c.SetStyle("powderblue")
 	res := c.GetHex()
 	if res != 0xB0E0E6 {
-		t.Error("expected %x, got %x", 0xB0E0E6, res)
+		t.Errorf("expected %x, got %x", 0xB0E0E6, res)
 	}
 }
 
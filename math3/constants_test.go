@@ -3,7 +3,7 @@ package math3_test
 import (
 	"math"
 
-	math3 "github.com/rydrman/three.go/math"
+	math3 "github.com/rydrman/three.go/math3"
 )
 
 //these are all constants to be used in testing
@@ -16,13 +16,12 @@ const (
 )
 
 var (
+	negInf2 = math3.NewVector2().Set(math.Inf(-1), math.Inf(-1))
+	posInf2 = math3.NewVector2().Set(math.Inf(1), math.Inf(1))
 
-	//var negInf2 = new THREE.Vector2( math.Inf(-1), math.Inf(-1) )
-	//var posInf2 = new THREE.Vector2( math.Inf(1), math.Inf(1) )
-
-	//var zero2 = new THREE.Vector2()
-	//var one2 = new THREE.Vector2( 1, 1 )
-	//var two2 = new THREE.Vector2( 2, 2 )
+	zero2 = math3.NewVector2()
+	one2  = math3.NewVector2().Set(1, 1)
+	two2  = math3.NewVector2().Set(2, 2)
 
 	negInf3 = math3.NewVector3().Set(math.Inf(-1), math.Inf(-1), math.Inf(-1))
 	posInf3 = math3.NewVector3().Set(math.Inf(1), math.Inf(1), math.Inf(1))
@@ -30,4 +29,11 @@ var (
 	zero3 = math3.NewVector3()
 	one3  = math3.NewVector3().Set(1, 1, 1)
 	two3  = math3.NewVector3().Set(2, 2, 2)
+
+	negInf4 = math3.NewVector4().Set(math.Inf(-1), math.Inf(-1), math.Inf(-1), math.Inf(-1))
+	posInf4 = math3.NewVector4().Set(math.Inf(1), math.Inf(1), math.Inf(1), math.Inf(1))
+
+	zero4 = math3.NewVector4().Set(0, 0, 0, 0)
+	one4  = math3.NewVector4().Set(1, 1, 1, 1)
+	two4  = math3.NewVector4().Set(2, 2, 2, 2)
 )
@@ -4,7 +4,7 @@ import (
 	"math"
 	"testing"
 
-	"github.com/rydrman/marshmallow"
+	mm "github.com/rydrman/three.go/math3"
 )
 
 var (
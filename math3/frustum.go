@@ -0,0 +1,172 @@
+package math3
+
+import "fmt"
+
+// Frustum represents the volume enclosed by six planes, most often
+// the region of space that is visible to a camera. Points on the
+// positive side of every plane are inside of the frustum.
+type Frustum struct {
+	Planes [6]*Plane
+}
+
+// NewFrustum constructs a frustum of six default planes
+func NewFrustum() *Frustum {
+
+	f := &Frustum{}
+
+	for i := range f.Planes {
+		f.Planes[i] = NewPlane()
+	}
+
+	return f
+
+}
+
+func (f *Frustum) String() string {
+	return fmt.Sprintf("&Frustum{Planes: %v}", f.Planes)
+}
+
+func (f *Frustum) Set(p0, p1, p2, p3, p4, p5 *Plane) *Frustum {
+
+	f.Planes[0].Copy(p0)
+	f.Planes[1].Copy(p1)
+	f.Planes[2].Copy(p2)
+	f.Planes[3].Copy(p3)
+	f.Planes[4].Copy(p4)
+	f.Planes[5].Copy(p5)
+
+	return f
+
+}
+
+func (f *Frustum) Clone() *Frustum {
+
+	return NewFrustum().Copy(f)
+
+}
+
+func (f *Frustum) Copy(frustum *Frustum) *Frustum {
+
+	for i, plane := range frustum.Planes {
+		f.Planes[i].Copy(plane)
+	}
+
+	return f
+
+}
+
+// SetFromMatrix sets the planes of this frustum to the clip volume of the
+// given projection matrix, most often the product of a camera's projection
+// and world inverse matrices
+func (f *Frustum) SetFromMatrix(m *Matrix4) *Frustum {
+
+	planes := f.Planes
+	me := m.Elements
+	me0, me1, me2, me3 := me[0], me[1], me[2], me[3]
+	me4, me5, me6, me7 := me[4], me[5], me[6], me[7]
+	me8, me9, me10, me11 := me[8], me[9], me[10], me[11]
+	me12, me13, me14, me15 := me[12], me[13], me[14], me[15]
+
+	planes[0].SetComponents(me3-me0, me7-me4, me11-me8, me15-me12).Normalize()
+	planes[1].SetComponents(me3+me0, me7+me4, me11+me8, me15+me12).Normalize()
+	planes[2].SetComponents(me3+me1, me7+me5, me11+me9, me15+me13).Normalize()
+	planes[3].SetComponents(me3-me1, me7-me5, me11-me9, me15-me13).Normalize()
+	planes[4].SetComponents(me3-me2, me7-me6, me11-me10, me15-me14).Normalize()
+	planes[5].SetComponents(me3+me2, me7+me6, me11+me10, me15+me14).Normalize()
+
+	return f
+
+}
+
+// IntersectsObject reports whether the bounding sphere
+// of object, in world space, intersects this frustum
+func (f *Frustum) IntersectsObject(object Bounded) bool {
+
+	sphere := object.GetBoundingSphere().Clone().ApplyMatrix4(object.GetMatrixWorld())
+
+	return f.IntersectsSphere(sphere)
+
+}
+
+func (f *Frustum) IntersectsSphere(sphere *Sphere) bool {
+
+	center := sphere.Center
+	negRadius := -sphere.Radius
+
+	for _, plane := range f.Planes {
+
+		distance := plane.DistanceToPoint(center)
+
+		if distance < negRadius {
+
+			return false
+
+		}
+
+	}
+
+	return true
+
+}
+
+func (f *Frustum) IntersectsBox(box *Box3) bool {
+
+	p1 := NewVector3()
+	p2 := NewVector3()
+
+	for _, plane := range f.Planes {
+
+		if plane.Normal.X > 0 {
+			p1.X = box.Min.X
+			p2.X = box.Max.X
+		} else {
+			p1.X = box.Max.X
+			p2.X = box.Min.X
+		}
+		if plane.Normal.Y > 0 {
+			p1.Y = box.Min.Y
+			p2.Y = box.Max.Y
+		} else {
+			p1.Y = box.Max.Y
+			p2.Y = box.Min.Y
+		}
+		if plane.Normal.Z > 0 {
+			p1.Z = box.Min.Z
+			p2.Z = box.Max.Z
+		} else {
+			p1.Z = box.Max.Z
+			p2.Z = box.Min.Z
+		}
+
+		d1 := plane.DistanceToPoint(p1)
+		d2 := plane.DistanceToPoint(p2)
+
+		// if both outside plane, no intersection
+
+		if d1 < 0 && d2 < 0 {
+
+			return false
+
+		}
+
+	}
+
+	return true
+
+}
+
+func (f *Frustum) ContainsPoint(point *Vector3) bool {
+
+	for _, plane := range f.Planes {
+
+		if plane.DistanceToPoint(point) < 0 {
+
+			return false
+
+		}
+
+	}
+
+	return true
+
+}
@@ -0,0 +1,134 @@
+package math3_test
+
+import (
+	"testing"
+
+	math3 "github.com/rydrman/three.go/math3"
+)
+
+type testBounded struct {
+	matrixWorld *math3.Matrix4
+	sphere      *math3.Sphere
+}
+
+func (b *testBounded) GetMatrixWorld() *math3.Matrix4 {
+	return b.matrixWorld
+}
+
+func (b *testBounded) GetBoundingSphere() *math3.Sphere {
+	return b.sphere
+}
+
+func TestFrustum_Instancing(t *testing.T) {
+	a := math3.NewFrustum()
+	for _, p := range a.Planes {
+		if !p.Equals(math3.NewPlane()) {
+			t.Errorf("unexpected default plane %s", p)
+		}
+	}
+
+	p0 := math3.NewPlane().SetComponents(-1, 0, 0, x)
+	a.Set(p0, p0, p0, p0, p0, p0)
+	b := a.Clone()
+	for i := range a.Planes {
+		if !b.Planes[i].Equals(p0) {
+			t.Error("clone should equal original")
+		}
+	}
+
+	a.Planes[0].Constant = y
+	if b.Planes[0].Equals(a.Planes[0]) {
+		t.Error("clone should create a deep copy")
+	}
+}
+
+func TestFrustum_ContainsPoint(t *testing.T) {
+	m := math3.NewMatrix4().MakeOrthographic(-1, 1, 1, -1, 1, 100)
+	a := math3.NewFrustum().SetFromMatrix(m)
+
+	if a.ContainsPoint(zero3) {
+		t.Error("point before the near plane should be outside")
+	}
+	if !a.ContainsPoint(math3.NewVector3().Set(0, 0, -50)) {
+		t.Error("point in the middle should be inside")
+	}
+	if !a.ContainsPoint(math3.NewVector3().Set(-1, -1, -1.5)) {
+		t.Error("point on the corner should be inside")
+	}
+	if a.ContainsPoint(math3.NewVector3().Set(1.1, 0, -50)) {
+		t.Error("point to the right should be outside")
+	}
+	if a.ContainsPoint(math3.NewVector3().Set(0, 0, -101)) {
+		t.Error("point beyond the far plane should be outside")
+	}
+
+	m.MakePerspective(90, 1, 1, 100)
+	a.SetFromMatrix(m)
+
+	if !a.ContainsPoint(math3.NewVector3().Set(49, 49, -50)) {
+		t.Error("point within the field of view should be inside")
+	}
+	if a.ContainsPoint(math3.NewVector3().Set(51, 0, -50)) {
+		t.Error("point outside the field of view should be outside")
+	}
+}
+
+func TestFrustum_IntersectsSphere(t *testing.T) {
+	m := math3.NewMatrix4().MakeOrthographic(-1, 1, 1, -1, 1, 100)
+	a := math3.NewFrustum().SetFromMatrix(m)
+
+	if a.IntersectsSphere(math3.NewSphere().Set(zero3, 0.9)) {
+		t.Error("sphere before the near plane should not intersect")
+	}
+	if !a.IntersectsSphere(math3.NewSphere().Set(zero3, 1.1)) {
+		t.Error("sphere crossing the near plane should intersect")
+	}
+	if !a.IntersectsSphere(math3.NewSphere().Set(math3.NewVector3().Set(2, 0, -50), 1.1)) {
+		t.Error("sphere crossing the right plane should intersect")
+	}
+	if a.IntersectsSphere(math3.NewSphere().Set(math3.NewVector3().Set(2, 0, -50), 0.9)) {
+		t.Error("sphere outside the right plane should not intersect")
+	}
+}
+
+func TestFrustum_IntersectsObject(t *testing.T) {
+	m := math3.NewMatrix4().MakeOrthographic(-1, 1, 1, -1, 1, 100)
+	a := math3.NewFrustum().SetFromMatrix(m)
+
+	object := &testBounded{
+		matrixWorld: math3.NewMatrix4(),
+		sphere:      math3.NewSphere().Set(zero3.Clone(), 0.5),
+	}
+
+	if a.IntersectsObject(object) {
+		t.Error("object at the origin should not intersect")
+	}
+
+	object.matrixWorld.MakeTranslation(0, 0, -x)
+	if !a.IntersectsObject(object) {
+		t.Error("translated object should intersect")
+	}
+	if !object.sphere.Center.Equals(zero3) {
+		t.Error("the bounding sphere of the object should not be modified")
+	}
+}
+
+func TestFrustum_IntersectsBox(t *testing.T) {
+	m := math3.NewMatrix4().MakeOrthographic(-1, 1, 1, -1, 1, 100)
+	a := math3.NewFrustum().SetFromMatrix(m)
+
+	box := math3.NewBox3().Set(zero3.Clone(), one3.Clone())
+	if a.IntersectsBox(box) {
+		t.Error("box before the near plane should not intersect")
+	}
+
+	box.Translate(math3.NewVector3().Set(-0.5, -0.5, -1.5))
+	if !a.IntersectsBox(box) {
+		t.Error("box crossing the near plane should intersect")
+	}
+
+	box.Translate(math3.NewVector3().Set(x, 0, -z))
+	if a.IntersectsBox(box) {
+		t.Error("box to the right should not intersect")
+	}
+}
@@ -0,0 +1,392 @@
+package math3
+
+import "sort"
+
+// The ending modes of a CubicInterpolant, which shape
+// the spline before its first and after its last sample
+const (
+	ZeroCurvatureEnding = 2400
+	ZeroSlopeEnding     = 2401
+	WrapAroundEnding    = 2402
+)
+
+// Interpolator is implemented by all of the interpolant types, Evaluate
+// samples the curve at t into the result buffer and returns the buffer
+type Interpolator interface {
+	Evaluate(t float64) []float64
+	GetInterpolant() *Interpolant
+}
+
+// Interpolant holds the samples of a curve, which are given as ValueSize
+// values in SampleValues for each of the increasing ParameterPositions.
+// Evaluating before the first or after the last position returns the
+// first or last sample. The interval of the last evaluation is cached,
+// so curves are sampled quickly when t changes gradually.
+type Interpolant struct {
+	ParameterPositions []float64
+	SampleValues       []float64
+	ValueSize          int
+
+	// ResultBuffer receives the result of each evaluation
+	ResultBuffer []float64
+
+	cachedIndex int
+}
+
+func newInterpolant(parameterPositions, sampleValues []float64, sampleSize int, resultBuffer []float64) *Interpolant {
+
+	if nil == resultBuffer {
+		resultBuffer = make([]float64, sampleSize)
+	}
+
+	return &Interpolant{
+		ParameterPositions: parameterPositions,
+		SampleValues:       sampleValues,
+		ValueSize:          sampleSize,
+
+		ResultBuffer: resultBuffer,
+	}
+
+}
+
+// GetInterpolant returns the base interpolant, allowing it to be
+// accessed from any of the specific interpolant types
+func (p *Interpolant) GetInterpolant() *Interpolant {
+
+	return p
+
+}
+
+// seek returns the index i1 of the first position after t, which is 0
+// before the start and the number of positions after the end. changed
+// reports whether the interval differs from the previous evaluation.
+func (p *Interpolant) seek(t float64) (i1 int, changed bool) {
+
+	pp := p.ParameterPositions
+	n := len(pp)
+
+	inside := func(i int) bool {
+		return (i == 0 || pp[i-1] <= t) && (i == n || t < pp[i])
+	}
+
+	i1 = p.cachedIndex
+	if i1 > n {
+		i1 = n
+	}
+
+	if inside(i1) && i1 == p.cachedIndex {
+		return i1, false
+	}
+
+	// scan the neighboring intervals before searching all of them,
+	// as t usually moves a little at a time in either direction
+	found := false
+	for i := i1 + 1; i <= n && i <= i1+2 && !found; i++ {
+		if inside(i) {
+			i1, found = i, true
+		}
+	}
+	for i := i1 - 1; i >= 0 && i >= i1-2 && !found; i-- {
+		if inside(i) {
+			i1, found = i, true
+		}
+	}
+
+	if !found && !inside(i1) {
+		i1 = sort.Search(n, func(i int) bool { return t < pp[i] })
+	}
+
+	changed = i1 != p.cachedIndex
+	p.cachedIndex = i1
+
+	return i1, changed
+
+}
+
+// outside copies the first or last sample into the result buffer
+// and returns true when i1 is before the start or after the end
+func (p *Interpolant) outside(i1 int) bool {
+
+	n := len(p.ParameterPositions)
+
+	switch {
+	case n == 0:
+	case i1 == 0:
+		p.copySampleValue(0)
+	case i1 == n:
+		p.copySampleValue(n - 1)
+	default:
+		return false
+	}
+
+	return true
+
+}
+
+// copySampleValue copies the sample at index into the result buffer
+func (p *Interpolant) copySampleValue(index int) []float64 {
+
+	stride := p.ValueSize
+	copy(p.ResultBuffer[:stride], p.SampleValues[index*stride:(index+1)*stride])
+
+	return p.ResultBuffer
+
+}
+
+// LinearInterpolant interpolates linearly between samples
+type LinearInterpolant struct {
+	*Interpolant
+}
+
+// NewLinearInterpolant creates a linear interpolant, a result
+// buffer of sampleSize values is created if resultBuffer is nil
+func NewLinearInterpolant(parameterPositions, sampleValues []float64, sampleSize int, resultBuffer []float64) *LinearInterpolant {
+
+	return &LinearInterpolant{
+		Interpolant: newInterpolant(parameterPositions, sampleValues, sampleSize, resultBuffer),
+	}
+
+}
+
+func (p *LinearInterpolant) Evaluate(t float64) []float64 {
+
+	i1, _ := p.seek(t)
+	if p.outside(i1) {
+		return p.ResultBuffer
+	}
+
+	result, values, stride := p.ResultBuffer, p.SampleValues, p.ValueSize
+	t0, t1 := p.ParameterPositions[i1-1], p.ParameterPositions[i1]
+
+	offset1 := i1 * stride
+	offset0 := offset1 - stride
+
+	weight1 := (t - t0) / (t1 - t0)
+	weight0 := 1 - weight1
+
+	for i := 0; i != stride; i++ {
+		result[i] = values[offset0+i]*weight0 + values[offset1+i]*weight1
+	}
+
+	return result
+
+}
+
+// DiscreteInterpolant holds the value of each sample until the next one
+type DiscreteInterpolant struct {
+	*Interpolant
+}
+
+// NewDiscreteInterpolant creates a discrete interpolant, a result
+// buffer of sampleSize values is created if resultBuffer is nil
+func NewDiscreteInterpolant(parameterPositions, sampleValues []float64, sampleSize int, resultBuffer []float64) *DiscreteInterpolant {
+
+	return &DiscreteInterpolant{
+		Interpolant: newInterpolant(parameterPositions, sampleValues, sampleSize, resultBuffer),
+	}
+
+}
+
+func (p *DiscreteInterpolant) Evaluate(t float64) []float64 {
+
+	i1, _ := p.seek(t)
+	if p.outside(i1) {
+		return p.ResultBuffer
+	}
+
+	return p.copySampleValue(i1 - 1)
+
+}
+
+// QuaternionLinearInterpolant spherically interpolates between samples
+// of x, y, z, w quaternions, the value size may hold several quaternions
+type QuaternionLinearInterpolant struct {
+	*Interpolant
+
+	q0, q1 *Quaternion
+}
+
+// NewQuaternionLinearInterpolant creates a quaternion interpolant, a
+// result buffer of sampleSize values is created if resultBuffer is nil
+func NewQuaternionLinearInterpolant(parameterPositions, sampleValues []float64, sampleSize int, resultBuffer []float64) *QuaternionLinearInterpolant {
+
+	return &QuaternionLinearInterpolant{
+		Interpolant: newInterpolant(parameterPositions, sampleValues, sampleSize, resultBuffer),
+
+		q0: NewQuaternion(),
+		q1: NewQuaternion(),
+	}
+
+}
+
+func (p *QuaternionLinearInterpolant) Evaluate(t float64) []float64 {
+
+	i1, _ := p.seek(t)
+	if p.outside(i1) {
+		return p.ResultBuffer
+	}
+
+	result, values, stride := p.ResultBuffer, p.SampleValues, p.ValueSize
+	t0, t1 := p.ParameterPositions[i1-1], p.ParameterPositions[i1]
+
+	offset1 := i1 * stride
+	offset0 := offset1 - stride
+	alpha := (t - t0) / (t1 - t0)
+
+	for i := 0; i+4 <= stride; i += 4 {
+
+		p.q1.FromArray(values, offset1+i)
+		p.q0.FromArray(values, offset0+i).Slerp(p.q1, alpha).ToArray(result, i)
+
+	}
+
+	return result
+
+}
+
+// CubicInterpolant interpolates smoothly through the samples with a
+// cubic spline whose tangents are taken from the neighboring samples.
+// The spline is shaped at either end by EndingStart and EndingEnd.
+type CubicInterpolant struct {
+	*Interpolant
+
+	// EndingStart and EndingEnd are each one of ZeroCurvatureEnding,
+	// ZeroSlopeEnding or WrapAroundEnding
+	EndingStart int
+	EndingEnd   int
+
+	// the weights and offsets of the samples before and after the
+	// current interval, along with the endings they were computed for
+	weightPrev, weightNext float64
+	offsetPrev, offsetNext int
+	endings                [2]int
+}
+
+// NewCubicInterpolant creates a cubic interpolant with zero curvature
+// endings, a result buffer of sampleSize values is created if
+// resultBuffer is nil
+func NewCubicInterpolant(parameterPositions, sampleValues []float64, sampleSize int, resultBuffer []float64) *CubicInterpolant {
+
+	return &CubicInterpolant{
+		Interpolant: newInterpolant(parameterPositions, sampleValues, sampleSize, resultBuffer),
+
+		EndingStart: ZeroCurvatureEnding,
+		EndingEnd:   ZeroCurvatureEnding,
+	}
+
+}
+
+func (p *CubicInterpolant) Evaluate(t float64) []float64 {
+
+	i1, changed := p.seek(t)
+	if p.outside(i1) {
+		return p.ResultBuffer
+	}
+
+	endings := [2]int{p.EndingStart, p.EndingEnd}
+	if changed || endings != p.endings {
+		p.intervalChanged(i1)
+		p.endings = endings
+	}
+
+	result, values, stride := p.ResultBuffer, p.SampleValues, p.ValueSize
+	t0, t1 := p.ParameterPositions[i1-1], p.ParameterPositions[i1]
+
+	o1 := i1 * stride
+	o0 := o1 - stride
+	oP, oN := p.offsetPrev, p.offsetNext
+	wP, wN := p.weightPrev, p.weightNext
+
+	s := (t - t0) / (t1 - t0)
+	ss := s * s
+	sss := ss * s
+
+	// evaluate polynomials
+	sP := -wP*sss + 2*wP*ss - wP*s
+	s0 := (1+wP)*sss + (-1.5-2*wP)*ss + (-0.5+wP)*s + 1
+	s1 := (-1-wN)*sss + (1.5+wN)*ss + 0.5*s
+	sN := wN*sss - wN*ss
+
+	// combine data linearly
+	for i := 0; i != stride; i++ {
+		result[i] = sP*values[oP+i] + s0*values[o0+i] + s1*values[o1+i] + sN*values[oN+i]
+	}
+
+	return result
+
+}
+
+// intervalChanged finds the samples before and after the interval ending
+// at i1, which are made up according to the endings at either end
+func (p *CubicInterpolant) intervalChanged(i1 int) {
+
+	pp := p.ParameterPositions
+	n := len(pp)
+	t0, t1 := pp[i1-1], pp[i1]
+
+	iPrev, iNext := i1-2, i1+1
+	var tPrev, tNext float64
+
+	if iPrev >= 0 {
+
+		tPrev = pp[iPrev]
+
+	} else {
+
+		switch p.EndingStart {
+
+		case ZeroSlopeEnding:
+			// f'(t0) = 0
+			iPrev = i1
+			tPrev = 2*t0 - t1
+
+		case WrapAroundEnding:
+			// use the other end of the curve
+			iPrev = n - 2
+			tPrev = t0 + pp[iPrev] - pp[iPrev+1]
+
+		default:
+			// f''(t0) = 0, a natural spline
+			iPrev = i1
+			tPrev = t1
+
+		}
+
+	}
+
+	if iNext < n {
+
+		tNext = pp[iNext]
+
+	} else {
+
+		switch p.EndingEnd {
+
+		case ZeroSlopeEnding:
+			// f'(tN) = 0
+			iNext = i1 - 1
+			tNext = 2*t1 - t0
+
+		case WrapAroundEnding:
+			// use the other end of the curve
+			iNext = 1
+			tNext = t1 + pp[1] - pp[0]
+
+		default:
+			// f''(tN) = 0, a natural spline
+			iNext = i1 - 1
+			tNext = t0
+
+		}
+
+	}
+
+	halfDt := (t1 - t0) * 0.5
+	stride := p.ValueSize
+
+	p.weightPrev = halfDt / (t0 - tPrev)
+	p.weightNext = halfDt / (tNext - t1)
+	p.offsetPrev = iPrev * stride
+	p.offsetNext = iNext * stride
+
+}
@@ -0,0 +1,107 @@
+package math3_test
+
+import (
+	"math"
+	"testing"
+
+	mm "github.com/rydrman/three.go/math3"
+)
+
+func expectSample(t *testing.T, interpolant mm.Interpolator, time float64, expected ...float64) {
+	t.Helper()
+	result := interpolant.Evaluate(time)
+	for i := range expected {
+		if math.Abs(result[i]-expected[i]) > 1e-9 {
+			t.Errorf("%v: expected %v, got %v", time, expected, result)
+			return
+		}
+	}
+}
+
+func TestLinearInterpolant(t *testing.T) {
+	buffer := make([]float64, 4)
+	interpolant := mm.NewLinearInterpolant([]float64{0, 1, 3, 4}, []float64{0, 10, 1, 20, 5, 40, 5, 0}, 2, buffer[2:])
+
+	// in order, backwards and jumping across the samples
+	for _, time := range []float64{0.5, 1.5, 2.5, 3.5, 2, 0.25, 3.75, -1, 5, 1} {
+		expected := []float64{0, 10}
+		switch {
+		case time >= 4:
+			expected = []float64{5, 0}
+		case time >= 3:
+			expected = []float64{5, 40 - (time-3)*40}
+		case time >= 1:
+			expected = []float64{1 + (time-1)*2, 20 + (time-1)*10}
+		case time >= 0:
+			expected = []float64{time, 10 + time*10}
+		}
+		expectSample(t, interpolant, time, expected...)
+	}
+
+	if &interpolant.Evaluate(0)[0] != &buffer[2] || interpolant.GetInterpolant().ValueSize != 2 {
+		t.Error("expected the result buffer to be used")
+	}
+
+	empty := mm.NewLinearInterpolant(nil, nil, 3, nil)
+	if len(empty.Evaluate(1)) != 3 {
+		t.Error("expected an empty result for no samples")
+	}
+}
+
+func TestDiscreteInterpolant(t *testing.T) {
+	interpolant := mm.NewDiscreteInterpolant([]float64{0, 1, 2}, []float64{3, 4, 5}, 1, nil)
+
+	expectSample(t, interpolant, -1, 3)
+	expectSample(t, interpolant, 0.9, 3)
+	expectSample(t, interpolant, 1, 4)
+	expectSample(t, interpolant, 1.9, 4)
+	expectSample(t, interpolant, 3, 5)
+}
+
+func TestCubicInterpolant(t *testing.T) {
+	interpolant := mm.NewCubicInterpolant([]float64{0, 1, 2, 3}, []float64{0, 1, 2, 3}, 1, nil)
+
+	// samples on a line are reproduced with zero curvature endings
+	for _, time := range []float64{0, 0.5, 1, 1.5, 2.25, 3} {
+		expectSample(t, interpolant, time, time)
+	}
+
+	interpolant.EndingStart = mm.ZeroSlopeEnding
+	interpolant.EndingEnd = mm.ZeroSlopeEnding
+	expectSample(t, interpolant, 0.5, 0.375)
+	expectSample(t, interpolant, 1.5, 1.5)
+	expectSample(t, interpolant, 2.5, 2.625)
+
+	loop := mm.NewCubicInterpolant([]float64{0, 1, 2}, []float64{0, 1, 0}, 1, nil)
+	expectSample(t, loop, 0.5, 0.625)
+	loop.EndingStart = mm.WrapAroundEnding
+	loop.EndingEnd = mm.WrapAroundEnding
+	expectSample(t, loop, 0.5, 0.5)
+	expectSample(t, loop, 1.5, 0.5)
+}
+
+func TestQuaternionLinearInterpolant(t *testing.T) {
+	axis := mm.NewVector3().Set(0, 0, 1)
+	a := mm.NewQuaternion().SetFromAxisAngle(axis, 0)
+	b := mm.NewQuaternion().SetFromAxisAngle(axis, math.Pi/2)
+
+	// two rotations are interpolated side by side
+	values := make([]float64, 16)
+	a.ToArray(values, 0)
+	b.ToArray(values, 4)
+	b.ToArray(values, 8)
+	a.ToArray(values, 12)
+	interpolant := mm.NewQuaternionLinearInterpolant([]float64{0, 2}, values, 8, nil)
+
+	result := interpolant.Evaluate(0.5)
+	expected := mm.NewQuaternion().Copy(a).Slerp(b, 0.25)
+	if !quatEquals(mm.NewQuaternion().FromArray(result, 0), expected) {
+		t.Errorf("expected %v, got %v", expected, result[:4])
+	}
+	expected = mm.NewQuaternion().Copy(b).Slerp(a, 0.25)
+	if !quatEquals(mm.NewQuaternion().FromArray(result, 4), expected) {
+		t.Errorf("expected %v, got %v", expected, result[4:])
+	}
+
+	expectSample(t, interpolant, 3, values[8:]...)
+}
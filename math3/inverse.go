@@ -0,0 +1,185 @@
+package math3
+
+import (
+	"errors"
+	"math"
+)
+
+// InverseEpsilon is the default tolerance of InverseOf, matrices whose
+// determinant is at most this fraction of the product of the lengths of
+// their columns are considered singular
+const InverseEpsilon = 1e-12
+
+// ErrSingularMatrix is returned when inverting a matrix whose
+// determinant is too close to zero
+var ErrSingularMatrix = errors.New("math3: matrix is singular and cannot be inverted")
+
+// isSingular reports whether det, the determinant of the column major
+// n x n matrix e, is too small to invert. The determinant is compared to
+// the product of the lengths of the columns, which bounds it by Hadamard's
+// inequality, so the test does not depend on the scale of the matrix.
+func isSingular(e []float64, n int, det, epsilon float64) bool {
+
+	bound := 1.0
+	for col := 0; col < n; col++ {
+
+		sum := 0.0
+		for _, v := range e[col*n : (col+1)*n] {
+			sum += v * v
+		}
+		bound *= math.Sqrt(sum)
+
+	}
+
+	return math.IsNaN(det) || math.Abs(det) <= epsilon*bound
+
+}
+
+// pseudoInverse computes the Moore-Penrose pseudo-inverse of the n x n
+// matrix a, stored in row major order, through the eigen decomposition
+// of aᵀa. Directions that the matrix collapses are discarded rather than
+// inverted, so a zero matrix produces a zero matrix.
+func pseudoInverse(a []float64, n int) []float64 {
+
+	at := transposeN(a, n)
+	values, vectors := jacobiEigen(multiplyN(at, a, n), n)
+
+	maxValue := 0.0
+	for _, value := range values {
+		maxValue = math.Max(maxValue, math.Abs(value))
+	}
+
+	// eigenvalues below this are treated as zero
+	tolerance := maxValue * float64(n) * 1e-12
+
+	// d = V diag(1 / value) Vᵀ
+	d := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+
+		for j := 0; j < n; j++ {
+
+			sum := 0.0
+			for k, value := range values {
+
+				if math.Abs(value) > tolerance {
+					sum += vectors[i*n+k] * vectors[j*n+k] / value
+				}
+
+			}
+			d[i*n+j] = sum
+
+		}
+
+	}
+
+	return multiplyN(d, at, n)
+
+}
+
+// jacobiEigen returns the eigenvalues of the symmetric n x n matrix s and
+// the matching eigenvectors as the columns of a row major matrix
+func jacobiEigen(s []float64, n int) (values, vectors []float64) {
+
+	a := append([]float64(nil), s...)
+	v := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		v[i*n+i] = 1
+	}
+
+	for sweep := 0; sweep < 50; sweep++ {
+
+		off := 0.0
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				off += a[p*n+q] * a[p*n+q]
+			}
+		}
+
+		if off < 1e-30 {
+			break
+		}
+
+		for p := 0; p < n-1; p++ {
+
+			for q := p + 1; q < n; q++ {
+
+				apq := a[p*n+q]
+				if apq == 0 {
+					continue
+				}
+
+				// rotate rows and columns p and q to eliminate a[p][q]
+				theta := (a[q*n+q] - a[p*n+p]) / (2 * apq)
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				sn := t * c
+
+				for k := 0; k < n; k++ {
+					akp, akq := a[k*n+p], a[k*n+q]
+					a[k*n+p] = c*akp - sn*akq
+					a[k*n+q] = sn*akp + c*akq
+				}
+
+				for k := 0; k < n; k++ {
+					apk, aqk := a[p*n+k], a[q*n+k]
+					a[p*n+k] = c*apk - sn*aqk
+					a[q*n+k] = sn*apk + c*aqk
+				}
+
+				for k := 0; k < n; k++ {
+					vkp, vkq := v[k*n+p], v[k*n+q]
+					v[k*n+p] = c*vkp - sn*vkq
+					v[k*n+q] = sn*vkp + c*vkq
+				}
+
+			}
+
+		}
+
+	}
+
+	values = make([]float64, n)
+	for i := range values {
+		values[i] = a[i*n+i]
+	}
+
+	return values, v
+
+}
+
+// multiplyN returns the product of the row major n x n matrices a and b
+func multiplyN(a, b []float64, n int) []float64 {
+
+	result := make([]float64, n*n)
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			sum := 0.0
+			for k := 0; k < n; k++ {
+				sum += a[i*n+k] * b[k*n+j]
+			}
+			result[i*n+j] = sum
+		}
+	}
+
+	return result
+
+}
+
+// transposeN returns the transpose of the n x n matrix a
+func transposeN(a []float64, n int) []float64 {
+
+	result := make([]float64, n*n)
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			result[j*n+i] = a[i*n+j]
+		}
+	}
+
+	return result
+
+}
@@ -0,0 +1,137 @@
+package math3
+
+import "fmt"
+
+// Line3 represents a line segment in 3D space
+type Line3 struct {
+	Start *Vector3
+	End   *Vector3
+}
+
+// NewLine3 constructs a zero length line at the origin
+func NewLine3() *Line3 {
+
+	return &Line3{
+		NewVector3(),
+		NewVector3(),
+	}
+
+}
+
+func (l *Line3) String() string {
+	return fmt.Sprintf("&Line3{Start: %s, End: %s}", l.Start, l.End)
+}
+
+func (l *Line3) Set(start, end *Vector3) *Line3 {
+
+	l.Start.Copy(start)
+	l.End.Copy(end)
+
+	return l
+
+}
+
+func (l *Line3) Clone() *Line3 {
+
+	return NewLine3().Copy(l)
+
+}
+
+func (l *Line3) Copy(line *Line3) *Line3 {
+
+	l.Start.Copy(line.Start)
+	l.End.Copy(line.End)
+
+	return l
+
+}
+
+func (l *Line3) GetCenter(target *Vector3) *Vector3 {
+
+	if nil == target {
+		target = NewVector3()
+	}
+
+	return target.AddVectors(l.Start, l.End).MultiplyScalar(0.5)
+
+}
+
+// Delta returns the vector from the start to the end of this line
+func (l *Line3) Delta(target *Vector3) *Vector3 {
+
+	if nil == target {
+		target = NewVector3()
+	}
+
+	return target.SubVectors(l.End, l.Start)
+
+}
+
+func (l *Line3) DistanceSq() float64 {
+
+	return l.Start.DistanceToSquared(l.End)
+
+}
+
+func (l *Line3) Distance() float64 {
+
+	return l.Start.DistanceTo(l.End)
+
+}
+
+// At returns the point at t along this line, where 0 is the
+// start and 1 is the end
+func (l *Line3) At(t float64, target *Vector3) *Vector3 {
+
+	return l.Delta(target).MultiplyScalar(t).Add(l.Start)
+
+}
+
+// ClosestPointToPointParameter returns the parameter along this line of
+// the point closest to the given point, clamped to [0, 1] if clampToLine is set
+func (l *Line3) ClosestPointToPointParameter(point *Vector3, clampToLine bool) float64 {
+
+	startP := NewVector3().SubVectors(point, l.Start)
+	startEnd := NewVector3().SubVectors(l.End, l.Start)
+
+	startEnd2 := startEnd.Dot(startEnd)
+	if 0 == startEnd2 {
+		return 0
+	}
+
+	t := startEnd.Dot(startP) / startEnd2
+
+	if clampToLine {
+
+		t = Clamp(t, 0, 1)
+
+	}
+
+	return t
+
+}
+
+// ClosestPointToPoint returns the point on this line closest to the given point,
+// which is limited to the segment if clampToLine is set
+func (l *Line3) ClosestPointToPoint(point *Vector3, clampToLine bool, target *Vector3) *Vector3 {
+
+	t := l.ClosestPointToPointParameter(point, clampToLine)
+
+	return l.At(t, target)
+
+}
+
+func (l *Line3) ApplyMatrix4(matrix *Matrix4) *Line3 {
+
+	l.Start.ApplyMatrix4(matrix)
+	l.End.ApplyMatrix4(matrix)
+
+	return l
+
+}
+
+func (l *Line3) Equals(line *Line3) bool {
+
+	return line.Start.Equals(l.Start) && line.End.Equals(l.End)
+
+}
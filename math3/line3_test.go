@@ -0,0 +1,81 @@
+package math3_test
+
+import (
+	"testing"
+
+	math3 "github.com/rydrman/three.go/math3"
+)
+
+func TestLine3_Instancing(t *testing.T) {
+	a := math3.NewLine3()
+	if !a.Start.Equals(zero3) || !a.End.Equals(zero3) {
+		t.Errorf("unexpected default line %s", a)
+	}
+
+	a.Set(one3, two3)
+	b := a.Clone()
+	if !b.Equals(a) {
+		t.Error("clone should equal original")
+	}
+
+	a.Start.Set(x, y, z)
+	if b.Start.Equals(a.Start) {
+		t.Error("clone should create a deep copy")
+	}
+}
+
+func TestLine3_Measurements(t *testing.T) {
+	a := math3.NewLine3().Set(one3, math3.NewVector3().Set(1, 1, 1+z))
+
+	if !a.GetCenter(nil).Equals(math3.NewVector3().Set(1, 1, 1+z/2)) {
+		t.Error("unexpected center")
+	}
+	if !a.Delta(nil).Equals(math3.NewVector3().Set(0, 0, z)) {
+		t.Error("unexpected delta")
+	}
+	if a.Distance() != z || a.DistanceSq() != z*z {
+		t.Error("unexpected distance")
+	}
+	if !a.At(-1, nil).Equals(math3.NewVector3().Set(1, 1, 1-z)) {
+		t.Error("at should extrapolate beyond the segment")
+	}
+}
+
+func TestLine3_ClosestPointToPoint(t *testing.T) {
+	a := math3.NewLine3().Set(one3, math3.NewVector3().Set(1, 1, 2))
+
+	// nearby the ray
+	if p := a.ClosestPointToPointParameter(zero3, true); p != 0 {
+		t.Errorf("expected clamped parameter 0, got %f", p)
+	}
+	if p := a.ClosestPointToPointParameter(zero3, false); p != -1 {
+		t.Errorf("expected parameter -1, got %f", p)
+	}
+
+	p := a.ClosestPointToPoint(zero3, false, nil)
+	if !p.Equals(math3.NewVector3().Set(1, 1, 0)) {
+		t.Errorf("unexpected closest point %s", p)
+	}
+
+	p = a.ClosestPointToPoint(zero3, true, nil)
+	if !p.Equals(one3) {
+		t.Errorf("unexpected clamped closest point %s", p)
+	}
+
+	// degenerate lines return the start point
+	a.Set(one3, one3)
+	if !a.ClosestPointToPoint(zero3, false, nil).Equals(one3) {
+		t.Error("degenerate line should return the start point")
+	}
+}
+
+func TestLine3_ApplyMatrix4(t *testing.T) {
+	a := math3.NewLine3().Set(zero3, one3)
+	m := math3.NewMatrix4().MakeTranslation(x, y, z)
+
+	a.ApplyMatrix4(m)
+	if !a.Start.Equals(math3.NewVector3().Set(x, y, z)) ||
+		!a.End.Equals(math3.NewVector3().Set(x+1, y+1, z+1)) {
+		t.Errorf("unexpected transformed line %s", a)
+	}
+}
@@ -85,3 +85,10 @@ func GenerateUUID() string {
 	return string(uuid)
 
 }
+
+// charAt returns the character at rune index i of s
+func charAt(s string, i int) string {
+
+	return string([]rune(s)[i])
+
+}
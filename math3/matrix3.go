@@ -100,33 +100,22 @@ func (m *Matrix3) ApplyToVector3Array(array []float64, offset int, length int) [
 
 }
 
-/*func (m *Matrix3) ApplyToBuffer() {
+// ApplyToBufferAttribute transforms each item of attribute as a vector
+func (m *Matrix3) ApplyToBufferAttribute(attribute Attribute) Attribute {
 
-    var v1
-
-    return function applyToBuffer( buffer, offset, length ) {
-
-        if v1 == nil { v1 = NewVector3() }
-        if offset == nil { offset = 0 }
-        if length == nil { length = len(buffer) / buffer.ItemSize }
-
-        for ( i := 0, j = offset i < length i ++, j ++ ) {
-
-            v1.X = buffer.GetX( j )
-            v1.Y = buffer.GetY( j )
-            v1.Z = buffer.GetZ( j )
-
-            v1.ApplyMatrix3( mat )
+	v1 := NewVector3()
 
-            buffer.SetXYZ( v1.X, v1.Y, v1.Z )
+	for i, l := 0, attribute.Count(); i < l; i++ {
 
-        }
+		attribute.GetVector3(i, v1)
+		v1.ApplyMatrix3(m)
+		attribute.SetVector3(i, v1)
 
-        return buffer
+	}
 
-    }
+	return attribute
 
-}(),*/
+}
 
 func (m *Matrix3) MultiplyScalar(s float64) *Matrix3 {
 
@@ -158,22 +147,29 @@ func (m *Matrix3) Determinant() float64 {
 
 }
 
-func (m *Matrix3) GetInverse(matrix *Matrix3) *Matrix3 {
+// Invert inverts this matrix in place, see InverseOf
+func (m *Matrix3) Invert() (*Matrix3, error) {
 
-	defer func() {
-		if r := recover(); r != nil {
-			m.Identity()
-		}
-	}()
+	err := m.InverseOf(m)
 
-	m.MustGetInverse(matrix)
+	return m, err
 
-	return m
 }
 
-func (m *Matrix3) MustGetInverse(matrix *Matrix3) *Matrix3 {
+// InverseOf sets this matrix to the inverse of src, see InverseOfEpsilon
+func (m *Matrix3) InverseOf(src *Matrix3) error {
+
+	return m.InverseOfEpsilon(src, InverseEpsilon)
 
-	me := matrix.Elements
+}
+
+// InverseOfEpsilon sets this matrix to the inverse of src. If the
+// determinant of src is at most epsilon times the product of the lengths
+// of its columns this matrix is set to the pseudo-inverse of src instead
+// and ErrSingularMatrix is returned.
+func (m *Matrix3) InverseOfEpsilon(src *Matrix3, epsilon float64) error {
+
+	me := src.Elements
 	te := m.Elements
 
 	n11, n21, n31 := me[0], me[1], me[2]
@@ -186,8 +182,11 @@ func (m *Matrix3) MustGetInverse(matrix *Matrix3) *Matrix3 {
 
 	det := n11*t11 + n21*t12 + n31*t13
 
-	if det == 0 {
-		panic("Matrix3.GetInverse(): can't invert matrix, determinant is 0")
+	if isSingular(me, 3, det, epsilon) {
+
+		m.PseudoInverseOf(src)
+		return ErrSingularMatrix
+
 	}
 
 	detInv := 1 / det
@@ -204,6 +203,16 @@ func (m *Matrix3) MustGetInverse(matrix *Matrix3) *Matrix3 {
 	te[7] = (n21*n13 - n23*n11) * detInv
 	te[8] = (n22*n11 - n21*n12) * detInv
 
+	return nil
+
+}
+
+// PseudoInverseOf sets this matrix to the Moore-Penrose pseudo-inverse
+// of src, which is the inverse of src when src is invertible
+func (m *Matrix3) PseudoInverseOf(src *Matrix3) *Matrix3 {
+
+	copy(m.Elements, pseudoInverse(src.Elements, 3))
+
 	return m
 
 }
@@ -229,7 +238,10 @@ func (m *Matrix3) Transpose() *Matrix3 {
 
 func (m *Matrix3) GetNormalMatrix(matrix4 *Matrix4) *Matrix3 {
 
-	return m.SetFromMatrix4(matrix4).GetInverse(m).Transpose()
+	// degenerate scales fall back to the pseudo-inverse
+	m.SetFromMatrix4(matrix4).Invert()
+
+	return m.Transpose()
 
 }
 
@@ -262,7 +274,7 @@ func (m *Matrix3) FromArray(array []float64) *Matrix3 {
 func (m *Matrix3) ToArray(array []float64, offset int) []float64 {
 
 	if array == nil {
-		array = make([]float64, 0)
+		array = make([]float64, offset+9)
 	}
 
 	te := m.Elements
@@ -4,7 +4,7 @@ import (
 	"math"
 	"testing"
 
-	"github.com/rydrman/marshmallow"
+	mm "github.com/rydrman/three.go/math3"
 )
 
 func matrixEquals3(a, b *mm.Matrix3) bool {
@@ -129,13 +129,15 @@ func TestMatrix3_Determinant(t *testing.T) {
 	}
 }
 
-func TestMatrix3_GetInverse(t *testing.T) {
+func TestMatrix3_InverseOf(t *testing.T) {
 	identity := mm.NewMatrix3()
 	identity4 := mm.NewMatrix4()
 	a := mm.NewMatrix3()
 	b := mm.NewMatrix3()
 
-	b.GetInverse(a)
+	if err := b.InverseOf(a); err != nil {
+		t.Error(err)
+	}
 	if !matrixEquals3(b, identity) {
 		t.Error("inverse of identity should be identity")
 	}
@@ -153,7 +155,10 @@ func TestMatrix3_GetInverse(t *testing.T) {
 
 	for _, m := range testMatrices {
 		a.SetFromMatrix4(m)
-		mInverse3 := b.GetInverse(a)
+		mInverse3, err := b.Copy(a).Invert()
+		if err != nil {
+			t.Error(err)
+		}
 
 		mInverse := toMatrix4(mInverse3)
 
@@ -179,17 +184,44 @@ func TestMatrix3_GetInverse(t *testing.T) {
 	}
 }
 
-func TestMatrix3_MustGetInverse(t *testing.T) {
+func TestMatrix3_InverseOfSingular(t *testing.T) {
 
-	m := mm.NewMatrix3().Set(0, 0, 0, 0, 0, 0, 0, 0, 0)
+	zero := mm.NewMatrix3().Set(0, 0, 0, 0, 0, 0, 0, 0, 0)
 
-	defer func() {
-		if r := recover(); r == nil {
-			t.Error("expected inverse to panic")
-		}
-	}()
+	m := mm.NewMatrix3()
+	if err := m.InverseOf(zero); err != mm.ErrSingularMatrix {
+		t.Errorf("expected singular matrix error, got %v", err)
+	}
+	if !matrixEquals3(m, zero) {
+		t.Error("pseudo-inverse of zero should be zero")
+	}
+
+	flat := mm.NewMatrix3().Set(4, 0, 0, 0, 0, 0, 0, 0, 0.5)
+	if _, err := m.Copy(flat).Invert(); err != mm.ErrSingularMatrix {
+		t.Errorf("expected singular matrix error, got %v", err)
+	}
+	if !matrixEquals3(m, mm.NewMatrix3().Set(0.25, 0, 0, 0, 0, 0, 0, 0, 2)) {
+		t.Errorf("expected scale pseudo-inverse, got %v", m.Elements)
+	}
+
+	small := mm.NewMatrix3().Set(1e-5, 0, 0, 0, 1e-5, 0, 0, 0, 1e-5)
+	if err := m.InverseOf(small); err != nil || math.Abs(m.Elements[0]-1e5) > 1e-6 {
+		t.Errorf("expected a small scale to be invertible, got %v", err)
+	}
+	if err := m.InverseOfEpsilon(small, 2); err != mm.ErrSingularMatrix {
+		t.Errorf("expected singular matrix error, got %v", err)
+	}
+
+}
+
+func TestMatrix3_GetNormalMatrix(t *testing.T) {
+
+	m := mm.NewMatrix4().MakeScale(2, 4, 0)
+	normal := mm.NewMatrix3().GetNormalMatrix(m)
 
-	m.MustGetInverse(m)
+	if !matrixEquals3(normal, mm.NewMatrix3().Set(0.5, 0, 0, 0, 0.25, 0, 0, 0, 0)) {
+		t.Errorf("expected degenerate normal matrix to drop the flat axis, got %v", normal.Elements)
+	}
 
 }
 
@@ -229,3 +261,14 @@ func TestMatrix3_Clone(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestMatrix3_ToArray(t *testing.T) {
+	a := mm.NewMatrix3().Set(1, 2, 3, 4, 5, 6, 7, 8, 9)
+	array := a.ToArray(nil, 0)
+	if len(array) != 9 || array[1] != 4 || array[8] != 9 {
+		t.Errorf("expected the elements in column major order, got %v", array)
+	}
+	if b := mm.NewMatrix3().FromArray(array); !matrixEquals3(a, b) {
+		t.Error("expected FromArray to read back the elements")
+	}
+}
@@ -441,55 +441,48 @@ func (m *Matrix4) MultiplyScalar(s float64) *Matrix4 {
 
 }
 
-//TODO
-/*func (m *Matrix4) ApplyToVector3Array(array []*Vector3, offset, length int) []*Vector3 {
+// ApplyToVector3Array transforms length values of array, starting at
+// offset, as consecutive x, y, z points. All remaining values are
+// transformed if length is negative.
+func (m *Matrix4) ApplyToVector3Array(array []float64, offset int, length int) []float64 {
 
-    v1 := NewVector3()
-
-    if length == -1 {
-        length = len(array)
-    }
-
-    for i, j := 0, offset; i < length; i, j = i+3, j+3 {
-
-        v1.FromArray(array, j)
-        v1.ApplyMatrix4(m)
-        v1.ToArray(array, j)
-
-    }
-
-    return array
+	v1 := NewVector3()
 
-}*/
+	if offset < 0 {
+		offset = 0
+	}
+	if length < 0 {
+		length = len(array) - offset
+	}
 
-//TODO
-/*func (m *Matrix4) ApplyToBuffer() *Matrix4 {
+	for i, j := 0, offset; i < length; i, j = i+3, j+3 {
 
-    var v1
+		v1.FromArray(array, j)
+		v1.ApplyMatrix4(m)
+		v1.ToArray(array, j)
 
-    return function applyToBuffer( buffer, offset, length ) *Matrix4 {
+	}
 
-        if v1 == nil { 1 = NewVector3().Set(); }
-        if offset == nil { ffset = 0; }
-        if length == nil { ength = buffer.Length / buffer.ItemSize; }
+	return array
 
-        for  i := 0; j := offset; i < length; i ++, j ++  {
+}
 
-            v1.X = buffer.GetX( j )
-            v1.Y = buffer.GetY( j )
-            v1.Z = buffer.GetZ( j )
+// ApplyToBufferAttribute transforms each item of attribute as a point
+func (m *Matrix4) ApplyToBufferAttribute(attribute Attribute) Attribute {
 
-            v1.ApplyMatrix4( m )
+	v1 := NewVector3()
 
-            buffer.SetXYZ( v1.X, v1.Y, v1.Z )
+	for i, l := 0, attribute.Count(); i < l; i++ {
 
-        }
+		attribute.GetVector3(i, v1)
+		v1.ApplyMatrix4(m)
+		attribute.SetVector3(i, v1)
 
-        return buffer
+	}
 
-    }
+	return attribute
 
-}(),*/
+}
 
 func (m *Matrix4) Determinant() float64 {
 
@@ -548,29 +541,31 @@ func (m *Matrix4) SetPosition(v *Vector3) *Matrix4 {
 
 }
 
-func (m *Matrix4) GetInverse(src *Matrix4) *Matrix4 {
+// Invert inverts this matrix in place, see InverseOf
+func (m *Matrix4) Invert() (*Matrix4, error) {
 
-	defer func() {
-		if r := recover(); r != nil {
-			m.Identity()
-		}
-	}()
-
-	m.MustGetInverse(src)
+	err := m.InverseOf(m)
 
-	return m
+	return m, err
 
 }
 
-func (m Matrix4) MustGetInverse(src *Matrix4) *Matrix4 {
+// InverseOf sets this matrix to the inverse of src, see InverseOfEpsilon
+func (m *Matrix4) InverseOf(src *Matrix4) error {
 
-	if nil == src {
-		src = NewMatrix4()
-	}
+	return m.InverseOfEpsilon(src, InverseEpsilon)
+
+}
+
+// InverseOfEpsilon sets this matrix to the inverse of src. If the
+// determinant of src is at most epsilon times the product of the lengths
+// of its columns this matrix is set to the pseudo-inverse of src instead
+// and ErrSingularMatrix is returned.
+func (m *Matrix4) InverseOfEpsilon(src *Matrix4, epsilon float64) error {
 
 	// based on http://www.Euclideanspace.Com/maths/algebra/matrix/functions/inverse/fourD/index.Htm
-	me := m.Elements
-	te := src.Elements
+	me := src.Elements
+	te := m.Elements
 
 	n11, n21, n31, n41 := me[0], me[1], me[2], me[3]
 	n12, n22, n32, n42 := me[4], me[5], me[6], me[7]
@@ -584,9 +579,10 @@ func (m Matrix4) MustGetInverse(src *Matrix4) *Matrix4 {
 
 	det := n11*t11 + n21*t12 + n31*t13 + n41*t14
 
-	if det == 0 {
+	if isSingular(me, 4, det, epsilon) {
 
-		panic("Matrix4.MustGetInverse(): can't invert matrix, determinant is 0")
+		m.PseudoInverseOf(src)
+		return ErrSingularMatrix
 
 	}
 
@@ -612,7 +608,17 @@ func (m Matrix4) MustGetInverse(src *Matrix4) *Matrix4 {
 	te[14] = (n14*n22*n31 - n12*n24*n31 - n14*n21*n32 + n11*n24*n32 + n12*n21*n34 - n11*n22*n34) * detInv
 	te[15] = (n12*n23*n31 - n13*n22*n31 + n13*n21*n32 - n11*n23*n32 - n12*n21*n33 + n11*n22*n33) * detInv
 
-	return src
+	return nil
+
+}
+
+// PseudoInverseOf sets this matrix to the Moore-Penrose pseudo-inverse
+// of src, which is the inverse of src when src is invertible
+func (m *Matrix4) PseudoInverseOf(src *Matrix4) *Matrix4 {
+
+	copy(m.Elements, pseudoInverse(src.Elements, 4))
+
+	return m
 
 }
 
@@ -744,68 +750,153 @@ func (m *Matrix4) MakeScale(x, y, z float64) *Matrix4 {
 
 }
 
-//TODO
-/*func (m *Matrix4) Compose(position *Vector3, quaternion *Quaternion, scale *Vector3) *Matrix4 {
+// Compose sets this matrix to the transform made from
+// the given position, rotation and scale
+func (m *Matrix4) Compose(position *Vector3, quaternion *Quaternion, scale *Vector3) *Matrix4 {
 
-    m.MakeRotationFromQuaternion(quaternion)
-    m.Scale(scale)
-    m.SetPosition(position)
+	m.MakeRotationFromQuaternion(quaternion)
+	m.Scale(scale)
+	m.SetPosition(position)
 
-}*/
+	return m
 
-//TODO
-/*func (m *Matrix4) Decompose() (position *Vector3, quaternion *Quaternion, scale *Vector3) *Matrix4 {
+}
 
-    vector := NewVector3()
-    matrix := NewMatrix4()
+// Decompose splits this matrix into the position, rotation and scale that
+// would compose it. Mirrored transforms are given a negative x scale. Axes
+// with zero scale do not contribute to the rotation.
+func (m *Matrix4) Decompose(position *Vector3, quaternion *Quaternion, scale *Vector3) *Matrix4 {
 
-    te := m.Elements
+	vector := NewVector3()
 
-    sx := vector.Set(te[0], te[1], te[2]).Length()
-    sy := vector.Set(te[4], te[5], te[6]).Length()
-    sz := vector.Set(te[8], te[9], te[10]).Length()
+	te := m.Elements
 
-    // if determine is negative, we need to invert one scale
-    det := m.Determinant()
-    if det < 0 {
+	sx := vector.Set(te[0], te[1], te[2]).Length()
+	sy := vector.Set(te[4], te[5], te[6]).Length()
+	sz := vector.Set(te[8], te[9], te[10]).Length()
 
-        sx = -sx
+	// if determinant is negative, we need to invert one scale
+	det := m.Determinant()
+	if det < 0 {
 
-    }
+		sx = -sx
 
-    position.X = te[12]
-    position.Y = te[13]
-    position.Z = te[14]
+	}
 
-    // scale the rotation part
+	position.X = te[12]
+	position.Y = te[13]
+	position.Z = te[14]
 
-    copy(m.Elements, matrix.Elements) // at this point matrix is incomplete so we can"t use .Copy()
+	// scale the rotation part
 
-    invSX := 1 / sx
-    invSY := 1 / sy
-    invSZ := 1 / sz
+	matrix := m.Clone()
 
-    matrix.Elements[0] *= invSX
-    matrix.Elements[1] *= invSX
-    matrix.Elements[2] *= invSX
+	invSX := inverseScale(sx)
+	invSY := inverseScale(sy)
+	invSZ := inverseScale(sz)
 
-    matrix.Elements[4] *= invSY
-    matrix.Elements[5] *= invSY
-    matrix.Elements[6] *= invSY
+	matrix.Elements[0] *= invSX
+	matrix.Elements[1] *= invSX
+	matrix.Elements[2] *= invSX
 
-    matrix.Elements[8] *= invSZ
-    matrix.Elements[9] *= invSZ
-    matrix.Elements[10] *= invSZ
+	matrix.Elements[4] *= invSY
+	matrix.Elements[5] *= invSY
+	matrix.Elements[6] *= invSY
 
-    quaternion.SetFromRotationMatrix(matrix)
+	matrix.Elements[8] *= invSZ
+	matrix.Elements[9] *= invSZ
+	matrix.Elements[10] *= invSZ
 
-    scale.X = sx
-    scale.Y = sy
-    scale.Z = sz
+	fillZeroAxes(matrix, sx == 0, sy == 0, sz == 0)
 
-    return
+	quaternion.SetFromRotationMatrix(matrix)
 
-}*/
+	scale.X = sx
+	scale.Y = sy
+	scale.Z = sz
+
+	return m
+
+}
+
+// fillZeroAxes replaces the flagged zero length basis vectors of the
+// rotation matrix m so that it forms a right handed orthonormal basis
+func fillZeroAxes(m *Matrix4, zeroX, zeroY, zeroZ bool) {
+
+	if !zeroX && !zeroY && !zeroZ {
+		return
+	}
+
+	xAxis := NewVector3()
+	yAxis := NewVector3()
+	zAxis := NewVector3()
+	m.ExtractBasis(xAxis, yAxis, zAxis)
+
+	axes := [3]*Vector3{xAxis, yAxis, zAxis}
+	zero := [3]bool{zeroX, zeroY, zeroZ}
+
+	zeros := 0
+	for _, z := range zero {
+		if z {
+			zeros++
+		}
+	}
+
+	switch zeros {
+
+	case 3:
+
+		m.Identity()
+		return
+
+	case 2:
+
+		// choose any vector perpendicular to the remaining axis
+		// as the next axis, then complete the basis below
+		for i := 0; i < 3; i++ {
+
+			if zero[i] {
+				continue
+			}
+
+			axis := axes[i]
+			next := (i + 1) % 3
+
+			reference := NewVector3().Set(1, 0, 0)
+			if math.Abs(axis.X) > 0.9 {
+				reference.Set(0, 1, 0)
+			}
+
+			axes[next].CrossVectors(axis, reference).Normalize()
+			zero[next] = false
+			break
+
+		}
+
+	}
+
+	for i := 0; i < 3; i++ {
+
+		if zero[i] {
+			axes[i].CrossVectors(axes[(i+1)%3], axes[(i+2)%3])
+		}
+
+	}
+
+	m.MakeBasis(xAxis, yAxis, zAxis)
+
+}
+
+// inverseScale returns 1 / s, or 0 for a zero scale
+func inverseScale(s float64) float64 {
+
+	if s == 0 {
+		return 0
+	}
+
+	return 1 / s
+
+}
 
 func (m *Matrix4) MakeFrustum(left, right, bottom, top, near, far float64) *Matrix4 {
 
@@ -901,7 +992,7 @@ func (m *Matrix4) Equals(matrix *Matrix4) bool {
 
 func (m *Matrix4) FromArray(array []float64) *Matrix4 {
 
-	copy(array, m.Elements)
+	copy(m.Elements, array)
 
 	return m
 
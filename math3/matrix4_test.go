@@ -4,13 +4,13 @@ import (
 	"math"
 	"testing"
 
-	"github.com/rydrman/marshmallow"
+	mm "github.com/rydrman/three.go/math3"
 )
 
 func matrixEquals4(a, b *mm.Matrix4) bool {
 	tolerance := 0.0001
 	for i, ea := range a.Elements {
-		delta := ea - b.Elements[i]
+		delta := math.Abs(ea - b.Elements[i])
 		if delta > tolerance {
 			return false
 		}
@@ -144,7 +144,7 @@ func TestMatrix4_Determinant(t *testing.T) {
 	}
 }
 
-func TestMatrix4_GetInverse(t *testing.T) {
+func TestMatrix4_InverseOf(t *testing.T) {
 	identity := mm.NewMatrix4()
 
 	a := mm.NewMatrix4()
@@ -153,7 +153,9 @@ func TestMatrix4_GetInverse(t *testing.T) {
 	if matrixEquals4(a, b) {
 		t.Error("b should not be an identity matrix yet")
 	}
-	b.GetInverse(a)
+	if err := b.InverseOf(a); err != nil {
+		t.Error(err)
+	}
 	if !matrixEquals4(b, mm.NewMatrix4()) {
 		t.Error("b should have become an identity matrix")
 	}
@@ -166,7 +168,7 @@ func TestMatrix4_GetInverse(t *testing.T) {
 		mm.NewMatrix4().MakeRotationZ(0.3),
 		mm.NewMatrix4().MakeRotationZ(-0.3),
 		mm.NewMatrix4().MakeScale(1, 2, 3),
-		mm.NewMatrix4().MakeScale(1/8, 1/2, 1/3),
+		mm.NewMatrix4().MakeScale(1.0/8.0, 1.0/2.0, 1.0/3.0),
 		mm.NewMatrix4().MakeFrustum(-1, 1, -1, 1, 1, 1000),
 		mm.NewMatrix4().MakeFrustum(-16, 16, -9, 9, 0.1, 10000),
 		mm.NewMatrix4().MakeTranslation(1, 2, 3),
@@ -174,9 +176,14 @@ func TestMatrix4_GetInverse(t *testing.T) {
 
 	for _, m := range testMatrices {
 
-		mInverse := mm.NewMatrix4().GetInverse(m)
-		mSelfInverse := m.Clone()
-		mSelfInverse.GetInverse(mSelfInverse)
+		mInverse := mm.NewMatrix4()
+		if err := mInverse.InverseOf(m); err != nil {
+			t.Error(err)
+		}
+		mSelfInverse, err := m.Clone().Invert()
+		if err != nil {
+			t.Error(err)
+		}
 
 		// self-inverse should the same as inverse
 		if !matrixEquals4(mSelfInverse, mInverse) {
@@ -200,17 +207,85 @@ func TestMatrix4_GetInverse(t *testing.T) {
 	}
 }
 
-func TestMatrix4_MustGetInverse(t *testing.T) {
+func TestMatrix4_InverseOfSingular(t *testing.T) {
+
+	zero := mm.NewMatrix4().Set(0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0)
+
+	m := mm.NewMatrix4()
+	if err := m.InverseOf(zero); err != mm.ErrSingularMatrix {
+		t.Errorf("expected singular matrix error, got %v", err)
+	}
+	if !matrixEquals4(m, zero) {
+		t.Error("pseudo-inverse of zero should be zero")
+	}
+
+	// the collapsed axis is dropped rather than inverted
+	if err := m.InverseOf(mm.NewMatrix4().MakeScale(1, 2, 0)); err != mm.ErrSingularMatrix {
+		t.Errorf("expected singular matrix error, got %v", err)
+	}
+	if !matrixEquals4(m, mm.NewMatrix4().MakeScale(1, 0.5, 0)) {
+		t.Errorf("expected scale pseudo-inverse, got %v", m.Elements)
+	}
+
+	flat := mm.NewMatrix4().MakeScale(1, 2, 0).SetPosition(mm.NewVector3().Set(1, 2, 3))
+	if _, err := m.Copy(flat).Invert(); err != mm.ErrSingularMatrix {
+		t.Errorf("expected singular matrix error, got %v", err)
+	}
+
+	// A A⁺ A == A and A⁺ A A⁺ == A⁺
+	product := mm.NewMatrix4().MultiplyMatrices(flat, m).Multiply(flat)
+	if !matrixEquals4(product, flat) {
+		t.Errorf("expected A A⁺ A == A, got %v", product.Elements)
+	}
+	product.MultiplyMatrices(m, flat).Multiply(m)
+	if !matrixEquals4(product, m) {
+		t.Errorf("expected A⁺ A A⁺ == A⁺, got %v", product.Elements)
+	}
+
+}
 
-	m := mm.NewMatrix4().Set(0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0)
+func TestMatrix4_PseudoInverseOf(t *testing.T) {
 
-	defer func() {
-		if r := recover(); r == nil {
-			t.Error("inverse should have panicked")
+	m := mm.NewMatrix4().MakeRotationY(0.3).Multiply(mm.NewMatrix4().MakeScale(2, 3, 4))
+
+	inverse := mm.NewMatrix4()
+	if err := inverse.InverseOf(m); err != nil {
+		t.Fatal(err)
+	}
+
+	if !matrixEquals4(mm.NewMatrix4().PseudoInverseOf(m), inverse) {
+		t.Error("pseudo-inverse of an invertible matrix should be its inverse")
+	}
+
+}
+
+func TestMatrix4_InverseEpsilon(t *testing.T) {
+
+	// the singularity test does not depend on the scale of the matrix
+	for _, scale := range []float64{1e-5, 1, 1e5} {
+		if err := mm.NewMatrix4().InverseOf(mm.NewMatrix4().MakeScale(scale, scale, scale)); err != nil {
+			t.Errorf("expected a scale of %v to be invertible, got %v", scale, err)
 		}
-	}()
+	}
+
+	// the second column is nearly parallel to the first
+	m := mm.NewMatrix4().Set(
+		1, 1, 0, 0,
+		0, 1e-4, 0, 0,
+		0, 0, 1, 0,
+		0, 0, 0, 1,
+	)
+
+	if err := mm.NewMatrix4().InverseOf(m); err != nil {
+		t.Errorf("expected a nearly singular matrix to be invertible, got %v", err)
+	}
+	if err := mm.NewMatrix4().InverseOfEpsilon(m, 1e-3); err != mm.ErrSingularMatrix {
+		t.Errorf("expected near-singular matrix error, got %v", err)
+	}
+	if err := mm.NewMatrix4().InverseOfEpsilon(m.Clone().MultiplyScalar(1e-5), 1e-3); err != mm.ErrSingularMatrix {
+		t.Errorf("expected near-singular matrix error for a small scale, got %v", err)
+	}
 
-	m.MustGetInverse(nil)
 }
 
 func TestMatrix4_MakeExtractBasis(t *testing.T) {
@@ -303,68 +378,141 @@ func TestMatrix4_Clone(t *testing.T) {
 	}
 }
 
-//TODO
-/*func TestMatrix4_ComposeDecompose(t *testing.T) {
-    tValues := [
-        mm.NewVector3().Set(),
-        mm.NewVector3().Set( 3, 0, 0 ),
-        mm.NewVector3().Set( 0, 4, 0 ),
-        mm.NewVector3().Set( 0, 0, 5 ),
-        mm.NewVector3().Set( -6, 0, 0 ),
-        mm.NewVector3().Set( 0, -7, 0 ),
-        mm.NewVector3().Set( 0, 0, -8 ),
-        mm.NewVector3().Set( -2, 5, -9 ),
-        mm.NewVector3().Set( -2, -5, -9 )
-    ]
-
-    sValues := [
-        mm.NewVector3().Set( 1, 1, 1 ),
-        mm.NewVector3().Set( 2, 2, 2 ),
-        mm.NewVector3().Set( 1, -1, 1 ),
-        mm.NewVector3().Set( -1, 1, 1 ),
-        mm.NewVector3().Set( 1, 1, -1 ),
-        mm.NewVector3().Set( 2, -2, 1 ),
-        mm.NewVector3().Set( -1, 2, -2 ),
-        mm.NewVector3().Set( -1, -1, -1 ),
-        mm.NewVector3().Set( -2, -2, -2 )
-    ]
-
-    rValues := [
-        new THREE.Quaternion(),
-        new THREE.Quaternion().SetFromEuler( new THREE.Euler( 1, 1, 0 ) ),
-        new THREE.Quaternion().SetFromEuler( new THREE.Euler( 1, -1, 1 ) ),
-        new THREE.Quaternion( 0, 0.9238795292366128, 0, 0.38268342717215614 )
-    ]
-
-
-    for( ti := 0 ti < tValues.length ti ++ ) {
-        for( si := 0 si < sValues.length si ++ ) {
-            for( ri := 0 ri < rValues.length ri ++ ) {
-                t := tValues[ti]
-                s := sValues[si]
-                r := rValues[ri]
-
-                m := mm.NewMatrix4().compose( t, r, s )
-                t2 := mm.NewVector3().Set()
-                r2 := new THREE.Quaternion()
-                s2 := mm.NewVector3().Set()
-
-                m.decompose( t2, r2, s2 )
-
-                m2 := mm.NewMatrix4().compose( t2, r2, s2 )
-
-                matrixIsSame := matrixEquals4( m, m2 )
-                /* debug code
-                if( ! matrixIsSame ) {
-                    console.log( t, s, r )
-                    console.log( t2, s2, r2 )
-                    console.log( m, m2 )
-                }*
-                if !matrixEquals4( m, m2 ) {
- t.Error()
+func TestMatrix4_FromArray(t *testing.T) {
+	array := []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+	m := mm.NewMatrix4().FromArray(array)
+
+	for i, v := range array {
+		if m.Elements[i] != v || v != float64(i) {
+			t.Fatalf("expected the elements to be copied from the array, got %v and %v", m.Elements, array)
+		}
+	}
+}
+
+func TestMatrix4_ComposeDecompose(t *testing.T) {
+	tValues := []*mm.Vector3{
+		mm.NewVector3(),
+		mm.NewVector3().Set(3, 0, 0),
+		mm.NewVector3().Set(0, 4, 0),
+		mm.NewVector3().Set(0, 0, 5),
+		mm.NewVector3().Set(-6, 0, 0),
+		mm.NewVector3().Set(0, -7, 0),
+		mm.NewVector3().Set(0, 0, -8),
+		mm.NewVector3().Set(-2, 5, -9),
+		mm.NewVector3().Set(-2, -5, -9),
+	}
+
+	sValues := []*mm.Vector3{
+		mm.NewVector3().Set(1, 1, 1),
+		mm.NewVector3().Set(2, 2, 2),
+		mm.NewVector3().Set(1, -1, 1),
+		mm.NewVector3().Set(-1, 1, 1),
+		mm.NewVector3().Set(1, 1, -1),
+		mm.NewVector3().Set(2, -2, 1),
+		mm.NewVector3().Set(-1, 2, -2),
+		mm.NewVector3().Set(-1, -1, -1),
+		mm.NewVector3().Set(-2, -2, -2),
+	}
+
+	rValues := []*mm.Quaternion{
+		mm.NewQuaternion(),
+		mm.NewQuaternion().SetFromEuler(mm.NewEuler().Set(1, 1, 0, mm.EulerDefaultOrder), false),
+		mm.NewQuaternion().SetFromEuler(mm.NewEuler().Set(1, -1, 1, mm.EulerDefaultOrder), false),
+		mm.NewQuaternion().Set(0, 0.9238795292366128, 0, 0.38268342717215614),
+	}
+
+	for _, tv := range tValues {
+		for _, sv := range sValues {
+			for _, rv := range rValues {
+				m := mm.NewMatrix4().Compose(tv, rv, sv)
+				t2 := mm.NewVector3()
+				r2 := mm.NewQuaternion()
+				s2 := mm.NewVector3()
+
+				m.Decompose(t2, r2, s2)
+
+				m2 := mm.NewMatrix4().Compose(t2, r2, s2)
+
+				if !matrixEquals4(m, m2) {
+					t.Errorf("compose(decompose(m)) != m for %s, %s, %s", tv, rv, sv)
+				}
+				if !t2.Equals(tv) {
+					t.Errorf("expected position %s, got %s", tv, t2)
+				}
+				if math.Abs(math.Abs(s2.X)-math.Abs(sv.X)) > 0.0001 ||
+					math.Abs(s2.Y-math.Abs(sv.Y)) > 0.0001 ||
+					math.Abs(s2.Z-math.Abs(sv.Z)) > 0.0001 {
+					t.Errorf("expected the magnitude of scale %s, got %s", sv, s2)
+				}
+				if (sv.X*sv.Y*sv.Z < 0) != (s2.X < 0) {
+					t.Errorf("mirrored transforms should have a negative x scale, got %s", s2)
+				}
+			}
+		}
+	}
 }
 
-            }
-        }
-    }
-}*/
+func TestMatrix4_DecomposeZeroScale(t *testing.T) {
+	q := mm.NewQuaternion().SetFromEuler(mm.NewEuler().Set(0, 0, 1, mm.EulerDefaultOrder), false)
+	m := mm.NewMatrix4().Compose(one3, q, mm.NewVector3().Set(1, 1, 0))
+
+	position := mm.NewVector3()
+	quaternion := mm.NewQuaternion()
+	scale := mm.NewVector3()
+	m.Decompose(position, quaternion, scale)
+
+	if !scale.Equals(mm.NewVector3().Set(1, 1, 0)) {
+		t.Errorf("unexpected scale %s", scale)
+	}
+	if math.IsNaN(quaternion.GetW()) || math.IsNaN(quaternion.GetX()) {
+		t.Errorf("zero scale should not produce an invalid rotation, got %s", quaternion)
+	}
+	if !matrixEquals4(mm.NewMatrix4().Compose(position, quaternion, scale), m) {
+		t.Error("compose(decompose(m)) should equal m")
+	}
+
+	m.Compose(one3, q, mm.NewVector3().Set(0, 2, 0))
+	m.Decompose(position, quaternion, scale)
+	if !matrixEquals4(mm.NewMatrix4().Compose(position, quaternion, scale), m) {
+		t.Error("compose(decompose(m)) should equal m with two zero scales")
+	}
+}
+
+type testAttribute struct {
+	array []float64
+}
+
+func (a *testAttribute) Count() int {
+	return len(a.array) / 3
+}
+
+func (a *testAttribute) GetVector3(index int, target *mm.Vector3) *mm.Vector3 {
+	return target.FromArray(a.array, index*3)
+}
+
+func (a *testAttribute) SetVector3(index int, v *mm.Vector3) {
+	v.ToArray(a.array, index*3)
+}
+
+func TestMatrix4_ApplyToBufferAttribute(t *testing.T) {
+	m := mm.NewMatrix4().MakeTranslation(x, y, z)
+
+	a := &testAttribute{[]float64{0, 0, 0, 1, 1, 1}}
+	m.ApplyToBufferAttribute(a)
+	expected := []float64{x, y, z, x + 1, y + 1, z + 1}
+	for i, v := range a.array {
+		if v != expected[i] {
+			t.Errorf("expected %v, got %v", expected, a.array)
+			break
+		}
+	}
+
+	array := m.ApplyToVector3Array([]float64{-1, 0, 0, 0, 0, 0, 0}, 1, -1)
+	expected = []float64{-1, x, y, z, x, y, z}
+	for i, v := range array {
+		if v != expected[i] {
+			t.Errorf("expected %v, got %v", expected, array)
+			break
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package math3
+
+import "fmt"
+
+// Plane represents an infinite plane in 3D space, defined by a unit
+// length normal and the negative distance from the origin to the plane
+// along that normal
+type Plane struct {
+	Normal   *Vector3
+	Constant float64
+}
+
+// NewPlane constructs a plane facing +x through the origin
+func NewPlane() *Plane {
+
+	return &Plane{
+		NewVector3().Set(1, 0, 0),
+		0,
+	}
+
+}
+
+func (p *Plane) String() string {
+	return fmt.Sprintf("&Plane{Normal: %s, Constant: %.4f}", p.Normal, p.Constant)
+}
+
+func (p *Plane) Set(normal *Vector3, constant float64) *Plane {
+
+	p.Normal.Copy(normal)
+	p.Constant = constant
+
+	return p
+
+}
+
+func (p *Plane) SetComponents(x, y, z, w float64) *Plane {
+
+	p.Normal.Set(x, y, z)
+	p.Constant = w
+
+	return p
+
+}
+
+func (p *Plane) SetFromNormalAndCoplanarPoint(normal, point *Vector3) *Plane {
+
+	p.Normal.Copy(normal)
+	p.Constant = -point.Dot(p.Normal)
+
+	return p
+
+}
+
+// SetFromCoplanarPoints sets this plane to pass through the given points,
+// with the normal facing the side from which a, b, c wind counter clockwise
+func (p *Plane) SetFromCoplanarPoints(a, b, c *Vector3) *Plane {
+
+	v1 := NewVector3()
+	v2 := NewVector3()
+
+	normal := v1.SubVectors(c, b).Cross(v2.SubVectors(a, b)).Normalize()
+
+	// Q: should an error be thrown if normal is zero (e.g. degenerate plane)?
+
+	return p.SetFromNormalAndCoplanarPoint(normal, a)
+
+}
+
+func (p *Plane) Clone() *Plane {
+
+	return NewPlane().Copy(p)
+
+}
+
+func (p *Plane) Copy(src *Plane) *Plane {
+
+	p.Normal.Copy(src.Normal)
+	p.Constant = src.Constant
+
+	return p
+
+}
+
+// Normalize scales the normal to unit length, adjusting the constant
+func (p *Plane) Normalize() *Plane {
+
+	inverseNormalLength := 1.0 / p.Normal.Length()
+	p.Normal.MultiplyScalar(inverseNormalLength)
+	p.Constant *= inverseNormalLength
+
+	return p
+
+}
+
+func (p *Plane) Negate() *Plane {
+
+	p.Constant *= -1
+	p.Normal.Negate()
+
+	return p
+
+}
+
+// DistanceToPoint returns the signed distance from the plane to the
+// point, positive on the side that the normal points to
+func (p *Plane) DistanceToPoint(point *Vector3) float64 {
+
+	return p.Normal.Dot(point) + p.Constant
+
+}
+
+func (p *Plane) DistanceToSphere(sphere *Sphere) float64 {
+
+	return p.DistanceToPoint(sphere.Center) - sphere.Radius
+
+}
+
+// ProjectPoint returns the projection of point onto this plane
+func (p *Plane) ProjectPoint(point, target *Vector3) *Vector3 {
+
+	return p.OrthoPoint(point, target).Sub(point).Negate()
+
+}
+
+// OrthoPoint returns the vector from the plane to point
+// along the normal of the plane
+func (p *Plane) OrthoPoint(point, target *Vector3) *Vector3 {
+
+	if nil == target {
+		target = NewVector3()
+	}
+
+	perpendicularMagnitude := p.DistanceToPoint(point)
+
+	return target.Copy(p.Normal).MultiplyScalar(perpendicularMagnitude)
+
+}
+
+// IntersectLine returns the point where the given line crosses this
+// plane, or nil if the line does not intersect it
+func (p *Plane) IntersectLine(line *Line3, target *Vector3) *Vector3 {
+
+	if nil == target {
+		target = NewVector3()
+	}
+
+	direction := line.Delta(nil)
+
+	denominator := p.Normal.Dot(direction)
+
+	if 0 == denominator {
+
+		// line is coplanar, return origin
+		if 0 == p.DistanceToPoint(line.Start) {
+
+			return target.Copy(line.Start)
+
+		}
+
+		// Unsure if this is the correct method to handle this case.
+		return nil
+
+	}
+
+	t := -(line.Start.Dot(p.Normal) + p.Constant) / denominator
+
+	if t < 0 || t > 1 {
+
+		return nil
+
+	}
+
+	return target.Copy(direction).MultiplyScalar(t).Add(line.Start)
+
+}
+
+func (p *Plane) IntersectsLine(line *Line3) bool {
+
+	// Note: this tests if a line intersects the plane, not whether it (or its end-points) are coplanar with it.
+
+	startSign := p.DistanceToPoint(line.Start)
+	endSign := p.DistanceToPoint(line.End)
+
+	return (startSign < 0 && endSign > 0) || (endSign < 0 && startSign > 0)
+
+}
+
+func (p *Plane) IntersectsBox(box *Box3) bool {
+
+	return box.IntersectsPlane(p)
+
+}
+
+func (p *Plane) IntersectsSphere(sphere *Sphere) bool {
+
+	return sphere.IntersectsPlane(p)
+
+}
+
+func (p *Plane) CoplanarPoint(target *Vector3) *Vector3 {
+
+	if nil == target {
+		target = NewVector3()
+	}
+
+	return target.Copy(p.Normal).MultiplyScalar(-p.Constant)
+
+}
+
+// ApplyMatrix4 transforms this plane by the given matrix. The normal
+// matrix of the transform is computed if optionalNormalMatrix is nil.
+func (p *Plane) ApplyMatrix4(matrix *Matrix4, optionalNormalMatrix *Matrix3) *Plane {
+
+	referencePoint := p.CoplanarPoint(nil).ApplyMatrix4(matrix)
+
+	normalMatrix := optionalNormalMatrix
+	if nil == normalMatrix {
+		normalMatrix = NewMatrix3().GetNormalMatrix(matrix)
+	}
+
+	normal := p.Normal.ApplyMatrix3(normalMatrix).Normalize()
+
+	p.Constant = -referencePoint.Dot(normal)
+
+	return p
+
+}
+
+func (p *Plane) Translate(offset *Vector3) *Plane {
+
+	p.Constant -= offset.Dot(p.Normal)
+
+	return p
+
+}
+
+func (p *Plane) Equals(plane *Plane) bool {
+
+	return plane.Normal.Equals(p.Normal) && (plane.Constant == p.Constant)
+
+}
@@ -0,0 +1,116 @@
+package math3_test
+
+import (
+	"math"
+	"testing"
+
+	math3 "github.com/rydrman/three.go/math3"
+)
+
+func TestPlane_Instancing(t *testing.T) {
+	a := math3.NewPlane()
+	if a.Normal.X != 1 || a.Normal.Y != 0 || a.Normal.Z != 0 || a.Constant != 0 {
+		t.Errorf("unexpected default plane %s", a)
+	}
+
+	a.SetComponents(0, 1, 0, 2)
+	b := a.Clone()
+	if !b.Equals(a) {
+		t.Error("clone should equal original")
+	}
+
+	a.Normal.Set(0, 0, 1)
+	if b.Normal.Equals(a.Normal) {
+		t.Error("clone should create a deep copy")
+	}
+}
+
+func TestPlane_DistanceToPoint(t *testing.T) {
+	a := math3.NewPlane().SetFromNormalAndCoplanarPoint(
+		math3.NewVector3().Set(0, 1, 0),
+		math3.NewVector3().Set(x, y, z),
+	)
+
+	if d := a.DistanceToPoint(zero3); d != -y {
+		t.Errorf("expected distance %d, got %f", -y, d)
+	}
+
+	a.SetComponents(2, 0, 0, -2).Normalize()
+	if a.Normal.X != 1 || a.Constant != -1 {
+		t.Errorf("normalize should scale the normal and constant, got %s", a)
+	}
+	if d := a.DistanceToPoint(two3); d != 1 {
+		t.Errorf("expected distance 1, got %f", d)
+	}
+}
+
+func TestPlane_ApplyMatrix4(t *testing.T) {
+	a := math3.NewPlane().SetComponents(1, 0, 0, 0)
+
+	m := math3.NewMatrix4().MakeTranslation(x, 0, 0)
+	a.ApplyMatrix4(m, nil)
+	if a.Constant != -x {
+		t.Errorf("translation should move the plane, got %s", a)
+	}
+
+	m.MakeRotationZ(math.Pi / 2)
+	a.ApplyMatrix4(m, nil)
+	if math.Abs(a.Normal.Y-1) > 0.0001 || math.Abs(a.Constant+x) > 0.0001 {
+		t.Errorf("rotation should turn the plane, got %s", a)
+	}
+}
+
+func TestPlane_SetFromCoplanarPoints(t *testing.T) {
+	a := math3.NewPlane().SetFromCoplanarPoints(
+		math3.NewVector3().Set(0, 0, z),
+		math3.NewVector3().Set(1, 0, z),
+		math3.NewVector3().Set(0, 1, z),
+	)
+
+	if !a.Normal.Equals(math3.NewVector3().Set(0, 0, 1)) || a.Constant != -z {
+		t.Errorf("unexpected plane %s", a)
+	}
+}
+
+func TestPlane_ProjectPoint(t *testing.T) {
+	a := math3.NewPlane().SetComponents(0, 1, 0, -1)
+
+	p := a.ProjectPoint(math3.NewVector3().Set(x, y, z), nil)
+	if !p.Equals(math3.NewVector3().Set(x, 1, z)) {
+		t.Errorf("unexpected projection %s", p)
+	}
+
+	o := a.OrthoPoint(math3.NewVector3().Set(x, y, z), nil)
+	if !o.Equals(math3.NewVector3().Set(0, y-1, 0)) {
+		t.Errorf("unexpected ortho point %s", o)
+	}
+
+	a.Translate(math3.NewVector3().Set(x, 1, z))
+	if a.Constant != -2 {
+		t.Errorf("translate should move the plane along its normal, got %s", a)
+	}
+}
+
+func TestPlane_IntersectLine(t *testing.T) {
+	a := math3.NewPlane().SetComponents(1, 0, 0, -x)
+
+	l := math3.NewLine3().Set(zero3, math3.NewVector3().Set(2*x, 0, 0))
+	p := a.IntersectLine(l, nil)
+	if p == nil || !p.Equals(math3.NewVector3().Set(x, 0, 0)) {
+		t.Errorf("unexpected intersection %v", p)
+	}
+	if !a.IntersectsLine(l) {
+		t.Error("line should intersect the plane")
+	}
+
+	l.End.Set(1, 0, 0)
+	if a.IntersectLine(l, nil) != nil || a.IntersectsLine(l) {
+		t.Error("short line should not reach the plane")
+	}
+
+	l.Set(math3.NewVector3().Set(x, 0, 0), math3.NewVector3().Set(x, 1, 0))
+	p = a.IntersectLine(l, nil)
+	if p == nil || !p.Equals(l.Start) {
+		t.Errorf("coplanar line should return its start, got %v", p)
+	}
+}
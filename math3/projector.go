@@ -2,5 +2,6 @@ package math3
 
 type Projector interface {
 	Positioner
+	GetMatrixWorldInverse() *Matrix4
 	GetProjectionMatrix() *Matrix4
 }
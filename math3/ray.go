@@ -0,0 +1,574 @@
+package math3
+
+import (
+	"fmt"
+	"math"
+)
+
+// Ray represents a ray that emits from an origin in a certain direction
+type Ray struct {
+	Origin    *Vector3
+	Direction *Vector3
+}
+
+// NewRay constructs a ray at the origin with a zero direction
+func NewRay() *Ray {
+
+	return &Ray{
+		NewVector3(),
+		NewVector3(),
+	}
+
+}
+
+func (r *Ray) String() string {
+	return fmt.Sprintf("&Ray{Origin: %s, Direction: %s}", r.Origin, r.Direction)
+}
+
+// Set sets the origin and direction of this ray, the direction
+// is expected to be normalized
+func (r *Ray) Set(origin, direction *Vector3) *Ray {
+
+	r.Origin.Copy(origin)
+	r.Direction.Copy(direction)
+
+	return r
+
+}
+
+func (r *Ray) Clone() *Ray {
+
+	return NewRay().Copy(r)
+
+}
+
+func (r *Ray) Copy(ray *Ray) *Ray {
+
+	r.Origin.Copy(ray.Origin)
+	r.Direction.Copy(ray.Direction)
+
+	return r
+
+}
+
+// At returns the point at distance t along this ray
+func (r *Ray) At(t float64, target *Vector3) *Vector3 {
+
+	if nil == target {
+		target = NewVector3()
+	}
+
+	return target.Copy(r.Direction).MultiplyScalar(t).Add(r.Origin)
+
+}
+
+// LookAt points this ray at the given position
+func (r *Ray) LookAt(v *Vector3) *Ray {
+
+	r.Direction.Copy(v).Sub(r.Origin).Normalize()
+
+	return r
+
+}
+
+// Recast moves the origin of this ray to the point at distance t
+func (r *Ray) Recast(t float64) *Ray {
+
+	r.Origin.Copy(r.At(t, nil))
+
+	return r
+
+}
+
+func (r *Ray) ClosestPointToPoint(point, target *Vector3) *Vector3 {
+
+	if nil == target {
+		target = NewVector3()
+	}
+
+	target.SubVectors(point, r.Origin)
+	directionDistance := target.Dot(r.Direction)
+
+	if directionDistance < 0 {
+
+		return target.Copy(r.Origin)
+
+	}
+
+	return target.Copy(r.Direction).MultiplyScalar(directionDistance).Add(r.Origin)
+
+}
+
+func (r *Ray) DistanceToPoint(point *Vector3) float64 {
+
+	return math.Sqrt(r.DistanceSqToPoint(point))
+
+}
+
+func (r *Ray) DistanceSqToPoint(point *Vector3) float64 {
+
+	v1 := NewVector3()
+
+	directionDistance := v1.SubVectors(point, r.Origin).Dot(r.Direction)
+
+	// point behind the ray
+
+	if directionDistance < 0 {
+
+		return r.Origin.DistanceToSquared(point)
+
+	}
+
+	v1.Copy(r.Direction).MultiplyScalar(directionDistance).Add(r.Origin)
+
+	return v1.DistanceToSquared(point)
+
+}
+
+// DistanceSqToSegment returns the squared distance between this ray and the
+// segment v0, v1. The closest points on the ray and segment are written to
+// optionalPointOnRay and optionalPointOnSegment when they are not nil.
+func (r *Ray) DistanceSqToSegment(v0, v1, optionalPointOnRay, optionalPointOnSegment *Vector3) float64 {
+
+	// from http://www.geometrictools.com/GTEngine/Include/Mathematics/GteDistRaySegment.h
+	// It returns the min distance between the ray and the segment
+	// defined by v0 and v1
+	// It can also set two optional targets :
+	// - The closest point on the ray
+	// - The closest point on the segment
+
+	segCenter := NewVector3().Copy(v0).Add(v1).MultiplyScalar(0.5)
+	segDir := NewVector3().Copy(v1).Sub(v0).Normalize()
+	diff := NewVector3().Copy(r.Origin).Sub(segCenter)
+
+	segExtent := v0.DistanceTo(v1) * 0.5
+	a01 := -r.Direction.Dot(segDir)
+	b0 := diff.Dot(r.Direction)
+	b1 := -diff.Dot(segDir)
+	c := diff.LengthSq()
+	det := math.Abs(1 - a01*a01)
+
+	var s0, s1, sqrDist, extDet float64
+
+	if det > 0 {
+
+		// The ray and segment are not parallel.
+
+		s0 = a01*b1 - b0
+		s1 = a01*b0 - b1
+		extDet = segExtent * det
+
+		if s0 >= 0 {
+
+			if s1 >= -extDet {
+
+				if s1 <= extDet {
+
+					// region 0
+					// Minimum at interior points of ray and segment.
+
+					invDet := 1 / det
+					s0 *= invDet
+					s1 *= invDet
+					sqrDist = s0*(s0+a01*s1+2*b0) + s1*(a01*s0+s1+2*b1) + c
+
+				} else {
+
+					// region 1
+
+					s1 = segExtent
+					s0 = math.Max(0, -(a01*s1 + b0))
+					sqrDist = -s0*s0 + s1*(s1+2*b1) + c
+
+				}
+
+			} else {
+
+				// region 5
+
+				s1 = -segExtent
+				s0 = math.Max(0, -(a01*s1 + b0))
+				sqrDist = -s0*s0 + s1*(s1+2*b1) + c
+
+			}
+
+		} else {
+
+			if s1 <= -extDet {
+
+				// region 4
+
+				s0 = math.Max(0, -(-a01*segExtent + b0))
+				if s0 > 0 {
+					s1 = -segExtent
+				} else {
+					s1 = math.Min(math.Max(-segExtent, -b1), segExtent)
+				}
+				sqrDist = -s0*s0 + s1*(s1+2*b1) + c
+
+			} else if s1 <= extDet {
+
+				// region 3
+
+				s0 = 0
+				s1 = math.Min(math.Max(-segExtent, -b1), segExtent)
+				sqrDist = s1*(s1+2*b1) + c
+
+			} else {
+
+				// region 2
+
+				s0 = math.Max(0, -(a01*segExtent + b0))
+				if s0 > 0 {
+					s1 = segExtent
+				} else {
+					s1 = math.Min(math.Max(-segExtent, -b1), segExtent)
+				}
+				sqrDist = -s0*s0 + s1*(s1+2*b1) + c
+
+			}
+
+		}
+
+	} else {
+
+		// Ray and segment are parallel.
+
+		if a01 > 0 {
+			s1 = -segExtent
+		} else {
+			s1 = segExtent
+		}
+		s0 = math.Max(0, -(a01*s1 + b0))
+		sqrDist = -s0*s0 + s1*(s1+2*b1) + c
+
+	}
+
+	if optionalPointOnRay != nil {
+
+		optionalPointOnRay.Copy(r.Direction).MultiplyScalar(s0).Add(r.Origin)
+
+	}
+
+	if optionalPointOnSegment != nil {
+
+		optionalPointOnSegment.Copy(segDir).MultiplyScalar(s1).Add(segCenter)
+
+	}
+
+	return sqrDist
+
+}
+
+// IntersectSphere returns the first point where this ray enters
+// the sphere, or nil if there is no intersection
+func (r *Ray) IntersectSphere(sphere *Sphere, target *Vector3) *Vector3 {
+
+	v1 := NewVector3().SubVectors(sphere.Center, r.Origin)
+	tca := v1.Dot(r.Direction)
+	d2 := v1.Dot(v1) - tca*tca
+	radius2 := sphere.Radius * sphere.Radius
+
+	if d2 > radius2 {
+		return nil
+	}
+
+	thc := math.Sqrt(radius2 - d2)
+
+	// t0 = first intersect point - entrance on front of sphere
+	t0 := tca - thc
+
+	// t1 = second intersect point - exit point on back of sphere
+	t1 := tca + thc
+
+	// test to see if both t0 and t1 are behind the ray - if so, return nil
+	if t0 < 0 && t1 < 0 {
+		return nil
+	}
+
+	// test to see if t0 is behind the ray:
+	// if it is, the ray is inside the sphere, so return the second exit point scaled by t1,
+	// in order to always return an intersect point that is in front of the ray.
+	if t0 < 0 {
+		return r.At(t1, target)
+	}
+
+	// else t0 is in front of the ray, so return the first collision point scaled by t0
+	return r.At(t0, target)
+
+}
+
+func (r *Ray) IntersectsSphere(sphere *Sphere) bool {
+
+	return r.DistanceToPoint(sphere.Center) <= sphere.Radius
+
+}
+
+// DistanceToPlane returns the distance along this ray to the given
+// plane, ok is false if the ray never reaches the plane
+func (r *Ray) DistanceToPlane(plane *Plane) (distance float64, ok bool) {
+
+	denominator := plane.Normal.Dot(r.Direction)
+
+	if 0 == denominator {
+
+		// line is coplanar, return origin
+		if 0 == plane.DistanceToPoint(r.Origin) {
+
+			return 0, true
+
+		}
+
+		// Null is preferable to undefined since undefined means.... it is undefined
+
+		return 0, false
+
+	}
+
+	t := -(r.Origin.Dot(plane.Normal) + plane.Constant) / denominator
+
+	// Return if the ray never intersects the plane
+
+	if t < 0 {
+		return 0, false
+	}
+
+	return t, true
+
+}
+
+// IntersectPlane returns the point where this ray crosses
+// the plane, or nil if there is no intersection
+func (r *Ray) IntersectPlane(plane *Plane, target *Vector3) *Vector3 {
+
+	t, ok := r.DistanceToPlane(plane)
+
+	if !ok {
+
+		return nil
+
+	}
+
+	return r.At(t, target)
+
+}
+
+func (r *Ray) IntersectsPlane(plane *Plane) bool {
+
+	// check if the ray lies on the plane first
+
+	distToPoint := plane.DistanceToPoint(r.Origin)
+
+	if 0 == distToPoint {
+
+		return true
+
+	}
+
+	denominator := plane.Normal.Dot(r.Direction)
+
+	if denominator*distToPoint < 0 {
+
+		return true
+
+	}
+
+	// ray origin is behind the plane (and is pointing behind it)
+
+	return false
+
+}
+
+// IntersectBox returns the first point where this ray enters
+// the box, or nil if there is no intersection
+func (r *Ray) IntersectBox(box *Box3, target *Vector3) *Vector3 {
+
+	// http://www.scratchapixel.com/lessons/3d-basic-lessons/lesson-7-intersecting-simple-shapes/ray-box-intersection/
+
+	var tmin, tmax, tymin, tymax, tzmin, tzmax float64
+
+	invdirx := 1 / r.Direction.X
+	invdiry := 1 / r.Direction.Y
+	invdirz := 1 / r.Direction.Z
+
+	origin := r.Origin
+
+	if invdirx >= 0 {
+
+		tmin = (box.Min.X - origin.X) * invdirx
+		tmax = (box.Max.X - origin.X) * invdirx
+
+	} else {
+
+		tmin = (box.Max.X - origin.X) * invdirx
+		tmax = (box.Min.X - origin.X) * invdirx
+
+	}
+
+	if invdiry >= 0 {
+
+		tymin = (box.Min.Y - origin.Y) * invdiry
+		tymax = (box.Max.Y - origin.Y) * invdiry
+
+	} else {
+
+		tymin = (box.Max.Y - origin.Y) * invdiry
+		tymax = (box.Min.Y - origin.Y) * invdiry
+
+	}
+
+	if (tmin > tymax) || (tymin > tmax) {
+		return nil
+	}
+
+	// These lines also handle the case where tmin or tmax is NaN
+	// (result of 0 * Infinity). x !== x returns true if x is NaN
+
+	if tymin > tmin || tmin != tmin {
+		tmin = tymin
+	}
+
+	if tymax < tmax || tmax != tmax {
+		tmax = tymax
+	}
+
+	if invdirz >= 0 {
+
+		tzmin = (box.Min.Z - origin.Z) * invdirz
+		tzmax = (box.Max.Z - origin.Z) * invdirz
+
+	} else {
+
+		tzmin = (box.Max.Z - origin.Z) * invdirz
+		tzmax = (box.Min.Z - origin.Z) * invdirz
+
+	}
+
+	if (tmin > tzmax) || (tzmin > tmax) {
+		return nil
+	}
+
+	if tzmin > tmin || tmin != tmin {
+		tmin = tzmin
+	}
+
+	if tzmax < tmax || tmax != tmax {
+		tmax = tzmax
+	}
+
+	//return point closest to the ray (positive side)
+
+	if tmax < 0 {
+		return nil
+	}
+
+	if tmin >= 0 {
+		return r.At(tmin, target)
+	}
+
+	return r.At(tmax, target)
+
+}
+
+func (r *Ray) IntersectsBox(box *Box3) bool {
+
+	return r.IntersectBox(box, nil) != nil
+
+}
+
+// IntersectTriangle returns the point where this ray crosses the triangle
+// a, b, c, or nil if there is no intersection. Triangles facing away from
+// the ray are ignored when backfaceCulling is set.
+func (r *Ray) IntersectTriangle(a, b, c *Vector3, backfaceCulling bool, target *Vector3) *Vector3 {
+
+	// Compute the offset origin, edges, and normal.
+
+	// from http://www.geometrictools.com/GTEngine/Include/Mathematics/GteIntrRay3Triangle3.h
+
+	edge1 := NewVector3().SubVectors(b, a)
+	edge2 := NewVector3().SubVectors(c, a)
+	normal := NewVector3().CrossVectors(edge1, edge2)
+
+	// Solve Q + t*D = b1*E1 + b2*E2 (Q = kDiff, D = ray direction,
+	// E1 = kEdge1, E2 = kEdge2, N = Cross(E1,E2)) by
+	//   |Dot(D,N)|*b1 = sign(Dot(D,N))*Dot(D,Cross(Q,E2))
+	//   |Dot(D,N)|*b2 = sign(Dot(D,N))*Dot(D,Cross(E1,Q))
+	//   |Dot(D,N)|*t = -sign(Dot(D,N))*Dot(Q,N)
+	DdN := r.Direction.Dot(normal)
+	var sign float64
+
+	if DdN > 0 {
+
+		if backfaceCulling {
+			return nil
+		}
+		sign = 1
+
+	} else if DdN < 0 {
+
+		sign = -1
+		DdN = -DdN
+
+	} else {
+
+		return nil
+
+	}
+
+	diff := NewVector3().SubVectors(r.Origin, a)
+	DdQxE2 := sign * r.Direction.Dot(edge2.CrossVectors(diff, edge2))
+
+	// b1 < 0, no intersection
+	if DdQxE2 < 0 {
+
+		return nil
+
+	}
+
+	DdE1xQ := sign * r.Direction.Dot(edge1.Cross(diff))
+
+	// b2 < 0, no intersection
+	if DdE1xQ < 0 {
+
+		return nil
+
+	}
+
+	// b1+b2 > 1, no intersection
+	if DdQxE2+DdE1xQ > DdN {
+
+		return nil
+
+	}
+
+	// Line intersects triangle, check if ray does.
+	QdN := -sign * diff.Dot(normal)
+
+	// t < 0, no intersection
+	if QdN < 0 {
+
+		return nil
+
+	}
+
+	// Ray intersects triangle.
+	return r.At(QdN/DdN, target)
+
+}
+
+func (r *Ray) ApplyMatrix4(matrix4 *Matrix4) *Ray {
+
+	r.Direction.Add(r.Origin).ApplyMatrix4(matrix4)
+	r.Origin.ApplyMatrix4(matrix4)
+	r.Direction.Sub(r.Origin)
+	r.Direction.Normalize()
+
+	return r
+
+}
+
+func (r *Ray) Equals(ray *Ray) bool {
+
+	return ray.Origin.Equals(r.Origin) && ray.Direction.Equals(r.Direction)
+
+}
@@ -0,0 +1,226 @@
+package math3_test
+
+import (
+	"math"
+	"testing"
+
+	math3 "github.com/rydrman/three.go/math3"
+)
+
+func TestRay_Instancing(t *testing.T) {
+	a := math3.NewRay()
+	if !a.Origin.Equals(zero3) || !a.Direction.Equals(zero3) {
+		t.Errorf("unexpected default ray %s", a)
+	}
+
+	a.Set(one3, two3)
+	b := a.Clone()
+	if !b.Equals(a) {
+		t.Error("clone should equal original")
+	}
+
+	a.Origin.Set(x, y, z)
+	if b.Origin.Equals(a.Origin) {
+		t.Error("clone should create a deep copy")
+	}
+}
+
+func TestRay_AtRecast(t *testing.T) {
+	a := math3.NewRay().Set(one3, math3.NewVector3().Set(0, 0, 1))
+
+	if !a.At(0, nil).Equals(one3) {
+		t.Error("at 0 should be the origin")
+	}
+	if !a.At(-1, nil).Equals(math3.NewVector3().Set(1, 1, 0)) {
+		t.Error("at should move along the direction")
+	}
+
+	a.Recast(1)
+	if !a.Origin.Equals(math3.NewVector3().Set(1, 1, 2)) {
+		t.Errorf("recast should move the origin, got %s", a.Origin)
+	}
+
+	a.LookAt(math3.NewVector3().Set(1, 1, 5))
+	if !a.Direction.Equals(math3.NewVector3().Set(0, 0, 1)) {
+		t.Errorf("lookAt should point at the target, got %s", a.Direction)
+	}
+}
+
+func TestRay_DistanceToPoint(t *testing.T) {
+	a := math3.NewRay().Set(one3, math3.NewVector3().Set(0, 0, 1))
+
+	// behind the ray
+	if d := a.DistanceToPoint(zero3); d != math.Sqrt(3) {
+		t.Errorf("expected distance to origin, got %f", d)
+	}
+
+	// front of the ray
+	if d := a.DistanceToPoint(math3.NewVector3().Set(0, 0, 50)); d != math.Sqrt(2) {
+		t.Errorf("expected perpendicular distance, got %f", d)
+	}
+
+	p := a.ClosestPointToPoint(math3.NewVector3().Set(0, 0, 50), nil)
+	if !p.Equals(math3.NewVector3().Set(1, 1, 50)) {
+		t.Errorf("unexpected closest point %s", p)
+	}
+}
+
+func TestRay_DistanceSqToSegment(t *testing.T) {
+	a := math3.NewRay().Set(zero3, math3.NewVector3().Set(0, 0, 1))
+
+	onRay := math3.NewVector3()
+	onSegment := math3.NewVector3()
+	d := a.DistanceSqToSegment(
+		math3.NewVector3().Set(-1, 2, 5),
+		math3.NewVector3().Set(1, 2, 5),
+		onRay,
+		onSegment,
+	)
+
+	if math.Abs(d-4) > 0.0001 {
+		t.Errorf("expected squared distance 4, got %f", d)
+	}
+	if !vector3Near(onRay, math3.NewVector3().Set(0, 0, 5)) {
+		t.Errorf("unexpected point on ray %s", onRay)
+	}
+	if !vector3Near(onSegment, math3.NewVector3().Set(0, 2, 5)) {
+		t.Errorf("unexpected point on segment %s", onSegment)
+	}
+
+	// parallel segment behind the ray
+	d = a.DistanceSqToSegment(
+		math3.NewVector3().Set(1, 0, -5),
+		math3.NewVector3().Set(1, 0, -2),
+		nil, nil,
+	)
+	if math.Abs(d-5) > 0.0001 {
+		t.Errorf("expected squared distance 5, got %f", d)
+	}
+}
+
+func TestRay_IntersectSphere(t *testing.T) {
+	a := math3.NewRay().Set(zero3, math3.NewVector3().Set(0, 0, 1))
+	sphere := math3.NewSphere().Set(math3.NewVector3().Set(0, 0, 5), 1)
+
+	p := a.IntersectSphere(sphere, nil)
+	if p == nil || !p.Equals(math3.NewVector3().Set(0, 0, 4)) {
+		t.Errorf("ray should enter the sphere at z=4, got %v", p)
+	}
+	if !a.IntersectsSphere(sphere) {
+		t.Error("ray should intersect the sphere")
+	}
+
+	// inside the sphere returns the exit point
+	a.Origin.Set(0, 0, 5)
+	p = a.IntersectSphere(sphere, nil)
+	if p == nil || !p.Equals(math3.NewVector3().Set(0, 0, 6)) {
+		t.Errorf("ray should exit the sphere at z=6, got %v", p)
+	}
+
+	// behind the ray
+	a.Origin.Set(0, 0, 10)
+	if a.IntersectSphere(sphere, nil) != nil || a.IntersectsSphere(sphere) {
+		t.Error("sphere behind the ray should not intersect")
+	}
+}
+
+func TestRay_IntersectPlane(t *testing.T) {
+	a := math3.NewRay().Set(zero3, math3.NewVector3().Set(0, 0, 1))
+	plane := math3.NewPlane().SetFromNormalAndCoplanarPoint(
+		math3.NewVector3().Set(0, 0, -1),
+		math3.NewVector3().Set(0, 0, z),
+	)
+
+	if d, ok := a.DistanceToPlane(plane); !ok || d != z {
+		t.Errorf("expected distance %d, got %f (%v)", z, d, ok)
+	}
+
+	p := a.IntersectPlane(plane, nil)
+	if p == nil || !p.Equals(math3.NewVector3().Set(0, 0, z)) {
+		t.Errorf("unexpected intersection %v", p)
+	}
+	if !a.IntersectsPlane(plane) {
+		t.Error("ray should intersect the plane")
+	}
+
+	a.Direction.Set(0, 0, -1)
+	if a.IntersectPlane(plane, nil) != nil || a.IntersectsPlane(plane) {
+		t.Error("ray pointing away should not intersect the plane")
+	}
+
+	a.Direction.Set(1, 0, 0)
+	if _, ok := a.DistanceToPlane(plane); ok {
+		t.Error("parallel ray should not intersect the plane")
+	}
+}
+
+func TestRay_IntersectBox(t *testing.T) {
+	box := math3.NewBox3().Set(math3.NewVector3().Set(-1, -1, -1), one3)
+
+	a := math3.NewRay().Set(math3.NewVector3().Set(-2, 0, 0), math3.NewVector3().Set(1, 0, 0))
+	p := a.IntersectBox(box, nil)
+	if p == nil || !p.Equals(math3.NewVector3().Set(-1, 0, 0)) {
+		t.Errorf("unexpected intersection %v", p)
+	}
+
+	// inside the box returns the exit point
+	a.Origin.Set(0, 0, 0)
+	p = a.IntersectBox(box, nil)
+	if p == nil || !p.Equals(math3.NewVector3().Set(1, 0, 0)) {
+		t.Errorf("unexpected intersection %v", p)
+	}
+
+	a.Origin.Set(-2, 2, 0)
+	if a.IntersectsBox(box) {
+		t.Error("ray should miss the box")
+	}
+
+	a.Set(math3.NewVector3().Set(2, 0, 0), math3.NewVector3().Set(1, 0, 0))
+	if a.IntersectsBox(box) {
+		t.Error("box behind the ray should not intersect")
+	}
+}
+
+func TestRay_IntersectTriangle(t *testing.T) {
+	a := math3.NewRay().Set(math3.NewVector3().Set(0.25, 0.25, 1), math3.NewVector3().Set(0, 0, -1))
+
+	v0 := math3.NewVector3().Set(0, 0, 0)
+	v1 := math3.NewVector3().Set(1, 0, 0)
+	v2 := math3.NewVector3().Set(0, 1, 0)
+
+	p := a.IntersectTriangle(v0, v1, v2, true, nil)
+	if p == nil || !vector3Near(p, math3.NewVector3().Set(0.25, 0.25, 0)) {
+		t.Errorf("unexpected intersection %v", p)
+	}
+
+	// reversed winding faces away from the ray
+	if a.IntersectTriangle(v0, v2, v1, true, nil) != nil {
+		t.Error("back faces should be culled")
+	}
+	if a.IntersectTriangle(v0, v2, v1, false, nil) == nil {
+		t.Error("back faces should intersect without culling")
+	}
+
+	a.Origin.Set(1, 1, 1)
+	if a.IntersectTriangle(v0, v1, v2, false, nil) != nil {
+		t.Error("ray should miss the triangle")
+	}
+
+	a.Origin.Set(0.25, 0.25, -1)
+	if a.IntersectTriangle(v0, v1, v2, false, nil) != nil {
+		t.Error("triangle behind the ray should not intersect")
+	}
+}
+
+func TestRay_ApplyMatrix4(t *testing.T) {
+	a := math3.NewRay().Set(zero3, math3.NewVector3().Set(0, 0, 1))
+	m := math3.NewMatrix4().MakeTranslation(x, y, z)
+
+	a.ApplyMatrix4(m)
+	if !a.Origin.Equals(math3.NewVector3().Set(x, y, z)) {
+		t.Errorf("origin should be translated, got %s", a.Origin)
+	}
+	if !vector3Near(a.Direction, math3.NewVector3().Set(0, 0, 1)) {
+		t.Errorf("direction should not be translated, got %s", a.Direction)
+	}
+}
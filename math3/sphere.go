@@ -0,0 +1,176 @@
+package math3
+
+import (
+	"fmt"
+	"math"
+)
+
+// Sphere represents a sphere in 3D space, most
+// often used as the bounding volume of an object
+type Sphere struct {
+	Center *Vector3
+	Radius float64
+}
+
+// NewSphere constructs a sphere of radius 0 at the origin
+func NewSphere() *Sphere {
+
+	return &Sphere{
+		NewVector3(),
+		0,
+	}
+
+}
+
+func (s *Sphere) String() string {
+	return fmt.Sprintf("&Sphere{Center: %s, Radius: %.4f}", s.Center, s.Radius)
+}
+
+func (s *Sphere) Set(center *Vector3, radius float64) *Sphere {
+
+	s.Center.Copy(center)
+	s.Radius = radius
+
+	return s
+
+}
+
+// SetFromPoints sets this sphere to enclose all of the given points. If
+// optionalCenter is nil, the center of the bounding box of the points is used.
+func (s *Sphere) SetFromPoints(points []*Vector3, optionalCenter *Vector3) *Sphere {
+
+	center := s.Center
+
+	if optionalCenter != nil {
+
+		center.Copy(optionalCenter)
+
+	} else {
+
+		NewBox3().SetFromPoints(points).Center(center)
+
+	}
+
+	maxRadiusSq := 0.0
+
+	for _, point := range points {
+
+		maxRadiusSq = math.Max(maxRadiusSq, center.DistanceToSquared(point))
+
+	}
+
+	s.Radius = math.Sqrt(maxRadiusSq)
+
+	return s
+
+}
+
+func (s *Sphere) Clone() *Sphere {
+
+	return NewSphere().Copy(s)
+
+}
+
+func (s *Sphere) Copy(src *Sphere) *Sphere {
+
+	s.Center.Copy(src.Center)
+	s.Radius = src.Radius
+
+	return s
+
+}
+
+func (s *Sphere) IsEmpty() bool {
+
+	return s.Radius <= 0
+
+}
+
+func (s *Sphere) ContainsPoint(point *Vector3) bool {
+
+	return point.DistanceToSquared(s.Center) <= (s.Radius * s.Radius)
+
+}
+
+func (s *Sphere) DistanceToPoint(point *Vector3) float64 {
+
+	return point.DistanceTo(s.Center) - s.Radius
+
+}
+
+func (s *Sphere) IntersectsSphere(sphere *Sphere) bool {
+
+	radiusSum := s.Radius + sphere.Radius
+
+	return sphere.Center.DistanceToSquared(s.Center) <= (radiusSum * radiusSum)
+
+}
+
+func (s *Sphere) IntersectsBox(box *Box3) bool {
+
+	return box.IntersectsSphere(s)
+
+}
+
+func (s *Sphere) IntersectsPlane(plane *Plane) bool {
+
+	return math.Abs(plane.DistanceToPoint(s.Center)) <= s.Radius
+
+}
+
+func (s *Sphere) ClampPoint(point, target *Vector3) *Vector3 {
+
+	deltaLengthSq := s.Center.DistanceToSquared(point)
+
+	if nil == target {
+		target = NewVector3()
+	}
+
+	target.Copy(point)
+
+	if deltaLengthSq > (s.Radius * s.Radius) {
+
+		target.Sub(s.Center).Normalize()
+		target.MultiplyScalar(s.Radius).Add(s.Center)
+
+	}
+
+	return target
+
+}
+
+func (s *Sphere) GetBoundingBox(target *Box3) *Box3 {
+
+	if nil == target {
+		target = NewBox3()
+	}
+
+	target.Set(s.Center, s.Center)
+	target.ExpandByScalar(s.Radius)
+
+	return target
+
+}
+
+func (s *Sphere) ApplyMatrix4(matrix *Matrix4) *Sphere {
+
+	s.Center.ApplyMatrix4(matrix)
+	s.Radius = s.Radius * matrix.GetMaxScaleOnAxis()
+
+	return s
+
+}
+
+func (s *Sphere) Translate(offset *Vector3) *Sphere {
+
+	s.Center.Add(offset)
+
+	return s
+
+}
+
+func (s *Sphere) Equals(sphere *Sphere) bool {
+
+	return sphere.Center.Equals(s.Center) && (sphere.Radius == s.Radius)
+
+}
@@ -0,0 +1,103 @@
+package math3_test
+
+import (
+	"testing"
+
+	math3 "github.com/rydrman/three.go/math3"
+)
+
+func TestSphere_Instancing(t *testing.T) {
+	a := math3.NewSphere()
+	if !a.Center.Equals(zero3) || a.Radius != 0 {
+		t.Error("new sphere should be at the origin with radius 0")
+	}
+
+	a.Set(one3, 1)
+	b := a.Clone()
+	if !b.Equals(a) {
+		t.Error("clone should equal original")
+	}
+
+	a.Center.Set(x, y, z)
+	if b.Center.Equals(a.Center) {
+		t.Error("clone should create a deep copy")
+	}
+}
+
+func TestSphere_SetFromPoints(t *testing.T) {
+	points := []*math3.Vector3{
+		math3.NewVector3().Set(-1, 0, 0),
+		math3.NewVector3().Set(1, 0, 0),
+		math3.NewVector3().Set(0, 1, 0),
+		math3.NewVector3().Set(0, -1, 0),
+	}
+
+	a := math3.NewSphere().SetFromPoints(points, nil)
+	if !a.Center.Equals(zero3) || a.Radius != 1 {
+		t.Errorf("unexpected sphere %s", a)
+	}
+
+	a.SetFromPoints(points, one3)
+	if !a.Center.Equals(one3) || a.Radius != math3.NewVector3().Set(-2, -1, -1).Length() {
+		t.Errorf("unexpected sphere with center %s", a)
+	}
+}
+
+func TestSphere_ContainsPoint(t *testing.T) {
+	a := math3.NewSphere().Set(one3, 1)
+
+	if !a.ContainsPoint(one3) {
+		t.Error("sphere should contain its center")
+	}
+	if a.ContainsPoint(zero3) {
+		t.Error("sphere should not contain a distant point")
+	}
+	if a.DistanceToPoint(math3.NewVector3().Set(1, 1, 3)) != 1 {
+		t.Error("unexpected distance to point")
+	}
+}
+
+func TestSphere_IntersectsSphere(t *testing.T) {
+	a := math3.NewSphere().Set(one3, 1)
+	b := math3.NewSphere().Set(zero3, 1)
+	c := math3.NewSphere().Set(math3.NewVector3().Set(5, 5, 5), 1)
+
+	if !a.IntersectsSphere(b) {
+		t.Error("overlapping spheres should intersect")
+	}
+	if a.IntersectsSphere(c) {
+		t.Error("distant spheres should not intersect")
+	}
+}
+
+func TestSphere_ApplyMatrix4(t *testing.T) {
+	a := math3.NewSphere().Set(one3, 1)
+	m := math3.NewMatrix4().MakeTranslation(1, 2, 3)
+	s := math3.NewMatrix4().MakeScale(2, 3, 1)
+
+	a.ApplyMatrix4(m.Multiply(s))
+	if !a.Center.Equals(math3.NewVector3().Set(3, 5, 4)) || a.Radius != 3 {
+		t.Errorf("unexpected sphere %s", a)
+	}
+
+	box := a.GetBoundingBox(nil)
+	if !box.Min.Equals(math3.NewVector3().Set(0, 2, 1)) || !box.Max.Equals(math3.NewVector3().Set(6, 8, 7)) {
+		t.Errorf("unexpected bounding box %s", box)
+	}
+}
+
+func TestSphere_IntersectsPlane(t *testing.T) {
+	a := math3.NewSphere().Set(zero3, 1)
+	b := math3.NewPlane().Set(math3.NewVector3().Set(0, 1, 0), 1)
+	c := math3.NewPlane().Set(math3.NewVector3().Set(0, 1, 0), 1.25)
+
+	if !a.IntersectsPlane(b) || !b.IntersectsSphere(a) {
+		t.Error("touching plane should intersect")
+	}
+	if a.IntersectsPlane(c) || c.IntersectsSphere(a) {
+		t.Error("distant plane should not intersect")
+	}
+	if c.DistanceToSphere(a) != 0.25 {
+		t.Errorf("expected distance 0.25, got %f", c.DistanceToSphere(a))
+	}
+}
@@ -0,0 +1,240 @@
+package math3
+
+import (
+	"fmt"
+	"math"
+)
+
+// Triangle represents a triangle in 3D space defined by three points
+type Triangle struct {
+	A *Vector3
+	B *Vector3
+	C *Vector3
+}
+
+// NewTriangle constructs a degenerate triangle with all points at the origin
+func NewTriangle() *Triangle {
+
+	return &Triangle{
+		NewVector3(),
+		NewVector3(),
+		NewVector3(),
+	}
+
+}
+
+// TriangleNormal computes the unit normal of the triangle a, b, c,
+// facing the side from which the points wind counter clockwise
+func TriangleNormal(a, b, c, target *Vector3) *Vector3 {
+
+	if nil == target {
+		target = NewVector3()
+	}
+
+	v0 := NewVector3()
+
+	target.SubVectors(c, b)
+	v0.SubVectors(a, b)
+	target.Cross(v0)
+
+	resultLengthSq := target.LengthSq()
+	if resultLengthSq > 0 {
+
+		return target.MultiplyScalar(1 / math.Sqrt(resultLengthSq))
+
+	}
+
+	return target.Set(0, 0, 0)
+
+}
+
+// TriangleBarycoordFromPoint computes the barycentric coordinates of point
+// relative to the triangle a, b, c. Degenerate triangles produce (-2, -1, -1).
+// based on: http://www.blackpawn.com/texts/pointinpoly/default.html
+func TriangleBarycoordFromPoint(point, a, b, c, target *Vector3) *Vector3 {
+
+	v0 := NewVector3().SubVectors(c, a)
+	v1 := NewVector3().SubVectors(b, a)
+	v2 := NewVector3().SubVectors(point, a)
+
+	dot00 := v0.Dot(v0)
+	dot01 := v0.Dot(v1)
+	dot02 := v0.Dot(v2)
+	dot11 := v1.Dot(v1)
+	dot12 := v1.Dot(v2)
+
+	denom := (dot00*dot11 - dot01*dot01)
+
+	if nil == target {
+		target = NewVector3()
+	}
+
+	// collinear or singular triangle
+	if 0 == denom {
+
+		// arbitrary location outside of triangle?
+		// not sure if this is the best idea, maybe should be returning undefined
+		return target.Set(-2, -1, -1)
+
+	}
+
+	invDenom := 1 / denom
+	u := (dot11*dot02 - dot01*dot12) * invDenom
+	v := (dot00*dot12 - dot01*dot02) * invDenom
+
+	// barycentric coordinates must always sum to 1
+	return target.Set(1-u-v, v, u)
+
+}
+
+// TriangleContainsPoint reports whether point lies within the triangle a, b, c
+// when projected onto the plane of the triangle
+func TriangleContainsPoint(point, a, b, c *Vector3) bool {
+
+	result := TriangleBarycoordFromPoint(point, a, b, c, nil)
+
+	return (result.X >= 0) && (result.Y >= 0) && ((result.X + result.Y) <= 1)
+
+}
+
+func (t *Triangle) String() string {
+	return fmt.Sprintf("&Triangle{A: %s, B: %s, C: %s}", t.A, t.B, t.C)
+}
+
+func (t *Triangle) Set(a, b, c *Vector3) *Triangle {
+
+	t.A.Copy(a)
+	t.B.Copy(b)
+	t.C.Copy(c)
+
+	return t
+
+}
+
+func (t *Triangle) SetFromPointsAndIndices(points []*Vector3, i0, i1, i2 int) *Triangle {
+
+	t.A.Copy(points[i0])
+	t.B.Copy(points[i1])
+	t.C.Copy(points[i2])
+
+	return t
+
+}
+
+func (t *Triangle) Clone() *Triangle {
+
+	return NewTriangle().Copy(t)
+
+}
+
+func (t *Triangle) Copy(triangle *Triangle) *Triangle {
+
+	t.A.Copy(triangle.A)
+	t.B.Copy(triangle.B)
+	t.C.Copy(triangle.C)
+
+	return t
+
+}
+
+func (t *Triangle) Area() float64 {
+
+	v0 := NewVector3().SubVectors(t.C, t.B)
+	v1 := NewVector3().SubVectors(t.A, t.B)
+
+	return v0.Cross(v1).Length() * 0.5
+
+}
+
+func (t *Triangle) Midpoint(target *Vector3) *Vector3 {
+
+	if nil == target {
+		target = NewVector3()
+	}
+
+	return target.AddVectors(t.A, t.B).Add(t.C).MultiplyScalar(1.0 / 3)
+
+}
+
+func (t *Triangle) Normal(target *Vector3) *Vector3 {
+
+	return TriangleNormal(t.A, t.B, t.C, target)
+
+}
+
+func (t *Triangle) Plane(target *Plane) *Plane {
+
+	if nil == target {
+		target = NewPlane()
+	}
+
+	return target.SetFromCoplanarPoints(t.A, t.B, t.C)
+
+}
+
+func (t *Triangle) BarycoordFromPoint(point, target *Vector3) *Vector3 {
+
+	return TriangleBarycoordFromPoint(point, t.A, t.B, t.C, target)
+
+}
+
+func (t *Triangle) ContainsPoint(point *Vector3) bool {
+
+	return TriangleContainsPoint(point, t.A, t.B, t.C)
+
+}
+
+// ClosestPointToPoint returns the point on or within
+// this triangle that is closest to the given point
+func (t *Triangle) ClosestPointToPoint(point, target *Vector3) *Vector3 {
+
+	if nil == target {
+		target = NewVector3()
+	}
+
+	plane := t.Plane(nil)
+	projectedPoint := plane.ProjectPoint(point, nil)
+
+	// check if the projection lies within the triangle
+	if t.ContainsPoint(projectedPoint) {
+
+		// if so, this is the closest point
+		return target.Copy(projectedPoint)
+
+	}
+
+	// if not, the point falls outside the triangle. the target is the closest point to the triangle's edges or vertices
+
+	edgeList := []*Line3{
+		NewLine3().Set(t.A, t.B),
+		NewLine3().Set(t.B, t.C),
+		NewLine3().Set(t.C, t.A),
+	}
+
+	closestPoint := NewVector3()
+	minDistance := math.Inf(1)
+
+	for _, edge := range edgeList {
+
+		edge.ClosestPointToPoint(projectedPoint, true, closestPoint)
+
+		distance := projectedPoint.DistanceToSquared(closestPoint)
+
+		if distance < minDistance {
+
+			minDistance = distance
+			target.Copy(closestPoint)
+
+		}
+
+	}
+
+	return target
+
+}
+
+func (t *Triangle) Equals(triangle *Triangle) bool {
+
+	return triangle.A.Equals(t.A) && triangle.B.Equals(t.B) && triangle.C.Equals(t.C)
+
+}
@@ -0,0 +1,122 @@
+package math3_test
+
+import (
+	"testing"
+
+	math3 "github.com/rydrman/three.go/math3"
+)
+
+func newTestTriangle() *math3.Triangle {
+	return math3.NewTriangle().Set(
+		math3.NewVector3().Set(0, 0, 0),
+		math3.NewVector3().Set(2, 0, 0),
+		math3.NewVector3().Set(0, 2, 0),
+	)
+}
+
+func TestTriangle_Instancing(t *testing.T) {
+	a := math3.NewTriangle()
+	if !a.A.Equals(zero3) || !a.B.Equals(zero3) || !a.C.Equals(zero3) {
+		t.Errorf("unexpected default triangle %s", a)
+	}
+
+	points := []*math3.Vector3{one3, zero3, two3}
+	a.SetFromPointsAndIndices(points, 1, 0, 2)
+	if !a.A.Equals(zero3) || !a.B.Equals(one3) || !a.C.Equals(two3) {
+		t.Errorf("unexpected triangle from indices %s", a)
+	}
+
+	b := a.Clone()
+	if !b.Equals(a) {
+		t.Error("clone should equal original")
+	}
+
+	a.A.Set(x, y, z)
+	if b.A.Equals(a.A) {
+		t.Error("clone should create a deep copy")
+	}
+}
+
+func TestTriangle_AreaNormalPlane(t *testing.T) {
+	a := newTestTriangle()
+
+	if a.Area() != 2 {
+		t.Errorf("expected area 2, got %f", a.Area())
+	}
+	if !a.Normal(nil).Equals(math3.NewVector3().Set(0, 0, 1)) {
+		t.Errorf("unexpected normal %s", a.Normal(nil))
+	}
+	if !vector3Near(a.Midpoint(nil), math3.NewVector3().Set(2.0/3, 2.0/3, 0)) {
+		t.Errorf("unexpected midpoint %s", a.Midpoint(nil))
+	}
+
+	plane := a.Plane(nil)
+	if !plane.Normal.Equals(a.Normal(nil)) || plane.Constant != 0 {
+		t.Errorf("unexpected plane %s", plane)
+	}
+
+	degenerate := math3.NewTriangle().Set(zero3, one3, two3)
+	if degenerate.Area() != 0 || !degenerate.Normal(nil).Equals(zero3) {
+		t.Error("degenerate triangle should have no area or normal")
+	}
+}
+
+func TestTriangle_Barycoord(t *testing.T) {
+	a := newTestTriangle()
+
+	if !a.BarycoordFromPoint(a.A, nil).Equals(math3.NewVector3().Set(1, 0, 0)) {
+		t.Error("a should have barycoord (1, 0, 0)")
+	}
+	if !a.BarycoordFromPoint(a.B, nil).Equals(math3.NewVector3().Set(0, 1, 0)) {
+		t.Error("b should have barycoord (0, 1, 0)")
+	}
+	if !a.BarycoordFromPoint(a.C, nil).Equals(math3.NewVector3().Set(0, 0, 1)) {
+		t.Error("c should have barycoord (0, 0, 1)")
+	}
+
+	p := a.BarycoordFromPoint(math3.NewVector3().Set(1, 1, 0), nil)
+	if !p.Equals(math3.NewVector3().Set(0, 0.5, 0.5)) {
+		t.Errorf("unexpected barycoord %s", p)
+	}
+
+	degenerate := math3.NewTriangle().Set(zero3, one3, two3)
+	if !degenerate.BarycoordFromPoint(one3, nil).Equals(math3.NewVector3().Set(-2, -1, -1)) {
+		t.Error("degenerate triangle should produce an outside barycoord")
+	}
+}
+
+func TestTriangle_ContainsPoint(t *testing.T) {
+	a := newTestTriangle()
+
+	if !a.ContainsPoint(math3.NewVector3().Set(0.5, 0.5, 0)) {
+		t.Error("point should be inside the triangle")
+	}
+	if !a.ContainsPoint(math3.NewVector3().Set(0.5, 0.5, 5)) {
+		t.Error("containment should ignore the distance from the plane")
+	}
+	if a.ContainsPoint(math3.NewVector3().Set(2, 2, 0)) {
+		t.Error("point should be outside the triangle")
+	}
+}
+
+func TestTriangle_ClosestPointToPoint(t *testing.T) {
+	a := newTestTriangle()
+
+	// inside the triangle
+	p := a.ClosestPointToPoint(math3.NewVector3().Set(0.5, 0.5, 3), nil)
+	if !vector3Near(p, math3.NewVector3().Set(0.5, 0.5, 0)) {
+		t.Errorf("unexpected closest point %s", p)
+	}
+
+	// nearest an edge
+	p = a.ClosestPointToPoint(math3.NewVector3().Set(1, -1, 0), nil)
+	if !vector3Near(p, math3.NewVector3().Set(1, 0, 0)) {
+		t.Errorf("unexpected closest point %s", p)
+	}
+
+	// nearest a vertex
+	p = a.ClosestPointToPoint(math3.NewVector3().Set(-1, -1, 1), nil)
+	if !vector3Near(p, zero3) {
+		t.Errorf("unexpected closest point %s", p)
+	}
+}
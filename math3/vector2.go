@@ -0,0 +1,488 @@
+package math3
+
+import (
+	"fmt"
+	"math"
+)
+
+type Vector2 struct {
+	X float64
+	Y float64
+}
+
+func NewVector2() *Vector2 {
+
+	return &Vector2{0, 0}
+
+}
+
+func Vector2_Max() *Vector2 {
+	return &Vector2{
+		math.MaxFloat64,
+		math.MaxFloat64,
+	}
+}
+func Vector2_Min() *Vector2 {
+	return &Vector2{
+		-math.MaxFloat64,
+		-math.MaxFloat64,
+	}
+}
+
+func (v *Vector2) String() string {
+	return fmt.Sprintf("&Vector2{X: %.4f, Y: %.4f}", v.X, v.Y)
+}
+
+func (v *Vector2) Set(x, y float64) *Vector2 {
+
+	v.X = x
+	v.Y = y
+
+	return v
+
+}
+
+func (v *Vector2) SetScalar(scalar float64) *Vector2 {
+
+	v.X = scalar
+	v.Y = scalar
+
+	return v
+
+}
+
+func (v *Vector2) SetX(x float64) *Vector2 {
+
+	v.X = x
+
+	return v
+
+}
+
+func (v *Vector2) SetY(y float64) *Vector2 {
+
+	v.Y = y
+
+	return v
+
+}
+
+func (v *Vector2) SetComponent(index int, value float64) *Vector2 {
+
+	switch index {
+
+	case 0:
+		v.X = value
+	case 1:
+		v.Y = value
+	default:
+		panic(fmt.Sprintf("index is out of range: %d", index))
+	}
+
+	return v
+
+}
+
+func (v *Vector2) GetComponent(index int) float64 {
+
+	switch index {
+
+	case 0:
+		return v.X
+	case 1:
+		return v.Y
+	default:
+		panic(fmt.Sprintf("index is out of range: %d", index))
+
+	}
+
+}
+
+func (v *Vector2) Clone() *Vector2 {
+
+	return NewVector2().Set(v.X, v.Y)
+
+}
+
+func (v *Vector2) Copy(other *Vector2) *Vector2 {
+
+	v.X = other.X
+	v.Y = other.Y
+
+	return v
+
+}
+
+func (v *Vector2) Add(other *Vector2) *Vector2 {
+
+	v.X += other.X
+	v.Y += other.Y
+
+	return v
+
+}
+
+func (v *Vector2) AddScalar(s float64) *Vector2 {
+
+	v.X += s
+	v.Y += s
+
+	return v
+
+}
+
+func (v *Vector2) AddVectors(a, b *Vector2) *Vector2 {
+
+	v.X = a.X + b.X
+	v.Y = a.Y + b.Y
+
+	return v
+
+}
+
+func (v *Vector2) AddScaledVector(other *Vector2, s float64) *Vector2 {
+
+	v.X += other.X * s
+	v.Y += other.Y * s
+
+	return v
+
+}
+
+func (v *Vector2) Sub(other *Vector2) *Vector2 {
+
+	v.X -= other.X
+	v.Y -= other.Y
+
+	return v
+
+}
+
+func (v *Vector2) SubScalar(s float64) *Vector2 {
+
+	v.X -= s
+	v.Y -= s
+
+	return v
+
+}
+
+func (v *Vector2) SubVectors(a, b *Vector2) *Vector2 {
+
+	v.X = a.X - b.X
+	v.Y = a.Y - b.Y
+
+	return v
+
+}
+
+func (v *Vector2) Multiply(other *Vector2) *Vector2 {
+
+	v.X *= other.X
+	v.Y *= other.Y
+
+	return v
+
+}
+
+func (v *Vector2) MultiplyScalar(scalar float64) *Vector2 {
+
+	if false == math.IsInf(scalar, 0) {
+
+		v.X *= scalar
+		v.Y *= scalar
+
+	} else {
+
+		v.X = 0
+		v.Y = 0
+
+	}
+
+	return v
+
+}
+
+func (v *Vector2) MultiplyVectors(a, b *Vector2) *Vector2 {
+
+	v.X = a.X * b.X
+	v.Y = a.Y * b.Y
+
+	return v
+
+}
+
+func (v *Vector2) Divide(other *Vector2) *Vector2 {
+
+	v.X /= other.X
+	v.Y /= other.Y
+
+	return v
+
+}
+
+func (v *Vector2) DivideScalar(scalar float64) *Vector2 {
+
+	return v.MultiplyScalar(1 / scalar)
+
+}
+
+// ApplyMatrix3 transforms this vector as a point in homogeneous
+// 2D coordinates, where z is implicitly 1
+func (v *Vector2) ApplyMatrix3(m *Matrix3) *Vector2 {
+
+	x := v.X
+	y := v.Y
+	e := m.Elements
+
+	v.X = e[0]*x + e[3]*y + e[6]
+	v.Y = e[1]*x + e[4]*y + e[7]
+
+	return v
+
+}
+
+func (v *Vector2) Min(other *Vector2) *Vector2 {
+
+	v.X = math.Min(v.X, other.X)
+	v.Y = math.Min(v.Y, other.Y)
+
+	return v
+
+}
+
+func (v *Vector2) Max(other *Vector2) *Vector2 {
+
+	v.X = math.Max(v.X, other.X)
+	v.Y = math.Max(v.Y, other.Y)
+
+	return v
+
+}
+
+func (v *Vector2) Clamp(min, max *Vector2) *Vector2 {
+
+	// This function assumes min < max, if v assumption isn"t true it will not operate correctly
+
+	v.X = math.Max(min.X, math.Min(max.X, v.X))
+	v.Y = math.Max(min.Y, math.Min(max.Y, v.Y))
+
+	return v
+
+}
+
+func (v *Vector2) ClampScalar(minVal, maxVal float64) *Vector2 {
+
+	min := NewVector2()
+	max := NewVector2()
+
+	min.Set(minVal, minVal)
+	max.Set(maxVal, maxVal)
+
+	return v.Clamp(min, max)
+
+}
+
+func (v *Vector2) ClampLength(min, max float64) *Vector2 {
+
+	length := v.Length()
+
+	return v.MultiplyScalar(math.Max(min, math.Min(max, length)) / length)
+
+}
+
+func (v *Vector2) Floor() *Vector2 {
+
+	v.X = math.Floor(v.X)
+	v.Y = math.Floor(v.Y)
+
+	return v
+
+}
+
+func (v *Vector2) Ceil() *Vector2 {
+
+	v.X = math.Ceil(v.X)
+	v.Y = math.Ceil(v.Y)
+
+	return v
+
+}
+
+func (v *Vector2) Round() *Vector2 {
+
+	v.X = Round(v.X)
+	v.Y = Round(v.Y)
+
+	return v
+
+}
+
+func (v *Vector2) RoundToZero() *Vector2 {
+
+	if v.X < 0 {
+		v.X = math.Ceil(v.X)
+	} else {
+		v.X = math.Floor(v.X)
+	}
+	if v.Y < 0 {
+		v.Y = math.Ceil(v.Y)
+	} else {
+		v.Y = math.Floor(v.Y)
+	}
+	return v
+
+}
+
+func (v *Vector2) Negate() *Vector2 {
+
+	v.X = -v.X
+	v.Y = -v.Y
+
+	return v
+
+}
+
+func (v *Vector2) Dot(other *Vector2) float64 {
+
+	return v.X*other.X + v.Y*other.Y
+
+}
+
+func (v *Vector2) LengthSq() float64 {
+
+	return v.X*v.X + v.Y*v.Y
+
+}
+
+func (v *Vector2) Length() float64 {
+
+	return math.Sqrt(v.X*v.X + v.Y*v.Y)
+
+}
+
+func (v *Vector2) LengthManhattan() float64 {
+
+	return math.Abs(v.X) + math.Abs(v.Y)
+
+}
+
+func (v *Vector2) Normalize() *Vector2 {
+
+	return v.DivideScalar(v.Length())
+
+}
+
+// Angle returns the angle of this vector from the +x axis
+// in radians, in the range [0, 2PI)
+func (v *Vector2) Angle() float64 {
+
+	angle := math.Atan2(v.Y, v.X)
+
+	if angle < 0 {
+		angle += 2 * math.Pi
+	}
+
+	return angle
+
+}
+
+func (v *Vector2) DistanceTo(other *Vector2) float64 {
+
+	return math.Sqrt(v.DistanceToSquared(other))
+
+}
+
+func (v *Vector2) DistanceToSquared(other *Vector2) float64 {
+
+	dx := v.X - other.X
+	dy := v.Y - other.Y
+
+	return dx*dx + dy*dy
+
+}
+
+func (v *Vector2) DistanceToManhattan(other *Vector2) float64 {
+
+	return math.Abs(v.X-other.X) + math.Abs(v.Y-other.Y)
+
+}
+
+func (v *Vector2) SetLength(length float64) *Vector2 {
+
+	return v.MultiplyScalar(length / v.Length())
+
+}
+
+func (v *Vector2) Lerp(other *Vector2, alpha float64) *Vector2 {
+
+	v.X += (other.X - v.X) * alpha
+	v.Y += (other.Y - v.Y) * alpha
+
+	return v
+
+}
+
+func (v *Vector2) LerpVectors(v1, v2 *Vector2, alpha float64) *Vector2 {
+
+	return v.SubVectors(v2, v1).MultiplyScalar(alpha).Add(v1)
+
+}
+
+// RotateAround rotates this vector counter clockwise
+// around center by the given angle in radians
+func (v *Vector2) RotateAround(center *Vector2, angle float64) *Vector2 {
+
+	c := math.Cos(angle)
+	s := math.Sin(angle)
+
+	x := v.X - center.X
+	y := v.Y - center.Y
+
+	v.X = x*c - y*s + center.X
+	v.Y = x*s + y*c + center.Y
+
+	return v
+
+}
+
+func (v *Vector2) Equals(other *Vector2) bool {
+
+	return ((other.X == v.X) && (other.Y == v.Y))
+
+}
+
+func (v *Vector2) FromArray(array []float64, offset int) *Vector2 {
+
+	v.X = array[offset]
+	v.Y = array[offset+1]
+
+	return v
+
+}
+
+func (v *Vector2) ToArray(target []float64, offset int) []float64 {
+
+	if target == nil {
+		target = make([]float64, offset+2)
+	}
+
+	target[offset] = v.X
+	target[offset+1] = v.Y
+
+	return target
+
+}
+
+func (v *Vector2) ToArray32(target []float32, offset int) []float32 {
+
+	if target == nil {
+		target = make([]float32, offset+2)
+	}
+
+	target[offset] = float32(v.X)
+	target[offset+1] = float32(v.Y)
+
+	return target
+
+}
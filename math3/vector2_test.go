@@ -0,0 +1,347 @@
+package math3_test
+
+import (
+	"math"
+	"testing"
+
+	mm "github.com/rydrman/three.go/math3"
+)
+
+func TestNewVector2(t *testing.T) {
+
+	a := mm.NewVector2()
+	if a.X != 0 ||
+		a.Y != 0 {
+		t.Error("new vector elements should all be 0")
+	}
+
+}
+
+func TestVector2_Set(t *testing.T) {
+
+	a := mm.NewVector2()
+	a.Set(x, y)
+	if a.X != x ||
+		a.Y != y {
+		t.Error("set should change the values properly")
+	}
+
+	a.SetX(y)
+	a.SetY(x)
+	if a.X != y ||
+		a.Y != x {
+		t.Error("setX and Y should change the values properly")
+	}
+
+	a.SetScalar(z)
+	if a.X != z ||
+		a.Y != z {
+		t.Error("setScalar should change all values")
+	}
+
+}
+
+func TestVector2_Copy(t *testing.T) {
+
+	a := mm.NewVector2().Set(x, y)
+	b := mm.NewVector2().Copy(a)
+	if b.X != a.X ||
+		b.Y != a.Y {
+		t.Error("copy should equal original")
+	}
+
+	// ensure that it is a true copy
+	a.Y = -1
+	if a.Y == b.Y {
+		t.Error("copy should create a deep copy")
+	}
+
+}
+
+func TestVector2_GetSetComponent(t *testing.T) {
+
+	a := mm.NewVector2()
+
+	a.SetComponent(0, x)
+	a.SetComponent(1, y)
+	if a.GetComponent(0) != x ||
+		a.GetComponent(1) != y {
+		t.Error("SetComponent should change the values properly")
+	}
+
+	defer func() {
+		if nil == recover() {
+			t.Error("out of range component should panic")
+		}
+	}()
+	a.GetComponent(2)
+
+}
+
+func TestVector2_AddSub(t *testing.T) {
+
+	a := mm.NewVector2().Set(x, y)
+	b := mm.NewVector2().Set(-x, -y)
+
+	a.Add(b)
+	if !a.Equals(zero2) {
+		t.Error("add returned incorrect value")
+	}
+
+	c := mm.NewVector2().AddVectors(b, b)
+	if c.X != -2*x || c.Y != -2*y {
+		t.Error("addVectors returned incorrect value")
+	}
+
+	a.Set(x, y).Sub(b)
+	if a.X != 2*x || a.Y != 2*y {
+		t.Error("sub returned incorrect value")
+	}
+
+	c.SubVectors(a, a)
+	if !c.Equals(zero2) {
+		t.Error("subVectors returned incorrect value")
+	}
+
+	c.Set(1, 1).AddScaledVector(two2, 2)
+	if c.X != 5 || c.Y != 5 {
+		t.Error("addScaledVector returned incorrect value")
+	}
+
+}
+
+func TestVector2_MultiplyDivide(t *testing.T) {
+
+	a := mm.NewVector2().Set(x, y)
+
+	a.MultiplyScalar(-2)
+	if a.X != x*-2 ||
+		a.Y != y*-2 {
+		t.Error("mulitply scalar returned incorrect value")
+	}
+
+	a.DivideScalar(-2)
+	if a.X != x ||
+		a.Y != y {
+		t.Error("divide scalar returned incorrect value")
+	}
+
+	a.DivideScalar(0)
+	if !a.Equals(zero2) {
+		t.Error("divide by zero should produce a zero vector")
+	}
+
+	a.Set(x, y).Multiply(two2)
+	if a.X != 2*x || a.Y != 2*y {
+		t.Error("multiply returned incorrect value")
+	}
+
+	a.Divide(two2)
+	if a.X != x || a.Y != y {
+		t.Error("divide returned incorrect value")
+	}
+
+}
+
+func TestVector2_MinMaxClamp(t *testing.T) {
+
+	a := mm.NewVector2().Set(x, y)
+	b := mm.NewVector2().Set(-x, -y)
+	c := mm.NewVector2()
+
+	c.Copy(a).Min(b)
+	if c.X != -x ||
+		c.Y != -y {
+		t.Error("min returned incorrect value")
+	}
+
+	c.Copy(a).Max(b)
+	if c.X != x ||
+		c.Y != y {
+		t.Error("max returned incorrect value")
+	}
+
+	c.Set(-2*x, 2*y)
+	c.Clamp(b, a)
+	if c.X != -x ||
+		c.Y != y {
+		t.Error("clamp returned incorrect value")
+	}
+
+	c.Copy(posInf2).ClampScalar(0, 1)
+	if !c.Equals(one2) {
+		t.Error("clampScalar returned incorrect value")
+	}
+
+	c.Copy(negInf2).ClampScalar(0, 1)
+	if !c.Equals(zero2) {
+		t.Error("clampScalar returned incorrect value")
+	}
+
+	c.Set(3, 4).ClampLength(0, 1)
+	if math.Abs(c.Length()-1) > 0.0001 {
+		t.Error("clampLength returned incorrect value")
+	}
+
+}
+
+func TestVector2_Rounding(t *testing.T) {
+
+	a := mm.NewVector2()
+
+	if !a.Set(-0.5, 1.5).Floor().Equals(mm.NewVector2().Set(-1, 1)) {
+		t.Error("floor returned incorrect value")
+	}
+	if !a.Set(-0.5, 1.5).Ceil().Equals(mm.NewVector2().Set(0, 2)) {
+		t.Error("ceil returned incorrect value")
+	}
+	if !a.Set(-1.7, 1.7).RoundToZero().Equals(mm.NewVector2().Set(-1, 1)) {
+		t.Error("roundToZero returned incorrect value")
+	}
+
+}
+
+func TestVector2_DotLength(t *testing.T) {
+
+	a := mm.NewVector2().Set(x, y)
+	b := mm.NewVector2().Set(-x, -y)
+
+	if a.Dot(b) != -x*x-y*y {
+		t.Error("dot returned incorrect value")
+	}
+	if a.Dot(zero2) != 0 {
+		t.Error("dot with 0 vector should be 0")
+	}
+
+	if a.LengthSq() != x*x+y*y {
+		t.Error("lengthSq returned incorrect value")
+	}
+	if a.Length() != math.Sqrt(x*x+y*y) {
+		t.Error("length returned incorrect value")
+	}
+	if b.LengthManhattan() != x+y {
+		t.Error("lengthManhattan returned incorrect value")
+	}
+
+	a.Normalize()
+	if math.Abs(a.Length()-1) > 0.0001 {
+		t.Error("normalize should produce a unit vector")
+	}
+
+	a.SetLength(x)
+	if math.Abs(a.Length()-x) > 0.0001 {
+		t.Error("setLength returned incorrect value")
+	}
+
+}
+
+func TestVector2_Distance(t *testing.T) {
+
+	a := mm.NewVector2().Set(x, 0)
+	b := mm.NewVector2().Set(0, -y)
+
+	if a.DistanceTo(zero2) != x {
+		t.Error("distanceTo returned incorrect value")
+	}
+	if b.DistanceToSquared(zero2) != y*y {
+		t.Error("distanceToSquared returned incorrect value")
+	}
+	if a.DistanceToManhattan(b) != x+y {
+		t.Error("distanceToManhattan returned incorrect value")
+	}
+
+}
+
+func TestVector2_AngleRotateAround(t *testing.T) {
+
+	if mm.NewVector2().Set(1, 0).Angle() != 0 {
+		t.Error("angle of +x should be 0")
+	}
+	if mm.NewVector2().Set(0, 1).Angle() != math.Pi/2 {
+		t.Error("angle of +y should be pi/2")
+	}
+	if mm.NewVector2().Set(0, -1).Angle() != 3*math.Pi/2 {
+		t.Error("angle should be in the range [0, 2pi)")
+	}
+
+	a := mm.NewVector2().Set(2, 1)
+	a.RotateAround(mm.NewVector2().Set(1, 1), math.Pi/2)
+	if math.Abs(a.X-1) > 0.0001 || math.Abs(a.Y-2) > 0.0001 {
+		t.Errorf("rotateAround returned incorrect value %s", a)
+	}
+
+}
+
+func TestVector2_ApplyMatrix3(t *testing.T) {
+
+	m := mm.NewMatrix3().Set(
+		2, 0, x,
+		0, 3, y,
+		0, 0, 1,
+	)
+
+	a := mm.NewVector2().Set(1, 1).ApplyMatrix3(m)
+	if a.X != 2+x || a.Y != 3+y {
+		t.Errorf("applyMatrix3 returned incorrect value %s", a)
+	}
+
+}
+
+func TestVector2_LerpClone(t *testing.T) {
+
+	a := mm.NewVector2().Set(x, 0)
+	b := mm.NewVector2().Set(0, -y)
+
+	if !a.Clone().Lerp(b, 0).Equals(a) {
+		t.Fail()
+	}
+
+	c := a.Clone().Lerp(b, 0.5)
+	if c.X != x*0.5 || c.Y != -y*0.5 {
+		t.Fail()
+	}
+
+	if !a.Clone().Lerp(b, 1).Equals(b) {
+		t.Fail()
+	}
+
+	if !mm.NewVector2().LerpVectors(a, b, 0.5).Equals(c) {
+		t.Fail()
+	}
+
+}
+
+func TestVector2_Array(t *testing.T) {
+
+	a := mm.NewVector2().FromArray([]float64{0, x, y}, 1)
+	if a.X != x || a.Y != y {
+		t.Error("fromArray returned incorrect value")
+	}
+
+	array := a.ToArray(nil, 0)
+	if len(array) != 2 || array[0] != x || array[1] != y {
+		t.Error("toArray returned incorrect value")
+	}
+
+	array32 := a.ToArray32(make([]float32, 3), 1)
+	if array32[1] != x || array32[2] != y {
+		t.Error("toArray32 returned incorrect value")
+	}
+
+}
+
+func TestVector2_Equals(t *testing.T) {
+
+	a := mm.NewVector2().Set(x, 0)
+	b := mm.NewVector2().Set(0, -y)
+
+	if a.Equals(b) || b.Equals(a) {
+		t.Fail()
+	}
+
+	a.Copy(b)
+	if !a.Equals(b) || !b.Equals(a) {
+		t.Fail()
+	}
+
+}
@@ -338,17 +338,24 @@ func (v *Vector3) Project(camera Projector) *Vector3 {
 
 	matrix := NewMatrix4()
 
-	matrix.MultiplyMatrices(camera.GetProjectionMatrix(), matrix.GetInverse(camera.GetMatrixWorld()))
+	matrix.MultiplyMatrices(camera.GetProjectionMatrix(), camera.GetMatrixWorldInverse())
 	return v.ApplyProjection(matrix)
 
 }
 
-func (v *Vector3) Unproject(camera Projector) *Vector3 {
+// Unproject maps this vector from the normalized device coordinates of
+// camera back into world space. ErrSingularMatrix is returned, with the
+// vector mapped through the pseudo-inverse, if the projection of the
+// camera cannot be inverted.
+func (v *Vector3) Unproject(camera Projector) (*Vector3, error) {
 
 	matrix := NewMatrix4()
+	projectionInverse := NewMatrix4()
 
-	matrix.MultiplyMatrices(camera.GetMatrixWorld(), matrix.GetInverse(camera.GetProjectionMatrix()))
-	return v.ApplyProjection(matrix)
+	err := projectionInverse.InverseOf(camera.GetProjectionMatrix())
+
+	matrix.MultiplyMatrices(camera.GetMatrixWorld(), projectionInverse)
+	return v.ApplyProjection(matrix), err
 
 }
 
@@ -4,9 +4,16 @@ import (
 	"math"
 	"testing"
 
-	"github.com/rydrman/marshmallow"
+	mm "github.com/rydrman/three.go/math3"
 )
 
+func vector3Near(a, b *mm.Vector3) bool {
+	tolerance := 0.0001
+	return math.Abs(a.X-b.X) < tolerance &&
+		math.Abs(a.Y-b.Y) < tolerance &&
+		math.Abs(a.Z-b.Z) < tolerance
+}
+
 func TestNewVector3(t *testing.T) {
 
 	a := mm.NewVector3()
@@ -0,0 +1,675 @@
+package math3
+
+import (
+	"fmt"
+	"math"
+)
+
+type Vector4 struct {
+	X float64
+	Y float64
+	Z float64
+	W float64
+}
+
+// NewVector4 constructs the vector (0, 0, 0, 1)
+func NewVector4() *Vector4 {
+
+	return &Vector4{0, 0, 0, 1}
+
+}
+
+func (v *Vector4) String() string {
+	return fmt.Sprintf("&Vector4{X: %.4f, Y: %.4f, Z: %.4f, W: %.4f}", v.X, v.Y, v.Z, v.W)
+}
+
+func (v *Vector4) Set(x, y, z, w float64) *Vector4 {
+
+	v.X = x
+	v.Y = y
+	v.Z = z
+	v.W = w
+
+	return v
+
+}
+
+func (v *Vector4) SetScalar(scalar float64) *Vector4 {
+
+	v.X = scalar
+	v.Y = scalar
+	v.Z = scalar
+	v.W = scalar
+
+	return v
+
+}
+
+func (v *Vector4) SetX(x float64) *Vector4 {
+
+	v.X = x
+
+	return v
+
+}
+
+func (v *Vector4) SetY(y float64) *Vector4 {
+
+	v.Y = y
+
+	return v
+
+}
+
+func (v *Vector4) SetZ(z float64) *Vector4 {
+
+	v.Z = z
+
+	return v
+
+}
+
+func (v *Vector4) SetW(w float64) *Vector4 {
+
+	v.W = w
+
+	return v
+
+}
+
+func (v *Vector4) SetComponent(index int, value float64) *Vector4 {
+
+	switch index {
+
+	case 0:
+		v.X = value
+	case 1:
+		v.Y = value
+	case 2:
+		v.Z = value
+	case 3:
+		v.W = value
+	default:
+		panic(fmt.Sprintf("index is out of range: %d", index))
+	}
+
+	return v
+
+}
+
+func (v *Vector4) GetComponent(index int) float64 {
+
+	switch index {
+
+	case 0:
+		return v.X
+	case 1:
+		return v.Y
+	case 2:
+		return v.Z
+	case 3:
+		return v.W
+	default:
+		panic(fmt.Sprintf("index is out of range: %d", index))
+
+	}
+
+}
+
+func (v *Vector4) Clone() *Vector4 {
+
+	return NewVector4().Set(v.X, v.Y, v.Z, v.W)
+
+}
+
+func (v *Vector4) Copy(other *Vector4) *Vector4 {
+
+	v.X = other.X
+	v.Y = other.Y
+	v.Z = other.Z
+	v.W = other.W
+
+	return v
+
+}
+
+func (v *Vector4) Add(other *Vector4) *Vector4 {
+
+	v.X += other.X
+	v.Y += other.Y
+	v.Z += other.Z
+	v.W += other.W
+
+	return v
+
+}
+
+func (v *Vector4) AddScalar(s float64) *Vector4 {
+
+	v.X += s
+	v.Y += s
+	v.Z += s
+	v.W += s
+
+	return v
+
+}
+
+func (v *Vector4) AddVectors(a, b *Vector4) *Vector4 {
+
+	v.X = a.X + b.X
+	v.Y = a.Y + b.Y
+	v.Z = a.Z + b.Z
+	v.W = a.W + b.W
+
+	return v
+
+}
+
+func (v *Vector4) AddScaledVector(other *Vector4, s float64) *Vector4 {
+
+	v.X += other.X * s
+	v.Y += other.Y * s
+	v.Z += other.Z * s
+	v.W += other.W * s
+
+	return v
+
+}
+
+func (v *Vector4) Sub(other *Vector4) *Vector4 {
+
+	v.X -= other.X
+	v.Y -= other.Y
+	v.Z -= other.Z
+	v.W -= other.W
+
+	return v
+
+}
+
+func (v *Vector4) SubScalar(s float64) *Vector4 {
+
+	v.X -= s
+	v.Y -= s
+	v.Z -= s
+	v.W -= s
+
+	return v
+
+}
+
+func (v *Vector4) SubVectors(a, b *Vector4) *Vector4 {
+
+	v.X = a.X - b.X
+	v.Y = a.Y - b.Y
+	v.Z = a.Z - b.Z
+	v.W = a.W - b.W
+
+	return v
+
+}
+
+func (v *Vector4) Multiply(other *Vector4) *Vector4 {
+
+	v.X *= other.X
+	v.Y *= other.Y
+	v.Z *= other.Z
+	v.W *= other.W
+
+	return v
+
+}
+
+func (v *Vector4) MultiplyScalar(scalar float64) *Vector4 {
+
+	if false == math.IsInf(scalar, 0) {
+
+		v.X *= scalar
+		v.Y *= scalar
+		v.Z *= scalar
+		v.W *= scalar
+
+	} else {
+
+		v.X = 0
+		v.Y = 0
+		v.Z = 0
+		v.W = 0
+
+	}
+
+	return v
+
+}
+
+func (v *Vector4) MultiplyVectors(a, b *Vector4) *Vector4 {
+
+	v.X = a.X * b.X
+	v.Y = a.Y * b.Y
+	v.Z = a.Z * b.Z
+	v.W = a.W * b.W
+
+	return v
+
+}
+
+func (v *Vector4) ApplyMatrix4(m *Matrix4) *Vector4 {
+
+	x := v.X
+	y := v.Y
+	z := v.Z
+	w := v.W
+	e := m.Elements
+
+	v.X = e[0]*x + e[4]*y + e[8]*z + e[12]*w
+	v.Y = e[1]*x + e[5]*y + e[9]*z + e[13]*w
+	v.Z = e[2]*x + e[6]*y + e[10]*z + e[14]*w
+	v.W = e[3]*x + e[7]*y + e[11]*z + e[15]*w
+
+	return v
+
+}
+
+func (v *Vector4) Divide(other *Vector4) *Vector4 {
+
+	v.X /= other.X
+	v.Y /= other.Y
+	v.Z /= other.Z
+	v.W /= other.W
+
+	return v
+
+}
+
+func (v *Vector4) DivideScalar(scalar float64) *Vector4 {
+
+	return v.MultiplyScalar(1 / scalar)
+
+}
+
+// SetAxisAngleFromQuaternion sets x, y and z to the axis of rotation
+// of the given quaternion and w to the angle in radians
+func (v *Vector4) SetAxisAngleFromQuaternion(q *Quaternion) *Vector4 {
+
+	// http://www.euclideanspace.com/maths/geometry/rotations/conversions/quaternionToAngle/index.htm
+
+	// q is assumed to be normalized
+
+	v.W = 2 * math.Acos(q.GetW())
+
+	s := math.Sqrt(1 - q.GetW()*q.GetW())
+
+	if s < 0.0001 {
+
+		v.X = 1
+		v.Y = 0
+		v.Z = 0
+
+	} else {
+
+		v.X = q.GetX() / s
+		v.Y = q.GetY() / s
+		v.Z = q.GetZ() / s
+
+	}
+
+	return v
+
+}
+
+// SetAxisAngleFromRotationMatrix sets x, y and z to the axis of rotation
+// of the given matrix and w to the angle in radians
+func (v *Vector4) SetAxisAngleFromRotationMatrix(m *Matrix4) *Vector4 {
+
+	// http://www.euclideanspace.com/maths/geometry/rotations/conversions/matrixToAngle/index.htm
+
+	// assumes the upper 3x3 of m is a pure rotation matrix (i.e, unscaled)
+
+	var angle, x, y, z float64
+
+	const epsilon = 0.01 // margin to allow for rounding errors
+	const epsilon2 = 0.1 // margin to distinguish between 0 and 180 degrees
+
+	te := m.Elements
+
+	m11, m12, m13 := te[0], te[4], te[8]
+	m21, m22, m23 := te[1], te[5], te[9]
+	m31, m32, m33 := te[2], te[6], te[10]
+
+	if (math.Abs(m12-m21) < epsilon) &&
+		(math.Abs(m13-m31) < epsilon) &&
+		(math.Abs(m23-m32) < epsilon) {
+
+		// singularity found
+		// first check for identity matrix which must have +1 for all terms
+		// in leading diagonal and zero in other terms
+
+		if (math.Abs(m12+m21) < epsilon2) &&
+			(math.Abs(m13+m31) < epsilon2) &&
+			(math.Abs(m23+m32) < epsilon2) &&
+			(math.Abs(m11+m22+m33-3) < epsilon2) {
+
+			// v singularity is identity matrix so angle = 0
+
+			return v.Set(1, 0, 0, 0)
+
+		}
+
+		// otherwise v singularity is angle = 180
+
+		angle = math.Pi
+
+		xx := (m11 + 1) / 2
+		yy := (m22 + 1) / 2
+		zz := (m33 + 1) / 2
+		xy := (m12 + m21) / 4
+		xz := (m13 + m31) / 4
+		yz := (m23 + m32) / 4
+
+		if (xx > yy) && (xx > zz) {
+
+			// m11 is the largest diagonal term
+
+			if xx < epsilon {
+
+				x = 0
+				y = 0.707106781
+				z = 0.707106781
+
+			} else {
+
+				x = math.Sqrt(xx)
+				y = xy / x
+				z = xz / x
+
+			}
+
+		} else if yy > zz {
+
+			// m22 is the largest diagonal term
+
+			if yy < epsilon {
+
+				x = 0.707106781
+				y = 0
+				z = 0.707106781
+
+			} else {
+
+				y = math.Sqrt(yy)
+				x = xy / y
+				z = yz / y
+
+			}
+
+		} else {
+
+			// m33 is the largest diagonal term so base result on v
+
+			if zz < epsilon {
+
+				x = 0.707106781
+				y = 0.707106781
+				z = 0
+
+			} else {
+
+				z = math.Sqrt(zz)
+				x = xz / z
+				y = yz / z
+
+			}
+
+		}
+
+		return v.Set(x, y, z, angle)
+
+	}
+
+	// as we have reached here there are no singularities so we can handle normally
+
+	s := math.Sqrt((m32-m23)*(m32-m23) +
+		(m13-m31)*(m13-m31) +
+		(m21-m12)*(m21-m12)) // used to normalize
+
+	if math.Abs(s) < 0.001 {
+		s = 1
+	}
+
+	// prevent divide by zero, should not happen if matrix is orthogonal and should be
+	// caught by singularity test above, but I"ve left it in just in case
+
+	v.X = (m32 - m23) / s
+	v.Y = (m13 - m31) / s
+	v.Z = (m21 - m12) / s
+	v.W = math.Acos((m11 + m22 + m33 - 1) / 2)
+
+	return v
+
+}
+
+func (v *Vector4) Min(other *Vector4) *Vector4 {
+
+	v.X = math.Min(v.X, other.X)
+	v.Y = math.Min(v.Y, other.Y)
+	v.Z = math.Min(v.Z, other.Z)
+	v.W = math.Min(v.W, other.W)
+
+	return v
+
+}
+
+func (v *Vector4) Max(other *Vector4) *Vector4 {
+
+	v.X = math.Max(v.X, other.X)
+	v.Y = math.Max(v.Y, other.Y)
+	v.Z = math.Max(v.Z, other.Z)
+	v.W = math.Max(v.W, other.W)
+
+	return v
+
+}
+
+func (v *Vector4) Clamp(min, max *Vector4) *Vector4 {
+
+	// This function assumes min < max, if v assumption isn"t true it will not operate correctly
+
+	v.X = math.Max(min.X, math.Min(max.X, v.X))
+	v.Y = math.Max(min.Y, math.Min(max.Y, v.Y))
+	v.Z = math.Max(min.Z, math.Min(max.Z, v.Z))
+	v.W = math.Max(min.W, math.Min(max.W, v.W))
+
+	return v
+
+}
+
+func (v *Vector4) ClampScalar(minVal, maxVal float64) *Vector4 {
+
+	min := NewVector4()
+	max := NewVector4()
+
+	min.Set(minVal, minVal, minVal, minVal)
+	max.Set(maxVal, maxVal, maxVal, maxVal)
+
+	return v.Clamp(min, max)
+
+}
+
+func (v *Vector4) ClampLength(min, max float64) *Vector4 {
+
+	length := v.Length()
+
+	return v.MultiplyScalar(math.Max(min, math.Min(max, length)) / length)
+
+}
+
+func (v *Vector4) Floor() *Vector4 {
+
+	v.X = math.Floor(v.X)
+	v.Y = math.Floor(v.Y)
+	v.Z = math.Floor(v.Z)
+	v.W = math.Floor(v.W)
+
+	return v
+
+}
+
+func (v *Vector4) Ceil() *Vector4 {
+
+	v.X = math.Ceil(v.X)
+	v.Y = math.Ceil(v.Y)
+	v.Z = math.Ceil(v.Z)
+	v.W = math.Ceil(v.W)
+
+	return v
+
+}
+
+func (v *Vector4) Round() *Vector4 {
+
+	v.X = Round(v.X)
+	v.Y = Round(v.Y)
+	v.Z = Round(v.Z)
+	v.W = Round(v.W)
+
+	return v
+
+}
+
+func (v *Vector4) RoundToZero() *Vector4 {
+
+	if v.X < 0 {
+		v.X = math.Ceil(v.X)
+	} else {
+		v.X = math.Floor(v.X)
+	}
+	if v.Y < 0 {
+		v.Y = math.Ceil(v.Y)
+	} else {
+		v.Y = math.Floor(v.Y)
+	}
+	if v.Z < 0 {
+		v.Z = math.Ceil(v.Z)
+	} else {
+		v.Z = math.Floor(v.Z)
+	}
+	if v.W < 0 {
+		v.W = math.Ceil(v.W)
+	} else {
+		v.W = math.Floor(v.W)
+	}
+	return v
+
+}
+
+func (v *Vector4) Negate() *Vector4 {
+
+	v.X = -v.X
+	v.Y = -v.Y
+	v.Z = -v.Z
+	v.W = -v.W
+
+	return v
+
+}
+
+func (v *Vector4) Dot(other *Vector4) float64 {
+
+	return v.X*other.X + v.Y*other.Y + v.Z*other.Z + v.W*other.W
+
+}
+
+func (v *Vector4) LengthSq() float64 {
+
+	return v.X*v.X + v.Y*v.Y + v.Z*v.Z + v.W*v.W
+
+}
+
+func (v *Vector4) Length() float64 {
+
+	return math.Sqrt(v.X*v.X + v.Y*v.Y + v.Z*v.Z + v.W*v.W)
+
+}
+
+func (v *Vector4) LengthManhattan() float64 {
+
+	return math.Abs(v.X) + math.Abs(v.Y) + math.Abs(v.Z) + math.Abs(v.W)
+
+}
+
+func (v *Vector4) Normalize() *Vector4 {
+
+	return v.DivideScalar(v.Length())
+
+}
+
+func (v *Vector4) SetLength(length float64) *Vector4 {
+
+	return v.MultiplyScalar(length / v.Length())
+
+}
+
+func (v *Vector4) Lerp(other *Vector4, alpha float64) *Vector4 {
+
+	v.X += (other.X - v.X) * alpha
+	v.Y += (other.Y - v.Y) * alpha
+	v.Z += (other.Z - v.Z) * alpha
+	v.W += (other.W - v.W) * alpha
+
+	return v
+
+}
+
+func (v *Vector4) LerpVectors(v1, v2 *Vector4, alpha float64) *Vector4 {
+
+	return v.SubVectors(v2, v1).MultiplyScalar(alpha).Add(v1)
+
+}
+
+func (v *Vector4) Equals(other *Vector4) bool {
+
+	return ((other.X == v.X) && (other.Y == v.Y) && (other.Z == v.Z) && (other.W == v.W))
+
+}
+
+func (v *Vector4) FromArray(array []float64, offset int) *Vector4 {
+
+	v.X = array[offset]
+	v.Y = array[offset+1]
+	v.Z = array[offset+2]
+	v.W = array[offset+3]
+
+	return v
+
+}
+
+func (v *Vector4) ToArray(target []float64, offset int) []float64 {
+
+	if target == nil {
+		target = make([]float64, offset+4)
+	}
+
+	target[offset] = v.X
+	target[offset+1] = v.Y
+	target[offset+2] = v.Z
+	target[offset+3] = v.W
+
+	return target
+
+}
+
+func (v *Vector4) ToArray32(target []float32, offset int) []float32 {
+
+	if target == nil {
+		target = make([]float32, offset+4)
+	}
+
+	target[offset] = float32(v.X)
+	target[offset+1] = float32(v.Y)
+	target[offset+2] = float32(v.Z)
+	target[offset+3] = float32(v.W)
+
+	return target
+
+}
@@ -0,0 +1,358 @@
+package math3_test
+
+import (
+	"math"
+	"testing"
+
+	mm "github.com/rydrman/three.go/math3"
+)
+
+func vector4Near(a, b *mm.Vector4) bool {
+	tolerance := 0.0001
+	return math.Abs(a.X-b.X) < tolerance &&
+		math.Abs(a.Y-b.Y) < tolerance &&
+		math.Abs(a.Z-b.Z) < tolerance &&
+		math.Abs(a.W-b.W) < tolerance
+}
+
+func TestNewVector4(t *testing.T) {
+
+	a := mm.NewVector4()
+	if a.X != 0 ||
+		a.Y != 0 ||
+		a.Z != 0 ||
+		a.W != 1 {
+		t.Error("new vector should be (0, 0, 0, 1)")
+	}
+
+}
+
+func TestVector4_Set(t *testing.T) {
+
+	a := mm.NewVector4()
+	a.Set(x, y, z, w)
+	if a.X != x ||
+		a.Y != y ||
+		a.Z != z ||
+		a.W != w {
+		t.Error("set should change the values properly")
+	}
+
+	a.SetX(w).SetY(z).SetZ(y).SetW(x)
+	if a.X != w ||
+		a.Y != z ||
+		a.Z != y ||
+		a.W != x {
+		t.Error("setX, Y, Z and W should change the values properly")
+	}
+
+	a.SetScalar(x)
+	if !a.Equals(mm.NewVector4().Set(x, x, x, x)) {
+		t.Error("setScalar should change all values")
+	}
+
+}
+
+func TestVector4_Copy(t *testing.T) {
+
+	a := mm.NewVector4().Set(x, y, z, w)
+	b := mm.NewVector4().Copy(a)
+	if !b.Equals(a) {
+		t.Error("copy should equal original")
+	}
+
+	// ensure that it is a true copy
+	a.W = -1
+	if a.W == b.W {
+		t.Error("copy should create a deep copy")
+	}
+
+}
+
+func TestVector4_GetSetComponent(t *testing.T) {
+
+	a := mm.NewVector4()
+
+	a.SetComponent(0, x)
+	a.SetComponent(1, y)
+	a.SetComponent(2, z)
+	a.SetComponent(3, w)
+	if a.GetComponent(0) != x ||
+		a.GetComponent(1) != y ||
+		a.GetComponent(2) != z ||
+		a.GetComponent(3) != w {
+		t.Error("SetComponent should change the values properly")
+	}
+
+	defer func() {
+		if nil == recover() {
+			t.Error("out of range component should panic")
+		}
+	}()
+	a.SetComponent(4, 0)
+
+}
+
+func TestVector4_AddSub(t *testing.T) {
+
+	a := mm.NewVector4().Set(x, y, z, w)
+	b := mm.NewVector4().Set(-x, -y, -z, -w)
+
+	a.Add(b)
+	if !a.Equals(zero4) {
+		t.Error("add returned incorrect value")
+	}
+
+	c := mm.NewVector4().AddVectors(b, b)
+	if !c.Equals(mm.NewVector4().Set(-2*x, -2*y, -2*z, -2*w)) {
+		t.Error("addVectors returned incorrect value")
+	}
+
+	a.Set(x, y, z, w).Sub(b)
+	if !a.Equals(mm.NewVector4().Set(2*x, 2*y, 2*z, 2*w)) {
+		t.Error("sub returned incorrect value")
+	}
+
+	c.SubVectors(a, a)
+	if !c.Equals(zero4) {
+		t.Error("subVectors returned incorrect value")
+	}
+
+	c.Copy(one4).AddScaledVector(two4, 2)
+	if !c.Equals(mm.NewVector4().Set(5, 5, 5, 5)) {
+		t.Error("addScaledVector returned incorrect value")
+	}
+
+}
+
+func TestVector4_MultiplyDivide(t *testing.T) {
+
+	a := mm.NewVector4().Set(x, y, z, w)
+
+	a.MultiplyScalar(-2)
+	if !a.Equals(mm.NewVector4().Set(-2*x, -2*y, -2*z, -2*w)) {
+		t.Error("mulitply scalar returned incorrect value")
+	}
+
+	a.DivideScalar(-2)
+	if !a.Equals(mm.NewVector4().Set(x, y, z, w)) {
+		t.Error("divide scalar returned incorrect value")
+	}
+
+	a.DivideScalar(0)
+	if !a.Equals(zero4) {
+		t.Error("divide by zero should produce a zero vector")
+	}
+
+	a.Set(x, y, z, w).Multiply(two4)
+	if !a.Equals(mm.NewVector4().Set(2*x, 2*y, 2*z, 2*w)) {
+		t.Error("multiply returned incorrect value")
+	}
+
+	a.Divide(two4)
+	if !a.Equals(mm.NewVector4().Set(x, y, z, w)) {
+		t.Error("divide returned incorrect value")
+	}
+
+}
+
+func TestVector4_MinMaxClamp(t *testing.T) {
+
+	a := mm.NewVector4().Set(x, y, z, w)
+	b := mm.NewVector4().Set(-x, -y, -z, -w)
+	c := mm.NewVector4()
+
+	if !c.Copy(a).Min(b).Equals(b) {
+		t.Error("min returned incorrect value")
+	}
+
+	if !c.Copy(a).Max(b).Equals(a) {
+		t.Error("max returned incorrect value")
+	}
+
+	c.Set(-2*x, 2*y, -2*z, 2*w)
+	c.Clamp(b, a)
+	if !c.Equals(mm.NewVector4().Set(-x, y, -z, w)) {
+		t.Error("clamp returned incorrect value")
+	}
+
+	if !c.Copy(posInf4).ClampScalar(0, 1).Equals(one4) {
+		t.Error("clampScalar returned incorrect value")
+	}
+
+	if !c.Copy(negInf4).ClampScalar(0, 1).Equals(zero4) {
+		t.Error("clampScalar returned incorrect value")
+	}
+
+	c.Set(x, y, z, w).ClampLength(0, 1)
+	if math.Abs(c.Length()-1) > 0.0001 {
+		t.Error("clampLength returned incorrect value")
+	}
+
+}
+
+func TestVector4_Rounding(t *testing.T) {
+
+	a := mm.NewVector4()
+
+	if !a.Set(-0.5, 1.5, -1.5, 0.5).Floor().Equals(mm.NewVector4().Set(-1, 1, -2, 0)) {
+		t.Error("floor returned incorrect value")
+	}
+	if !a.Set(-0.5, 1.5, -1.5, 0.5).Ceil().Equals(mm.NewVector4().Set(0, 2, -1, 1)) {
+		t.Error("ceil returned incorrect value")
+	}
+	if !a.Set(-1.7, 1.7, -0.2, 0.2).RoundToZero().Equals(mm.NewVector4().Set(-1, 1, 0, 0)) {
+		t.Error("roundToZero returned incorrect value")
+	}
+
+}
+
+func TestVector4_DotLength(t *testing.T) {
+
+	a := mm.NewVector4().Set(x, y, z, w)
+	b := mm.NewVector4().Set(-x, -y, -z, -w)
+
+	if a.Dot(b) != -x*x-y*y-z*z-w*w {
+		t.Error("dot returned incorrect value")
+	}
+	if a.Dot(zero4) != 0 {
+		t.Error("dot with 0 vector should be 0")
+	}
+
+	if a.LengthSq() != x*x+y*y+z*z+w*w {
+		t.Error("lengthSq returned incorrect value")
+	}
+	if a.Length() != math.Sqrt(x*x+y*y+z*z+w*w) {
+		t.Error("length returned incorrect value")
+	}
+	if b.LengthManhattan() != x+y+z+w {
+		t.Error("lengthManhattan returned incorrect value")
+	}
+
+	a.Normalize()
+	if math.Abs(a.Length()-1) > 0.0001 {
+		t.Error("normalize should produce a unit vector")
+	}
+
+	a.SetLength(x)
+	if math.Abs(a.Length()-x) > 0.0001 {
+		t.Error("setLength returned incorrect value")
+	}
+
+}
+
+func TestVector4_ApplyMatrix4(t *testing.T) {
+
+	m := mm.NewMatrix4().MakeTranslation(x, y, z)
+
+	a := mm.NewVector4().Set(1, 1, 1, 1).ApplyMatrix4(m)
+	if !a.Equals(mm.NewVector4().Set(1+x, 1+y, 1+z, 1)) {
+		t.Errorf("applyMatrix4 should translate points, got %s", a)
+	}
+
+	a.Set(1, 1, 1, 0).ApplyMatrix4(m)
+	if !a.Equals(mm.NewVector4().Set(1, 1, 1, 0)) {
+		t.Errorf("applyMatrix4 should not translate directions, got %s", a)
+	}
+
+	m.MakePerspective(90, 1, 1, 10)
+	a.Set(0, 0, -1, 1).ApplyMatrix4(m)
+	if a.W != 1 {
+		t.Errorf("applyMatrix4 should compute the w component, got %s", a)
+	}
+
+}
+
+func TestVector4_SetAxisAngle(t *testing.T) {
+
+	axis := mm.NewVector3().Set(0, 1, 0)
+	q := mm.NewQuaternion().SetFromAxisAngle(axis, math.Pi/2)
+	expected := mm.NewVector4().Set(0, 1, 0, math.Pi/2)
+
+	a := mm.NewVector4().SetAxisAngleFromQuaternion(q)
+	if !vector4Near(a, expected) {
+		t.Errorf("axis angle from quaternion returned incorrect value %s", a)
+	}
+
+	a.SetAxisAngleFromQuaternion(mm.NewQuaternion())
+	if !a.Equals(mm.NewVector4().Set(1, 0, 0, 0)) {
+		t.Errorf("identity quaternion should produce a zero angle, got %s", a)
+	}
+
+	m := mm.NewMatrix4().MakeRotationAxis(axis, math.Pi/2)
+	a.SetAxisAngleFromRotationMatrix(m)
+	if !vector4Near(a, expected) {
+		t.Errorf("axis angle from matrix returned incorrect value %s", a)
+	}
+
+	a.SetAxisAngleFromRotationMatrix(mm.NewMatrix4())
+	if !a.Equals(mm.NewVector4().Set(1, 0, 0, 0)) {
+		t.Errorf("identity matrix should produce a zero angle, got %s", a)
+	}
+
+	m.MakeRotationAxis(mm.NewVector3().Set(0, 0, 1), math.Pi)
+	a.SetAxisAngleFromRotationMatrix(m)
+	if !vector4Near(a, mm.NewVector4().Set(0, 0, 1, math.Pi)) {
+		t.Errorf("half turn should be detected, got %s", a)
+	}
+
+}
+
+func TestVector4_LerpClone(t *testing.T) {
+
+	a := mm.NewVector4().Set(x, 0, z, 0)
+	b := mm.NewVector4().Set(0, -y, 0, -w)
+
+	if !a.Clone().Lerp(b, 0).Equals(a) {
+		t.Fail()
+	}
+
+	c := a.Clone().Lerp(b, 0.5)
+	if !c.Equals(mm.NewVector4().Set(x*0.5, -y*0.5, z*0.5, -w*0.5)) {
+		t.Fail()
+	}
+
+	if !a.Clone().Lerp(b, 1).Equals(b) {
+		t.Fail()
+	}
+
+	if !mm.NewVector4().LerpVectors(a, b, 0.5).Equals(c) {
+		t.Fail()
+	}
+
+}
+
+func TestVector4_Array(t *testing.T) {
+
+	a := mm.NewVector4().FromArray([]float64{0, x, y, z, w}, 1)
+	if !a.Equals(mm.NewVector4().Set(x, y, z, w)) {
+		t.Error("fromArray returned incorrect value")
+	}
+
+	array := a.ToArray(nil, 0)
+	if len(array) != 4 || array[0] != x || array[3] != w {
+		t.Error("toArray returned incorrect value")
+	}
+
+	array32 := a.ToArray32(make([]float32, 5), 1)
+	if array32[1] != x || array32[4] != w {
+		t.Error("toArray32 returned incorrect value")
+	}
+
+}
+
+func TestVector4_Equals(t *testing.T) {
+
+	a := mm.NewVector4().Set(x, 0, z, 0)
+	b := mm.NewVector4().Set(0, -y, 0, -w)
+
+	if a.Equals(b) || b.Equals(a) {
+		t.Fail()
+	}
+
+	a.Copy(b)
+	if !a.Equals(b) || !b.Equals(a) {
+		t.Fail()
+	}
+
+}
@@ -0,0 +1,215 @@
+package objects
+
+import (
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/math3"
+)
+
+// Line is a continuous line through every vertex of its geometry
+type Line struct {
+	*Object
+
+	Geometry *core.BufferGeometry
+	Material Material
+}
+
+// NewLine creates a line from the given geometry and material,
+// a new empty geometry is used if geometry is nil
+func NewLine(geometry *core.BufferGeometry, material Material) *Line {
+
+	if nil == geometry {
+		geometry = core.NewBufferGeometry()
+	}
+
+	return &Line{
+		Object: NewObject(),
+
+		Geometry: geometry,
+		Material: material,
+	}
+
+}
+
+// GetBoundingSphere returns the bounding sphere of the geometry
+// of this line, computing it if needed
+func (l *Line) GetBoundingSphere() *math3.Sphere {
+
+	return boundingSphere(l.Geometry)
+
+}
+
+// ForEachSegment calls fn with the vertex indices of each segment
+// in the draw range of this line
+func (l *Line) ForEachSegment(fn func(a, b int)) {
+
+	forEachSegment(l.Geometry, 1, false, fn)
+
+}
+
+// Raycast appends the segments of this line that pass within
+// the line threshold of raycaster to intersects
+func (l *Line) Raycast(raycaster *Raycaster, intersects []*Intersection) []*Intersection {
+
+	return raycastLine(l, l, l.ForEachSegment, raycaster, intersects)
+
+}
+
+// LineLoop is a line that joins its last vertex back to its first
+type LineLoop struct {
+	*Line
+}
+
+// NewLineLoop creates a line loop from the given geometry and material
+func NewLineLoop(geometry *core.BufferGeometry, material Material) *LineLoop {
+
+	return &LineLoop{NewLine(geometry, material)}
+
+}
+
+// ForEachSegment calls fn with the vertex indices of each segment
+// in the draw range of this loop, including the closing segment
+func (l *LineLoop) ForEachSegment(fn func(a, b int)) {
+
+	forEachSegment(l.Geometry, 1, true, fn)
+
+}
+
+// Raycast appends the segments of this loop that pass within
+// the line threshold of raycaster to intersects
+func (l *LineLoop) Raycast(raycaster *Raycaster, intersects []*Intersection) []*Intersection {
+
+	return raycastLine(l, l.Line, l.ForEachSegment, raycaster, intersects)
+
+}
+
+// LineSegments draws a separate line between each pair of vertices
+type LineSegments struct {
+	*Line
+}
+
+// NewLineSegments creates line segments from the given geometry and material
+func NewLineSegments(geometry *core.BufferGeometry, material Material) *LineSegments {
+
+	return &LineSegments{NewLine(geometry, material)}
+
+}
+
+// ForEachSegment calls fn with the vertex indices of each segment
+// in the draw range of these line segments
+func (l *LineSegments) ForEachSegment(fn func(a, b int)) {
+
+	forEachSegment(l.Geometry, 2, false, fn)
+
+}
+
+// Raycast appends the segments that pass within the
+// line threshold of raycaster to intersects
+func (l *LineSegments) Raycast(raycaster *Raycaster, intersects []*Intersection) []*Intersection {
+
+	return raycastLine(l, l.Line, l.ForEachSegment, raycaster, intersects)
+
+}
+
+// raycastLine tests the segments of line given by forEach against
+// the ray of raycaster, reporting hits against node
+func raycastLine(node Node, line *Line, forEach func(func(a, b int)), raycaster *Raycaster, intersects []*Intersection) []*Intersection {
+
+	threshold := raycaster.LineThreshold
+	thresholdSq := threshold * threshold
+
+	// Checking boundingSphere distance to ray
+
+	sphere := line.GetBoundingSphere().Clone().ApplyMatrix4(line.MatrixWorld)
+	sphere.Radius += threshold
+
+	if !raycaster.Ray.IntersectsSphere(sphere) {
+		return intersects
+	}
+
+	// objects flattened to a plane or a point cannot be intersected
+	ray, err := localRay(raycaster, line.Object)
+	if err != nil {
+		return intersects
+	}
+	position := line.Geometry.GetAttribute("position")
+
+	vStart := math3.NewVector3()
+	vEnd := math3.NewVector3()
+
+	forEach(func(a, b int) {
+
+		position.GetVector3(a, vStart)
+		position.GetVector3(b, vEnd)
+
+		interRay := math3.NewVector3()
+		interSegment := math3.NewVector3()
+
+		distSq := ray.DistanceSqToSegment(vStart, vEnd, interRay, interSegment)
+
+		if distSq > thresholdSq {
+			return
+		}
+
+		// Move back to world space for distance calculation
+		interRay.ApplyMatrix4(line.MatrixWorld)
+
+		distance := raycaster.Ray.Origin.DistanceTo(interRay)
+
+		if !raycaster.inRange(distance) {
+			return
+		}
+
+		intersects = append(intersects, &Intersection{
+			Distance: distance,
+			// What do we want? intersection point on the ray or on the segment??
+			// point: raycaster.ray.at( distance ),
+			Point:  interSegment.ApplyMatrix4(line.MatrixWorld),
+			Index:  a,
+			Object: node,
+		})
+
+	})
+
+	return intersects
+
+}
+
+func forEachSegment(geometry *core.BufferGeometry, step int, closed bool, fn func(a, b int)) {
+
+	index := geometry.Index
+	position := geometry.GetAttribute("position")
+
+	if position == nil {
+		return
+	}
+
+	count := position.Count()
+	if index != nil {
+		count = index.Count()
+	}
+
+	start, end := drawRange(geometry.DrawRange, count)
+
+	vertex := func(i int) int {
+
+		if index != nil {
+			return int(index.GetX(i))
+		}
+
+		return i
+
+	}
+
+	for i := start; i+1 < end; i += step {
+
+		fn(vertex(i), vertex(i+1))
+
+	}
+
+	if closed && end-start > 2 {
+
+		fn(vertex(end-1), vertex(start))
+
+	}
+
+}
@@ -0,0 +1,318 @@
+package objects
+
+import (
+	"github.com/golang/glog"
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/materials"
+	"github.com/rydrman/three.go/math3"
+)
+
+// Material describes the appearance of a renderable object,
+// it is implemented by all of the types in the materials package
+type Material interface {
+	GetMaterial() *materials.Material
+}
+
+// Mesh is an object made of triangles
+type Mesh struct {
+	*Object
+
+	Geometry *core.BufferGeometry
+	Material Material
+
+	// DrawMode defines how the vertices of the geometry form triangles,
+	// one of the three.*DrawMode constants
+	DrawMode int
+
+	// MorphTargetInfluences holds the weight of each morph target of the
+	// geometry and MorphTargetDictionary maps target names to indices
+	MorphTargetInfluences []float64
+	MorphTargetDictionary map[string]int
+}
+
+// NewMesh creates a mesh from the given geometry and material,
+// a new empty geometry is used if geometry is nil
+func NewMesh(geometry *core.BufferGeometry, material Material) *Mesh {
+
+	if nil == geometry {
+		geometry = core.NewBufferGeometry()
+	}
+
+	return &Mesh{
+		Object: NewObject(),
+
+		Geometry: geometry,
+		Material: material,
+
+		DrawMode: three.TrianglesDrawMode,
+	}
+
+}
+
+// UpdateMorphTargets resizes the morph target influences of this mesh to
+// match the number of morph targets of its geometry, keeping existing
+// weights and naming targets after their attributes where possible
+func (m *Mesh) UpdateMorphTargets() {
+
+	count := 0
+	var targets []*core.BufferAttribute
+
+	for _, morphs := range m.Geometry.MorphAttributes {
+		if len(morphs) > count {
+			count = len(morphs)
+			targets = morphs
+		}
+	}
+
+	influences := make([]float64, count)
+	copy(influences, m.MorphTargetInfluences)
+	m.MorphTargetInfluences = influences
+
+	if m.MorphTargetDictionary == nil {
+		m.MorphTargetDictionary = make(map[string]int)
+	}
+
+	for i, target := range targets {
+		if target.Name != "" {
+			m.MorphTargetDictionary[target.Name] = i
+		}
+	}
+
+}
+
+// SetDrawMode sets the way that the vertices of this mesh form triangles
+func (m *Mesh) SetDrawMode(value int) {
+
+	m.DrawMode = value
+
+}
+
+// GetBoundingSphere returns the bounding sphere of the geometry
+// of this mesh, computing it if needed
+func (m *Mesh) GetBoundingSphere() *math3.Sphere {
+
+	return boundingSphere(m.Geometry)
+
+}
+
+// Raycast appends the triangles of this mesh that are hit by the ray of
+// raycaster to intersects, honoring the side of the material
+func (m *Mesh) Raycast(raycaster *Raycaster, intersects []*Intersection) []*Intersection {
+
+	if m.Material == nil {
+		return intersects
+	}
+
+	material := m.Material.GetMaterial()
+
+	// Checking boundingSphere distance to ray
+
+	sphere := m.GetBoundingSphere().Clone().ApplyMatrix4(m.MatrixWorld)
+
+	if !raycaster.Ray.IntersectsSphere(sphere) {
+		return intersects
+	}
+
+	// objects flattened to a plane or a point cannot be intersected
+	ray, err := localRay(raycaster, m.Object)
+	if err != nil {
+		return intersects
+	}
+
+	// Check boundingBox before continuing
+
+	if m.Geometry.BoundingBox != nil && !ray.IntersectsBox(m.Geometry.BoundingBox) {
+		return intersects
+	}
+
+	position := m.Geometry.GetAttribute("position")
+	uv := m.Geometry.GetAttribute("uv")
+
+	vA := math3.NewVector3()
+	vB := math3.NewVector3()
+	vC := math3.NewVector3()
+	faceIndex := 0
+
+	m.ForEachTriangle(func(a, b, c int) {
+
+		face := faceIndex
+		faceIndex++
+
+		position.GetVector3(a, vA)
+		position.GetVector3(b, vB)
+		position.GetVector3(c, vC)
+
+		var point *math3.Vector3
+
+		if material.Side == three.BackSide {
+			point = ray.IntersectTriangle(vC, vB, vA, true, nil)
+		} else {
+			point = ray.IntersectTriangle(vA, vB, vC, material.Side != three.DoubleSide, nil)
+		}
+
+		if point == nil {
+			return
+		}
+
+		barycoord := math3.TriangleBarycoordFromPoint(point, vA, vB, vC, nil)
+
+		point.ApplyMatrix4(m.MatrixWorld)
+		distance := raycaster.Ray.Origin.DistanceTo(point)
+
+		if !raycaster.inRange(distance) {
+			return
+		}
+
+		intersection := &Intersection{
+			Distance: distance,
+			Point:    point,
+			Face: &Face{
+				A:      a,
+				B:      b,
+				C:      c,
+				Normal: math3.TriangleNormal(vA, vB, vC, nil),
+			},
+			FaceIndex: face,
+			Object:    m,
+		}
+
+		if uv != nil {
+
+			intersection.UV = math3.NewVector2().
+				AddScaledVector(math3.NewVector2().Set(uv.GetX(a), uv.GetY(a)), barycoord.X).
+				AddScaledVector(math3.NewVector2().Set(uv.GetX(b), uv.GetY(b)), barycoord.Y).
+				AddScaledVector(math3.NewVector2().Set(uv.GetX(c), uv.GetY(c)), barycoord.Z)
+
+		}
+
+		intersects = append(intersects, intersection)
+
+	})
+
+	return intersects
+
+}
+
+// ForEachTriangle calls fn with the vertex indices of each triangle
+// in the draw range of this mesh, taking the geometry index and draw
+// mode into account. Triangles of a strip are given with a consistent
+// winding.
+func (m *Mesh) ForEachTriangle(fn func(a, b, c int)) {
+
+	m.forEachTriangle(nil, fn)
+
+}
+
+// ForEachGroupTriangle calls fn with the vertex indices of each triangle
+// in the given group of the geometry that is also in the draw range
+func (m *Mesh) ForEachGroupTriangle(group core.Group, fn func(a, b, c int)) {
+
+	m.forEachTriangle(&group, fn)
+
+}
+
+func (m *Mesh) forEachTriangle(group *core.Group, fn func(a, b, c int)) {
+
+	index := m.Geometry.Index
+	position := m.Geometry.GetAttribute("position")
+
+	if position == nil {
+		return
+	}
+
+	count := position.Count()
+	if index != nil {
+		count = index.Count()
+	}
+
+	start, end := drawRange(m.Geometry.DrawRange, count)
+
+	if group != nil {
+
+		if group.Start > start {
+			start = group.Start
+		}
+		if group.Start+group.Count < end {
+			end = group.Start + group.Count
+		}
+
+	}
+
+	vertex := func(i int) int {
+
+		if index != nil {
+			return int(index.GetX(i))
+		}
+
+		return i
+
+	}
+
+	switch m.DrawMode {
+
+	case three.TrianglesDrawMode:
+
+		for i := start; i+2 < end; i += 3 {
+
+			fn(vertex(i), vertex(i+1), vertex(i+2))
+
+		}
+
+	case three.TriangleStripDrawMode:
+
+		for i := start; i+2 < end; i++ {
+
+			if (i-start)%2 == 0 {
+				fn(vertex(i), vertex(i+1), vertex(i+2))
+			} else {
+				fn(vertex(i+1), vertex(i), vertex(i+2))
+			}
+
+		}
+
+	case three.TriangleFanDrawMode:
+
+		for i := start + 1; i+1 < end; i++ {
+
+			fn(vertex(start), vertex(i), vertex(i+1))
+
+		}
+
+	default:
+
+		glog.Warningf("objects.Mesh: unknown draw mode: %d", m.DrawMode)
+
+	}
+
+}
+
+// boundingSphere returns the bounding sphere of geometry,
+// computing it the first time that it is needed
+func boundingSphere(geometry *core.BufferGeometry) *math3.Sphere {
+
+	if geometry.BoundingSphere == nil {
+		geometry.ComputeBoundingSphere()
+	}
+
+	return geometry.BoundingSphere
+
+}
+
+// drawRange returns the first and one past the last element that
+// should be drawn out of the given count
+func drawRange(r core.DrawRange, count int) (start, end int) {
+
+	start = r.Start
+	if start < 0 {
+		start = 0
+	}
+
+	end = count
+	if r.Count >= 0 && start+r.Count < end {
+		end = start + r.Count
+	}
+
+	return start, end
+
+}
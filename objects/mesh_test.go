@@ -0,0 +1,131 @@
+package objects_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/objects"
+)
+
+func newTestGeometry(vertices int) *core.BufferGeometry {
+	g := core.NewBufferGeometry()
+	g.AddAttribute("position", core.NewFloat32BufferAttribute(make([]float32, vertices*3), 3))
+	return g
+}
+
+func collectTriangles(m *objects.Mesh) [][3]int {
+	var triangles [][3]int
+	m.ForEachTriangle(func(a, b, c int) {
+		triangles = append(triangles, [3]int{a, b, c})
+	})
+	return triangles
+}
+
+func collectSegments(forEach func(func(a, b int))) [][2]int {
+	var segments [][2]int
+	forEach(func(a, b int) {
+		segments = append(segments, [2]int{a, b})
+	})
+	return segments
+}
+
+func TestMesh_DrawModes(t *testing.T) {
+	m := objects.NewMesh(newTestGeometry(5), nil)
+
+	expected := [][3]int{{0, 1, 2}}
+	if got := collectTriangles(m); !reflect.DeepEqual(got, expected) {
+		t.Errorf("triangles: expected %v, got %v", expected, got)
+	}
+
+	m.SetDrawMode(three.TriangleStripDrawMode)
+	expected = [][3]int{{0, 1, 2}, {2, 1, 3}, {2, 3, 4}}
+	if got := collectTriangles(m); !reflect.DeepEqual(got, expected) {
+		t.Errorf("strip: expected %v, got %v", expected, got)
+	}
+
+	m.SetDrawMode(three.TriangleFanDrawMode)
+	expected = [][3]int{{0, 1, 2}, {0, 2, 3}, {0, 3, 4}}
+	if got := collectTriangles(m); !reflect.DeepEqual(got, expected) {
+		t.Errorf("fan: expected %v, got %v", expected, got)
+	}
+}
+
+func TestMesh_IndexAndDrawRange(t *testing.T) {
+	g := newTestGeometry(4)
+	g.SetIndex(core.NewIndexAttribute([]int{0, 1, 2, 2, 1, 3, 3, 1, 0}))
+	m := objects.NewMesh(g, nil)
+
+	expected := [][3]int{{0, 1, 2}, {2, 1, 3}, {3, 1, 0}}
+	if got := collectTriangles(m); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	g.SetDrawRange(3, 3)
+	expected = [][3]int{{2, 1, 3}}
+	if got := collectTriangles(m); !reflect.DeepEqual(got, expected) {
+		t.Errorf("draw range: expected %v, got %v", expected, got)
+	}
+}
+
+func TestMesh_SceneGraph(t *testing.T) {
+	parent := objects.NewObject()
+	m := objects.NewMesh(nil, nil)
+	parent.Add(m)
+
+	if m.Geometry == nil {
+		t.Error("mesh should be given an empty geometry")
+	}
+	if m.GetParent() != objects.Node(parent) || len(parent.GetChildren()) != 1 {
+		t.Error("mesh should be added to the scene graph")
+	}
+}
+
+func TestLine_Segments(t *testing.T) {
+	g := newTestGeometry(4)
+
+	line := objects.NewLine(g, nil)
+	expected := [][2]int{{0, 1}, {1, 2}, {2, 3}}
+	if got := collectSegments(line.ForEachSegment); !reflect.DeepEqual(got, expected) {
+		t.Errorf("line: expected %v, got %v", expected, got)
+	}
+
+	loop := objects.NewLineLoop(g, nil)
+	expected = [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 0}}
+	if got := collectSegments(loop.ForEachSegment); !reflect.DeepEqual(got, expected) {
+		t.Errorf("loop: expected %v, got %v", expected, got)
+	}
+
+	segments := objects.NewLineSegments(g, nil)
+	expected = [][2]int{{0, 1}, {2, 3}}
+	if got := collectSegments(segments.ForEachSegment); !reflect.DeepEqual(got, expected) {
+		t.Errorf("segments: expected %v, got %v", expected, got)
+	}
+}
+
+func TestMesh_GetBoundingSphere(t *testing.T) {
+	g := core.NewBufferGeometry()
+	g.AddAttribute("position", core.NewFloat32BufferAttribute([]float32{
+		-1, 0, 0,
+		1, 0, 0,
+	}, 3))
+
+	m := objects.NewMesh(g, nil)
+	if !m.FrustumCulled {
+		t.Error("mesh should be frustum culled by default")
+	}
+
+	sphere := m.GetBoundingSphere()
+	if g.BoundingSphere != sphere {
+		t.Error("bounding sphere should be computed on the geometry")
+	}
+	if sphere.Radius != 1 || sphere.Center.X != 0 {
+		t.Errorf("unexpected bounding sphere %s", sphere)
+	}
+
+	var _ math3.Bounded = objects.NewLineSegments(g, nil)
+	var _ math3.Bounded = objects.NewPoints(g, nil)
+	var _ math3.Bounded = objects.NewSprite(nil)
+}
@@ -1,13 +1,61 @@
 package objects
 
+import "github.com/rydrman/three.go/math3"
+
 // Node represents an item that can exist in an object hierearchy.
 // A node is the most basic of objects in the marshmallow library
 type Node interface {
+	math3.Positioner
+
 	Add(Node)
 	Remove(Node) bool
 
 	GetParent() Node
 	GetChildren() []Node
 
+	// UpdateMatrixWorld updates the world matrix of this node and its
+	// descendants, force recomputes it even if nothing has changed
+	UpdateMatrixWorld(force bool)
+
 	setParent(Node)
+	getObject() *Object
+}
+
+// Traverse calls fn for the given node and all of its descendants,
+// parents are always visited before their children
+func Traverse(n Node, fn func(Node)) {
+
+	fn(n)
+
+	for _, child := range n.GetChildren() {
+
+		Traverse(child, fn)
+
+	}
+
+}
+
+// TraverseVisible is like Traverse, but skips nodes that are not
+// visible along with all of their descendants
+func TraverseVisible(n Node, fn func(Node)) {
+
+	if !n.getObject().Visible {
+		return
+	}
+
+	fn(n)
+
+	for _, child := range n.GetChildren() {
+
+		TraverseVisible(child, fn)
+
+	}
+
+}
+
+// ObjectOf returns the base object of any node
+func ObjectOf(n Node) *Object {
+
+	return n.getObject()
+
 }
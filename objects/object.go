@@ -1,20 +1,103 @@
 package objects
 
-import "github.com/golang/glog"
+import (
+	"github.com/golang/glog"
+	"github.com/rydrman/three.go/math3"
+)
+
+var (
+	// DefaultUp is the up direction given to new objects
+	DefaultUp = &math3.Vector3{X: 0, Y: 1, Z: 0}
+	// DefaultMatrixAutoUpdate is the MatrixAutoUpdate value given to new objects
+	DefaultMatrixAutoUpdate = true
+)
 
 // Object acts as a common base to many more
 // specific types in the marshmallow library
 type Object struct {
+	UUID string
+	Name string
+
+	// Up is the direction used as up when orienting the object with LookAt
+	Up *math3.Vector3
+
+	// Position, Rotation, Quaternion and Scale define the local transform
+	// of this object. Rotation and Quaternion are kept in sync with each
+	// other, so should be modified in place rather than replaced
+	Position   *math3.Vector3
+	Rotation   *math3.Euler
+	Quaternion *math3.Quaternion
+	Scale      *math3.Vector3
+
+	// Matrix is the local transform of this object relative to its parent
+	Matrix *math3.Matrix4
+	// MatrixWorld is the global transform of this object
+	MatrixWorld *math3.Matrix4
+
+	// MatrixAutoUpdate recomputes Matrix from the position, rotation and
+	// scale of this object each time that the world matrix is updated
+	MatrixAutoUpdate bool
+	// MatrixWorldNeedsUpdate causes the world matrix to be recomputed
+	// in the next call to UpdateMatrixWorld
+	MatrixWorldNeedsUpdate bool
+
+	Visible bool
+	// FrustumCulled allows renderers to skip this object when its
+	// bounds fall entirely outside of the view of the camera
+	FrustumCulled bool
+
+	// CastShadow renders this object into the shadow maps of lights and
+	// ReceiveShadow darkens it where those shadows fall
+	CastShadow    bool
+	ReceiveShadow bool
+
 	parent   Node
 	children []Node
 }
 
 // NewObject creates a new Object instace with default values
 func NewObject() *Object {
-	return &Object{}
+
+	o := &Object{
+		UUID: math3.GenerateUUID(),
+
+		Up: DefaultUp.Clone(),
+
+		Position:   math3.NewVector3(),
+		Rotation:   math3.NewEuler(),
+		Quaternion: math3.NewQuaternion(),
+		Scale:      math3.NewVector3().Set(1, 1, 1),
+
+		Matrix:      math3.NewMatrix4(),
+		MatrixWorld: math3.NewMatrix4(),
+
+		MatrixAutoUpdate: DefaultMatrixAutoUpdate,
+
+		Visible:       true,
+		FrustumCulled: true,
+	}
+
+	o.Rotation.OnChange(o.onRotationChange)
+	o.Quaternion.OnChange(o.onQuaternionChange)
+
+	return o
+
+}
+
+func (o *Object) onRotationChange() {
+
+	o.Quaternion.SetFromEuler(o.Rotation, false)
+
 }
 
-// Add adds the given node as a child of this object
+func (o *Object) onQuaternionChange() {
+
+	o.Rotation.SetFromQuaternion(o.Quaternion, math3.CurrentOrder, false)
+
+}
+
+// Add adds the given node as a child of this object,
+// removing it from its current parent if it has one
 func (o *Object) Add(n Node) {
 
 	if n == Node(o) {
@@ -22,6 +105,12 @@ func (o *Object) Add(n Node) {
 		return
 	}
 
+	if parent := n.GetParent(); parent != nil {
+
+		parent.Remove(n)
+
+	}
+
 	o.children = append(o.children, n)
 	n.setParent(o)
 
@@ -66,12 +155,289 @@ func (o *Object) GetChildren() []Node {
 // through the Add and Remove functions
 func (o *Object) setParent(n Node) {
 
-	if o.parent != nil {
+	o.parent = n
+
+}
+
+// getObject returns the base object of this node
+func (o *Object) getObject() *Object {
+
+	return o
+
+}
+
+// GetObject returns the base object, allowing it to be
+// accessed from any of the specific object types
+func (o *Object) GetObject() *Object {
+
+	return o
+
+}
+
+// GetMatrixWorld returns the global transform of this object
+func (o *Object) GetMatrixWorld() *math3.Matrix4 {
+
+	return o.MatrixWorld
+
+}
+
+// ApplyMatrix multiplies the given matrix into the local transform
+// of this object, updating its position, rotation and scale
+func (o *Object) ApplyMatrix(matrix *math3.Matrix4) {
+
+	o.Matrix.MultiplyMatrices(matrix, o.Matrix)
+
+	o.Matrix.Decompose(o.Position, o.Quaternion, o.Scale)
+
+}
+
+// SetRotationFromAxisAngle sets the rotation of this object to a rotation
+// of angle radians around the given normalized axis
+func (o *Object) SetRotationFromAxisAngle(axis *math3.Vector3, angle float64) {
+
+	o.Quaternion.SetFromAxisAngle(axis, angle)
+
+}
+
+// SetRotationFromEuler sets the rotation of this object from the given euler
+func (o *Object) SetRotationFromEuler(euler *math3.Euler) {
+
+	o.Quaternion.SetFromEuler(euler, true)
+
+}
+
+// SetRotationFromMatrix sets the rotation of this object from the given
+// matrix, whose upper 3x3 is assumed to be a pure, unscaled rotation
+func (o *Object) SetRotationFromMatrix(m *math3.Matrix4) {
+
+	o.Quaternion.SetFromRotationMatrix(m)
+
+}
+
+// SetRotationFromQuaternion sets the rotation of this object
+// from the given normalized quaternion
+func (o *Object) SetRotationFromQuaternion(q *math3.Quaternion) {
+
+	o.Quaternion.Copy(q)
+
+}
+
+// RotateOnAxis rotates this object by angle radians around
+// the given normalized axis in object space
+func (o *Object) RotateOnAxis(axis *math3.Vector3, angle float64) *Object {
+
+	q1 := math3.NewQuaternion().SetFromAxisAngle(axis, angle)
+
+	o.Quaternion.Multiply(q1)
+
+	return o
+
+}
+
+// RotateX rotates this object around its local x axis
+func (o *Object) RotateX(angle float64) *Object {
+
+	return o.RotateOnAxis(&math3.Vector3{X: 1, Y: 0, Z: 0}, angle)
+
+}
+
+// RotateY rotates this object around its local y axis
+func (o *Object) RotateY(angle float64) *Object {
+
+	return o.RotateOnAxis(&math3.Vector3{X: 0, Y: 1, Z: 0}, angle)
+
+}
+
+// RotateZ rotates this object around its local z axis
+func (o *Object) RotateZ(angle float64) *Object {
+
+	return o.RotateOnAxis(&math3.Vector3{X: 0, Y: 0, Z: 1}, angle)
+
+}
+
+// TranslateOnAxis moves this object by distance along
+// the given normalized axis in object space
+func (o *Object) TranslateOnAxis(axis *math3.Vector3, distance float64) *Object {
+
+	v1 := math3.NewVector3().Copy(axis).ApplyQuaternion(o.Quaternion)
 
-		o.parent.Remove(o)
+	o.Position.Add(v1.MultiplyScalar(distance))
 
+	return o
+
+}
+
+// TranslateX moves this object along its local x axis
+func (o *Object) TranslateX(distance float64) *Object {
+
+	return o.TranslateOnAxis(&math3.Vector3{X: 1, Y: 0, Z: 0}, distance)
+
+}
+
+// TranslateY moves this object along its local y axis
+func (o *Object) TranslateY(distance float64) *Object {
+
+	return o.TranslateOnAxis(&math3.Vector3{X: 0, Y: 1, Z: 0}, distance)
+
+}
+
+// TranslateZ moves this object along its local z axis
+func (o *Object) TranslateZ(distance float64) *Object {
+
+	return o.TranslateOnAxis(&math3.Vector3{X: 0, Y: 0, Z: 1}, distance)
+
+}
+
+// LocalToWorld converts the given vector from the local
+// space of this object into world space
+func (o *Object) LocalToWorld(vector *math3.Vector3) *math3.Vector3 {
+
+	return vector.ApplyMatrix4(o.MatrixWorld)
+
+}
+
+// WorldToLocal converts the given vector from world space into the local
+// space of this object. ErrSingularMatrix is returned, with the vector
+// mapped through the pseudo-inverse, if the world matrix of this object
+// cannot be inverted.
+func (o *Object) WorldToLocal(vector *math3.Vector3) (*math3.Vector3, error) {
+
+	m1 := math3.NewMatrix4()
+	err := m1.InverseOf(o.MatrixWorld)
+
+	return vector.ApplyMatrix4(m1), err
+
+}
+
+// LookAt rotates this object so that its positive z axis
+// faces the given point in local space
+func (o *Object) LookAt(vector *math3.Vector3) {
+
+	m1 := math3.NewMatrix4().LookAt(vector, o.Position, o.Up)
+
+	o.Quaternion.SetFromRotationMatrix(m1)
+
+}
+
+// GetWorldPosition returns the position of this object
+// in world space, the world matrix is not updated first
+func (o *Object) GetWorldPosition(target *math3.Vector3) *math3.Vector3 {
+
+	if target == nil {
+		target = math3.NewVector3()
 	}
 
-	o.parent = n
+	return target.SetFromMatrixPosition(o.MatrixWorld)
+
+}
+
+// GetWorldQuaternion returns the rotation of this object
+// in world space, the world matrix is not updated first
+func (o *Object) GetWorldQuaternion(target *math3.Quaternion) *math3.Quaternion {
+
+	if target == nil {
+		target = math3.NewQuaternion()
+	}
+
+	o.MatrixWorld.Decompose(math3.NewVector3(), target, math3.NewVector3())
+
+	return target
+
+}
+
+// GetWorldScale returns the scale of this object
+// in world space, the world matrix is not updated first
+func (o *Object) GetWorldScale(target *math3.Vector3) *math3.Vector3 {
+
+	if target == nil {
+		target = math3.NewVector3()
+	}
+
+	o.MatrixWorld.Decompose(math3.NewVector3(), math3.NewQuaternion(), target)
+
+	return target
+
+}
+
+// GetWorldDirection returns the direction of the positive z
+// axis of this object in world space
+func (o *Object) GetWorldDirection(target *math3.Vector3) *math3.Vector3 {
+
+	if target == nil {
+		target = math3.NewVector3()
+	}
+
+	return target.Set(0, 0, 1).ApplyQuaternion(o.GetWorldQuaternion(nil))
+
+}
+
+// UpdateMatrix recomputes the local matrix of this object
+// from its position, rotation and scale
+func (o *Object) UpdateMatrix() {
+
+	o.Matrix.Compose(o.Position, o.Quaternion, o.Scale)
+
+	o.MatrixWorldNeedsUpdate = true
+
+}
+
+// UpdateMatrixWorld updates the world matrix of this object and its
+// descendants, force recomputes it even if nothing has changed
+func (o *Object) UpdateMatrixWorld(force bool) {
+
+	if o.MatrixAutoUpdate {
+		o.UpdateMatrix()
+	}
+
+	if o.MatrixWorldNeedsUpdate || force {
+
+		if o.parent == nil {
+
+			o.MatrixWorld.Copy(o.Matrix)
+
+		} else {
+
+			o.MatrixWorld.MultiplyMatrices(o.parent.GetMatrixWorld(), o.Matrix)
+
+		}
+
+		o.MatrixWorldNeedsUpdate = false
+
+		force = true
+
+	}
+
+	for _, child := range o.children {
+
+		child.UpdateMatrixWorld(force)
+
+	}
+
+}
+
+// Copy copies the name, transform and flags of the given object into
+// this one, children and the parent of this object are unchanged
+func (o *Object) Copy(src *Object) *Object {
+
+	o.Name = src.Name
+
+	o.Up.Copy(src.Up)
+
+	o.Position.Copy(src.Position)
+	o.Quaternion.Copy(src.Quaternion)
+	o.Scale.Copy(src.Scale)
+
+	o.Matrix.Copy(src.Matrix)
+	o.MatrixWorld.Copy(src.MatrixWorld)
+
+	o.MatrixAutoUpdate = src.MatrixAutoUpdate
+	o.MatrixWorldNeedsUpdate = src.MatrixWorldNeedsUpdate
+
+	o.Visible = src.Visible
+	o.FrustumCulled = src.FrustumCulled
+	o.CastShadow = src.CastShadow
+	o.ReceiveShadow = src.ReceiveShadow
+
+	return o
 
 }
@@ -0,0 +1,142 @@
+package objects_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/objects"
+)
+
+func vectorNear(a, b *math3.Vector3) bool {
+	tolerance := 0.0001
+	return math.Abs(a.X-b.X) < tolerance &&
+		math.Abs(a.Y-b.Y) < tolerance &&
+		math.Abs(a.Z-b.Z) < tolerance
+}
+
+func TestObject_RotationSync(t *testing.T) {
+	o := objects.NewObject()
+
+	o.Rotation.Set(0, math.Pi/2, 0, math3.CurrentOrder)
+	expected := math3.NewQuaternion().SetFromEuler(o.Rotation, false)
+	if !o.Quaternion.Equals(expected) {
+		t.Errorf("quaternion should follow rotation, got %s", o.Quaternion)
+	}
+
+	o.Quaternion.SetFromAxisAngle(math3.NewVector3().Set(1, 0, 0), math.Pi/4)
+	if math.Abs(o.Rotation.GetX()-math.Pi/4) > 0.0001 || o.Rotation.GetY() != 0 {
+		t.Errorf("rotation should follow quaternion, got %f, %f", o.Rotation.GetX(), o.Rotation.GetY())
+	}
+}
+
+func TestObject_UpdateMatrixWorld(t *testing.T) {
+	parent := objects.NewObject()
+	child := objects.NewObject()
+	parent.Add(child)
+
+	parent.Position.Set(1, 0, 0)
+	parent.Scale.Set(2, 2, 2)
+	child.Position.Set(0, 1, 0)
+
+	parent.UpdateMatrixWorld(false)
+
+	pos := child.GetWorldPosition(nil)
+	if !vectorNear(pos, math3.NewVector3().Set(1, 2, 0)) {
+		t.Errorf("child world position should include parent transform, got %s", pos)
+	}
+
+	scale := child.GetWorldScale(nil)
+	if !vectorNear(scale, math3.NewVector3().Set(2, 2, 2)) {
+		t.Errorf("child world scale should include parent scale, got %s", scale)
+	}
+
+	child.MatrixAutoUpdate = false
+	child.Position.Set(0, 5, 0)
+	parent.UpdateMatrixWorld(false)
+	if !vectorNear(child.GetWorldPosition(nil), pos) {
+		t.Error("matrix should not be recomputed when auto update is disabled")
+	}
+}
+
+func TestObject_LocalToWorld(t *testing.T) {
+	o := objects.NewObject()
+	o.Position.Set(2, 3, 4)
+	o.RotateY(math.Pi / 2)
+	o.UpdateMatrixWorld(false)
+
+	local := math3.NewVector3().Set(1, 0, 0)
+	world := o.LocalToWorld(local.Clone())
+	if !vectorNear(world, math3.NewVector3().Set(2, 3, 3)) {
+		t.Errorf("unexpected world position %s", world)
+	}
+
+	back, err := o.WorldToLocal(world)
+	if err != nil || !vectorNear(back, local) {
+		t.Errorf("world to local should invert local to world, got %s", back)
+	}
+
+	o.Scale.Set(1e-6, 1e-6, 1e-6)
+	o.UpdateMatrixWorld(false)
+	if _, err := o.WorldToLocal(world); err != nil {
+		t.Errorf("expected a small scale to be invertible, got %v", err)
+	}
+
+	o.Scale.Set(1, 0, 1)
+	o.UpdateMatrixWorld(false)
+	if _, err := o.WorldToLocal(world); err != math3.ErrSingularMatrix {
+		t.Errorf("expected a singular matrix error, got %v", err)
+	}
+}
+
+func TestObject_TranslateOnAxis(t *testing.T) {
+	o := objects.NewObject()
+	o.RotateZ(math.Pi / 2)
+	o.TranslateX(2)
+
+	if !vectorNear(o.Position, math3.NewVector3().Set(0, 2, 0)) {
+		t.Errorf("translation should be along the rotated axis, got %s", o.Position)
+	}
+}
+
+func TestObject_LookAt(t *testing.T) {
+	o := objects.NewObject()
+	o.Position.Set(1, 0, 0)
+	o.LookAt(math3.NewVector3().Set(1, 0, 5))
+	o.UpdateMatrixWorld(false)
+
+	dir := o.GetWorldDirection(nil)
+	if !vectorNear(dir, math3.NewVector3().Set(0, 0, 1)) {
+		t.Errorf("object should face the target, got %s", dir)
+	}
+
+	o.LookAt(math3.NewVector3().Set(-1, 0, 0))
+	o.UpdateMatrixWorld(false)
+
+	dir = o.GetWorldDirection(nil)
+	if !vectorNear(dir, math3.NewVector3().Set(-1, 0, 0)) {
+		t.Errorf("object should face the target, got %s", dir)
+	}
+}
+
+func TestObject_Reparent(t *testing.T) {
+	a := objects.NewObject()
+	b := objects.NewObject()
+	child := objects.NewObject()
+
+	a.Add(child)
+	b.Add(child)
+
+	if len(a.GetChildren()) != 0 {
+		t.Error("child should be removed from its previous parent")
+	}
+	if child.GetParent() != objects.Node(b) || len(b.GetChildren()) != 1 {
+		t.Error("child should be added to its new parent")
+	}
+
+	count := 0
+	objects.Traverse(b, func(objects.Node) { count++ })
+	if count != 2 {
+		t.Errorf("expected to visit 2 nodes, visited %d", count)
+	}
+}
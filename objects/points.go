@@ -0,0 +1,118 @@
+package objects
+
+import (
+	"math"
+
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/math3"
+)
+
+// Points draws a single point at each vertex of its geometry
+type Points struct {
+	*Object
+
+	Geometry *core.BufferGeometry
+	Material Material
+}
+
+// NewPoints creates points from the given geometry and material,
+// a new empty geometry is used if geometry is nil
+func NewPoints(geometry *core.BufferGeometry, material Material) *Points {
+
+	if nil == geometry {
+		geometry = core.NewBufferGeometry()
+	}
+
+	return &Points{
+		Object: NewObject(),
+
+		Geometry: geometry,
+		Material: material,
+	}
+
+}
+
+// GetBoundingSphere returns the bounding sphere of the geometry
+// of these points, computing it if needed
+func (p *Points) GetBoundingSphere() *math3.Sphere {
+
+	return boundingSphere(p.Geometry)
+
+}
+
+// Raycast appends the vertices of these points that lie within
+// the points threshold of the ray of raycaster to intersects
+func (p *Points) Raycast(raycaster *Raycaster, intersects []*Intersection) []*Intersection {
+
+	threshold := raycaster.PointsThreshold
+
+	// Checking boundingSphere distance to ray
+
+	sphere := p.GetBoundingSphere().Clone().ApplyMatrix4(p.MatrixWorld)
+	sphere.Radius += threshold
+
+	if !raycaster.Ray.IntersectsSphere(sphere) {
+		return intersects
+	}
+
+	// objects flattened to a plane or a point cannot be intersected
+	ray, err := localRay(raycaster, p.Object)
+	if err != nil {
+		return intersects
+	}
+
+	localThreshold := threshold / ((p.Scale.X + p.Scale.Y + p.Scale.Z) / 3)
+	localThresholdSq := localThreshold * localThreshold
+
+	index := p.Geometry.Index
+	position := p.Geometry.GetAttribute("position")
+
+	if position == nil {
+		return intersects
+	}
+
+	count := position.Count()
+	if index != nil {
+		count = index.Count()
+	}
+
+	start, end := drawRange(p.Geometry.DrawRange, count)
+	point := math3.NewVector3()
+
+	for i := start; i < end; i++ {
+
+		vertex := i
+		if index != nil {
+			vertex = int(index.GetX(i))
+		}
+
+		position.GetVector3(vertex, point)
+
+		rayPointDistanceSq := ray.DistanceSqToPoint(point)
+
+		if rayPointDistanceSq >= localThresholdSq {
+			continue
+		}
+
+		intersectPoint := ray.ClosestPointToPoint(point, nil)
+		intersectPoint.ApplyMatrix4(p.MatrixWorld)
+
+		distance := raycaster.Ray.Origin.DistanceTo(intersectPoint)
+
+		if !raycaster.inRange(distance) {
+			continue
+		}
+
+		intersects = append(intersects, &Intersection{
+			Distance:      distance,
+			DistanceToRay: math.Sqrt(rayPointDistanceSq),
+			Point:         intersectPoint,
+			Index:         vertex,
+			Object:        p,
+		})
+
+	}
+
+	return intersects
+
+}
@@ -0,0 +1,207 @@
+package objects
+
+import (
+	"math"
+	"sort"
+
+	"github.com/rydrman/three.go/math3"
+)
+
+// Raycastable is implemented by objects that can be hit by a Raycaster
+type Raycastable interface {
+	// Raycast appends the intersections of the ray of raycaster
+	// with this object to intersects, returning the new slice
+	Raycast(raycaster *Raycaster, intersects []*Intersection) []*Intersection
+}
+
+// Face identifies the triangle of a mesh that was hit by a ray
+type Face struct {
+	// A, B and C are the indices of the vertices of the triangle
+	A, B, C int
+	// Normal is the normal of the triangle in object space
+	Normal *math3.Vector3
+}
+
+// Intersection describes a point where a ray hit an object
+type Intersection struct {
+	// Distance is the distance from the origin of the ray to Point
+	Distance float64
+	// DistanceToRay is the distance from the ray to the vertex that
+	// was hit, it is only set for points
+	DistanceToRay float64
+	// Point is the point of intersection in world space
+	Point *math3.Vector3
+
+	// Face and FaceIndex identify the triangle that was hit, Face
+	// is nil for objects other than meshes
+	Face      *Face
+	FaceIndex int
+	// Index is the index of the vertex or the start of the segment
+	// that was hit for points and lines
+	Index int
+	// UV is the texture coordinate at the point of intersection,
+	// it is nil if the object has no uv attribute
+	UV *math3.Vector2
+
+	Object Node
+}
+
+// Raycaster finds the objects in a scene that are hit by a ray,
+// most often for picking objects with the mouse
+type Raycaster struct {
+	Ray *math3.Ray
+
+	// Near and Far limit the distance along the ray in which
+	// intersections are reported
+	Near float64
+	Far  float64
+
+	// LineThreshold is the distance from a line within which
+	// it is considered hit, in the local space of the line
+	LineThreshold float64
+	// PointsThreshold is the distance from a point within
+	// which it is considered hit, in world space
+	PointsThreshold float64
+}
+
+// NewRaycaster creates a raycaster with an empty ray
+// that reports all intersections in front of it
+func NewRaycaster() *Raycaster {
+
+	return &Raycaster{
+		Ray: math3.NewRay(),
+
+		Near: 0,
+		Far:  math.Inf(1),
+
+		LineThreshold:   1,
+		PointsThreshold: 1,
+	}
+
+}
+
+// Set sets the ray of this raycaster, the direction
+// is expected to be normalized
+func (r *Raycaster) Set(origin, direction *math3.Vector3) *Raycaster {
+
+	r.Ray.Set(origin, direction)
+
+	return r
+
+}
+
+// SetFromCamera sets the ray of this raycaster to pass through the given
+// normalized device coordinates, in the range [-1, 1] from the bottom left
+// of the view of camera. Cameras with a perspective projection cast rays
+// from their position, others cast parallel rays along their view direction.
+// ErrSingularMatrix is returned if the projection of camera cannot be inverted.
+func (r *Raycaster) SetFromCamera(coords *math3.Vector2, camera math3.Projector) (*Raycaster, error) {
+
+	projection := camera.GetProjectionMatrix().Elements
+
+	if projection[15] == 0 {
+
+		r.Ray.Origin.SetFromMatrixPosition(camera.GetMatrixWorld())
+		if _, err := r.Ray.Direction.Set(coords.X, coords.Y, 0.5).Unproject(camera); err != nil {
+			return r, err
+		}
+		r.Ray.Direction.Sub(r.Ray.Origin).Normalize()
+
+	} else {
+
+		// the plane of the camera is at z = 0 in view space
+		if _, err := r.Ray.Origin.Set(coords.X, coords.Y, projection[14]).Unproject(camera); err != nil {
+			return r, err
+		}
+		r.Ray.Direction.Set(0, 0, -1).TransformDirection(camera.GetMatrixWorld())
+
+	}
+
+	return r, nil
+
+}
+
+// IntersectObject returns the intersections of the ray with node,
+// and its descendants if recursive is set, sorted nearest first
+func (r *Raycaster) IntersectObject(node Node, recursive bool) []*Intersection {
+
+	intersects := r.intersectObject(node, nil, recursive)
+
+	sortIntersections(intersects)
+
+	return intersects
+
+}
+
+// IntersectObjects returns the intersections of the ray with each of
+// the given nodes, and their descendants if recursive is set, sorted
+// nearest first
+func (r *Raycaster) IntersectObjects(nodes []Node, recursive bool) []*Intersection {
+
+	var intersects []*Intersection
+
+	for _, node := range nodes {
+
+		intersects = r.intersectObject(node, intersects, recursive)
+
+	}
+
+	sortIntersections(intersects)
+
+	return intersects
+
+}
+
+func (r *Raycaster) intersectObject(node Node, intersects []*Intersection, recursive bool) []*Intersection {
+
+	if !node.getObject().Visible {
+		return intersects
+	}
+
+	if raycastable, ok := node.(Raycastable); ok {
+
+		intersects = raycastable.Raycast(r, intersects)
+
+	}
+
+	if recursive {
+
+		for _, child := range node.GetChildren() {
+
+			intersects = r.intersectObject(child, intersects, true)
+
+		}
+
+	}
+
+	return intersects
+
+}
+
+// inRange reports whether distance is within the near and far limits
+func (r *Raycaster) inRange(distance float64) bool {
+
+	return distance >= r.Near && distance <= r.Far
+
+}
+
+func sortIntersections(intersects []*Intersection) {
+
+	sort.SliceStable(intersects, func(i, j int) bool {
+		return intersects[i].Distance < intersects[j].Distance
+	})
+
+}
+
+// localRay returns the ray of raycaster in the local space of object,
+// or an error if the world matrix of object cannot be inverted
+func localRay(raycaster *Raycaster, object *Object) (*math3.Ray, error) {
+
+	inverseMatrix := math3.NewMatrix4()
+	if err := inverseMatrix.InverseOf(object.MatrixWorld); err != nil {
+		return nil, err
+	}
+
+	return raycaster.Ray.Clone().ApplyMatrix4(inverseMatrix), nil
+
+}
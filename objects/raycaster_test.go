@@ -0,0 +1,221 @@
+package objects_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/cameras"
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/geometries"
+	"github.com/rydrman/three.go/materials"
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/objects"
+)
+
+func newTestRaycaster() *objects.Raycaster {
+	return objects.NewRaycaster().Set(
+		math3.NewVector3().Set(0, 0, 10),
+		math3.NewVector3().Set(0, 0, -1),
+	)
+}
+
+func newTestPlaneMesh() *objects.Mesh {
+	return objects.NewMesh(geometries.NewPlaneGeometry(2, 2, 1, 1), materials.NewMeshBasicMaterial())
+}
+
+func TestRaycaster_SetFromCamera(t *testing.T) {
+	r := objects.NewRaycaster()
+
+	perspective := cameras.NewPerspectiveCamera(90, 1, 1, 100)
+	perspective.Position.Set(0, 0, 5)
+	perspective.UpdateMatrixWorld(false)
+
+	r.SetFromCamera(math3.NewVector2(), perspective)
+	if !vectorNear(r.Ray.Origin, math3.NewVector3().Set(0, 0, 5)) {
+		t.Errorf("perspective rays should start at the camera, got %s", r.Ray.Origin)
+	}
+	if !vectorNear(r.Ray.Direction, math3.NewVector3().Set(0, 0, -1)) {
+		t.Errorf("unexpected direction %s", r.Ray.Direction)
+	}
+
+	r.SetFromCamera(math3.NewVector2().Set(1, 0), perspective)
+	expected := math3.NewVector3().Set(1, 0, -1).Normalize()
+	if !vectorNear(r.Ray.Direction, expected) {
+		t.Errorf("expected the edge of a 90 degree view, got %s", r.Ray.Direction)
+	}
+
+	orthographic := cameras.NewOrthographicCamera(-2, 2, 2, -2, 1, 100)
+	orthographic.Position.Set(0, 0, 5)
+	orthographic.UpdateMatrixWorld(false)
+
+	r.SetFromCamera(math3.NewVector2().Set(0.5, -0.5), orthographic)
+	if !vectorNear(r.Ray.Origin, math3.NewVector3().Set(1, -1, 5)) {
+		t.Errorf("orthographic rays should start on the camera plane, got %s", r.Ray.Origin)
+	}
+	if !vectorNear(r.Ray.Direction, math3.NewVector3().Set(0, 0, -1)) {
+		t.Errorf("unexpected direction %s", r.Ray.Direction)
+	}
+}
+
+func TestRaycaster_Singular(t *testing.T) {
+	r := newTestRaycaster()
+
+	mesh := newTestPlaneMesh()
+	mesh.Scale.Set(0, 1, 1)
+	mesh.UpdateMatrixWorld(false)
+	if len(r.IntersectObject(mesh, false)) != 0 {
+		t.Error("expected no intersection with a flattened mesh")
+	}
+
+	camera := cameras.NewPerspectiveCamera(90, 1, 1, 100)
+	camera.ProjectionMatrix.MultiplyScalar(0)
+	if _, err := r.SetFromCamera(math3.NewVector2(), camera); err != math3.ErrSingularMatrix {
+		t.Errorf("expected a singular projection error, got %v", err)
+	}
+}
+
+func TestRaycaster_Mesh(t *testing.T) {
+	r := newTestRaycaster()
+	r.Ray.Origin.Set(0.3, 0.1, 10)
+
+	near := newTestPlaneMesh()
+	near.Position.Set(0, 0, 1)
+	far := newTestPlaneMesh()
+	missed := newTestPlaneMesh()
+	missed.Position.Set(5, 0, 0)
+
+	parent := objects.NewObject()
+	parent.Add(far)
+	parent.Add(near)
+	parent.Add(missed)
+	parent.UpdateMatrixWorld(false)
+
+	if len(r.IntersectObject(parent, false)) != 0 {
+		t.Error("children should only be tested when recursive")
+	}
+
+	intersects := r.IntersectObject(parent, true)
+	if len(intersects) != 2 {
+		t.Fatalf("expected 2 intersections, got %d", len(intersects))
+	}
+	if intersects[0].Object != objects.Node(near) || intersects[1].Object != objects.Node(far) {
+		t.Error("intersections should be sorted nearest first")
+	}
+
+	hit := intersects[0]
+	if hit.Distance != 9 || !vectorNear(hit.Point, math3.NewVector3().Set(0.3, 0.1, 1)) {
+		t.Errorf("unexpected intersection at %f, %s", hit.Distance, hit.Point)
+	}
+	if hit.Face == nil || !vectorNear(hit.Face.Normal, math3.NewVector3().Set(0, 0, 1)) {
+		t.Error("intersection should include the face that was hit")
+	}
+	if hit.UV == nil || math.Abs(hit.UV.X-0.65) > 0.0001 || math.Abs(hit.UV.Y-0.55) > 0.0001 {
+		t.Errorf("expected the uv of the point on the plane, got %v", hit.UV)
+	}
+
+	r.Far = 9.5
+	if intersects = r.IntersectObject(parent, true); len(intersects) != 1 {
+		t.Errorf("far should limit the intersections, got %d", len(intersects))
+	}
+	r.Far = math.Inf(1)
+
+	near.Visible = false
+	if intersects = r.IntersectObjects([]objects.Node{near, far}, false); len(intersects) != 1 {
+		t.Errorf("invisible objects should be ignored, got %d", len(intersects))
+	}
+}
+
+func TestRaycaster_MeshSide(t *testing.T) {
+	r := newTestRaycaster()
+	r.Ray.Origin.Set(0.3, 0.1, 10)
+	mesh := newTestPlaneMesh()
+	material := mesh.Material.(*materials.MeshBasicMaterial)
+
+	mesh.Rotation.SetY(math.Pi)
+	mesh.UpdateMatrixWorld(false)
+
+	if len(r.IntersectObject(mesh, false)) != 0 {
+		t.Error("back faces should not be hit by front side materials")
+	}
+
+	material.Side = three.BackSide
+	if len(r.IntersectObject(mesh, false)) != 1 {
+		t.Error("back faces should be hit by back side materials")
+	}
+
+	material.Side = three.DoubleSide
+	mesh.Rotation.SetY(0)
+	mesh.UpdateMatrixWorld(false)
+	if len(r.IntersectObject(mesh, false)) != 1 {
+		t.Error("front faces should be hit by double sided materials")
+	}
+}
+
+func TestRaycaster_Line(t *testing.T) {
+	r := newTestRaycaster()
+
+	g := core.NewBufferGeometry()
+	g.AddAttribute("position", core.NewFloat32BufferAttribute([]float32{
+		-1, 0.5, 0,
+		1, 0.5, 0,
+		1, 5, 0,
+	}, 3))
+	line := objects.NewLine(g, nil)
+
+	intersects := r.IntersectObject(line, false)
+	if len(intersects) != 1 {
+		t.Fatalf("expected 1 intersection, got %d", len(intersects))
+	}
+	if intersects[0].Index != 0 || !vectorNear(intersects[0].Point, math3.NewVector3().Set(0, 0.5, 0)) {
+		t.Errorf("unexpected intersection %d, %s", intersects[0].Index, intersects[0].Point)
+	}
+
+	r.LineThreshold = 0.25
+	if len(r.IntersectObject(line, false)) != 0 {
+		t.Error("line outside of the threshold should not be hit")
+	}
+}
+
+func TestRaycaster_Points(t *testing.T) {
+	r := newTestRaycaster()
+	r.PointsThreshold = 0.5
+
+	g := core.NewBufferGeometry()
+	g.AddAttribute("position", core.NewFloat32BufferAttribute([]float32{
+		0.25, 0, 0,
+		0, 0, 1,
+		2, 0, 0,
+	}, 3))
+	points := objects.NewPoints(g, nil)
+
+	intersects := r.IntersectObject(points, false)
+	if len(intersects) != 2 {
+		t.Fatalf("expected 2 intersections, got %d", len(intersects))
+	}
+	if intersects[0].Index != 1 || intersects[1].Index != 0 {
+		t.Error("points should be sorted nearest first")
+	}
+	if intersects[1].DistanceToRay != 0.25 {
+		t.Errorf("expected distance to ray of 0.25, got %f", intersects[1].DistanceToRay)
+	}
+}
+
+func TestRaycaster_Sprite(t *testing.T) {
+	r := newTestRaycaster()
+
+	sprite := objects.NewSprite(nil)
+	sprite.Position.Set(0.4, 0, 0)
+	sprite.UpdateMatrixWorld(false)
+
+	intersects := r.IntersectObject(sprite, false)
+	if len(intersects) != 1 || intersects[0].Distance != 10 {
+		t.Fatal("ray through the sprite should hit it")
+	}
+
+	sprite.Position.Set(0.6, 0, 0)
+	sprite.UpdateMatrixWorld(false)
+	if len(r.IntersectObject(sprite, false)) != 0 {
+		t.Error("ray outside of the sprite should not hit it")
+	}
+}
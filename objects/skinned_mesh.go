@@ -0,0 +1,248 @@
+package objects
+
+import (
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/math3"
+)
+
+const (
+	// AttachedBindMode keeps a skinned mesh bound to its skeleton
+	// as the mesh itself is moved around
+	AttachedBindMode = "attached"
+	// DetachedBindMode keeps the bind matrix of a skinned
+	// mesh fixed when the mesh is moved
+	DetachedBindMode = "detached"
+)
+
+// Bone is a joint of a skeleton, it is an ordinary object
+// that is used to mark the parts of a hierarchy that deform meshes
+type Bone struct {
+	*Object
+}
+
+// NewBone creates a new bone at the origin
+func NewBone() *Bone {
+
+	return &Bone{
+		Object: NewObject(),
+	}
+
+}
+
+// Skeleton is the set of bones that deform a skinned mesh
+type Skeleton struct {
+	Bones []*Bone
+	// BoneInverses holds the inverse of the world matrix
+	// of each bone when the skeleton was bound
+	BoneInverses []*math3.Matrix4
+	// BoneMatrices holds the offset matrix of each bone as
+	// 16 column major values, it is filled in by Update
+	BoneMatrices []float64
+}
+
+// NewSkeleton creates a skeleton of the given bones. If boneInverses is
+// nil the current world matrices of the bones are used as the bind pose.
+func NewSkeleton(bones []*Bone, boneInverses []*math3.Matrix4) *Skeleton {
+
+	s := &Skeleton{
+		Bones:        bones,
+		BoneMatrices: make([]float64, len(bones)*16),
+	}
+
+	if boneInverses == nil {
+
+		s.CalculateInverses()
+
+	} else {
+
+		s.BoneInverses = boneInverses
+
+		for len(s.BoneInverses) < len(bones) {
+			s.BoneInverses = append(s.BoneInverses, math3.NewMatrix4())
+		}
+
+	}
+
+	return s
+
+}
+
+// CalculateInverses sets the bind pose of this skeleton
+// to the current world matrices of its bones
+func (s *Skeleton) CalculateInverses() {
+
+	s.BoneInverses = make([]*math3.Matrix4, len(s.Bones))
+
+	for i, bone := range s.Bones {
+
+		s.BoneInverses[i] = math3.NewMatrix4()
+
+		if bone != nil {
+			s.BoneInverses[i].InverseOf(bone.MatrixWorld)
+		}
+
+	}
+
+}
+
+// Pose moves the bones of this skeleton back into their bind pose
+func (s *Skeleton) Pose() {
+
+	for i, bone := range s.Bones {
+		if bone != nil {
+			bone.MatrixWorld.InverseOf(s.BoneInverses[i])
+		}
+	}
+
+	parentInverse := math3.NewMatrix4()
+
+	for _, bone := range s.Bones {
+
+		if bone == nil {
+			continue
+		}
+
+		if parent := bone.GetParent(); parent != nil {
+
+			parentInverse.InverseOf(parent.GetMatrixWorld())
+			bone.Matrix.MultiplyMatrices(parentInverse, bone.MatrixWorld)
+
+		} else {
+
+			bone.Matrix.Copy(bone.MatrixWorld)
+
+		}
+
+		bone.Matrix.Decompose(bone.Position, bone.Quaternion, bone.Scale)
+
+	}
+
+}
+
+// Update recomputes the bone matrices of this skeleton from
+// the current world matrices of its bones
+func (s *Skeleton) Update() {
+
+	offset := math3.NewMatrix4()
+
+	for i, bone := range s.Bones {
+
+		offset.Identity()
+		if bone != nil {
+			offset.MultiplyMatrices(bone.MatrixWorld, s.BoneInverses[i])
+		}
+
+		offset.ToArray(s.BoneMatrices, i*16)
+
+	}
+
+}
+
+// GetBoneByName returns the first bone with the given name, or nil
+func (s *Skeleton) GetBoneByName(name string) *Bone {
+
+	for _, bone := range s.Bones {
+		if bone != nil && bone.Name == name {
+			return bone
+		}
+	}
+
+	return nil
+
+}
+
+// SkinnedMesh is a mesh that is deformed by the bones of a skeleton,
+// each vertex is weighted between the bones given by its skinIndex
+// attribute using the weights in its skinWeight attribute
+type SkinnedMesh struct {
+	*Mesh
+
+	// BindMode is one of AttachedBindMode or DetachedBindMode
+	BindMode          string
+	BindMatrix        *math3.Matrix4
+	BindMatrixInverse *math3.Matrix4
+
+	Skeleton *Skeleton
+}
+
+// NewSkinnedMesh creates a skinned mesh from the given geometry and
+// material, it is not deformed until it has been bound to a skeleton
+func NewSkinnedMesh(geometry *core.BufferGeometry, material Material) *SkinnedMesh {
+
+	return &SkinnedMesh{
+		Mesh: NewMesh(geometry, material),
+
+		BindMode:          AttachedBindMode,
+		BindMatrix:        math3.NewMatrix4(),
+		BindMatrixInverse: math3.NewMatrix4(),
+	}
+
+}
+
+// Bind binds this mesh to the given skeleton, if bindMatrix is
+// nil the current world matrix of this mesh is used
+func (m *SkinnedMesh) Bind(skeleton *Skeleton, bindMatrix *math3.Matrix4) {
+
+	m.Skeleton = skeleton
+
+	if bindMatrix == nil {
+		m.Object.UpdateMatrixWorld(true)
+		bindMatrix = m.MatrixWorld
+	}
+
+	m.BindMatrix.Copy(bindMatrix)
+	m.BindMatrixInverse.InverseOf(bindMatrix)
+
+}
+
+// Pose moves the skeleton of this mesh back into its bind pose
+func (m *SkinnedMesh) Pose() {
+
+	if m.Skeleton != nil {
+		m.Skeleton.Pose()
+	}
+
+}
+
+// NormalizeSkinWeights scales the skin weights of each
+// vertex so that they add up to one
+func (m *SkinnedMesh) NormalizeSkinWeights() {
+
+	weights := m.Geometry.GetAttribute("skinWeight")
+	if weights == nil {
+		return
+	}
+
+	for i, l := 0, weights.Count(); i < l; i++ {
+
+		sum := 0.0
+		for j := 0; j < weights.ItemSize; j++ {
+			sum += weights.Get(i*weights.ItemSize + j)
+		}
+
+		if sum == 0 {
+			continue
+		}
+
+		for j := 0; j < weights.ItemSize; j++ {
+			k := i*weights.ItemSize + j
+			weights.Set(k, weights.Get(k)/sum)
+		}
+
+	}
+
+}
+
+// UpdateMatrixWorld updates the world matrix of this mesh and its
+// descendants, keeping the bind matrix in sync in the attached mode
+func (m *SkinnedMesh) UpdateMatrixWorld(force bool) {
+
+	m.Object.UpdateMatrixWorld(force)
+
+	if m.BindMode == AttachedBindMode {
+		m.BindMatrixInverse.InverseOf(m.MatrixWorld)
+	} else if m.BindMode == DetachedBindMode {
+		m.BindMatrixInverse.InverseOf(m.BindMatrix)
+	}
+
+}
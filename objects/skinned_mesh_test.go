@@ -0,0 +1,81 @@
+package objects_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/objects"
+)
+
+func TestSkeleton_PoseAndUpdate(t *testing.T) {
+	hip := objects.NewBone()
+	knee := objects.NewBone()
+	knee.Position.Set(0, 1, 0)
+	hip.Add(knee)
+	hip.UpdateMatrixWorld(true)
+
+	skeleton := objects.NewSkeleton([]*objects.Bone{hip, knee}, nil)
+	skeleton.Update()
+	for i, v := range skeleton.BoneMatrices {
+		expected := 0.0
+		if i%16%5 == 0 {
+			expected = 1
+		}
+		if math.Abs(v-expected) > 1e-9 {
+			t.Fatalf("expected identity bone matrices in the bind pose, got %v", skeleton.BoneMatrices)
+		}
+	}
+
+	hip.Position.Set(2, 0, 0)
+	knee.Position.Set(0, 3, 0)
+	hip.UpdateMatrixWorld(true)
+	skeleton.Update()
+	if skeleton.BoneMatrices[16+12] != 2 || skeleton.BoneMatrices[16+13] != 2 {
+		t.Errorf("expected the knee to be offset from its bind pose, got %v", skeleton.BoneMatrices[16:])
+	}
+
+	skeleton.Pose()
+	if hip.Position.X != 0 || math.Abs(knee.Position.Y-1) > 1e-9 {
+		t.Errorf("expected the bones to return to the bind pose, got %v and %v", hip.Position, knee.Position)
+	}
+	if skeleton.GetBoneByName("knee") != nil {
+		t.Error("unnamed bones should not be found by name")
+	}
+}
+
+func TestSkinnedMesh_Bind(t *testing.T) {
+	g := newTestGeometry(2)
+	g.AddAttribute("skinWeight", core.NewFloat32BufferAttribute([]float32{1, 3, 0, 0, 0, 0, 0, 0}, 4))
+
+	mesh := objects.NewSkinnedMesh(g, nil)
+	mesh.Position.Set(0, 0, 5)
+	mesh.Bind(objects.NewSkeleton(nil, nil), nil)
+	if mesh.BindMatrix.Elements[14] != 5 || mesh.BindMatrixInverse.Elements[14] != -5 {
+		t.Error("expected the mesh to be bound with its world matrix")
+	}
+
+	mesh.NormalizeSkinWeights()
+	weights := mesh.Geometry.GetAttribute("skinWeight")
+	if weights.GetX(0) != 0.25 || weights.GetY(0) != 0.75 || weights.GetX(1) != 0 {
+		t.Errorf("expected normalized weights, got %v", weights.Float32)
+	}
+}
+
+func TestMesh_UpdateMorphTargets(t *testing.T) {
+	m := objects.NewMesh(newTestGeometry(1), nil)
+	smile := core.NewFloat32BufferAttribute(make([]float32, 3), 3)
+	smile.Name = "smile"
+	m.Geometry.MorphAttributes["position"] = []*core.BufferAttribute{
+		core.NewFloat32BufferAttribute(make([]float32, 3), 3), smile,
+	}
+
+	m.MorphTargetInfluences = []float64{0.5}
+	m.UpdateMorphTargets()
+	if len(m.MorphTargetInfluences) != 2 || m.MorphTargetInfluences[0] != 0.5 {
+		t.Errorf("expected existing influences to be kept, got %v", m.MorphTargetInfluences)
+	}
+	if index, ok := m.MorphTargetDictionary["smile"]; !ok || index != 1 || len(m.MorphTargetDictionary) != 1 {
+		t.Errorf("expected named targets in the dictionary, got %v", m.MorphTargetDictionary)
+	}
+}
@@ -0,0 +1,62 @@
+package objects
+
+import (
+	"math"
+
+	"github.com/rydrman/three.go/math3"
+)
+
+// Sprite is a unit square centered on its position that
+// always faces the camera
+type Sprite struct {
+	*Object
+
+	Material Material
+}
+
+// NewSprite creates a sprite with the given material
+func NewSprite(material Material) *Sprite {
+
+	return &Sprite{
+		Object: NewObject(),
+
+		Material: material,
+	}
+
+}
+
+// GetBoundingSphere returns a sphere that encloses the sprite
+// in any orientation
+func (s *Sprite) GetBoundingSphere() *math3.Sphere {
+
+	return math3.NewSphere().Set(math3.NewVector3(), math.Sqrt(0.5))
+
+}
+
+// Raycast appends this sprite to intersects if the ray of raycaster
+// passes close to its center, relative to its world scale
+func (s *Sprite) Raycast(raycaster *Raycaster, intersects []*Intersection) []*Intersection {
+
+	matrixPosition := math3.NewVector3().SetFromMatrixPosition(s.MatrixWorld)
+	intersectPoint := raycaster.Ray.ClosestPointToPoint(matrixPosition, nil)
+
+	worldScale := math3.NewVector3().SetFromMatrixScale(s.MatrixWorld)
+	guessSizeSq := worldScale.X * worldScale.Y / 4
+
+	if matrixPosition.DistanceToSquared(intersectPoint) > guessSizeSq {
+		return intersects
+	}
+
+	distance := raycaster.Ray.Origin.DistanceTo(intersectPoint)
+
+	if !raycaster.inRange(distance) {
+		return intersects
+	}
+
+	return append(intersects, &Intersection{
+		Distance: distance,
+		Point:    intersectPoint,
+		Object:   s,
+	})
+
+}
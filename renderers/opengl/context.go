@@ -0,0 +1,83 @@
+// Package opengl renders scenes into windows using OpenGL and GLFW.
+//
+// GLFW must only be called from the main thread, so importing this
+// package locks the main goroutine to the main thread for the life of
+// the program. Renderers must be created and used from the main
+// goroutine. The renderers package itself has no such requirement.
+package opengl
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/go-gl/gl/v2.1/gl"
+	"github.com/go-gl/glfw/v3.2/glfw"
+)
+
+var (
+	glfwOnce sync.Once
+	glfwErr  error
+
+	glOnce sync.Once
+	glErr  error
+)
+
+// GLFW requires that all of its calls are made from the main thread, the
+// main goroutine runs on the main thread during initialization so it is
+// locked there before main is called
+func init() {
+
+	runtime.LockOSThread()
+
+}
+
+// Init initializes the windowing system, it must be called from the main
+// goroutine as GLFW requires that all of its calls are made from the main
+// thread. It is safe to call Init more than once, only the first call after
+// Terminate has any effect and every call returns the result of that first
+// initialization. NewWindowRenderer calls Init automatically.
+func Init() error {
+
+	glfwOnce.Do(func() {
+
+		if err := glfw.Init(); err != nil {
+			glfwErr = fmt.Errorf("opengl: failed to initialize glfw: %v", err)
+		}
+
+	})
+
+	return glfwErr
+
+}
+
+// Terminate releases all resources held by the windowing system.
+// Any remaining windows are destroyed and no renderer that depends on
+// a window may be used afterwards, until Init is called again.
+func Terminate() {
+
+	if glfwErr == nil {
+		glfw.Terminate()
+	}
+
+	// allow the windowing system and opengl to be initialized again
+	glfwOnce, glfwErr = sync.Once{}, nil
+	glOnce, glErr = sync.Once{}, nil
+
+}
+
+// initGL loads the OpenGL function pointers, it must be called
+// with a current context and only the first call has any effect
+func initGL() error {
+
+	glOnce.Do(func() {
+
+		if err := gl.Init(); err != nil {
+			glErr = fmt.Errorf("opengl: failed to initialize opengl: %v", err)
+		}
+
+	})
+
+	return glErr
+
+}
@@ -0,0 +1,107 @@
+package opengl
+
+import (
+	"fmt"
+
+	"github.com/go-gl/gl/v2.1/gl"
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/renderers"
+)
+
+// glRenderTarget holds the opengl objects backing a render target
+type glRenderTarget struct {
+	width       int
+	height      int
+	format      int
+	depthFormat int
+
+	framebuffer  uint32
+	colorbuffer  uint32
+	depthbuffer  uint32
+	stencilDepth bool
+}
+
+// newGLRenderTarget allocates a framebuffer object with renderbuffer
+// attachments matching the given target, a context must be current
+func newGLRenderTarget(target *renderers.RenderTarget) (*glRenderTarget, error) {
+
+	if err := target.Validate(); err != nil {
+		return nil, err
+	}
+
+	t := &glRenderTarget{
+		width:       target.Width,
+		height:      target.Height,
+		format:      target.Format,
+		depthFormat: target.DepthFormat,
+	}
+
+	gl.GenFramebuffersEXT(1, &t.framebuffer)
+	gl.BindFramebufferEXT(gl.FRAMEBUFFER_EXT, t.framebuffer)
+
+	colorFormat := uint32(gl.RGBA8)
+	if target.Format == three.RGBFormat {
+		colorFormat = gl.RGB8
+	}
+
+	gl.GenRenderbuffersEXT(1, &t.colorbuffer)
+	gl.BindRenderbufferEXT(gl.RENDERBUFFER_EXT, t.colorbuffer)
+	gl.RenderbufferStorageEXT(gl.RENDERBUFFER_EXT, colorFormat, int32(t.width), int32(t.height))
+	gl.FramebufferRenderbufferEXT(gl.FRAMEBUFFER_EXT, gl.COLOR_ATTACHMENT0_EXT, gl.RENDERBUFFER_EXT, t.colorbuffer)
+
+	if target.HasDepth() {
+
+		gl.GenRenderbuffersEXT(1, &t.depthbuffer)
+		gl.BindRenderbufferEXT(gl.RENDERBUFFER_EXT, t.depthbuffer)
+
+		if target.HasStencil() {
+
+			gl.RenderbufferStorageEXT(gl.RENDERBUFFER_EXT, gl.DEPTH24_STENCIL8_EXT, int32(t.width), int32(t.height))
+			gl.FramebufferRenderbufferEXT(gl.FRAMEBUFFER_EXT, gl.DEPTH_ATTACHMENT_EXT, gl.RENDERBUFFER_EXT, t.depthbuffer)
+			gl.FramebufferRenderbufferEXT(gl.FRAMEBUFFER_EXT, gl.STENCIL_ATTACHMENT_EXT, gl.RENDERBUFFER_EXT, t.depthbuffer)
+
+		} else {
+
+			gl.RenderbufferStorageEXT(gl.RENDERBUFFER_EXT, gl.DEPTH_COMPONENT24, int32(t.width), int32(t.height))
+			gl.FramebufferRenderbufferEXT(gl.FRAMEBUFFER_EXT, gl.DEPTH_ATTACHMENT_EXT, gl.RENDERBUFFER_EXT, t.depthbuffer)
+
+		}
+
+	}
+
+	gl.BindRenderbufferEXT(gl.RENDERBUFFER_EXT, 0)
+
+	status := gl.CheckFramebufferStatusEXT(gl.FRAMEBUFFER_EXT)
+
+	gl.BindFramebufferEXT(gl.FRAMEBUFFER_EXT, 0)
+
+	if status != gl.FRAMEBUFFER_COMPLETE_EXT {
+		t.delete()
+		return nil, fmt.Errorf("opengl: incomplete render target framebuffer, status 0x%x", status)
+	}
+
+	return t, nil
+
+}
+
+// matches returns true if this framebuffer can still
+// be used to back the given target
+func (t *glRenderTarget) matches(target *renderers.RenderTarget) bool {
+
+	return t.width == target.Width &&
+		t.height == target.Height &&
+		t.format == target.Format &&
+		t.depthFormat == target.DepthFormat
+
+}
+
+func (t *glRenderTarget) delete() {
+
+	if t.depthbuffer != 0 {
+		gl.DeleteRenderbuffersEXT(1, &t.depthbuffer)
+	}
+
+	gl.DeleteRenderbuffersEXT(1, &t.colorbuffer)
+	gl.DeleteFramebuffersEXT(1, &t.framebuffer)
+
+}
@@ -0,0 +1,319 @@
+package opengl
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/go-gl/gl/v2.1/gl"
+	"github.com/go-gl/glfw/v3.2/glfw"
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/renderers"
+	"github.com/rydrman/three.go/scenes"
+)
+
+type WindowRenderer struct {
+	title  string
+	window *glfw.Window
+
+	current *glRenderTarget
+	targets map[*renderers.RenderTarget]*glRenderTarget
+}
+
+// NewWindowRenderer creates a renderer that draws into a new window of the
+// given size, initializing the windowing system and OpenGL as required
+func NewWindowRenderer(w, h int, title string) (*WindowRenderer, error) {
+
+	if err := Init(); err != nil {
+		return nil, err
+	}
+
+	r := &WindowRenderer{
+		title:   title,
+		targets: make(map[*renderers.RenderTarget]*glRenderTarget),
+	}
+
+	err := r.setupWindow(w, h)
+	if nil != err {
+		r.Destroy()
+		return nil, err
+	}
+
+	return r, nil
+
+}
+
+func (r *WindowRenderer) setupWindow(w, h int) error {
+
+	if r.window != nil {
+
+		r.window.Destroy()
+
+	}
+
+	glfw.WindowHint(glfw.Resizable, glfw.False)
+	glfw.WindowHint(glfw.ContextVersionMajor, 2)
+	glfw.WindowHint(glfw.ContextVersionMinor, 1)
+	//glfw.WindowHint(glfw.OpenGLProfile, glfw.OpenGLCoreProfile)
+	//glfw.WindowHint(glfw.OpenGLForwardCompatible, glfw.True)
+
+	win, err := glfw.CreateWindow(w, h, r.title, nil, nil)
+	if err != nil {
+		return err
+	}
+	r.window = win
+
+	r.window.MakeContextCurrent()
+
+	return initGL()
+
+}
+
+func (r *WindowRenderer) Render(scene *scenes.Scene, camera math3.Projector) {
+
+	if nil == r.window {
+		return
+	}
+
+	r.window.MakeContextCurrent()
+
+	r.bindFramebuffer(r.current)
+
+	if scene.BackgroundColor != nil {
+
+		gl.ClearColor(scene.BackgroundColor.R32(), scene.BackgroundColor.G32(), scene.BackgroundColor.B32(), 1)
+
+	}
+
+	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT | gl.STENCIL_BUFFER_BIT)
+
+	if r.current == nil {
+
+		r.window.SwapBuffers()
+
+	}
+
+}
+
+// SetRenderTarget directs all following renders into the given
+// target, or back to the window if nil
+func (r *WindowRenderer) SetRenderTarget(target *renderers.RenderTarget) error {
+
+	if target == nil {
+		r.current = nil
+		return nil
+	}
+
+	t, err := r.targetFramebuffer(target)
+	if err != nil {
+		return err
+	}
+
+	r.current = t
+
+	return nil
+
+}
+
+// DisposeRenderTarget releases the opengl objects allocated
+// for the given target by this renderer
+func (r *WindowRenderer) DisposeRenderTarget(target *renderers.RenderTarget) {
+
+	t, ok := r.targets[target]
+	if !ok || r.window == nil {
+		return
+	}
+
+	r.window.MakeContextCurrent()
+
+	if r.current == t {
+		r.current = nil
+	}
+
+	t.delete()
+	delete(r.targets, target)
+
+}
+
+// targetFramebuffer returns the framebuffer for the given target,
+// allocating it if the target is new or has changed since it was
+// last used
+func (r *WindowRenderer) targetFramebuffer(target *renderers.RenderTarget) (*glRenderTarget, error) {
+
+	if r.window == nil {
+		return nil, fmt.Errorf("opengl: window has been destroyed")
+	}
+
+	t, ok := r.targets[target]
+	if ok && t.matches(target) {
+		return t, nil
+	}
+
+	r.window.MakeContextCurrent()
+
+	if ok {
+		t.delete()
+		delete(r.targets, target)
+	}
+
+	t, err := newGLRenderTarget(target)
+	if err != nil {
+		return nil, err
+	}
+
+	r.targets[target] = t
+
+	return t, nil
+
+}
+
+// bindFramebuffer binds the given target, or the window if nil,
+// and sets the viewport to cover it
+func (r *WindowRenderer) bindFramebuffer(t *glRenderTarget) (w, h int) {
+
+	if t == nil {
+
+		w, h = r.window.GetFramebufferSize()
+		gl.BindFramebufferEXT(gl.FRAMEBUFFER_EXT, 0)
+
+	} else {
+
+		w, h = t.width, t.height
+		gl.BindFramebufferEXT(gl.FRAMEBUFFER_EXT, t.framebuffer)
+
+	}
+
+	gl.Viewport(0, 0, int32(w), int32(h))
+
+	return w, h
+
+}
+
+// readTarget makes the context current and binds the framebuffer
+// to be read for the given target, or the window if nil
+func (r *WindowRenderer) readTarget(target *renderers.RenderTarget) (w, h int, err error) {
+
+	if r.window == nil {
+		return 0, 0, fmt.Errorf("opengl: window has been destroyed")
+	}
+
+	var t *glRenderTarget
+
+	if target != nil {
+
+		var ok bool
+		if t, ok = r.targets[target]; !ok {
+			return 0, 0, fmt.Errorf("opengl: render target has not been rendered to")
+		}
+
+	}
+
+	r.window.MakeContextCurrent()
+
+	w, h = r.bindFramebuffer(t)
+
+	return w, h, nil
+
+}
+
+// ReadPixels copies the color buffer of the given target,
+// or the window if nil, into a new image
+func (r *WindowRenderer) ReadPixels(target *renderers.RenderTarget) (*image.RGBA, error) {
+
+	w, h, err := r.readTarget(target)
+	if err != nil {
+		return nil, err
+	}
+	defer r.bindFramebuffer(r.current)
+
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+
+	if w > 0 && h > 0 {
+
+		gl.PixelStorei(gl.PACK_ALIGNMENT, 1)
+		gl.ReadPixels(0, 0, int32(w), int32(h), gl.RGBA, gl.UNSIGNED_BYTE, gl.Ptr(img.Pix))
+
+	}
+
+	// opengl rows start at the bottom of the image
+	row := make([]uint8, img.Stride)
+	for y := 0; y < h/2; y++ {
+
+		top := img.Pix[y*img.Stride : (y+1)*img.Stride]
+		bottom := img.Pix[(h-1-y)*img.Stride : (h-y)*img.Stride]
+
+		copy(row, top)
+		copy(top, bottom)
+		copy(bottom, row)
+
+	}
+
+	return img, nil
+
+}
+
+// ReadDepth copies the depth buffer of the given target, or the
+// window if nil, in rows from top to bottom
+func (r *WindowRenderer) ReadDepth(target *renderers.RenderTarget) ([]float64, error) {
+
+	if target != nil && !target.HasDepth() {
+		return nil, fmt.Errorf("opengl: render target has no depth buffer")
+	}
+
+	w, h, err := r.readTarget(target)
+	if err != nil {
+		return nil, err
+	}
+	defer r.bindFramebuffer(r.current)
+
+	raw := make([]float32, w*h)
+
+	if w > 0 && h > 0 {
+
+		gl.PixelStorei(gl.PACK_ALIGNMENT, 4)
+		gl.ReadPixels(0, 0, int32(w), int32(h), gl.DEPTH_COMPONENT, gl.FLOAT, gl.Ptr(raw))
+
+	}
+
+	// opengl rows start at the bottom of the image
+	depth := make([]float64, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			depth[y*w+x] = float64(raw[(h-1-y)*w+x])
+		}
+	}
+
+	return depth, nil
+
+}
+
+func (r *WindowRenderer) ShouldClose() bool {
+
+	glfw.PollEvents()
+
+	if r.window != nil {
+
+		return r.window.ShouldClose()
+
+	}
+
+	return true
+}
+
+func (r *WindowRenderer) Destroy() {
+
+	if r.window != nil {
+
+		r.window.MakeContextCurrent()
+
+		for target, t := range r.targets {
+			t.delete()
+			delete(r.targets, target)
+		}
+		r.current = nil
+
+		r.window.Destroy()
+		r.window = nil
+
+	}
+
+}
@@ -0,0 +1,71 @@
+package renderers
+
+import (
+	"fmt"
+
+	"github.com/rydrman/three.go"
+)
+
+// RenderTarget is an offscreen buffer that a renderer can draw into
+// instead of its default output. The target itself only describes
+// the buffer, each renderer allocates its own storage for it the
+// first time that it is used.
+type RenderTarget struct {
+	Width  int
+	Height int
+
+	// Format is the color format of the target,
+	// either three.RGBAFormat or three.RGBFormat
+	Format int
+	// DepthFormat is three.DepthFormat or three.DepthStencilFormat,
+	// or zero for a target without a depth buffer
+	DepthFormat int
+}
+
+// NewRenderTarget creates a new RGBA render target of the given size
+// with a depth buffer
+func NewRenderTarget(w, h int) *RenderTarget {
+
+	return &RenderTarget{
+		Width:  w,
+		Height: h,
+
+		Format:      three.RGBAFormat,
+		DepthFormat: three.DepthFormat,
+	}
+
+}
+
+// HasDepth returns true if this target has a depth buffer
+func (t *RenderTarget) HasDepth() bool {
+
+	return t.DepthFormat == three.DepthFormat || t.DepthFormat == three.DepthStencilFormat
+
+}
+
+// HasStencil returns true if this target has a stencil buffer
+func (t *RenderTarget) HasStencil() bool {
+
+	return t.DepthFormat == three.DepthStencilFormat
+
+}
+
+// Validate checks that this target describes a buffer
+// that can actually be allocated
+func (t *RenderTarget) Validate() error {
+
+	if t.Width <= 0 || t.Height <= 0 {
+		return fmt.Errorf("renderers: invalid render target size %dx%d", t.Width, t.Height)
+	}
+
+	if t.Format != three.RGBAFormat && t.Format != three.RGBFormat {
+		return fmt.Errorf("renderers: unsupported render target format %d", t.Format)
+	}
+
+	if t.DepthFormat != 0 && !t.HasDepth() {
+		return fmt.Errorf("renderers: unsupported render target depth format %d", t.DepthFormat)
+	}
+
+	return nil
+
+}
@@ -1,10 +1,25 @@
 package renderers
 
 import (
+	"image"
+
 	"github.com/rydrman/three.go/math3"
 	"github.com/rydrman/three.go/scenes"
 )
 
 type Renderer interface {
 	Render(*scenes.Scene, math3.Projector)
+
+	// SetRenderTarget directs all following renders into the given
+	// target, or back to the default output of the renderer if nil
+	SetRenderTarget(*RenderTarget) error
+
+	// ReadPixels copies the color buffer of the given target, or the
+	// default output if nil, into a new image
+	ReadPixels(*RenderTarget) (*image.RGBA, error)
+
+	// ReadDepth copies the depth buffer of the given target, or the
+	// default output if nil, in rows from top to bottom with values
+	// in the range [0, 1]
+	ReadDepth(*RenderTarget) ([]float64, error)
 }
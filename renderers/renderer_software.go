@@ -0,0 +1,879 @@
+package renderers
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"sort"
+
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/lights"
+	"github.com/rydrman/three.go/materials"
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/objects"
+	"github.com/rydrman/three.go/scenes"
+)
+
+// TriangleSource is implemented by scene nodes that can be drawn
+// by the SoftwareRenderer. Positions are returned in object space as
+// flat x, y, z triplets and colors as flat r, g, b triplets, with one
+// entry per vertex and three vertices per triangle.
+type TriangleSource interface {
+	math3.Positioner
+	Triangles() (positions, colors []float64)
+}
+
+// SoftwareRenderer is a pure go renderer that rasterizes scenes into
+// an in memory image. It does not require a window or any graphics
+// hardware, making it suitable for thumbnails and testing.
+type SoftwareRenderer struct {
+	// CullFace selects which triangles are discarded before rasterization,
+	// one of the three.CullFace* constants
+	CullFace int
+	// FrontFace defines the winding of front facing triangles,
+	// one of the three.FrontFaceDirection* constants
+	FrontFace int
+
+	// DepthTest enables testing fragments against the depth buffer
+	DepthTest bool
+	// DepthWrite enables writing the depth of passing fragments
+	DepthWrite bool
+	// DepthFunc is the comparison used for depth testing,
+	// one of the three.*Depth constants
+	DepthFunc int
+
+	// ShadowMap controls the rendering of shadows
+	ShadowMap ShadowMapSettings
+
+	screen  *softwareBuffers
+	current *softwareBuffers
+	targets map[*RenderTarget]*softwareBuffers
+}
+
+// ShadowMapSettings controls how a renderer draws shadows
+type ShadowMapSettings struct {
+	// Enabled renders shadow maps for the lights that cast shadows
+	// and applies them to the objects that receive them
+	Enabled bool
+	// Type is the filtering applied when looking up shadow maps, one of
+	// three.BasicShadowMap, three.PCFShadowMap or three.PCFSoftShadowMap
+	Type int
+	// RenderReverseSided draws the opposite side of single sided
+	// materials into shadow maps, which reduces self shadowing
+	RenderReverseSided bool
+}
+
+// softwareBuffers holds the storage for either the default output
+// of a software renderer or one of its render targets
+type softwareBuffers struct {
+	width  int
+	height int
+	opaque bool
+	color  *image.RGBA
+	// depth is nil when there is no depth buffer
+	depth []float64
+}
+
+func newSoftwareBuffers(w, h int, opaque, depth bool) *softwareBuffers {
+
+	b := &softwareBuffers{
+		width:  w,
+		height: h,
+		opaque: opaque,
+		color:  image.NewRGBA(image.Rect(0, 0, w, h)),
+	}
+
+	if depth {
+		b.depth = make([]float64, w*h)
+	}
+
+	return b
+
+}
+
+// NewSoftwareRenderer creates a software renderer that draws
+// into an image of the given size
+func NewSoftwareRenderer(w, h int) *SoftwareRenderer {
+
+	r := &SoftwareRenderer{
+		CullFace:  three.CullFaceBack,
+		FrontFace: three.FrontFaceDirectionCCW,
+
+		DepthTest:  true,
+		DepthWrite: true,
+		DepthFunc:  three.LessEqualDepth,
+
+		ShadowMap: ShadowMapSettings{
+			Type:               three.PCFShadowMap,
+			RenderReverseSided: true,
+		},
+
+		targets: make(map[*RenderTarget]*softwareBuffers),
+	}
+
+	r.SetSize(w, h)
+
+	return r
+
+}
+
+// SetSize resizes the default color and depth buffers of this
+// renderer, discarding their current contents
+func (r *SoftwareRenderer) SetSize(w, h int) {
+
+	rendering := r.current == r.screen
+
+	r.screen = newSoftwareBuffers(w, h, false, true)
+
+	if rendering {
+		r.current = r.screen
+	}
+
+}
+
+// GetSize returns the size of the default output image
+func (r *SoftwareRenderer) GetSize() (w, h int) {
+
+	return r.screen.width, r.screen.height
+
+}
+
+// Image returns the default color buffer that this renderer draws into
+func (r *SoftwareRenderer) Image() *image.RGBA {
+
+	return r.screen.color
+
+}
+
+// Depth returns the default depth buffer value at the given pixel
+// in the range [0, 1], where 1 is the far plane
+func (r *SoftwareRenderer) Depth(x, y int) float64 {
+
+	return r.screen.depth[y*r.screen.width+x]
+
+}
+
+// SetRenderTarget directs all following renders into the given
+// target, or back to the default output if nil
+func (r *SoftwareRenderer) SetRenderTarget(target *RenderTarget) error {
+
+	if target == nil {
+		r.current = r.screen
+		return nil
+	}
+
+	buffers, err := r.targetBuffers(target)
+	if err != nil {
+		return err
+	}
+
+	r.current = buffers
+
+	return nil
+
+}
+
+// DisposeRenderTarget releases the storage allocated for
+// the given target by this renderer
+func (r *SoftwareRenderer) DisposeRenderTarget(target *RenderTarget) {
+
+	if buffers, ok := r.targets[target]; ok {
+
+		if r.current == buffers {
+			r.current = r.screen
+		}
+
+		delete(r.targets, target)
+
+	}
+
+}
+
+// targetBuffers returns the storage for the given target, allocating
+// it if the target is new or has changed since it was last used
+func (r *SoftwareRenderer) targetBuffers(target *RenderTarget) (*softwareBuffers, error) {
+
+	if err := target.Validate(); err != nil {
+		return nil, err
+	}
+
+	opaque := target.Format == three.RGBFormat
+	buffers, ok := r.targets[target]
+
+	if !ok ||
+		buffers.width != target.Width ||
+		buffers.height != target.Height ||
+		buffers.opaque != opaque ||
+		(buffers.depth != nil) != target.HasDepth() {
+
+		buffers = newSoftwareBuffers(target.Width, target.Height, opaque, target.HasDepth())
+		r.targets[target] = buffers
+
+	}
+
+	return buffers, nil
+
+}
+
+// ReadPixels copies the color buffer of the given target,
+// or the default output if nil, into a new image
+func (r *SoftwareRenderer) ReadPixels(target *RenderTarget) (*image.RGBA, error) {
+
+	buffers := r.screen
+
+	if target != nil {
+
+		var ok bool
+		if buffers, ok = r.targets[target]; !ok {
+			return nil, fmt.Errorf("renderers: render target has not been rendered to")
+		}
+
+	}
+
+	img := image.NewRGBA(buffers.color.Rect)
+	copy(img.Pix, buffers.color.Pix)
+
+	return img, nil
+
+}
+
+// ReadDepth copies the depth buffer of the given target, or the
+// default output if nil, in rows from top to bottom
+func (r *SoftwareRenderer) ReadDepth(target *RenderTarget) ([]float64, error) {
+
+	buffers := r.screen
+
+	if target != nil {
+
+		var ok bool
+		if buffers, ok = r.targets[target]; !ok {
+			return nil, fmt.Errorf("renderers: render target has not been rendered to")
+		}
+
+	}
+
+	if buffers.depth == nil {
+		return nil, fmt.Errorf("renderers: render target has no depth buffer")
+	}
+
+	depth := make([]float64, len(buffers.depth))
+	copy(depth, buffers.depth)
+
+	return depth, nil
+
+}
+
+// Clear fills the current color buffer with the given color, or
+// transparent black if c is nil, and resets the depth buffer
+// to the far plane
+func (r *SoftwareRenderer) Clear(c *math3.Color) {
+
+	fill := color.RGBA{}
+	if c != nil {
+		fill = color.RGBA{toByte(c.R), toByte(c.G), toByte(c.B), 255}
+	}
+	if r.current.opaque {
+		fill.A = 255
+	}
+
+	pix := r.current.color.Pix
+	for i := 0; i < len(pix); i += 4 {
+		pix[i] = fill.R
+		pix[i+1] = fill.G
+		pix[i+2] = fill.B
+		pix[i+3] = fill.A
+	}
+
+	for i := range r.current.depth {
+		r.current.depth[i] = 1
+	}
+
+}
+
+// Render draws every Mesh and TriangleSource in the scene as seen by the
+// given camera into the current render target, skipping hidden nodes
+// along with their descendants. Opaque objects are drawn
+// first, followed by transparent ones from back to front. Objects with
+// FrustumCulled set are skipped when their bounding sphere falls outside
+// of the view of the camera, which for a TriangleSource without a
+// GetBoundingSphere method is computed from its triangles every frame. Meshes with a lit material are shaded per
+// pixel by the lights found in the scene, including their shadows when
+// shadow maps are enabled. A nil camera renders the scene directly in
+// normalized device coordinates.
+func (r *SoftwareRenderer) Render(scene *scenes.Scene, camera math3.Projector) {
+
+	r.Clear(scene.BackgroundColor)
+
+	if scene.AutoUpdate {
+		scene.UpdateMatrixWorld(false)
+	}
+
+	frame := &softwareFrame{
+		viewProjection: math3.NewMatrix4(),
+		inverse:        math3.NewMatrix4(),
+		frustum:        math3.NewFrustum(),
+		lights:         lights.NewState().Setup(scene),
+		cameraPosition: math3.NewVector3(),
+	}
+
+	if camera != nil {
+
+		if node, ok := camera.(objects.Node); ok && node.GetParent() == nil {
+			node.UpdateMatrixWorld(false)
+		}
+
+		frame.viewProjection.MultiplyMatrices(camera.GetProjectionMatrix(), camera.GetMatrixWorldInverse())
+		frame.inverse.InverseOf(frame.viewProjection)
+		frame.cameraPosition.SetFromMatrixPosition(camera.GetMatrixWorld())
+
+	}
+
+	frame.frustum.SetFromMatrix(frame.viewProjection)
+
+	if r.ShadowMap.Enabled {
+
+		r.renderShadows(scene)
+
+		frame.lights.ShadowsEnabled = true
+		frame.lights.ShadowType = r.ShadowMap.Type
+
+	}
+
+	r.projectNode(scene, frame)
+
+	sort.SliceStable(frame.transparent, func(i, j int) bool {
+		return frame.transparent[i].z > frame.transparent[j].z
+	})
+
+	for _, item := range frame.opaque {
+		r.drawTriangles(item.positions, item.colors, item.varyings, item.mvp, item.state)
+	}
+
+	for _, item := range frame.transparent {
+		r.drawTriangles(item.positions, item.colors, item.varyings, item.mvp, item.state)
+	}
+
+}
+
+// softwareFrame collects the objects to be drawn in a single render
+type softwareFrame struct {
+	viewProjection *math3.Matrix4
+	// inverse is the inverse of viewProjection, used to
+	// transform clipping planes into clip space
+	inverse *math3.Matrix4
+	// frustum is the view volume of the camera in world space
+	frustum *math3.Frustum
+	// lights are the lights of the scene in world space
+	lights *lights.State
+	// cameraPosition is the world position of the camera,
+	// used as the view point when shading
+	cameraPosition *math3.Vector3
+
+	opaque      []*renderItem
+	transparent []*renderItem
+}
+
+// renderItem is a set of triangles queued to be drawn
+type renderItem struct {
+	positions []float64
+	colors    []float64
+	varyings  []float64
+	mvp       *math3.Matrix4
+	state     *rasterState
+
+	// z is the depth of the object origin in device coordinates
+	z float64
+}
+
+func (r *SoftwareRenderer) projectNode(node objects.Node, frame *softwareFrame) {
+
+	// hidden nodes hide their descendants as well
+	if !objects.ObjectOf(node).Visible {
+		return
+	}
+
+	switch n := node.(type) {
+
+	case *objects.Mesh:
+
+		r.projectMeshGroups(n, frame)
+
+	case *objects.SkinnedMesh:
+
+		// skinning is not applied, so meshes are drawn in their bind pose
+		r.projectMeshGroups(n.Mesh, frame)
+
+	case TriangleSource:
+
+		positions, colors := n.Triangles()
+		if objects.ObjectOf(node).FrustumCulled && !frame.frustum.IntersectsObject(triangleBoundsOf(n, positions)) {
+			break
+		}
+
+		frame.add(n, positions, colors, nil, r.objectState(n.GetMatrixWorld()))
+
+	}
+
+	for _, child := range node.GetChildren() {
+
+		r.projectNode(child, frame)
+
+	}
+
+}
+
+// triangleBounds gives a bounding sphere to a TriangleSource
+type triangleBounds struct {
+	math3.Positioner
+	sphere *math3.Sphere
+}
+
+func (b *triangleBounds) GetBoundingSphere() *math3.Sphere {
+
+	return b.sphere
+
+}
+
+// triangleBoundsOf returns source itself when it has a bounding sphere,
+// otherwise the sphere is computed from the given positions
+func triangleBoundsOf(source TriangleSource, positions []float64) math3.Bounded {
+
+	if bounded, ok := source.(math3.Bounded); ok {
+		return bounded
+	}
+
+	sphere := math3.NewBox3().SetFromArray(positions).GetBoundingSphere(nil)
+
+	return &triangleBounds{Positioner: source, sphere: sphere}
+
+}
+
+// projectMeshGroups queues the triangles of mesh, drawing each geometry
+// group with its own material when the mesh has a multi material
+func (r *SoftwareRenderer) projectMeshGroups(mesh *objects.Mesh, frame *softwareFrame) {
+
+	if mesh.FrustumCulled && !frame.frustum.IntersectsObject(mesh) {
+		return
+	}
+
+	multi, ok := mesh.Material.(*materials.MultiMaterial)
+	if !ok || len(mesh.Geometry.Groups) == 0 {
+		r.projectMesh(mesh, mesh.Material, nil, frame)
+		return
+	}
+
+	for i := range mesh.Geometry.Groups {
+
+		group := &mesh.Geometry.Groups[i]
+		if material := multi.Get(group.MaterialIndex); material != nil {
+			r.projectMesh(mesh, material, group, frame)
+		}
+
+	}
+
+}
+
+// projectMesh queues the triangles of mesh to be drawn with the given
+// material, limited to the triangles of group if it is not nil
+func (r *SoftwareRenderer) projectMesh(mesh *objects.Mesh, material objects.Material, group *core.Group, frame *softwareFrame) {
+
+	state := r.objectState(mesh.GetMatrixWorld())
+	color := math3.NewColor().SetRGB(1, 1, 1)
+	vertexColors := true
+
+	if material != nil {
+
+		base := material.GetMaterial()
+		if !base.Visible {
+			return
+		}
+
+		r.applyMaterial(state, base, frame)
+
+		if c := materialColor(material); c != nil {
+			color.Copy(c)
+		}
+		vertexColors = base.VertexColors == three.VertexColors
+
+		if lights.Lit(material) {
+			state.shade = frame.shader(material, mesh.ReceiveShadow)
+		}
+
+	}
+
+	positions, colors, varyings := meshTriangles(mesh, material, group, color, vertexColors, state.shade != nil)
+	frame.add(mesh, positions, colors, varyings, state)
+
+}
+
+// add queues the given triangles to be drawn with the transform of object
+func (f *softwareFrame) add(object math3.Positioner, positions, colors, varyings []float64, state *rasterState) {
+
+	matrixWorld := object.GetMatrixWorld()
+
+	item := &renderItem{
+		positions: positions,
+		colors:    colors,
+		varyings:  varyings,
+		mvp:       math3.NewMatrix4().MultiplyMatrices(f.viewProjection, matrixWorld),
+		state:     state,
+		z:         math3.NewVector3().SetFromMatrixPosition(matrixWorld).ApplyProjection(f.viewProjection).Z,
+	}
+
+	if state.blending {
+		f.transparent = append(f.transparent, item)
+	} else {
+		f.opaque = append(f.opaque, item)
+	}
+
+}
+
+// objectState returns the default raster state of this renderer for an
+// object with the given world transform. Mirrored objects have their
+// front face flipped so that culling is unaffected.
+func (r *SoftwareRenderer) objectState(matrixWorld *math3.Matrix4) *rasterState {
+
+	state := &rasterState{
+		cullFace:   r.CullFace,
+		frontFace:  r.FrontFace,
+		depthTest:  r.DepthTest,
+		depthWrite: r.DepthWrite,
+		depthFunc:  r.DepthFunc,
+		colorWrite: true,
+		opacity:    1,
+	}
+
+	if matrixWorld.Determinant() < 0 {
+
+		if state.frontFace == three.FrontFaceDirectionCW {
+			state.frontFace = three.FrontFaceDirectionCCW
+		} else {
+			state.frontFace = three.FrontFaceDirectionCW
+		}
+
+	}
+
+	return state
+
+}
+
+// applyMaterial overrides the given state with the settings of material
+func (r *SoftwareRenderer) applyMaterial(state *rasterState, material *materials.Material, frame *softwareFrame) {
+
+	switch material.Side {
+	case three.FrontSide:
+		state.cullFace = three.CullFaceBack
+	case three.BackSide:
+		state.cullFace = three.CullFaceFront
+	case three.DoubleSide:
+		state.cullFace = three.CullFaceNone
+	}
+
+	state.depthTest = material.DepthTest
+	state.depthWrite = material.DepthWrite
+	state.depthFunc = material.DepthFunc
+
+	state.colorWrite = material.ColorWrite
+	state.opacity = material.Opacity
+	state.alphaTest = material.AlphaTest
+
+	if material.PolygonOffset {
+		state.offsetFactor = material.PolygonOffsetFactor
+		state.offsetUnits = material.PolygonOffsetUnits
+	}
+
+	if material.Transparent {
+		setBlending(state, material)
+	}
+
+	for _, plane := range material.ClippingPlanes {
+		state.clipping = append(state.clipping, clipSpacePlane(plane, frame.inverse))
+	}
+	state.clipIntersection = material.ClipIntersection
+
+}
+
+// setBlending enables blending in the given state using
+// the blending mode of material
+func setBlending(state *rasterState, material *materials.Material) {
+
+	state.blending = true
+	state.equation = three.AddEquation
+	state.equationAlpha = three.AddEquation
+
+	switch material.Blending {
+
+	case three.NoBlending:
+		state.blending = false
+
+	case three.AdditiveBlending:
+		state.src, state.dst = three.SrcAlphaFactor, three.OneFactor
+		state.srcAlpha, state.dstAlpha = state.src, state.dst
+
+	case three.SubtractiveBlending:
+		state.src, state.dst = three.ZeroFactor, three.OneMinusSrcColorFactor
+		state.srcAlpha, state.dstAlpha = state.src, state.dst
+
+	case three.MultiplyBlending:
+		state.src, state.dst = three.ZeroFactor, three.SrcColorFactor
+		state.srcAlpha, state.dstAlpha = state.src, state.dst
+
+	case three.CustomBlending:
+		state.src, state.dst, state.equation = material.BlendSrc, material.BlendDst, material.BlendEquation
+		state.srcAlpha, state.dstAlpha, state.equationAlpha = material.BlendAlpha()
+
+	default:
+		state.src, state.dst = three.SrcAlphaFactor, three.OneMinusSrcAlphaFactor
+		state.srcAlpha, state.dstAlpha = three.OneFactor, three.OneMinusSrcAlphaFactor
+
+	}
+
+}
+
+// clipSpacePlane transforms a plane in world space into clip space
+// given the inverse of the view projection matrix
+func clipSpacePlane(plane *math3.Plane, inverse *math3.Matrix4) [4]float64 {
+
+	e := inverse.Elements
+	p := [4]float64{plane.Normal.X, plane.Normal.Y, plane.Normal.Z, plane.Constant}
+
+	var out [4]float64
+	for j := 0; j < 4; j++ {
+		out[j] = e[j*4]*p[0] + e[j*4+1]*p[1] + e[j*4+2]*p[2] + e[j*4+3]*p[3]
+	}
+
+	return out
+
+}
+
+// materialColor returns the diffuse color of the given
+// material, or nil if it does not have one
+func materialColor(material objects.Material) *math3.Color {
+
+	switch m := material.(type) {
+	case *materials.MeshBasicMaterial:
+		return m.Color
+	case *materials.MeshLambertMaterial:
+		return m.Color
+	case *materials.MeshPhongMaterial:
+		return m.Color
+	case *materials.MeshStandardMaterial:
+		return m.Color
+	case *materials.LineBasicMaterial:
+		return m.Color
+	case *materials.PointsMaterial:
+		return m.Color
+	}
+
+	return nil
+
+}
+
+// renderShadows renders the depth of every object that casts shadows
+// into the shadow maps of the visible lights that cast shadows
+func (r *SoftwareRenderer) renderShadows(scene *scenes.Scene) {
+
+	current := r.current
+	defer func() { r.current = current }()
+
+	objects.TraverseVisible(scene, func(node objects.Node) {
+
+		source, ok := node.(lights.Source)
+		if !ok || !source.GetLight().CastShadow {
+			return
+		}
+
+		shadow := lights.ShadowOf(source)
+		if shadow == nil {
+			return
+		}
+
+		shadow.Update(source)
+
+		shadowMap := shadow.Map
+		r.current = newSoftwareBuffers(shadowMap.Width, shadowMap.Height, true, true)
+
+		for i, viewProjection := range shadowMap.ViewProjections {
+
+			r.Clear(nil)
+			r.renderDepth(scene, viewProjection)
+			copy(shadowMap.Faces[i], r.current.depth)
+
+		}
+
+	})
+
+}
+
+// renderDepth draws the depth of the visible meshes that cast shadows
+// below node into the current depth buffer
+func (r *SoftwareRenderer) renderDepth(node objects.Node, viewProjection *math3.Matrix4) {
+
+	if !objects.ObjectOf(node).Visible {
+		return
+	}
+
+	var mesh *objects.Mesh
+	switch n := node.(type) {
+	case *objects.Mesh:
+		mesh = n
+	case *objects.SkinnedMesh:
+		// skinned meshes cast the shadow of their bind pose
+		mesh = n.Mesh
+	}
+
+	if mesh != nil && mesh.CastShadow && mesh.Material != nil {
+
+		material := mesh.Material.GetMaterial()
+
+		if material.Visible {
+
+			state := r.objectState(mesh.GetMatrixWorld())
+			state.colorWrite = false
+			state.depthTest = true
+			state.depthWrite = true
+			state.depthFunc = three.LessEqualDepth
+
+			side := material.Side
+			if r.ShadowMap.RenderReverseSided && side != three.DoubleSide {
+				if side == three.FrontSide {
+					side = three.BackSide
+				} else {
+					side = three.FrontSide
+				}
+			}
+
+			switch side {
+			case three.FrontSide:
+				state.cullFace = three.CullFaceBack
+			case three.BackSide:
+				state.cullFace = three.CullFaceFront
+			default:
+				state.cullFace = three.CullFaceNone
+			}
+
+			positions, _, _ := meshTriangles(mesh, mesh.Material, nil, math3.NewColor(), false, false)
+			mvp := math3.NewMatrix4().MultiplyMatrices(viewProjection, mesh.GetMatrixWorld())
+			r.drawTriangles(positions, nil, nil, mvp, state)
+
+		}
+
+	}
+
+	for _, child := range node.GetChildren() {
+
+		r.renderDepth(child, viewProjection)
+
+	}
+
+}
+
+// shadeFunc replaces color with its lit color given
+// a position and normal in world space
+type shadeFunc func(color *math3.Color, position, normal *math3.Vector3)
+
+// shader returns a function that shades fragments of
+// the given material with the lights of this frame
+func (f *softwareFrame) shader(material objects.Material, receiveShadow bool) shadeFunc {
+
+	view := math3.NewVector3()
+
+	return func(color *math3.Color, position, normal *math3.Vector3) {
+
+		view.SubVectors(f.cameraPosition, position)
+		f.lights.Shade(material, color, position, normal, view, receiveShadow, color)
+
+	}
+
+}
+
+// meshTriangles expands the triangles of the given mesh, or only those
+// in group if it is not nil, into flat position and color triplets
+// drawn with material. Vertices are given the base color,
+// multiplied by the color attribute of the geometry if vertexColors
+// is set. When lit is set the world position and normal of each vertex
+// are returned as varyings, flat shaded meshes and geometries without
+// normals use the normals of their faces.
+func meshTriangles(mesh *objects.Mesh, material objects.Material, group *core.Group, base *math3.Color, vertexColors, lit bool) (positions, colors, varyings []float64) {
+
+	position := mesh.Geometry.GetAttribute("position")
+
+	color := mesh.Geometry.GetAttribute("color")
+	if !vertexColors {
+		color = nil
+	}
+
+	normal := mesh.Geometry.GetAttribute("normal")
+	if material != nil && material.GetMaterial().Shading == three.FlatShading {
+		normal = nil
+	}
+
+	normalMatrix := math3.NewMatrix3().GetNormalMatrix(mesh.MatrixWorld)
+
+	v := math3.NewVector3()
+	n := math3.NewVector3()
+	face := [3]*math3.Vector3{math3.NewVector3(), math3.NewVector3(), math3.NewVector3()}
+
+	add := func(i int, faceNormal *math3.Vector3) {
+
+		position.GetVector3(i, v)
+		positions = append(positions, v.X, v.Y, v.Z)
+
+		if color != nil {
+			color.GetVector3(i, n)
+			colors = append(colors, n.X*base.R, n.Y*base.G, n.Z*base.B)
+		} else {
+			colors = append(colors, base.R, base.G, base.B)
+		}
+
+		if lit {
+
+			if normal != nil {
+				normal.GetVector3(i, n)
+			} else {
+				n.Copy(faceNormal)
+			}
+
+			v.ApplyMatrix4(mesh.MatrixWorld)
+			n.ApplyMatrix3(normalMatrix).Normalize()
+			varyings = append(varyings, v.X, v.Y, v.Z, n.X, n.Y, n.Z)
+
+		}
+
+	}
+
+	triangle := func(a, b, c int) {
+
+		var faceNormal *math3.Vector3
+
+		if lit && normal == nil {
+
+			position.GetVector3(a, face[0])
+			position.GetVector3(b, face[1])
+			position.GetVector3(c, face[2])
+			faceNormal = math3.TriangleNormal(face[0], face[1], face[2], nil)
+
+		}
+
+		add(a, faceNormal)
+		add(b, faceNormal)
+		add(c, faceNormal)
+
+	}
+
+	if group != nil {
+		mesh.ForEachGroupTriangle(*group, triangle)
+	} else {
+		mesh.ForEachTriangle(triangle)
+	}
+
+	return positions, colors, varyings
+
+}
+
+func toByte(v float64) uint8 {
+
+	return uint8(math3.Clamp(v, 0, 1)*255 + 0.5)
+
+}
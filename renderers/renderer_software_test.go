@@ -0,0 +1,779 @@
+package renderers_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/cameras"
+	"github.com/rydrman/three.go/core"
+	"github.com/rydrman/three.go/lights"
+	"github.com/rydrman/three.go/materials"
+	"github.com/rydrman/three.go/math3"
+	"github.com/rydrman/three.go/objects"
+	"github.com/rydrman/three.go/renderers"
+	"github.com/rydrman/three.go/scenes"
+)
+
+type testTriangles struct {
+	*objects.Object
+	positions []float64
+	colors    []float64
+}
+
+func (t *testTriangles) Triangles() ([]float64, []float64) {
+	return t.positions, t.colors
+}
+
+type boundedTriangles struct {
+	*testTriangles
+	sphere *math3.Sphere
+}
+
+func (t *boundedTriangles) GetBoundingSphere() *math3.Sphere {
+	return t.sphere
+}
+
+func newTestTriangle(z float64, ccw bool, r, g, b float64) *testTriangles {
+	positions := []float64{
+		-1, -1, z,
+		1, -1, z,
+		0, 1, z,
+	}
+	if !ccw {
+		positions = []float64{
+			-1, -1, z,
+			0, 1, z,
+			1, -1, z,
+		}
+	}
+	return &testTriangles{
+		Object:    objects.NewObject(),
+		positions: positions,
+		colors:    []float64{r, g, b, r, g, b, r, g, b},
+	}
+}
+
+func centerPixel(r *renderers.SoftwareRenderer) (uint8, uint8, uint8) {
+	w, h := r.GetSize()
+	c := r.Image().RGBAAt(w/2, h/2)
+	return c.R, c.G, c.B
+}
+
+func TestSoftwareRenderer_Clear(t *testing.T) {
+	r := renderers.NewSoftwareRenderer(4, 4)
+	scene := scenes.NewScene()
+	scene.BackgroundColor = math3.NewColor().SetRGB(0, 0, 1)
+	r.Render(scene, nil)
+
+	if red, green, blue := centerPixel(r); red != 0 || green != 0 || blue != 255 {
+		t.Errorf("expected background color, got %d, %d, %d", red, green, blue)
+	}
+	if r.Depth(0, 0) != 1 {
+		t.Error("depth should be cleared to the far plane")
+	}
+}
+
+func TestSoftwareRenderer_Triangle(t *testing.T) {
+	r := renderers.NewSoftwareRenderer(8, 8)
+	scene := scenes.NewScene()
+	scene.Add(newTestTriangle(0, true, 1, 0, 0))
+	r.Render(scene, nil)
+
+	if red, green, blue := centerPixel(r); red != 255 || green != 0 || blue != 0 {
+		t.Errorf("expected triangle color at center, got %d, %d, %d", red, green, blue)
+	}
+	if r.Depth(4, 4) != 0.5 {
+		t.Errorf("expected depth of 0.5, got %f", r.Depth(4, 4))
+	}
+}
+
+func TestSoftwareRenderer_CullFace(t *testing.T) {
+	r := renderers.NewSoftwareRenderer(8, 8)
+	scene := scenes.NewScene()
+	scene.Add(newTestTriangle(0, false, 1, 0, 0))
+	r.Render(scene, nil)
+
+	if red, _, _ := centerPixel(r); red != 0 {
+		t.Error("back facing triangle should be culled")
+	}
+
+	r.CullFace = three.CullFaceFront
+	r.Render(scene, nil)
+
+	if red, _, _ := centerPixel(r); red != 255 {
+		t.Error("back facing triangle should be drawn when culling front faces")
+	}
+
+	r.CullFace = three.CullFaceNone
+	scene.Add(newTestTriangle(0.5, true, 0, 1, 0))
+	r.Render(scene, nil)
+
+	if red, green, _ := centerPixel(r); red != 255 || green != 0 {
+		t.Error("nearer triangle should not be culled when culling is disabled")
+	}
+}
+
+func TestSoftwareRenderer_DepthFunc(t *testing.T) {
+	r := renderers.NewSoftwareRenderer(8, 8)
+	scene := scenes.NewScene()
+	scene.Add(newTestTriangle(-0.5, true, 1, 0, 0))
+	scene.Add(newTestTriangle(0.5, true, 0, 1, 0))
+	r.Render(scene, nil)
+
+	if red, green, _ := centerPixel(r); red != 255 || green != 0 {
+		t.Error("nearer triangle should be drawn over farther triangle")
+	}
+
+	r.DepthFunc = three.AlwaysDepth
+	r.Render(scene, nil)
+
+	if red, green, _ := centerPixel(r); red != 0 || green != 255 {
+		t.Error("last triangle should be drawn when depth always passes")
+	}
+
+	r.DepthFunc = three.GreaterDepth
+	r.Render(scene, nil)
+
+	if red, green, _ := centerPixel(r); red != 0 || green != 0 {
+		t.Error("no fragment should be greater than a cleared depth buffer")
+	}
+
+	r.DepthFunc = three.NeverDepth
+	r.Render(scene, nil)
+
+	if red, green, _ := centerPixel(r); red != 0 || green != 0 {
+		t.Error("no fragment should pass the never depth function")
+	}
+}
+
+func TestSoftwareRenderer_RenderTarget(t *testing.T) {
+	r := renderers.NewSoftwareRenderer(8, 8)
+	scene := scenes.NewScene()
+	scene.Add(newTestTriangle(0, true, 1, 0, 0))
+
+	target := renderers.NewRenderTarget(4, 2)
+	if err := r.SetRenderTarget(target); err != nil {
+		t.Fatal(err)
+	}
+	r.Render(scene, nil)
+
+	img, err := r.ReadPixels(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds().Dx() != 4 || img.Bounds().Dy() != 2 {
+		t.Errorf("expected a 4x2 image, got %s", img.Bounds())
+	}
+	if c := img.RGBAAt(2, 1); c.R != 255 || c.A != 255 {
+		t.Errorf("expected triangle color in target, got %v", c)
+	}
+	if c := r.Image().RGBAAt(4, 4); c.R != 0 {
+		t.Error("rendering into a target should not modify the default output")
+	}
+
+	depth, err := r.ReadDepth(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(depth) != 8 || depth[1*4+2] != 0.5 || depth[0] != 1 {
+		t.Errorf("unexpected depth values %v", depth)
+	}
+}
+
+func TestSoftwareRenderer_RenderTargetFormats(t *testing.T) {
+	r := renderers.NewSoftwareRenderer(8, 8)
+	scene := scenes.NewScene()
+	scene.BackgroundColor = nil
+
+	target := renderers.NewRenderTarget(2, 2)
+	target.Format = three.RGBFormat
+	target.DepthFormat = 0
+	if err := r.SetRenderTarget(target); err != nil {
+		t.Fatal(err)
+	}
+	r.Render(scene, nil)
+
+	img, err := r.ReadPixels(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := img.RGBAAt(0, 0); c.A != 255 {
+		t.Error("rgb targets should always be opaque")
+	}
+	if _, err := r.ReadDepth(target); err == nil {
+		t.Error("expected an error reading depth from a target without a depth buffer")
+	}
+	if _, err := r.ReadPixels(renderers.NewRenderTarget(2, 2)); err == nil {
+		t.Error("expected an error reading a target that was never rendered")
+	}
+
+	target.Format = three.LuminanceFormat
+	if err := r.SetRenderTarget(target); err == nil {
+		t.Error("expected an error for an unsupported color format")
+	}
+}
+
+func TestSoftwareRenderer_Camera(t *testing.T) {
+	r := renderers.NewSoftwareRenderer(8, 8)
+	scene := scenes.NewScene()
+	tri := newTestTriangle(0, true, 1, 0, 0)
+	scene.Add(tri)
+
+	cam := cameras.NewPerspectiveCamera(90, 1, 0.1, 100)
+	cam.Position.Set(0, 0, 5)
+	r.Render(scene, cam)
+
+	if red, _, _ := centerPixel(r); red != 255 {
+		t.Error("triangle in front of the camera should be drawn")
+	}
+	if c := r.Image().RGBAAt(0, 0); c.R != 0 {
+		t.Error("distant triangle should not cover the corners of the image")
+	}
+
+	cam.LookAt(math3.NewVector3().Set(0, 0, 10))
+	r.Render(scene, cam)
+
+	if red, _, _ := centerPixel(r); red != 0 {
+		t.Error("triangle behind the camera should not be drawn")
+	}
+}
+
+func TestSoftwareRenderer_Mesh(t *testing.T) {
+	r := renderers.NewSoftwareRenderer(8, 8)
+	scene := scenes.NewScene()
+
+	g := core.NewBufferGeometry()
+	g.AddAttribute("position", core.NewFloat32BufferAttribute([]float32{
+		-1, -1, 0,
+		1, -1, 0,
+		-1, 1, 0,
+		1, 1, 0,
+	}, 3))
+	g.AddAttribute("color", core.NewFloat32BufferAttribute([]float32{
+		0, 1, 0,
+		0, 1, 0,
+		0, 1, 0,
+		0, 1, 0,
+	}, 3))
+	mesh := objects.NewMesh(g, nil)
+	mesh.SetDrawMode(three.TriangleStripDrawMode)
+	scene.Add(mesh)
+
+	r.Render(scene, nil)
+
+	for _, p := range [][2]int{{0, 0}, {7, 0}, {0, 7}, {7, 7}, {4, 4}} {
+		if c := r.Image().RGBAAt(p[0], p[1]); c.G != 255 {
+			t.Errorf("expected the strip to cover pixel %v, got %v", p, c)
+		}
+	}
+
+	g.RemoveAttribute("color")
+	mesh.Position.X = 10
+	r.Render(scene, nil)
+
+	if red, green, blue := centerPixel(r); red != 0 || green != 0 || blue != 0 {
+		t.Errorf("mesh moved out of view should not be drawn, got %d, %d, %d", red, green, blue)
+	}
+
+	mesh.Position.X = 0
+	r.Render(scene, nil)
+
+	if red, green, blue := centerPixel(r); red != 255 || green != 255 || blue != 255 {
+		t.Errorf("mesh without colors should be white, got %d, %d, %d", red, green, blue)
+	}
+}
+
+func TestSoftwareRenderer_Visible(t *testing.T) {
+	r := renderers.NewSoftwareRenderer(4, 4)
+	scene := scenes.NewScene()
+
+	group := objects.NewObject()
+	parent := newTestQuad(0, nil)
+	child := newTestQuad(0, nil)
+	triangle := newTestTriangle(0, true, 1, 1, 1)
+	parent.Add(child)
+	group.Add(parent)
+	group.Add(triangle)
+	scene.Add(group)
+
+	drawn := func() bool {
+		r.Render(scene, nil)
+		red, _, _ := centerPixel(r)
+		return red != 0
+	}
+
+	if !drawn() {
+		t.Fatal("expected the visible nodes to be drawn")
+	}
+
+	group.Visible = false
+	if drawn() {
+		t.Error("expected the nodes of a hidden group not to be drawn")
+	}
+	group.Visible = true
+
+	parent.Visible = false
+	triangle.Visible = false
+	if drawn() {
+		t.Error("expected hidden nodes and their children not to be drawn")
+	}
+}
+
+func newTestQuad(z float64, material objects.Material) *objects.Mesh {
+	g := core.NewBufferGeometry()
+	g.AddAttribute("position", core.NewFloat32BufferAttribute([]float32{
+		-1, -1, float32(z),
+		1, -1, float32(z),
+		-1, 1, float32(z),
+		1, 1, float32(z),
+	}, 3))
+	mesh := objects.NewMesh(g, material)
+	mesh.SetDrawMode(three.TriangleStripDrawMode)
+	return mesh
+}
+
+func TestSoftwareRenderer_MaterialColorAndSide(t *testing.T) {
+	r := renderers.NewSoftwareRenderer(4, 4)
+	scene := scenes.NewScene()
+
+	material := materials.NewMeshBasicMaterial()
+	material.Color.SetRGB(1, 0, 0)
+	mesh := newTestQuad(0, material)
+	scene.Add(mesh)
+
+	r.Render(scene, nil)
+	if red, green, blue := centerPixel(r); red != 255 || green != 0 || blue != 0 {
+		t.Errorf("expected the material color, got %d, %d, %d", red, green, blue)
+	}
+
+	material.Side = three.BackSide
+	r.Render(scene, nil)
+	if red, _, _ := centerPixel(r); red != 0 {
+		t.Error("front faces should not be drawn for back side materials")
+	}
+
+	material.Side = three.DoubleSide
+	mesh.Scale.X = -1
+	r.Render(scene, nil)
+	if red, _, _ := centerPixel(r); red != 255 {
+		t.Error("mirrored double sided mesh should be drawn")
+	}
+
+	material.Side = three.FrontSide
+	r.Render(scene, nil)
+	if red, _, _ := centerPixel(r); red != 255 {
+		t.Error("mirroring should not change which side is the front")
+	}
+
+	material.Visible = false
+	r.Render(scene, nil)
+	if red, _, _ := centerPixel(r); red != 0 {
+		t.Error("invisible materials should not be drawn")
+	}
+}
+
+func TestSoftwareRenderer_VertexColors(t *testing.T) {
+	r := renderers.NewSoftwareRenderer(4, 4)
+	scene := scenes.NewScene()
+
+	material := materials.NewMeshBasicMaterial()
+	material.Color.SetRGB(1, 0.5, 1)
+	mesh := newTestQuad(0, material)
+	mesh.Geometry.AddAttribute("color", core.NewFloat32BufferAttribute([]float32{
+		0, 1, 1,
+		0, 1, 1,
+		0, 1, 1,
+		0, 1, 1,
+	}, 3))
+	scene.Add(mesh)
+
+	r.Render(scene, nil)
+	if red, green, blue := centerPixel(r); red != 255 || green != 128 || blue != 255 {
+		t.Errorf("vertex colors should be ignored by default, got %d, %d, %d", red, green, blue)
+	}
+
+	material.VertexColors = three.VertexColors
+	r.Render(scene, nil)
+	if red, green, blue := centerPixel(r); red != 0 || green != 128 || blue != 255 {
+		t.Errorf("vertex colors should multiply the material color, got %d, %d, %d", red, green, blue)
+	}
+}
+
+func TestSoftwareRenderer_Transparency(t *testing.T) {
+	r := renderers.NewSoftwareRenderer(4, 4)
+	scene := scenes.NewScene()
+	scene.BackgroundColor = math3.NewColor().SetRGB(0, 0, 1)
+
+	// added in front of the opaque quad, but should be drawn after it
+	front := materials.NewMeshBasicMaterial()
+	front.Color.SetRGB(1, 0, 0)
+	front.Transparent = true
+	front.Opacity = 0.5
+	scene.Add(newTestQuad(-0.5, front))
+
+	back := materials.NewMeshBasicMaterial()
+	back.Color.SetRGB(0, 1, 0)
+	scene.Add(newTestQuad(0.5, back))
+
+	r.Render(scene, nil)
+	if red, green, blue := centerPixel(r); red != 128 || green != 128 || blue != 0 {
+		t.Errorf("expected an even blend of red and green, got %d, %d, %d", red, green, blue)
+	}
+
+	front.Blending = three.AdditiveBlending
+	r.Render(scene, nil)
+	if red, green, blue := centerPixel(r); red != 128 || green != 255 || blue != 0 {
+		t.Errorf("expected half red added to green, got %d, %d, %d", red, green, blue)
+	}
+
+	front.Blending = three.CustomBlending
+	front.BlendSrc = three.OneFactor
+	front.BlendDst = three.OneFactor
+	front.BlendEquation = three.ReverseSubtractEquation
+	back.Color.SetRGB(1, 1, 0)
+	r.Render(scene, nil)
+	if red, green, blue := centerPixel(r); red != 0 || green != 255 || blue != 0 {
+		t.Errorf("expected red subtracted from yellow, got %d, %d, %d", red, green, blue)
+	}
+
+	front.Blending = three.NormalBlending
+	front.Transparent = false
+	r.Render(scene, nil)
+	if red, green, blue := centerPixel(r); red != 255 || green != 0 || blue != 0 {
+		t.Errorf("opacity should be ignored without transparency, got %d, %d, %d", red, green, blue)
+	}
+
+	front.AlphaTest = 0.75
+	r.Render(scene, nil)
+	if red, green, _ := centerPixel(r); red != 255 || green != 255 {
+		t.Errorf("fragments below the alpha test should be discarded, got %d, %d", red, green)
+	}
+}
+
+func TestSoftwareRenderer_DepthSettings(t *testing.T) {
+	r := renderers.NewSoftwareRenderer(4, 4)
+	scene := scenes.NewScene()
+
+	near := materials.NewMeshBasicMaterial()
+	near.Color.SetRGB(1, 0, 0)
+	scene.Add(newTestQuad(0, near))
+
+	far := materials.NewMeshBasicMaterial()
+	far.Color.SetRGB(0, 1, 0)
+	scene.Add(newTestQuad(0, far))
+
+	// equal depths pass with the default less or equal test
+	r.Render(scene, nil)
+	if red, green, _ := centerPixel(r); red != 0 || green != 255 {
+		t.Errorf("expected the last quad to be drawn, got %d, %d", red, green)
+	}
+
+	far.PolygonOffset = true
+	far.PolygonOffsetUnits = 1
+	r.Render(scene, nil)
+	if red, green, _ := centerPixel(r); red != 255 || green != 0 {
+		t.Errorf("polygon offset should push the quad behind, got %d, %d", red, green)
+	}
+
+	far.DepthTest = false
+	r.Render(scene, nil)
+	if _, green, _ := centerPixel(r); green != 255 {
+		t.Error("quad without depth test should always be drawn")
+	}
+
+	far.DepthTest = true
+	far.DepthFunc = three.GreaterDepth
+	r.Render(scene, nil)
+	if _, green, _ := centerPixel(r); green != 255 {
+		t.Error("offset quad should pass a greater depth test")
+	}
+
+	far.ColorWrite = false
+	r.Render(scene, nil)
+	if red, green, _ := centerPixel(r); red != 255 || green != 0 {
+		t.Errorf("quad without color write should not be visible, got %d, %d", red, green)
+	}
+}
+
+func TestSoftwareRenderer_ClippingPlanes(t *testing.T) {
+	r := renderers.NewSoftwareRenderer(8, 8)
+	scene := scenes.NewScene()
+
+	material := materials.NewMeshBasicMaterial()
+	material.Side = three.DoubleSide
+	scene.Add(newTestQuad(0, material))
+
+	cam := cameras.NewPerspectiveCamera(90, 1, 0.1, 100)
+	cam.Position.Set(0, 0, 1)
+
+	covered := func(x, y int) bool {
+		return r.Image().RGBAAt(x, y).R == 255
+	}
+
+	// keep x > 0
+	material.ClippingPlanes = []*math3.Plane{
+		math3.NewPlane().SetComponents(1, 0, 0, 0),
+	}
+	r.Render(scene, cam)
+	if covered(1, 4) || !covered(6, 4) {
+		t.Error("expected the left half of the quad to be clipped")
+	}
+
+	// keep x > 0 and y > 0
+	material.ClippingPlanes = append(material.ClippingPlanes, math3.NewPlane().SetComponents(0, 1, 0, 0))
+	r.Render(scene, cam)
+	if !covered(6, 1) || covered(6, 6) || covered(1, 1) || covered(1, 6) {
+		t.Error("expected only the top right quarter to remain")
+	}
+
+	// keep x > 0 or y > 0
+	material.ClipIntersection = true
+	r.Render(scene, cam)
+	if !covered(6, 1) || !covered(6, 6) || !covered(1, 1) || covered(1, 6) {
+		t.Error("expected only the bottom left quarter to be clipped")
+	}
+}
+
+func TestSoftwareRenderer_FrustumCulling(t *testing.T) {
+	r := renderers.NewSoftwareRenderer(4, 4)
+	scene := scenes.NewScene()
+
+	mesh := newTestQuad(0, nil)
+	scene.Add(mesh)
+
+	cam := cameras.NewPerspectiveCamera(90, 1, 0.1, 100)
+	cam.Position.Set(0, 0, 2)
+
+	r.Render(scene, cam)
+	if red, _, _ := centerPixel(r); red != 255 {
+		t.Error("mesh in view should be drawn")
+	}
+
+	// a bounding sphere out of view causes the mesh to be skipped
+	// entirely, even though its triangles would be visible
+	mesh.Geometry.BoundingSphere.Set(math3.NewVector3().Set(0, 0, 10), 1)
+	r.Render(scene, cam)
+	if red, _, _ := centerPixel(r); red != 0 {
+		t.Error("mesh with bounds outside of the frustum should be culled")
+	}
+
+	mesh.FrustumCulled = false
+	r.Render(scene, cam)
+	if red, _, _ := centerPixel(r); red != 255 {
+		t.Error("mesh should be drawn when frustum culling is disabled")
+	}
+
+	// triangle sources are culled by their own bounds when they have
+	// them, otherwise by the bounds of their triangles
+	scene.Remove(mesh)
+	triangle := newTestTriangle(0, true, 0, 1, 0)
+	scene.Add(triangle)
+	r.Render(scene, cam)
+	if _, green, _ := centerPixel(r); green != 255 {
+		t.Error("triangle source in view should be drawn")
+	}
+
+	bounded := &boundedTriangles{
+		testTriangles: triangle,
+		sphere:        math3.NewSphere().Set(math3.NewVector3().Set(0, 0, 10), 1),
+	}
+	scene.Remove(triangle)
+	scene.Add(bounded)
+	r.Render(scene, cam)
+	if _, green, _ := centerPixel(r); green != 0 {
+		t.Error("triangle source with bounds outside of the frustum should be culled")
+	}
+
+	bounded.FrustumCulled = false
+	r.Render(scene, cam)
+	if _, green, _ := centerPixel(r); green != 255 {
+		t.Error("triangle source should be drawn when frustum culling is disabled")
+	}
+}
+
+func TestSoftwareRenderer_Lights(t *testing.T) {
+	r := renderers.NewSoftwareRenderer(4, 4)
+	scene := scenes.NewScene()
+
+	material := materials.NewMeshLambertMaterial()
+	material.Color.SetRGB(1, 0, 0)
+	scene.Add(newTestQuad(0, material))
+
+	r.Render(scene, nil)
+	if red, _, _ := centerPixel(r); red != 0 {
+		t.Errorf("lit materials should be black without lights, got %d", red)
+	}
+
+	ambient := lights.NewAmbientLight(nil, 0.5)
+	scene.Add(ambient)
+	r.Render(scene, nil)
+	if red, green, _ := centerPixel(r); red != 128 || green != 0 {
+		t.Errorf("expected half lit red from the ambient light, got %d, %d", red, green)
+	}
+
+	ambient.Visible = false
+	directional := lights.NewDirectionalLight(nil, 1)
+	directional.Position.Set(0, math.Sin(math.Pi/6), math.Cos(math.Pi/6))
+	scene.Add(directional)
+	r.Render(scene, nil)
+	if red, _, _ := centerPixel(r); red != 221 {
+		t.Errorf("expected directional light scaled by the angle to the normal, got %d", red)
+	}
+
+	material.Lights = false
+	r.Render(scene, nil)
+	if red, _, _ := centerPixel(r); red != 255 {
+		t.Errorf("materials without lights should be unlit, got %d", red)
+	}
+}
+
+func TestSoftwareRenderer_Shadows(t *testing.T) {
+	r := renderers.NewSoftwareRenderer(8, 8)
+	scene := scenes.NewScene()
+
+	camera := cameras.NewOrthographicCamera(-1, 1, 1, -1, 0.1, 100)
+	camera.Position.Set(0, 0, 10)
+
+	ground := newTestQuad(0, materials.NewMeshLambertMaterial())
+	ground.ReceiveShadow = true
+	scene.Add(ground)
+
+	// the occluder sits between the lights and the center of the ground
+	blocker := materials.NewMeshBasicMaterial()
+	blocker.Side = three.DoubleSide
+	occluder := newTestQuad(0, blocker)
+	occluder.Position.Set(1, 0, 2)
+	occluder.Scale.Set(0.25, 0.25, 1)
+	occluder.CastShadow = true
+	holder := objects.NewObject()
+	holder.Add(occluder)
+	scene.Add(holder)
+
+	directional := lights.NewDirectionalLight(nil, 1)
+	directional.Shadow.MapSize.Set(256, 256)
+
+	spot := lights.NewSpotLight(nil, 1, 0, math.Pi/4, 0, 1)
+	spot.Shadow.MapSize.Set(256, 256)
+
+	point := lights.NewPointLight(nil, 1, 0, 1)
+	point.Shadow.MapSize.Set(256, 256)
+
+	corner := func() uint8 {
+		return r.Image().RGBAAt(0, 0).R
+	}
+
+	for _, light := range []lights.Source{directional, spot, point} {
+		light.GetLight().Position.Set(3, 0, 6)
+		light.GetLight().CastShadow = true
+		scene.Add(light)
+
+		r.ShadowMap.Enabled = false
+		r.Render(scene, camera)
+		if red, _, _ := centerPixel(r); red == 0 {
+			t.Errorf("%T: expected no shadow when shadow maps are disabled", light)
+		}
+
+		r.ShadowMap.Enabled = true
+		for _, filter := range []int{three.BasicShadowMap, three.PCFShadowMap, three.PCFSoftShadowMap} {
+			r.ShadowMap.Type = filter
+			r.Render(scene, camera)
+			if red, _, _ := centerPixel(r); red != 0 {
+				t.Errorf("%T: expected the center to be in shadow with filter %d, got %d", light, filter, red)
+			}
+			if corner() == 0 {
+				t.Errorf("%T: expected the corner to be lit with filter %d", light, filter)
+			}
+		}
+
+		ground.ReceiveShadow = false
+		r.Render(scene, camera)
+		if red, _, _ := centerPixel(r); red == 0 {
+			t.Errorf("%T: expected no shadow on objects that do not receive them", light)
+		}
+		ground.ReceiveShadow = true
+
+		light.GetLight().CastShadow = false
+		r.Render(scene, camera)
+		if red, _, _ := centerPixel(r); red == 0 {
+			t.Errorf("%T: expected no shadow from lights that do not cast them", light)
+		}
+		light.GetLight().CastShadow = true
+
+		holder.Visible = false
+		r.Render(scene, camera)
+		if red, _, _ := centerPixel(r); red == 0 {
+			t.Errorf("%T: expected no shadow from hidden groups", light)
+		}
+		holder.Visible = true
+
+		scene.Remove(light)
+	}
+}
+
+func TestSoftwareRenderer_SkinnedShadows(t *testing.T) {
+	r := renderers.NewSoftwareRenderer(8, 8)
+	r.ShadowMap.Enabled = true
+	scene := scenes.NewScene()
+
+	camera := cameras.NewOrthographicCamera(-1, 1, 1, -1, 0.1, 100)
+	camera.Position.Set(0, 0, 10)
+
+	ground := newTestQuad(0, materials.NewMeshLambertMaterial())
+	ground.ReceiveShadow = true
+	scene.Add(ground)
+
+	quad := newTestQuad(0, nil)
+	blocker := materials.NewMeshBasicMaterial()
+	blocker.Side = three.DoubleSide
+	occluder := objects.NewSkinnedMesh(quad.Geometry, blocker)
+	occluder.SetDrawMode(three.TriangleStripDrawMode)
+	occluder.Position.Set(1, 0, 2)
+	occluder.Scale.Set(0.25, 0.25, 1)
+	occluder.CastShadow = true
+	scene.Add(occluder)
+
+	light := lights.NewDirectionalLight(nil, 1)
+	light.Shadow.MapSize.Set(256, 256)
+	light.Position.Set(3, 0, 6)
+	light.CastShadow = true
+	scene.Add(light)
+
+	r.Render(scene, camera)
+	if red, _, _ := centerPixel(r); red != 0 {
+		t.Errorf("expected the skinned mesh to cast a shadow, got %d", red)
+	}
+}
+
+func TestSoftwareRenderer_MultiMaterial(t *testing.T) {
+	r := renderers.NewSoftwareRenderer(4, 4)
+	scene := scenes.NewScene()
+
+	red := materials.NewMeshBasicMaterial()
+	red.Color.SetRGB(1, 0, 0)
+	green := materials.NewMeshBasicMaterial()
+	green.Color.SetRGB(0, 1, 0)
+
+	mesh := newTestQuad(0, materials.NewMultiMaterial(red, green))
+	mesh.SetDrawMode(three.TrianglesDrawMode)
+	mesh.Geometry.SetIndex(core.NewIndexAttribute([]int{0, 1, 2, 1, 3, 2}))
+	mesh.Geometry.AddGroup(0, 3, 0)
+	mesh.Geometry.AddGroup(3, 3, 1)
+	scene.Add(mesh)
+
+	r.Render(scene, nil)
+	if c := r.Image().RGBAAt(0, 3); c.R != 255 || c.G != 0 {
+		t.Errorf("expected the first group to use the first material, got %v", c)
+	}
+	if c := r.Image().RGBAAt(3, 0); c.R != 0 || c.G != 255 {
+		t.Errorf("expected the second group to use the second material, got %v", c)
+	}
+
+	mesh.Geometry.ClearGroups()
+	mesh.Geometry.AddGroup(0, 3, 0)
+	mesh.Geometry.AddGroup(3, 3, 5)
+	r.Render(scene, nil)
+	if c := r.Image().RGBAAt(3, 0); c.R != 0 || c.G != 0 {
+		t.Errorf("groups without a material should not be drawn, got %v", c)
+	}
+}
@@ -0,0 +1,538 @@
+package renderers
+
+import (
+	"math"
+
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/math3"
+)
+
+// clipVertex is a vertex in homogeneous clip space
+// along with the attributes to be interpolated across it
+type clipVertex struct {
+	x, y, z, w float64
+	r, g, b    float64
+	// varying holds the world position and normal of lit vertices
+	varying [6]float64
+}
+
+// rasterState is the fixed function state used while
+// rasterizing a set of triangles
+type rasterState struct {
+	cullFace  int
+	frontFace int
+
+	depthTest  bool
+	depthWrite bool
+	depthFunc  int
+
+	colorWrite bool
+
+	// blending is only applied when enabled, the factors are
+	// three.*Factor constants and the equations three.*Equation
+	blending      bool
+	equation      int
+	src           int
+	dst           int
+	equationAlpha int
+	srcAlpha      int
+	dstAlpha      int
+
+	opacity   float64
+	alphaTest float64
+
+	offsetFactor float64
+	offsetUnits  float64
+
+	// clipping planes in clip space, points with a negative
+	// dot product against a plane are clipped
+	clipping         [][4]float64
+	clipIntersection bool
+
+	// shade lights each fragment when it is set, using
+	// the interpolated world position and normal
+	shade shadeFunc
+}
+
+// screenVertex is a vertex after the perspective divide and viewport
+// transform, attributes are pre-divided by w for perspective
+// correct interpolation
+type screenVertex struct {
+	x, y, z float64
+	invW    float64
+	r, g, b float64
+	varying [6]float64
+}
+
+// nearPlane clips everything in front of the near plane, -w <= z
+var nearPlane = [4]float64{0, 0, 1, 1}
+
+// drawTriangles rasterizes the given triangles into the current buffers.
+// Varyings are only used by lit triangles, with the world position and
+// normal of each vertex as six consecutive values.
+func (r *SoftwareRenderer) drawTriangles(positions, colors, varyings []float64, mvp *math3.Matrix4, state *rasterState) {
+
+	// without textures or vertex alpha every fragment
+	// has the same alpha, so the test applies to all of them
+	if state.opacity < state.alphaTest {
+		return
+	}
+
+	e := mvp.Elements
+	tri := make([]clipVertex, 3)
+
+	for i := 0; i+9 <= len(positions); i += 9 {
+
+		for v := 0; v < 3; v++ {
+
+			o := i + v*3
+			px, py, pz := positions[o], positions[o+1], positions[o+2]
+
+			cv := &tri[v]
+			cv.x = e[0]*px + e[4]*py + e[8]*pz + e[12]
+			cv.y = e[1]*px + e[5]*py + e[9]*pz + e[13]
+			cv.z = e[2]*px + e[6]*py + e[10]*pz + e[14]
+			cv.w = e[3]*px + e[7]*py + e[11]*pz + e[15]
+
+			if o+2 < len(colors) {
+				cv.r, cv.g, cv.b = colors[o], colors[o+1], colors[o+2]
+			} else {
+				cv.r, cv.g, cv.b = 1, 1, 1
+			}
+
+			if state.shade != nil {
+				copy(cv.varying[:], varyings[(i/3+v)*6:])
+			}
+
+		}
+
+		polygon := clipPolygon(tri, nearPlane, false)
+
+		for _, part := range clipUserPlanes(polygon, state) {
+
+			for j := 1; j+1 < len(part); j++ {
+
+				r.rasterize(
+					r.toScreen(&part[0]),
+					r.toScreen(&part[j]),
+					r.toScreen(&part[j+1]),
+					state,
+				)
+
+			}
+
+		}
+
+	}
+
+}
+
+// clipUserPlanes clips the polygon against the clipping planes of the
+// given state, returning the convex parts of the polygon that remain
+func clipUserPlanes(polygon []clipVertex, state *rasterState) [][]clipVertex {
+
+	if len(state.clipping) == 0 {
+		return [][]clipVertex{polygon}
+	}
+
+	if !state.clipIntersection {
+
+		for _, plane := range state.clipping {
+			polygon = clipPolygon(polygon, plane, false)
+		}
+
+		return [][]clipVertex{polygon}
+
+	}
+
+	// only the region behind every plane is clipped, so what remains is
+	// the union of the regions in front of each plane. It is split into
+	// disjoint convex parts, where part i is in front of plane i but
+	// behind all of the planes before it.
+
+	parts := make([][]clipVertex, 0, len(state.clipping))
+
+	for i, plane := range state.clipping {
+
+		part := clipPolygon(polygon, plane, false)
+
+		for _, previous := range state.clipping[:i] {
+			part = clipPolygon(part, previous, true)
+		}
+
+		if len(part) >= 3 {
+			parts = append(parts, part)
+		}
+
+	}
+
+	return parts
+
+}
+
+// clipPolygon clips the given convex polygon against a plane in clip
+// space, keeping the part in front of the plane or behind it if
+// inverted is set
+func clipPolygon(polygon []clipVertex, plane [4]float64, inverted bool) []clipVertex {
+
+	out := make([]clipVertex, 0, len(polygon)+1)
+
+	distance := func(v *clipVertex) float64 {
+
+		d := plane[0]*v.x + plane[1]*v.y + plane[2]*v.z + plane[3]*v.w
+		if inverted {
+			return -d
+		}
+
+		return d
+
+	}
+
+	for i := range polygon {
+
+		a := polygon[i]
+		b := polygon[(i+1)%len(polygon)]
+
+		da := distance(&a)
+		db := distance(&b)
+
+		if da >= 0 {
+			out = append(out, a)
+		}
+
+		if (da >= 0) != (db >= 0) {
+			out = append(out, lerpClip(a, b, da/(da-db)))
+		}
+
+	}
+
+	return out
+
+}
+
+func lerpClip(a, b clipVertex, t float64) clipVertex {
+
+	v := clipVertex{
+		x: a.x + (b.x-a.x)*t,
+		y: a.y + (b.y-a.y)*t,
+		z: a.z + (b.z-a.z)*t,
+		w: a.w + (b.w-a.w)*t,
+		r: a.r + (b.r-a.r)*t,
+		g: a.g + (b.g-a.g)*t,
+		b: a.b + (b.b-a.b)*t,
+	}
+
+	for i := range v.varying {
+		v.varying[i] = a.varying[i] + (b.varying[i]-a.varying[i])*t
+	}
+
+	return v
+
+}
+
+func (r *SoftwareRenderer) toScreen(v *clipVertex) *screenVertex {
+
+	invW := 1 / v.w
+
+	s := &screenVertex{
+		x:    (v.x*invW + 1) * 0.5 * float64(r.current.width),
+		y:    (1 - v.y*invW) * 0.5 * float64(r.current.height),
+		z:    (v.z*invW + 1) * 0.5,
+		invW: invW,
+		r:    v.r * invW,
+		g:    v.g * invW,
+		b:    v.b * invW,
+	}
+
+	for i := range s.varying {
+		s.varying[i] = v.varying[i] * invW
+	}
+
+	return s
+
+}
+
+// edge returns twice the signed area of the triangle a, b, (px, py)
+func edge(a, b *screenVertex, px, py float64) float64 {
+
+	return (b.x-a.x)*(py-a.y) - (b.y-a.y)*(px-a.x)
+
+}
+
+// isTopLeft reports whether the edge from a to b is a top or left
+// edge of a triangle with positive area, pixels lying exactly on
+// these edges are drawn so that shared edges are only filled once
+func isTopLeft(a, b *screenVertex) bool {
+
+	dx := b.x - a.x
+	dy := b.y - a.y
+
+	return (dy == 0 && dx > 0) || dy < 0
+
+}
+
+func isCulled(area float64, state *rasterState) bool {
+
+	// screen space has y pointing down, so counter clockwise
+	// triangles in device coordinates have a negative area here
+	front := area < 0
+	if state.frontFace == three.FrontFaceDirectionCW {
+		front = !front
+	}
+
+	switch state.cullFace {
+	case three.CullFaceBack:
+		return !front
+	case three.CullFaceFront:
+		return front
+	case three.CullFaceFrontBack:
+		return true
+	}
+
+	return false
+
+}
+
+func (r *SoftwareRenderer) rasterize(a, b, c *screenVertex, state *rasterState) {
+
+	area := edge(a, b, c.x, c.y)
+
+	if area == 0 || isCulled(area, state) {
+		return
+	}
+
+	if area < 0 {
+		b, c = c, b
+		area = -area
+	}
+
+	offset := 0.0
+	if state.offsetFactor != 0 || state.offsetUnits != 0 {
+
+		// the slope of the depth across the triangle in pixels
+		dzdx := ((b.z-a.z)*(c.y-a.y) - (c.z-a.z)*(b.y-a.y)) / area
+		dzdy := ((c.z-a.z)*(b.x-a.x) - (b.z-a.z)*(c.x-a.x)) / area
+
+		offset = state.offsetFactor*math.Max(math.Abs(dzdx), math.Abs(dzdy)) +
+			state.offsetUnits*depthResolution
+
+	}
+
+	buffers := r.current
+
+	minX := int(math.Max(0, math.Floor(math.Min(a.x, math.Min(b.x, c.x)))))
+	maxX := int(math.Min(float64(buffers.width-1), math.Ceil(math.Max(a.x, math.Max(b.x, c.x)))))
+	minY := int(math.Max(0, math.Floor(math.Min(a.y, math.Min(b.y, c.y)))))
+	maxY := int(math.Min(float64(buffers.height-1), math.Ceil(math.Max(a.y, math.Max(b.y, c.y)))))
+
+	topLeftA := isTopLeft(b, c)
+	topLeftB := isTopLeft(c, a)
+	topLeftC := isTopLeft(a, b)
+
+	invArea := 1 / area
+
+	color := math3.NewColor()
+	position := math3.NewVector3()
+	normal := math3.NewVector3()
+
+	for y := minY; y <= maxY; y++ {
+
+		py := float64(y) + 0.5
+
+		for x := minX; x <= maxX; x++ {
+
+			px := float64(x) + 0.5
+
+			wa := edge(b, c, px, py)
+			wb := edge(c, a, px, py)
+			wc := edge(a, b, px, py)
+
+			if wa < 0 || wb < 0 || wc < 0 ||
+				(wa == 0 && !topLeftA) ||
+				(wb == 0 && !topLeftB) ||
+				(wc == 0 && !topLeftC) {
+				continue
+			}
+
+			wa *= invArea
+			wb *= invArea
+			wc *= invArea
+
+			z := wa*a.z + wb*b.z + wc*c.z
+			if z < 0 || z > 1 {
+				continue
+			}
+
+			z = math3.Clamp(z+offset, 0, 1)
+
+			i := y*buffers.width + x
+
+			if state.depthTest && buffers.depth != nil {
+
+				if !depthPasses(state.depthFunc, z, buffers.depth[i]) {
+					continue
+				}
+
+				if state.depthWrite {
+					buffers.depth[i] = z
+				}
+
+			}
+
+			if !state.colorWrite {
+				continue
+			}
+
+			w := 1 / (wa*a.invW + wb*b.invW + wc*c.invW)
+
+			color.SetRGB(
+				(wa*a.r+wb*b.r+wc*c.r)*w,
+				(wa*a.g+wb*b.g+wc*c.g)*w,
+				(wa*a.b+wb*b.b+wc*c.b)*w,
+			)
+
+			if state.shade != nil {
+
+				var varying [6]float64
+				for k := range varying {
+					varying[k] = (wa*a.varying[k] + wb*b.varying[k] + wc*c.varying[k]) * w
+				}
+
+				position.Set(varying[0], varying[1], varying[2])
+				normal.Set(varying[3], varying[4], varying[5]).Normalize()
+				state.shade(color, position, normal)
+
+			}
+
+			writeFragment(buffers, x, y, color.R, color.G, color.B, state)
+
+		}
+
+	}
+
+}
+
+// depthResolution is the smallest difference between
+// two depths in a 24 bit depth buffer
+const depthResolution = 1.0 / (1 << 24)
+
+// writeFragment stores the given color in the color buffer,
+// blending it with the current color if enabled
+func writeFragment(buffers *softwareBuffers, x, y int, sr, sg, sb float64, state *rasterState) {
+
+	o := buffers.color.PixOffset(x, y)
+	pix := buffers.color.Pix
+
+	if !state.blending {
+
+		pix[o] = toByte(sr)
+		pix[o+1] = toByte(sg)
+		pix[o+2] = toByte(sb)
+		pix[o+3] = 255
+		return
+
+	}
+
+	sa := state.opacity
+
+	dr := float64(pix[o]) / 255
+	dg := float64(pix[o+1]) / 255
+	db := float64(pix[o+2]) / 255
+	da := float64(pix[o+3]) / 255
+
+	srcR, srcG, srcB, _ := blendFactor(state.src, sr, sg, sb, sa, dr, dg, db, da)
+	dstR, dstG, dstB, _ := blendFactor(state.dst, sr, sg, sb, sa, dr, dg, db, da)
+	_, _, _, srcA := blendFactor(state.srcAlpha, sr, sg, sb, sa, dr, dg, db, da)
+	_, _, _, dstA := blendFactor(state.dstAlpha, sr, sg, sb, sa, dr, dg, db, da)
+
+	pix[o] = toByte(blendEquation(state.equation, sr, dr, srcR, dstR))
+	pix[o+1] = toByte(blendEquation(state.equation, sg, dg, srcG, dstG))
+	pix[o+2] = toByte(blendEquation(state.equation, sb, db, srcB, dstB))
+
+	if buffers.opaque {
+		pix[o+3] = 255
+	} else {
+		pix[o+3] = toByte(blendEquation(state.equationAlpha, sa, da, srcA, dstA))
+	}
+
+}
+
+// blendFactor returns the weights given to each channel by one of the
+// three.*Factor constants for the given source and destination colors
+func blendFactor(factor int, sr, sg, sb, sa, dr, dg, db, da float64) (r, g, b, a float64) {
+
+	switch factor {
+	case three.ZeroFactor:
+		return 0, 0, 0, 0
+	case three.OneFactor:
+		return 1, 1, 1, 1
+	case three.SrcColorFactor:
+		return sr, sg, sb, sa
+	case three.OneMinusSrcColorFactor:
+		return 1 - sr, 1 - sg, 1 - sb, 1 - sa
+	case three.SrcAlphaFactor:
+		return sa, sa, sa, sa
+	case three.OneMinusSrcAlphaFactor:
+		return 1 - sa, 1 - sa, 1 - sa, 1 - sa
+	case three.DstAlphaFactor:
+		return da, da, da, da
+	case three.OneMinusDstAlphaFactor:
+		return 1 - da, 1 - da, 1 - da, 1 - da
+	case three.DstColorFactor:
+		return dr, dg, db, da
+	case three.OneMinusDstColorFactor:
+		return 1 - dr, 1 - dg, 1 - db, 1 - da
+	case three.SrcAlphaSaturateFactor:
+		f := math.Min(sa, 1-da)
+		return f, f, f, 1
+	}
+
+	return 0, 0, 0, 0
+
+}
+
+// blendEquation combines a source and destination value using
+// one of the three.*Equation constants
+func blendEquation(equation int, src, dst, srcFactor, dstFactor float64) float64 {
+
+	switch equation {
+	case three.SubtractEquation:
+		return src*srcFactor - dst*dstFactor
+	case three.ReverseSubtractEquation:
+		return dst*dstFactor - src*srcFactor
+	case three.MinEquation:
+		return math.Min(src, dst)
+	case three.MaxEquation:
+		return math.Max(src, dst)
+	}
+
+	return src*srcFactor + dst*dstFactor
+
+}
+
+// depthPasses compares the incoming depth against the stored
+// depth using one of the three.*Depth functions
+func depthPasses(depthFunc int, incoming, stored float64) bool {
+
+	switch depthFunc {
+	case three.NeverDepth:
+		return false
+	case three.AlwaysDepth:
+		return true
+	case three.LessDepth:
+		return incoming < stored
+	case three.LessEqualDepth:
+		return incoming <= stored
+	case three.EqualDepth:
+		return incoming == stored
+	case three.GreaterEqualDepth:
+		return incoming >= stored
+	case three.GreaterDepth:
+		return incoming > stored
+	case three.NotEqualDepth:
+		return incoming != stored
+	}
+
+	return true
+
+}
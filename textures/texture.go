@@ -0,0 +1,103 @@
+/*
+Package textures contains the images that can be mapped
+onto the surfaces of materials.
+*/
+package textures
+
+import (
+	"image"
+
+	"github.com/rydrman/three.go"
+	"github.com/rydrman/three.go/math3"
+)
+
+// Texture is an image along with the settings
+// used to sample it when mapped onto a surface
+type Texture struct {
+	UUID string
+	Name string
+
+	// Image is nil until the image has been loaded
+	Image image.Image
+	// SourceFile is the name that the image was loaded from, if any
+	SourceFile string
+
+	// Mapping is one of the three.*Mapping constants
+	Mapping int
+	// WrapS and WrapT are the three.*Wrapping constants
+	// used outside of the [0, 1] range in u and v
+	WrapS int
+	WrapT int
+	// MagFilter and MinFilter are three.*Filter constants
+	MagFilter int
+	MinFilter int
+	// Format is one of the three.*Format constants
+	Format int
+
+	// Offset and Repeat transform the uv coordinates of the surface
+	Offset *math3.Vector2
+	Repeat *math3.Vector2
+
+	GenerateMipmaps bool
+	// FlipY flips the image vertically when it is uploaded,
+	// as image rows start at the top while v starts at the bottom
+	FlipY bool
+
+	// Version is incremented each time the texture needs
+	// to be uploaded again by the renderer
+	Version int
+}
+
+// NewTexture creates a texture from the given image, which may be nil
+func NewTexture(img image.Image) *Texture {
+
+	return &Texture{
+		UUID: math3.GenerateUUID(),
+
+		Image: img,
+
+		Mapping:   three.UVMapping,
+		WrapS:     three.ClampToEdgeWrapping,
+		WrapT:     three.ClampToEdgeWrapping,
+		MagFilter: three.LinearFilter,
+		MinFilter: three.LinearMipMapLinearFilter,
+		Format:    three.RGBAFormat,
+
+		Offset: math3.NewVector2(),
+		Repeat: math3.NewVector2().Set(1, 1),
+
+		GenerateMipmaps: true,
+		FlipY:           true,
+	}
+
+}
+
+// SetNeedsUpdate flags this texture to be uploaded again by the renderer
+func (t *Texture) SetNeedsUpdate() {
+
+	t.Version++
+
+}
+
+func (t *Texture) Clone() *Texture {
+
+	return NewTexture(nil).Copy(t)
+
+}
+
+// Copy copies the image and settings of src into this texture,
+// the UUID of this texture is kept and the image is shared
+func (t *Texture) Copy(src *Texture) *Texture {
+
+	uuid := t.UUID
+	offset := t.Offset.Copy(src.Offset)
+	repeat := t.Repeat.Copy(src.Repeat)
+
+	*t = *src
+	t.UUID = uuid
+	t.Offset = offset
+	t.Repeat = repeat
+
+	return t
+
+}
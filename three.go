@@ -2,30 +2,13 @@
 Package three is the main package for the three.go graphics library
 for golang. It attempts to port the three.js architecture as accurately
 as possible while following proper golang practices.
+
+Importing three or any of its cpu side packages (math3, objects, scenes...)
+does not touch the graphics system. Windowing and OpenGL are initialized
+explicitly through the renderers package when a window is created.
 */
 package three
 
-import (
-	"runtime"
-
-	"github.com/go-gl/gl/v2.1/gl"
-	"github.com/go-gl/glfw/v3.2/glfw"
-)
-
-func init() {
-
-	runtime.LockOSThread()
-
-	if err := glfw.Init(); err != nil {
-		panic(err)
-	}
-
-	if err := gl.Init(); err != nil {
-		panic(err)
-	}
-
-}
-
 func CharAt(s string, i int) string {
 
 	return string([]rune(s)[i])